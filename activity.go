@@ -0,0 +1,158 @@
+// activity.go
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// activityEvent is a single attempt on a problem, taken from the attempt history.
+type activityEvent struct {
+	ProblemID string
+	Date      time.Time
+	Solved    bool
+	Tags      []string
+}
+
+// activityLog flattens the attempt history of all problems into events. Problems solved
+// before attempts were tracked contribute a single solve at their LastSolved date.
+func activityLog(problems []Problem) []activityEvent {
+	var events []activityEvent
+	for _, p := range problems {
+		if len(p.Attempts) == 0 && !p.LastSolved.IsZero() {
+			events = append(events, activityEvent{ProblemID: p.ID, Date: p.LastSolved, Solved: true, Tags: p.Tags})
+			continue
+		}
+		for _, a := range p.Attempts {
+			events = append(events, activityEvent{ProblemID: p.ID, Date: a.Date, Solved: a.Solved, Tags: p.Tags})
+		}
+	}
+	return events
+}
+
+// dayKey buckets a timestamp into a local calendar day.
+func dayKey(t time.Time) string {
+	return t.Local().Format("2006-01-02")
+}
+
+// startOfDay returns midnight (local time) of the day containing t.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Local().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// solvesPerDay counts solves per calendar day.
+func solvesPerDay(events []activityEvent) map[string]int {
+	days := make(map[string]int)
+	for _, e := range events {
+		if e.Solved {
+			days[dayKey(e.Date)]++
+		}
+	}
+	return days
+}
+
+// longestStreak returns the longest run of consecutive days with at least one solve in [start, end).
+func longestStreak(days map[string]int, start, end time.Time) int {
+	longest, current := 0, 0
+	for d := startOfDay(start); d.Before(end); d = d.AddDate(0, 0, 1) {
+		if days[dayKey(d)] > 0 {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
+
+// currentStreak returns the number of consecutive days with a solve ending today,
+// or ending yesterday if nothing has been solved yet today.
+func currentStreak(days map[string]int, now time.Time) int {
+	d := startOfDay(now)
+	if days[dayKey(d)] == 0 {
+		d = d.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for days[dayKey(d)] > 0 {
+		streak++
+		d = d.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// period is a half-open time range [Start, End).
+type period struct {
+	Label string
+	Start time.Time
+	End   time.Time
+}
+
+func (p period) contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(p.End)
+}
+
+// parseComparePeriods turns an expression such as "last-week", "last-month", "last-year" or
+// "14d" into the current period ending now and the preceding period of the same length.
+func parseComparePeriods(expr string, now time.Time) (previous, current period, err error) {
+	var days int
+	switch strings.ToLower(expr) {
+	case "last-week", "week":
+		days = 7
+	case "last-month", "month":
+		days = 30
+	case "last-quarter", "quarter":
+		days = 90
+	case "last-year", "year":
+		days = 365
+	default:
+		n, convErr := strconv.Atoi(strings.TrimSuffix(strings.ToLower(expr), "d"))
+		if convErr != nil || n <= 0 {
+			return previous, current, fmt.Errorf("unknown period '%s' (use last-week, last-month, last-quarter, last-year or Nd)", expr)
+		}
+		days = n
+	}
+
+	end := startOfDay(now).AddDate(0, 0, 1)
+	mid := end.AddDate(0, 0, -days)
+	current = period{Label: fmt.Sprintf("last %d days", days), Start: mid, End: end}
+	previous = period{Label: fmt.Sprintf("previous %d days", days), Start: mid.AddDate(0, 0, -days), End: mid}
+	return previous, current, nil
+}
+
+// periodSummary aggregates activity within a period.
+type periodSummary struct {
+	Solves      int
+	Attempts    int
+	NewProblems int
+	TagsCovered int
+	Streak      int
+}
+
+// summarizePeriod aggregates the activity log and the collection over a period.
+func summarizePeriod(problems []Problem, events []activityEvent, per period) periodSummary {
+	var s periodSummary
+	tags := make(map[string]bool)
+	for _, e := range events {
+		if !per.contains(e.Date) {
+			continue
+		}
+		s.Attempts++
+		if e.Solved {
+			s.Solves++
+			for _, tag := range e.Tags {
+				tags[tag] = true
+			}
+		}
+	}
+	for _, p := range problems {
+		if per.contains(p.DateAdded) {
+			s.NewProblems++
+		}
+	}
+	s.TagsCovered = len(tags)
+	s.Streak = longestStreak(solvesPerDay(events), per.Start, per.End)
+	return s
+}
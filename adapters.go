@@ -0,0 +1,298 @@
+// adapters.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// problemMetadata is what a platform knows about one of its problems.
+type problemMetadata struct {
+	ID         string // the problem's ID in saitama's conventions, e.g. LC1 or CF1000A
+	Title      string
+	Difficulty string
+	Rating     int
+	Tags       []string
+	URL        string
+	Editorial  string // official solution, when the platform publishes one (Codeforces' API doesn't link them)
+}
+
+// platformAdapter looks problems up on a judge's public API.
+type platformAdapter interface {
+	Platform() string
+	// Owns reports whether the problem lives on this platform, judging by its
+	// platform field, URL or ID prefix.
+	Owns(p Problem) bool
+	// Lookup returns the platform's metadata for the problem, or false if unknown.
+	Lookup(p Problem) (problemMetadata, bool, error)
+}
+
+// platformAdapters are tried in order by adapterFor.
+var platformAdapters = []platformAdapter{&leetcodeAdapter{}, &codeforcesAdapter{}}
+
+// adapterFor returns the adapter owning the problem, or nil.
+func adapterFor(p Problem) platformAdapter {
+	for _, a := range platformAdapters {
+		if a.Owns(p) {
+			return a
+		}
+	}
+	return nil
+}
+
+// fetchJSON GETs a URL and decodes the JSON response, going through the metadata cache.
+func fetchJSON(endpoint string, out interface{}) error {
+	return cachedJSON(endpoint, out, func() ([]byte, error) {
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "saitama-cli")
+		return fetchBody(req)
+	})
+}
+
+// fetchBody sends a request and reads the body of a 200 response.
+func fetchBody(req *http.Request) ([]byte, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with %s", req.URL.Host, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// urlHost returns the lowercase host of a URL without "www.".
+func urlHost(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+var leetcodeID = regexp.MustCompile(`^LC(\d+)$`)
+
+// leetcodeAdapter resolves problems against LeetCode's public problem list.
+type leetcodeAdapter struct {
+	mu       sync.Mutex // guards loading, so lookups can run concurrently
+	loadErr  error      // a failed download is not retried within one invocation
+	byNumber map[int]problemMetadata
+	bySlug   map[string]problemMetadata
+}
+
+func (a *leetcodeAdapter) Platform() string { return "leetcode" }
+
+func (a *leetcodeAdapter) Owns(p Problem) bool {
+	if p.Platform != "" {
+		return p.Platform == "leetcode"
+	}
+	host := urlHost(p.URL)
+	return host == "leetcode.com" || host == "leetcode.cn" || (p.URL == "" && leetcodeID.MatchString(p.ID))
+}
+
+func (a *leetcodeAdapter) load() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.byNumber != nil || a.loadErr != nil {
+		return a.loadErr
+	}
+	var list struct {
+		Pairs []struct {
+			Stat struct {
+				Number    int    `json:"frontend_question_id"`
+				Title     string `json:"question__title"`
+				Slug      string `json:"question__title_slug"`
+				Editorial bool   `json:"question__article__live"`
+			} `json:"stat"`
+			Difficulty struct {
+				Level int `json:"level"`
+			} `json:"difficulty"`
+		} `json:"stat_status_pairs"`
+	}
+	if err := fetchJSON("https://leetcode.com/api/problems/all/", &list); err != nil {
+		a.loadErr = fmt.Errorf("failed to fetch the LeetCode problem list: %w", err)
+		return a.loadErr
+	}
+	levels := map[int]string{1: "easy", 2: "medium", 3: "hard"}
+	a.byNumber = make(map[int]problemMetadata)
+	a.bySlug = make(map[string]problemMetadata)
+	for _, pair := range list.Pairs {
+		meta := problemMetadata{
+			ID:         "LC" + strconv.Itoa(pair.Stat.Number),
+			Title:      pair.Stat.Title,
+			Difficulty: levels[pair.Difficulty.Level],
+			URL:        "https://leetcode.com/problems/" + pair.Stat.Slug + "/",
+		}
+		if pair.Stat.Editorial {
+			meta.Editorial = meta.URL + "editorial/"
+		}
+		a.byNumber[pair.Stat.Number] = meta
+		a.bySlug[pair.Stat.Slug] = meta
+	}
+	slog.Debug("loaded leetcode problem list", "count", len(a.byNumber))
+	return nil
+}
+
+func (a *leetcodeAdapter) Lookup(p Problem) (problemMetadata, bool, error) {
+	if err := a.load(); err != nil {
+		return problemMetadata{}, false, err
+	}
+	if key := normalizeURL(p.URL); strings.HasPrefix(key, "leetcode.com/problems/") {
+		meta, ok := a.bySlug[strings.TrimPrefix(key, "leetcode.com/problems/")]
+		return meta, ok, nil
+	}
+	if m := leetcodeID.FindStringSubmatch(p.ID); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		meta, ok := a.byNumber[n]
+		return meta, ok, nil
+	}
+	return problemMetadata{}, false, nil
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// leetcodeSlug resolves the title slug of a LeetCode problem from its URL or number.
+func (a *leetcodeAdapter) leetcodeSlug(p Problem) (string, error) {
+	if key := normalizeURL(p.URL); strings.HasPrefix(key, "leetcode.com/problems/") {
+		return strings.TrimPrefix(key, "leetcode.com/problems/"), nil
+	}
+	meta, ok, err := a.Lookup(p)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("'%s' is not a known LeetCode problem", p.ID)
+	}
+	return strings.TrimPrefix(normalizeURL(meta.URL), "leetcode.com/problems/"), nil
+}
+
+// Hints fetches the official hints of a LeetCode problem as plain text.
+func (a *leetcodeAdapter) Hints(p Problem) ([]string, error) {
+	slug, err := a.leetcodeSlug(p)
+	if err != nil {
+		return nil, err
+	}
+	payload, _ := json.Marshal(map[string]interface{}{
+		"query":     "query hints($slug: String!) { question(titleSlug: $slug) { hints } }",
+		"variables": map[string]string{"slug": slug},
+	})
+
+	var result struct {
+		Data struct {
+			Question *struct {
+				Hints []string `json:"hints"`
+			} `json:"question"`
+		} `json:"data"`
+	}
+	err = cachedJSON("leetcode hints of "+slug, &result, func() ([]byte, error) {
+		req, err := http.NewRequest(http.MethodPost, "https://leetcode.com/graphql", bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "saitama-cli")
+		return fetchBody(req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leetcode hints: %w", err)
+	}
+	if result.Data.Question == nil {
+		return nil, fmt.Errorf("leetcode has no problem '%s'", slug)
+	}
+	hints := make([]string, 0, len(result.Data.Question.Hints))
+	for _, h := range result.Data.Question.Hints {
+		hints = append(hints, strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(h, ""))))
+	}
+	return hints, nil
+}
+
+var codeforcesID = regexp.MustCompile(`^CF(\d+)([A-Z]\d?)$`)
+
+// codeforcesAdapter resolves problems against the Codeforces problemset API.
+type codeforcesAdapter struct {
+	mu       sync.Mutex
+	loadErr  error
+	problems map[string]problemMetadata // keyed by contest id + index, e.g. "1234A"
+}
+
+func (a *codeforcesAdapter) Platform() string { return "codeforces" }
+
+func (a *codeforcesAdapter) Owns(p Problem) bool {
+	if p.Platform != "" {
+		return p.Platform == "codeforces"
+	}
+	return strings.HasSuffix(urlHost(p.URL), "codeforces.com") || (p.URL == "" && codeforcesID.MatchString(p.ID))
+}
+
+func (a *codeforcesAdapter) load() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.problems != nil || a.loadErr != nil {
+		return a.loadErr
+	}
+	var resp struct {
+		Status string `json:"status"`
+		Result struct {
+			Problems []struct {
+				ContestID int      `json:"contestId"`
+				Index     string   `json:"index"`
+				Name      string   `json:"name"`
+				Rating    int      `json:"rating"`
+				Tags      []string `json:"tags"`
+			} `json:"problems"`
+		} `json:"result"`
+	}
+	if err := fetchJSON("https://codeforces.com/api/problemset.problems", &resp); err != nil {
+		a.loadErr = fmt.Errorf("failed to fetch the Codeforces problemset: %w", err)
+		return a.loadErr
+	}
+	if resp.Status != "OK" {
+		a.loadErr = fmt.Errorf("codeforces API returned status %s", resp.Status)
+		return a.loadErr
+	}
+	a.problems = make(map[string]problemMetadata)
+	for _, cp := range resp.Result.Problems {
+		key := strconv.Itoa(cp.ContestID) + strings.ToUpper(cp.Index)
+		a.problems[key] = problemMetadata{
+			ID:         "CF" + key,
+			Title:      cp.Name,
+			Difficulty: difficultyFromRating("codeforces", cp.Rating),
+			Rating:     cp.Rating,
+			Tags:       cp.Tags,
+			URL:        fmt.Sprintf("https://codeforces.com/problemset/problem/%d/%s", cp.ContestID, cp.Index),
+		}
+	}
+	slog.Debug("loaded codeforces problemset", "count", len(a.problems))
+	return nil
+}
+
+func (a *codeforcesAdapter) Lookup(p Problem) (problemMetadata, bool, error) {
+	if err := a.load(); err != nil {
+		return problemMetadata{}, false, err
+	}
+	key := ""
+	if norm := normalizeURL(p.URL); strings.HasPrefix(norm, "codeforces.com/problem/") {
+		parts := strings.Split(strings.TrimPrefix(norm, "codeforces.com/problem/"), "/")
+		if len(parts) == 2 {
+			key = parts[0] + strings.ToUpper(parts[1])
+		}
+	} else if m := codeforcesID.FindStringSubmatch(p.ID); m != nil {
+		key = m[1] + m[2]
+	}
+	meta, ok := a.problems[key]
+	return meta, ok, nil
+}
@@ -0,0 +1,339 @@
+// agenda.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Kinds of agenda items, in the order they are listed within a day.
+const (
+	agendaTraining = "training" // the daily reminder of the config
+	agendaReminder = "reminder" // a reminder of the daemon, see 'saitama remind'
+	agendaSession  = "session"  // a session paused or lost to a closed terminal
+	agendaPlan     = "plan"     // a week of the study plan starts
+	agendaOverdue  = "overdue"
+	agendaReview   = "review"
+	agendaSnooze   = "snooze" // a problem comes back from snooze
+)
+
+var agendaKindOrder = map[string]int{
+	agendaTraining: 0, agendaReminder: 0, agendaSession: 1, agendaPlan: 2,
+	agendaOverdue: 3, agendaReview: 4, agendaSnooze: 5,
+}
+
+// agendaMaxListed is the number of reviews listed per day before they are summed up.
+const agendaMaxListed = 8
+
+// agendaItem is one entry of a day of the agenda.
+type agendaItem struct {
+	Kind      string `json:"kind"`
+	Time      string `json:"time,omitempty"` // HH:MM, for timed items
+	ProblemID string `json:"problem_id,omitempty"`
+	Text      string `json:"text"`
+}
+
+// agendaDay is a day of the agenda.
+type agendaDay struct {
+	Date  time.Time    `json:"date"`
+	Items []agendaItem `json:"items"`
+}
+
+// agendaSources are the schedules the agenda is made of, besides the problems.
+type agendaSources struct {
+	Reminders       ReminderConfig
+	Plan            *StudyPlan
+	Session         *Session
+	DaemonReminders []daemonReminder // empty when the daemon isn't running
+}
+
+// buildAgenda lays out the reviews, reminders, plan weeks and snoozes of days days
+// starting at from. Overdue reviews land on today.
+func buildAgenda(problems []Problem, src agendaSources, from time.Time, days int, now time.Time) []agendaDay {
+	start, today := startOfDay(from), startOfDay(now)
+	agenda := make([]agendaDay, days)
+	for i := range agenda {
+		agenda[i].Date = start.AddDate(0, 0, i)
+	}
+	add := func(day time.Time, item agendaItem) {
+		i := int(startOfDay(day).Sub(start).Hours()+12) / 24 // +12h absorbs DST shifts
+		if day.Before(start) || i >= days {
+			return
+		}
+		agenda[i].Items = append(agenda[i].Items, item)
+	}
+
+	// Daily items repeat from today on; the past of the week view has only reviews.
+	if src.Reminders.Enabled {
+		if at, err := time.Parse("15:04", src.Reminders.Time); err == nil {
+			for _, d := range agenda {
+				if d.Date.Before(today) {
+					continue
+				}
+				add(d.Date, agendaItem{Kind: agendaTraining, Time: at.Format("15:04"), Text: tr("Training session")})
+			}
+		}
+	}
+	for _, r := range src.DaemonReminders {
+		item := agendaItem{Kind: agendaReminder, Time: r.At.Local().Format("15:04"), Text: r.Message}
+		if !r.Daily {
+			add(r.At, item)
+			continue
+		}
+		for _, d := range agenda {
+			if !d.Date.Before(today) && !startOfDay(r.At).After(d.Date) {
+				add(d.Date, item)
+			}
+		}
+	}
+	if s := src.Session; s != nil && s.Current < len(s.Problems) {
+		add(today, agendaItem{Kind: agendaSession, Text: fmt.Sprintf(tr("Session in progress, %s left: saitama session resume"), formatClock(s.remaining(now)))})
+	}
+	if src.Plan != nil {
+		for _, w := range src.Plan.Weeks {
+			done := 0
+			for _, item := range w.Items {
+				if planItemDone(problems, item) {
+					done++
+				}
+			}
+			add(w.Start, agendaItem{Kind: agendaPlan, Text: fmt.Sprintf(tr("Plan week %d: %s (%d/%d done)"),
+				w.Number, strings.Join(w.Topics, ", "), done, len(w.Items))})
+		}
+	}
+	for _, p := range problems {
+		if p.Archived {
+			continue
+		}
+		if isSnoozed(p, now) {
+			add(p.SnoozedUntil, agendaItem{Kind: agendaSnooze, ProblemID: p.ID, Text: fmt.Sprintf(tr("%s - %s is back from snooze"), p.ID, p.Name)})
+			continue
+		}
+		due, ok := nextReview(p)
+		if !ok {
+			continue
+		}
+		if due.Before(today) {
+			days := int(today.Sub(startOfDay(due)).Hours()+12) / 24
+			add(today, agendaItem{Kind: agendaOverdue, ProblemID: p.ID, Text: fmt.Sprintf(tr("%s - %s (overdue %dd)"), p.ID, p.Name, days)})
+			continue
+		}
+		add(due, agendaItem{Kind: agendaReview, ProblemID: p.ID, Text: fmt.Sprintf("%s - %s", p.ID, p.Name)})
+	}
+
+	for _, d := range agenda {
+		sort.SliceStable(d.Items, func(i, j int) bool {
+			a, b := d.Items[i], d.Items[j]
+			if agendaKindOrder[a.Kind] != agendaKindOrder[b.Kind] {
+				return agendaKindOrder[a.Kind] < agendaKindOrder[b.Kind]
+			}
+			if a.Time != b.Time {
+				return a.Time < b.Time
+			}
+			return a.ProblemID < b.ProblemID
+		})
+	}
+	return agenda
+}
+
+// agendaIcon returns the emoji of an item in the list view.
+func agendaIcon(kind string) string {
+	switch kind {
+	case agendaTraining:
+		return "🥊"
+	case agendaReminder:
+		return "⏰"
+	case agendaSession:
+		return "⏸️ "
+	case agendaPlan:
+		return "📚"
+	case agendaOverdue:
+		return "⚠️ "
+	case agendaSnooze:
+		return "💤"
+	}
+	return "🔁"
+}
+
+// printAgendaList prints the agenda day by day.
+func printAgendaList(agenda []agendaDay, now time.Time) {
+	today := startOfDay(now)
+	for _, d := range agenda {
+		header := d.Date.Format("Mon Jan 02")
+		switch {
+		case d.Date.Equal(today):
+			printResult(color.New(color.FgHiYellow, color.Bold), tr("%s (today)"), header)
+		case d.Date.Before(today):
+			printResult(color.New(color.FgHiBlack), "%s", header)
+		default:
+			printResult(color.New(color.FgHiCyan), "%s", header)
+		}
+		if len(d.Items) == 0 {
+			printResult(color.New(color.FgHiBlack), tr("   nothing planned"))
+			continue
+		}
+		reviews := 0
+		for _, item := range d.Items {
+			if item.Kind == agendaReview || item.Kind == agendaOverdue {
+				reviews++
+				if reviews > agendaMaxListed {
+					continue
+				}
+			}
+			line := item.Text
+			if item.Time != "" {
+				line = item.Time + " " + line
+			}
+			c := color.New(color.FgWhite)
+			if item.Kind == agendaOverdue {
+				c = color.New(color.FgYellow)
+			}
+			printResult(c, "   %s %s", agendaIcon(item.Kind), line)
+		}
+		if reviews > agendaMaxListed {
+			printResult(color.New(color.FgHiBlack), tr("   ... and %d more review(s)"), reviews-agendaMaxListed)
+		}
+	}
+}
+
+// agendaCellWidth is the width of a day column of the week view, so that seven fit in
+// 80 columns.
+const agendaCellWidth = 10
+
+// agendaCell returns the short form of an item in the week view.
+func agendaCell(item agendaItem) string {
+	switch item.Kind {
+	case agendaTraining:
+		return "⚑ " + item.Time
+	case agendaReminder:
+		return "⏰" + item.Time
+	case agendaSession:
+		return tr("▶ session")
+	case agendaPlan:
+		return tr("▤ plan")
+	case agendaOverdue:
+		return "! " + item.ProblemID
+	case agendaSnooze:
+		return "z " + item.ProblemID
+	}
+	return "↻ " + item.ProblemID
+}
+
+// printAgendaWeek prints the agenda as a calendar week, one column per day.
+func printAgendaWeek(agenda []agendaDay, now time.Time, maxRows int) {
+	today := startOfDay(now)
+	var header strings.Builder
+	for _, d := range agenda {
+		label := fmt.Sprintf("%-*s", agendaCellWidth, d.Date.Format("Mon 02"))
+		switch {
+		case d.Date.Equal(today):
+			label = color.New(color.FgHiYellow, color.Bold, color.Underline).Sprint(label)
+		case d.Date.Before(today):
+			label = color.HiBlackString("%s", label)
+		default:
+			label = color.HiCyanString("%s", label)
+		}
+		header.WriteString(label + " ")
+	}
+	fmt.Fprintln(stdout, strings.TrimRight(header.String(), " "))
+
+	rows := 0
+	for _, d := range agenda {
+		rows = max(rows, len(d.Items))
+	}
+	truncated := rows > maxRows
+	rows = min(rows, maxRows)
+	for r := 0; r < rows; r++ {
+		var line strings.Builder
+		for _, d := range agenda {
+			cell := ""
+			switch {
+			case truncated && r == rows-1 && len(d.Items) > rows:
+				cell = fmt.Sprintf(tr("+%d more"), len(d.Items)-rows+1)
+			case r < len(d.Items):
+				cell = agendaCell(d.Items[r])
+			}
+			cell = fmt.Sprintf("%-*s", agendaCellWidth, truncateText(cell, agendaCellWidth))
+			switch {
+			case d.Date.Before(today):
+				cell = color.HiBlackString("%s", cell)
+			case r < len(d.Items) && d.Items[r].Kind == agendaOverdue:
+				cell = color.YellowString("%s", cell)
+			}
+			line.WriteString(cell + " ")
+		}
+		fmt.Fprintln(stdout, strings.TrimRight(line.String(), " "))
+	}
+	color.HiBlack(tr("↻ review  ! overdue  ⚑ training  ⏰ reminder  ▤ plan week  z back from snooze"))
+}
+
+func agendaCmd() *cobra.Command {
+	var week bool
+	var days int
+	cmd := &cobra.Command{
+		Use:   "agenda",
+		Short: "Show the upcoming reviews, reminders and plan weeks day by day",
+		Long: "Lays out what is coming: spaced-repetition reviews by due date (overdue ones on today), the daily " +
+			"training reminder, reminders of the daemon ('saitama remind'), weeks of the study plan ('saitama plan'), " +
+			"a paused session and problems coming back from snooze. --week shows this week as a calendar.",
+		Example: `  saitama agenda
+  saitama agenda --days 14
+  saitama agenda --week
+  saitama agenda -o json`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if days < 1 {
+				printError(tr("❌ --days must be at least 1"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			src := agendaSources{Reminders: cfg.Reminders}
+			if src.Plan, err = loadPlan(); err != nil {
+				color.Yellow(tr("⚠️  Could not read the study plan: %v"), err)
+			}
+			if src.Session, err = loadSession(); err != nil {
+				color.Yellow(tr("⚠️  Could not read the session: %v"), err)
+			}
+			// Without a daemon there are no reminders to show, which is not an error.
+			_ = callDaemon("remind.list", nil, &src.DaemonReminders)
+
+			now := time.Now()
+			from := now
+			if week {
+				from, days = weekStart(now), 7
+			}
+			agenda := buildAgenda(problems, src, from, days, now)
+			if jsonOutput() {
+				printJSON(agenda)
+				return
+			}
+
+			last := agenda[len(agenda)-1].Date
+			fmt.Fprintln(stderr)
+			color.HiMagenta(tr("📅 Agenda, %s → %s"), agenda[0].Date.Format("Jan 02"), last.Format("Jan 02"))
+			fmt.Fprintln(stderr)
+			if week {
+				printAgendaWeek(agenda, now, 10)
+			} else {
+				printAgendaList(agenda, now)
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().BoolVar(&week, "week", false, "show this week (Monday to Sunday) as a calendar")
+	cmd.Flags().IntVar(&days, "days", 7, "number of days to show, from today")
+	return cmd
+}
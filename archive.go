@@ -0,0 +1,216 @@
+// archive.go
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// activeProblems returns the problems that aren't archived or snoozed, the pool of pick,
+// mock, sessions and digests. The input slice is not modified.
+func activeProblems(problems []Problem) []Problem {
+	now := time.Now()
+	active := make([]Problem, 0, len(problems))
+	for _, p := range problems {
+		if !p.Archived && !isSnoozed(p, now) {
+			active = append(active, p)
+		}
+	}
+	return active
+}
+
+// lastActivity returns when a problem was last added, attempted or solved.
+func lastActivity(p Problem) time.Time {
+	last := p.DateAdded
+	if p.LastSolved.After(last) {
+		last = p.LastSolved
+	}
+	for _, a := range p.Attempts {
+		if a.Date.After(last) {
+			last = a.Date
+		}
+	}
+	return last
+}
+
+// archiveFilter selects the problems to archive in bulk. Every set criterion must match.
+type archiveFilter struct {
+	SolvedBefore time.Time // last solved before this day
+	MinSolves    int
+	InactiveDays int // no attempt or solve within this many days
+	Tags         []string
+}
+
+func (f archiveFilter) empty() bool {
+	return f.SolvedBefore.IsZero() && f.MinSolves == 0 && f.InactiveDays == 0 && len(f.Tags) == 0
+}
+
+func (f archiveFilter) matches(p Problem, now time.Time) bool {
+	if !f.SolvedBefore.IsZero() && (p.LastSolved.IsZero() || !p.LastSolved.Before(f.SolvedBefore)) {
+		return false
+	}
+	if f.MinSolves > 0 && solveCount(p) < f.MinSolves {
+		return false
+	}
+	if f.InactiveDays > 0 && lastActivity(p).After(now.AddDate(0, 0, -f.InactiveDays)) {
+		return false
+	}
+	return hasAnyTag(p, f.Tags)
+}
+
+// setArchived archives or unarchives the problems with the given IDs.
+func setArchived(ids []string, archived bool) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+
+	var changed []string
+	for _, id := range ids {
+		targetID := strings.ToUpper(id)
+		p, index := findProblemByID(problems, targetID)
+		if index == -1 {
+			printError(tr("❌ Problem with ID '%s' not found"), targetID)
+			return
+		}
+		if p.Archived != archived {
+			p.Archived = archived
+			changed = append(changed, p.ID)
+		}
+	}
+	if len(changed) == 0 {
+		color.Yellow(tr("Nothing to change."))
+		return
+	}
+	if err := saveProblems(problems); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	if archived {
+		color.Green(tr("📦 Archived %s"), strings.Join(changed, ", "))
+	} else {
+		color.Green(tr("✅ Back in the active pool: %s"), strings.Join(changed, ", "))
+	}
+}
+
+func archiveCmd() *cobra.Command {
+	var f archiveFilter
+	var solvedBefore string
+	var dryRun, yes bool
+	cmd := &cobra.Command{
+		Use:         "archive [id]...",
+		Annotations: mutates(),
+		Short:       "Take problems out of the active pool, by ID or in bulk",
+		Long: "Archived problems are kept with their history, but pick, next, boss, mock, sessions, plans and " +
+			"digests leave them out, and list hides them unless --archived is given. Archive problems by ID, or " +
+			"every problem matching all of the filters.",
+		Example: `  saitama archive LC1 LC2
+  saitama archive --solved-before 2023-01-01 --min-solves 3 --dry-run
+  saitama archive --inactive-days 180 --tag math
+  saitama unarchive LC1`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if solvedBefore != "" {
+				day, err := time.ParseInLocation("2006-01-02", solvedBefore, time.Local)
+				if err != nil {
+					printError(tr("❌ Invalid date '%s' (use YYYY-MM-DD)"), solvedBefore)
+					return
+				}
+				f.SolvedBefore = day
+			}
+			if len(args) > 0 {
+				if !f.empty() {
+					printError(tr("❌ Give either problem IDs or filters, not both"))
+					return
+				}
+				if dryRun {
+					color.Cyan(tr("🔍 Dry run: would archive %s"), strings.Join(args, ", "))
+					return
+				}
+				setArchived(args, true)
+				return
+			}
+			if f.empty() {
+				printError(tr("❌ Give problem IDs or at least one filter (--solved-before, --min-solves, --inactive-days, --tag)"))
+				return
+			}
+
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			now := time.Now()
+			var matches []int
+			for i, p := range problems {
+				if !p.Archived && f.matches(p, now) {
+					matches = append(matches, i)
+				}
+			}
+			if len(matches) == 0 {
+				color.Yellow(tr("🔍 No active problems match the given filters."))
+				return
+			}
+
+			color.Cyan(tr("📦 %d problem(s) to archive:"), len(matches))
+			for n, i := range matches {
+				if n == 20 {
+					color.HiBlack(tr("   ... and %d more"), len(matches)-n)
+					break
+				}
+				p := problems[i]
+				detail := fmt.Sprintf(tr("%d solve(s)"), solveCount(p))
+				if !p.LastSolved.IsZero() {
+					detail += fmt.Sprintf(tr(", last %s"), p.LastSolved.Format("2006-01-02"))
+				}
+				printResult(color.New(color.FgWhite), "   %s - %s (%s)", p.ID, p.Name, detail)
+			}
+			if dryRun {
+				color.Cyan(tr("🔍 Dry run: nothing was archived."))
+				return
+			}
+			if !yes {
+				confirm := false
+				prompt := &survey.Confirm{Message: fmt.Sprintf("Archive these %d problem(s)?", len(matches))}
+				if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+					color.Yellow(tr("Archive cancelled."))
+					return
+				}
+			}
+
+			for _, i := range matches {
+				problems[i].Archived = true
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("📦 Archived %d problem(s). List them with: saitama list --archived"), len(matches))
+		},
+	}
+	cmd.Flags().StringVar(&solvedBefore, "solved-before", "", "archive problems last solved before this day (YYYY-MM-DD)")
+	cmd.Flags().IntVar(&f.MinSolves, "min-solves", 0, "archive problems solved at least this many times")
+	cmd.Flags().IntVar(&f.InactiveDays, "inactive-days", 0, "archive problems not attempted nor solved for this many days")
+	cmd.Flags().StringSliceVarP(&f.Tags, "tag", "t", nil, "archive problems with one of these tags")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be archived without changing anything")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "archive without asking for confirmation")
+	return cmd
+}
+
+func unarchiveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "unarchive <id>...",
+		Annotations: mutates(),
+		Short:       "Put archived problems back in the active pool",
+		Example:     "  saitama list --archived\n  saitama unarchive LC1 CF1000A",
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			setArchived(args, false)
+		},
+	}
+}
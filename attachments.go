@@ -0,0 +1,226 @@
+// attachments.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// attachmentsDirName is the folder of the data directory holding the files attached to
+// problems, e.g. solutions: attachments/<ID>/<file>.
+const attachmentsDirName = "attachments"
+
+// attachmentsDir returns the folder of the files attached to a problem.
+func attachmentsDir(id string) (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, attachmentsDirName, unsafeFileChars.ReplaceAllString(id, "_")), nil
+}
+
+// listAttachments returns the names of the files attached to a problem, sorted.
+func listAttachments(id string) ([]string, error) {
+	dir, err := attachmentsDir(id)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachments: %w", err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// validAttachmentName reports whether a name can be a file of the attachments folder.
+func validAttachmentName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`) && !strings.HasSuffix(name, ".tmp")
+}
+
+// writeAttachment stores a file attached to a problem, replacing the one with the name.
+func writeAttachment(id, name string, r io.Reader) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	if !validAttachmentName(name) {
+		return fmt.Errorf("invalid attachment name '%s'", name)
+	}
+	dir, err := attachmentsDir(id)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create attachments folder: %w", err)
+	}
+	path := filepath.Join(dir, name)
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// attachFile copies a file into the attachments of a problem and returns its name.
+func attachFile(id, src string) (string, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return "", err
+	}
+	defer in.Close()
+	if info, err := in.Stat(); err != nil {
+		return "", err
+	} else if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("%s is not a regular file", src)
+	}
+	name := filepath.Base(src)
+	return name, writeAttachment(id, name, in)
+}
+
+// removeAttachment deletes a file attached to a problem, and the folder once empty.
+func removeAttachment(id, name string) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	dir, err := attachmentsDir(id)
+	if err != nil {
+		return err
+	}
+	if !validAttachmentName(name) {
+		return fmt.Errorf("invalid attachment name '%s'", name)
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s has no attachment '%s'", id, name)
+		}
+		return err
+	}
+	_ = os.Remove(dir) // only succeeds when empty
+	return nil
+}
+
+// moveAttachments moves the attachments of a renamed problem to its new ID.
+func moveAttachments(oldID, newID string) error {
+	from, err := attachmentsDir(oldID)
+	if err != nil {
+		return err
+	}
+	to, err := attachmentsDir(newID)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		return nil
+	}
+	return os.Rename(from, to)
+}
+
+// printAttachments lists the files attached to a problem in show.
+func printAttachments(id string) {
+	names, err := listAttachments(id)
+	if err != nil || len(names) == 0 {
+		return
+	}
+	printResult(color.New(color.FgWhite), tr("🗂️  Files: %s"), strings.Join(names, ", "))
+}
+
+func attachCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "attach <id> <file>...",
+		Annotations: mutates(),
+		Short:       "Attach files to a problem, e.g. your solution",
+		Long: "Copies files into the attachments folder of the problem in the data directory, replacing the files " +
+			"with the same names. Attachments travel with the problem in bundle exports ('saitama export study.zip').",
+		Example: `  saitama attach CF1915F solution.cpp
+  saitama attach list CF1915F
+  saitama attach remove CF1915F solution.cpp`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			id := strings.ToUpper(args[0])
+			if _, index := findProblemByID(problems, id); index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), id)
+				return
+			}
+			for _, src := range args[1:] {
+				name, err := attachFile(id, src)
+				if err != nil {
+					printError(tr("❌ Could not attach %s: %v"), src, err)
+					continue
+				}
+				color.Green(tr("🗂️  Attached %s to %s"), name, id)
+			}
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list <id>",
+		Aliases: []string{"ls"},
+		Short:   "List the files attached to a problem",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := strings.ToUpper(args[0])
+			names, err := listAttachments(id)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if jsonOutput() {
+				printJSON(append([]string{}, names...))
+				return
+			}
+			if len(names) == 0 {
+				color.Yellow(tr("🗂️  No files attached to %s. Attach one with: saitama attach %s <file>"), id, id)
+				return
+			}
+			dir, _ := attachmentsDir(id)
+			for _, name := range names {
+				fmt.Fprintln(stdout, filepath.Join(dir, name))
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "remove <id> <file>",
+		Annotations: mutates(),
+		Short:       "Remove a file attached to a problem",
+		Args:        cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := strings.ToUpper(args[0])
+			if err := removeAttachment(id, args[1]); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🗑️  Removed %s from %s"), args[1], id)
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,522 @@
+// backup.go
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// BackupConfig holds the settings of the daily full backups.
+type BackupConfig struct {
+	Daily     bool `json:"daily"`      // archive the full state on the first save of every day
+	KeepDaily int  `json:"keep_daily"` // number of daily backups kept
+}
+
+const (
+	snapshotTimeLayout = "20060102_150405" // per-save backups and state archives
+	dailyTimeLayout    = "20060102"
+	checksumExt        = ".sha256"
+)
+
+// backupTime reads the timestamp of a backup named prefix<timestamp>ext.
+func backupTime(name, prefix, ext string) (time.Time, bool) {
+	stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+	for _, layout := range []string{snapshotTimeLayout, dailyTimeLayout} {
+		if t, err := time.ParseInLocation(layout, stamp, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// fileChecksum returns the hex SHA-256 of a file.
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeChecksum writes the checksum of a backup next to it, in the format of sha256sum
+// so that 'sha256sum -c' checks it too.
+func writeChecksum(path string) error {
+	sum, err := fileChecksum(path)
+	if err != nil {
+		return fmt.Errorf("failed to checksum backup: %w", err)
+	}
+	line := fmt.Sprintf("%s  %s\n", sum, filepath.Base(path))
+	if err := os.WriteFile(path+checksumExt, []byte(line), 0644); err != nil {
+		return fmt.Errorf("failed to write checksum: %w", err)
+	}
+	return nil
+}
+
+// createScheduledBackup archives the full state once a day, unless disabled, and prunes
+// the daily backups beyond the configured number.
+func createScheduledBackup(backupDir string, now time.Time) error {
+	cfg, err := loadConfig()
+	if err != nil || !cfg.Backup.Daily {
+		return nil
+	}
+	target := filepath.Join(backupDir, fmt.Sprintf("daily_%s.tar.gz", now.Format(dailyTimeLayout)))
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+	tempFile := target + ".tmp"
+	if _, err := writeStateArchive(tempFile); err != nil {
+		_ = os.Remove(tempFile)
+		return err
+	}
+	if err := os.Rename(tempFile, target); err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("failed to write daily backup: %w", err)
+	}
+	if err := writeChecksum(target); err != nil {
+		return err
+	}
+	slog.Info("created daily backup", "path", target)
+	return pruneBackups(backupDir, "daily_", ".tar.gz", max(1, cfg.Backup.KeepDaily))
+}
+
+// backupCheck is the result of verifying one backup.
+type backupCheck struct {
+	File     string `json:"file"`
+	Problems int    `json:"problems"`
+	Checksum string `json:"checksum"` // ok, missing or mismatch
+	Error    string `json:"error,omitempty"`
+}
+
+// verifyBackup checks a backup against its checksum and that its problems parse: a
+// problems file, or the one inside a state archive.
+func verifyBackup(path string) backupCheck {
+	check := backupCheck{File: filepath.Base(path), Checksum: "missing"}
+	if data, err := os.ReadFile(path + checksumExt); err == nil {
+		want, _, _ := strings.Cut(strings.TrimSpace(string(data)), " ")
+		got, err := fileChecksum(path)
+		if err != nil {
+			check.Error = err.Error()
+			return check
+		}
+		check.Checksum = "ok"
+		if got != want {
+			check.Checksum = "mismatch"
+			check.Error = "the file doesn't match its checksum"
+			return check
+		}
+	}
+
+	parse := func(r io.Reader, name string) error {
+		var problems []Problem
+		if err := json.NewDecoder(r).Decode(&problems); err != nil {
+			return fmt.Errorf("%s doesn't parse: %w", name, err)
+		}
+		check.Problems = len(problems)
+		return nil
+	}
+	var err error
+	if strings.HasSuffix(path, ".json") {
+		var f *os.File
+		if f, err = os.Open(path); err == nil {
+			err = parse(f, "the file")
+			f.Close()
+		}
+	} else {
+		err = walkStateArchive(path, func(name string, r io.Reader) error {
+			if name == "problems.json" {
+				return parse(r, name)
+			}
+			_, err := io.Copy(io.Discard, r) // read through, so that truncation shows
+			return err
+		})
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+	return check
+}
+
+// walkStateArchive calls fn with every regular file of a state archive.
+func walkStateArchive(path string, fn func(name string, r io.Reader) error) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// getAppDir returns the directory holding all of saitama's state.
+func getAppDir() (string, error) {
+	dbPath, err := getDbPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(dbPath), nil
+}
+
+// isStateFile reports whether a path (relative to the app dir) belongs in a state archive.
+// Per-save backups, temporary files, logs, the metadata cache, the lock file, the daemon
+// socket and the prompt status cache are left out.
+func isStateFile(rel string) bool {
+	first := strings.Split(filepath.ToSlash(rel), "/")[0]
+	return first != ".saitama_backups" && first != lockFileName && first != logDirName && first != cacheDirName &&
+		first != daemonSocketName && first != promptStatusName &&
+		!strings.HasSuffix(rel, ".tmp")
+}
+
+// writeStateArchive bundles every state file of the app dir into a gzipped tarball.
+func writeStateArchive(target string) (int, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return 0, err
+	}
+
+	out, err := os.Create(target)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+
+	count := 0
+	err = filepath.Walk(appDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(appDir, path)
+		if err != nil || rel == "." {
+			return err
+		}
+		if !isStateFile(rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		absTarget, _ := filepath.Abs(target)
+		if path == absTarget {
+			return nil // never archive the archive itself
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return err
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive state: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return 0, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return count, nil
+}
+
+// readStateArchive extracts a state archive into the app dir, replacing existing files.
+func readStateArchive(source string) (int, error) {
+	if isReadOnly() {
+		return 0, errReadOnly
+	}
+	appDir, err := getAppDir()
+	if err != nil {
+		return 0, err
+	}
+
+	in, err := os.Open(source)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer in.Close()
+
+	gz, err := gzip.NewReader(in)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	count := 0
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		rel := filepath.FromSlash(header.Name)
+		if filepath.IsAbs(rel) || strings.HasPrefix(filepath.Clean(rel), "..") || !isStateFile(rel) {
+			return count, fmt.Errorf("archive contains an invalid path: %s", header.Name)
+		}
+
+		dest := filepath.Join(appDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return count, fmt.Errorf("failed to create directory: %w", err)
+		}
+		tempFile := dest + ".tmp"
+		f, err := os.OpenFile(tempFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+		if err != nil {
+			return count, fmt.Errorf("failed to write %s: %w", header.Name, err)
+		}
+		_, copyErr := io.Copy(f, tr)
+		closeErr := f.Close()
+		if copyErr != nil || closeErr != nil {
+			_ = os.Remove(tempFile)
+			return count, fmt.Errorf("failed to write %s", header.Name)
+		}
+		if err := os.Rename(tempFile, dest); err != nil {
+			_ = os.Remove(tempFile)
+			return count, fmt.Errorf("failed to replace %s: %w", header.Name, err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+func backupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up or restore the full application state",
+		Long: "Bundle problems, config and every other file saitama stores into a single archive, e.g. to move to a new machine. " +
+			"Besides the backup made on every save, the full state is archived once a day (\"backup\" in the config: " +
+			"daily, keep_daily), each with a checksum file.",
+		Example: `  saitama backup export ~/saitama-state.tar.gz
+  saitama backup import ~/saitama-state.tar.gz
+  saitama backup restore latest
+  saitama backup verify`,
+	}
+
+	cmd.AddCommand(gistCmd())
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "export <file.tar.gz>",
+		Short:   "Write the full application state to an archive",
+		Example: "  saitama backup export ~/saitama-state.tar.gz",
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			count, err := writeStateArchive(args[0])
+			if err != nil {
+				printError(tr("❌ Error exporting state: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Exported %d files to %s"), count, args[0])
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "import <file.tar.gz>",
+		Annotations: mutates(),
+		Short:       "Restore the full application state from an archive",
+		Example:     "  saitama backup import ~/saitama-state.tar.gz",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			confirm := false
+			prompt := &survey.Confirm{Message: "This will replace your current problems, config and other data. Continue?"}
+			if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+				color.Yellow(tr("Import cancelled."))
+				return
+			}
+
+			// Keep a copy of the current state in case the archive was not the right one.
+			backupDir, err := getBackupDir()
+			if err == nil {
+				err = os.MkdirAll(backupDir, 0755)
+			}
+			if err != nil {
+				printError(tr("❌ Error preparing backup directory: %v"), err)
+				return
+			}
+			safety := filepath.Join(backupDir, fmt.Sprintf("state_%s.tar.gz", time.Now().Format(snapshotTimeLayout)))
+			if _, err := writeStateArchive(safety); err == nil {
+				err = writeChecksum(safety)
+			}
+			if err != nil {
+				printError(tr("❌ Error backing up current state: %v"), err)
+				return
+			}
+
+			count, err := readStateArchive(args[0])
+			if err != nil {
+				printError(tr("❌ Error importing state: %v"), err)
+				color.Yellow(tr("💡 Your previous state was saved to %s"), safety)
+				return
+			}
+			color.Green(tr("✅ Restored %d files from %s"), count, args[0])
+			color.Cyan(tr("💾 Previous state saved to %s"), safety)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "restore <backup>",
+		Annotations: mutates(),
+		Short:       "Replace your problems with a per-save or pre-import backup",
+		Long: "Replaces the whole problem list with the one of a backup: a file, or the timestamp of a backup of " +
+			"the backup folder (\"latest\" for the newest). Problems added since the backup are removed. The " +
+			"backup must match its checksum. To restore a full state archive, use 'backup import'.",
+		Example: `  saitama backup restore latest
+  saitama backup restore 20260115_0930
+  saitama diff --backup 20260115_0930   # check it first`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := args[0]
+			if _, err := os.Stat(path); err != nil {
+				if path, err = findBackup(args[0]); err != nil {
+					printError("❌ %v", err)
+					return
+				}
+			}
+			if isStateArchive(path) {
+				printError(tr("❌ %s is a full state archive: restore it with 'saitama backup import %s'"), path, path)
+				return
+			}
+			if check := verifyBackup(path); check.Error != "" {
+				printError(tr("❌ Can't restore %s: %s"), path, check.Error)
+				return
+			}
+			problems, err := loadSnapshot(path)
+			if err != nil {
+				printError(tr("❌ Error reading backup: %v"), err)
+				return
+			}
+
+			confirm := false
+			prompt := &survey.Confirm{Message: fmt.Sprintf("Replace your current problems with the %d problems of %s?", len(problems), filepath.Base(path))}
+			if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+				color.Yellow(tr("Restore cancelled."))
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving problems: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Restored %d problems from %s (previous data kept in the local backups)"), len(problems), filepath.Base(path))
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "verify",
+		Short: "Check that every backup matches its checksum and parses",
+		Long: "Checks the per-save backups, the daily full backups and the state saved before 'backup import' " +
+			"in the backup folder: each must match the checksum written next to it, and its problems must parse. " +
+			"Backups made before checksums were written are only parsed.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			backupDir, err := getBackupDir()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			entries, err := os.ReadDir(backupDir)
+			if err != nil && !os.IsNotExist(err) {
+				printError(tr("❌ Error reading backups: %v"), err)
+				return
+			}
+			var checks []backupCheck
+			for _, e := range entries {
+				name := e.Name()
+				if e.IsDir() || !(strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".tar.gz")) {
+					continue
+				}
+				checks = append(checks, verifyBackup(filepath.Join(backupDir, name)))
+			}
+			sort.Slice(checks, func(i, j int) bool { return checks[i].File < checks[j].File })
+			if jsonOutput() {
+				printJSON(checks)
+				return
+			}
+			if len(checks) == 0 {
+				color.Yellow(tr("💾 No backups yet in %s"), backupDir)
+				return
+			}
+
+			failed, unchecked := 0, 0
+			for _, c := range checks {
+				switch {
+				case c.Error != "":
+					failed++
+					printResult(color.New(color.FgRed), "❌ %s: %s", c.File, c.Error)
+				case c.Checksum == "missing":
+					unchecked++
+					printResult(color.New(color.FgYellow), tr("⚠️  %s: %d problem(s), no checksum"), c.File, c.Problems)
+				default:
+					printResult(color.New(color.FgGreen), tr("✅ %s: %d problem(s)"), c.File, c.Problems)
+				}
+			}
+			fmt.Fprintln(stderr)
+			if failed > 0 {
+				printError(tr("❌ %d of %d backup(s) are damaged."), failed, len(checks))
+				return
+			}
+			color.Green(tr("✅ All %d backup(s) are readable, %d without a checksum."), len(checks), unchecked)
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,131 @@
+// bookmarks.go
+package main
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bookmarkLink matches a link of a Netscape bookmark file, as exported by every browser:
+// <DT><A HREF="https://..." ADD_DATE="1700000000" ...>Title</A>
+var bookmarkLink = regexp.MustCompile(`(?is)<a\s+([^>]*)>(.*?)</a>`)
+
+var (
+	bookmarkHref    = regexp.MustCompile(`(?i)\bhref\s*=\s*"([^"]*)"`)
+	bookmarkAddDate = regexp.MustCompile(`(?i)\badd_date\s*=\s*"(\d+)"`)
+	// "1. Two Sum - LeetCode" carries the LeetCode problem number.
+	leetcodeTitle = regexp.MustCompile(`^(\d+)\.\s*(.+)$`)
+	// Judges append their name to page titles, e.g. "Two Sum - LeetCode" or "Solve Me First | HackerRank".
+	judgeTitleSuffix = regexp.MustCompile(`(?i)\s*[-|–]\s*(leetcode|codeforces|hackerrank|atcoder|codechef)\s*$`)
+)
+
+// problemPagePrefixes are the normalized URL prefixes of problem pages on the built-in
+// platforms, so that bookmarks of their discussion or profile pages are left out.
+var problemPagePrefixes = map[string]string{
+	"leetcode":   "leetcode.com/problems/",
+	"codeforces": "codeforces.com/problem/",
+	"hackerrank": "hackerrank.com/challenges/",
+	"atcoder":    "atcoder.jp/contests/",
+	"codechef":   "codechef.com/problems/",
+}
+
+// isBookmarksFile reports whether a file looks like a browser bookmarks export.
+func isBookmarksFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".html" || ext == ".htm"
+}
+
+// bookmarkProblemID derives a problem ID from the platform's conventions when the URL or
+// title carries it (LC1, CF1000A, CCFLOW001), or returns "".
+func bookmarkProblemID(platform, key, title string) string {
+	switch platform {
+	case "leetcode":
+		if m := leetcodeTitle.FindStringSubmatch(title); m != nil {
+			return "LC" + m[1]
+		}
+	case "codeforces":
+		parts := strings.Split(strings.TrimPrefix(key, "codeforces.com/problem/"), "/")
+		if len(parts) == 2 {
+			return strings.ToUpper("CF" + parts[0] + parts[1])
+		}
+	case "codechef":
+		return strings.ToUpper("CC" + strings.TrimPrefix(key, "codechef.com/problems/"))
+	}
+	return ""
+}
+
+// bookmarkProblemName cleans a page title into a problem name, falling back to the
+// last part of the URL.
+func bookmarkProblemName(title, key string) string {
+	name := judgeTitleSuffix.ReplaceAllString(title, "")
+	if m := leetcodeTitle.FindStringSubmatch(name); m != nil {
+		name = m[2]
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		return name
+	}
+	return key[strings.LastIndex(key, "/")+1:]
+}
+
+// importBookmarks reads a Netscape bookmark file and turns the links to problem pages
+// of known platforms into problems. Problems whose ID can't be derived get sequential
+// BM IDs, numbered after the ones in existing. It returns the problems and how many
+// links were left out.
+func importBookmarks(filename string, defs []PlatformDef, existing []Problem) ([]Problem, int, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+	if !strings.Contains(strings.ToUpper(string(data[:min(len(data), 512)])), "NETSCAPE-BOOKMARK-FILE") {
+		return nil, 0, fmt.Errorf("%s is not a browser bookmarks export", filename)
+	}
+
+	var problems []Problem
+	nextBM, _ := strconv.Atoi(strings.TrimPrefix(nextSequentialID(existing, "BM"), "BM"))
+	seen := make(map[string]bool)
+	skipped := 0
+	for _, m := range bookmarkLink.FindAllStringSubmatch(string(data), -1) {
+		href := bookmarkHref.FindStringSubmatch(m[1])
+		if href == nil {
+			continue
+		}
+		link := html.UnescapeString(href[1])
+		def, ok := platformForURL(defs, link)
+		key := normalizeURL(link)
+		if prefix, builtin := problemPagePrefixes[def.Name]; !ok || (builtin && !strings.HasPrefix(key, prefix)) {
+			skipped++
+			continue
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		title := strings.TrimSpace(html.UnescapeString(m[2]))
+		p := Problem{
+			ID:        bookmarkProblemID(def.Name, key, title),
+			Name:      bookmarkProblemName(title, key),
+			Tags:      []string{},
+			Platform:  def.Name,
+			URL:       link,
+			DateAdded: time.Now(),
+		}
+		if added := bookmarkAddDate.FindStringSubmatch(m[1]); added != nil {
+			if secs, err := strconv.ParseInt(added[1], 10, 64); err == nil {
+				p.DateAdded = time.Unix(secs, 0)
+			}
+		}
+		if p.ID == "" {
+			p.ID = "BM" + strconv.Itoa(nextBM)
+			nextBM++
+		}
+		problems = append(problems, p)
+	}
+	return problems, skipped, nil
+}
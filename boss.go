@@ -0,0 +1,190 @@
+// boss.go
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// hasAnyTag reports whether the problem carries at least one of the given tags.
+// An empty tag list matches every problem.
+func hasAnyTag(p Problem, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	for _, want := range tags {
+		for _, tag := range p.Tags {
+			if strings.EqualFold(tag, strings.TrimSpace(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// bossCandidates returns the unsolved problems worthy of a boss fight: hard problems,
+// or when there are none, the problems with the highest rating.
+func bossCandidates(pool []Problem) []Problem {
+	var hard []Problem
+	bestRating := 0
+	for _, p := range pool {
+		if strings.EqualFold(p.Difficulty, "hard") {
+			hard = append(hard, p)
+		}
+		if p.Rating > bestRating {
+			bestRating = p.Rating
+		}
+	}
+	if len(hard) > 0 {
+		return hard
+	}
+
+	var rated []Problem
+	for _, p := range pool {
+		if bestRating > 0 && p.Rating == bestRating {
+			rated = append(rated, p)
+		}
+	}
+	return rated
+}
+
+func bossCmd() *cobra.Command {
+	var tags []string
+	var revenge, noTimer bool
+	var minutes int
+	cmd := &cobra.Command{
+		Use:         "boss",
+		Annotations: mutates(),
+		Short:       "Face a random hard unsolved problem",
+		Long:        color.HiRedString("👹 BOSS FIGHT! ") + "Pick one unsolved hard (or highest-rated) problem and fight it against the clock.",
+		Example: `  saitama boss                 # Random hard unsolved problem
+  saitama boss --tag graph     # Only graph bosses
+  saitama boss --revenge       # A boss that defeated you before
+  saitama boss --minutes 90    # Longer fight`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+
+			now := time.Now()
+			cooldown := time.Duration(cfg.Boss.CooldownDays) * 24 * time.Hour
+
+			var pool []Problem
+			var nextAvailable time.Time
+			for _, p := range problems {
+				if isSolved(p) || p.Archived || isSnoozed(p, now) || !hasAnyTag(p, tags) {
+					continue
+				}
+				if !p.LastBoss.IsZero() && now.Sub(p.LastBoss) < cooldown {
+					if available := p.LastBoss.Add(cooldown); nextAvailable.IsZero() || available.Before(nextAvailable) {
+						nextAvailable = available
+					}
+					continue
+				}
+				pool = append(pool, p)
+			}
+
+			if revenge {
+				var failed []Problem
+				for _, p := range pool {
+					if hasFailedAttempt(p) {
+						failed = append(failed, p)
+					}
+				}
+				if len(failed) == 0 {
+					color.Yellow(tr("⚠️  No previously failed problems available, any boss will do."))
+				} else {
+					pool = failed
+				}
+			}
+
+			candidates := bossCandidates(pool)
+			if len(candidates) == 0 {
+				color.Yellow(tr("👹 No boss found! You need unsolved hard (or rated) problems."))
+				if !nextAvailable.IsZero() {
+					color.Cyan(tr("💡 A boss comes off cooldown on %s"), nextAvailable.Format("2006-01-02"))
+				}
+				return
+			}
+
+			boss := candidates[rand.Intn(len(candidates))]
+			_, index := findProblemByID(problems, boss.ID)
+			problems[index].LastBoss = now
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiRed("☠️ ═══════════════════════════════════════════════ ☠️")
+			color.HiRed(tr("              👹  A WILD BOSS APPEARS!  👹          "))
+			color.HiRed("☠️ ═══════════════════════════════════════════════ ☠️")
+			fmt.Fprintln(stderr)
+			color.HiYellow("   🆔 %s", boss.ID)
+			color.HiWhite("   📝 %s", boss.Name)
+			if boss.Difficulty != "" {
+				color.Red(tr("   💀 Difficulty: %s"), boss.Difficulty)
+			}
+			if boss.Rating > 0 {
+				color.Red(tr("   ⚔️  Rating: %d"), boss.Rating)
+			}
+			if len(boss.Tags) > 0 {
+				color.Green("   🏷️  %s", strings.Join(boss.Tags, " • "))
+			}
+			if boss.URL != "" {
+				color.Cyan("   🔗 %s", boss.URL)
+			}
+			if hasFailedAttempt(boss) {
+				color.Magenta(tr("   🔥 This boss has defeated you before. Time for revenge!"))
+			}
+			fmt.Fprintln(stderr)
+
+			if noTimer {
+				color.HiGreen(tr("💪 Go get it, hero! ONE PUNCH! 🥊"))
+				return
+			}
+
+			if minutes <= 0 {
+				minutes = cfg.Boss.TimerMinutes
+			}
+			elapsed, _ := runCountdown(time.Duration(minutes)*time.Minute, "⏳ Boss fight:")
+
+			defeated := false
+			if err := survey.AskOne(&survey.Confirm{Message: "👊 Did you defeat the boss?"}, &defeated); err != nil {
+				color.Yellow(tr("👋 Result not recorded."))
+				return
+			}
+
+			if err := recordTimedAttempts([]timedAttempt{{ID: boss.ID, Solved: defeated, Spent: elapsed, At: time.Now()}}); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+
+			if defeated {
+				color.HiGreen(tr("🎉 BOSS DEFEATED in %s! ONE PUNCH! 🥊"), formatClock(elapsed))
+			} else {
+				color.Yellow(tr("😤 The boss survives... for now. It will return after its cooldown."))
+			}
+			fireSessionCompleted(SessionSummary{Kind: "boss", Started: now, Problems: []SessionProblem{
+				{ID: boss.ID, Name: boss.Name, Done: true, Solved: defeated, Seconds: int(elapsed.Seconds())},
+			}})
+		},
+	}
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "only fight bosses with one of these tags")
+	cmd.Flags().BoolVar(&revenge, "revenge", false, "prefer a boss you have failed before")
+	cmd.Flags().IntVarP(&minutes, "minutes", "m", 0, "fight duration in minutes (default from config, 60)")
+	cmd.Flags().BoolVar(&noTimer, "no-timer", false, "only reveal the boss without starting the timer")
+	return cmd
+}
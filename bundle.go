@@ -0,0 +1,242 @@
+// bundle.go
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// A bundle is a zip file sharing a complete study pack: the problems as in a JSON export,
+// their notes as Markdown for reading, and the files attached to them.
+const (
+	bundleFormat       = "saitama-bundle"
+	bundleVersion      = 1
+	bundleManifestName = "manifest.json"
+	bundleProblemsName = "problems.json"
+	bundleNotesDir     = "notes"
+)
+
+// bundleManifest describes the content of a bundle.
+type bundleManifest struct {
+	Format      string    `json:"format"`
+	Version     int       `json:"version"`
+	Exported    time.Time `json:"exported"`
+	Problems    int       `json:"problems"`
+	Notes       int       `json:"notes"`
+	Attachments int       `json:"attachments"`
+}
+
+// isBundleFile reports whether a file name looks like a bundle.
+func isBundleFile(filename string) bool {
+	return strings.EqualFold(filepath.Ext(filename), ".zip")
+}
+
+// exportBundle writes the problems, their notes and their attachments to a zip file.
+func exportBundle(problems []Problem, filename string) (bundleManifest, error) {
+	m := bundleManifest{Format: bundleFormat, Version: bundleVersion, Exported: time.Now(), Problems: len(problems)}
+	f, err := os.Create(filename)
+	if err != nil {
+		return m, fmt.Errorf("failed to create bundle: %w", err)
+	}
+	defer f.Close()
+	zw := zip.NewWriter(f)
+
+	writeEntry := func(name string, content []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: m.Exported})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(content)
+		return err
+	}
+
+	data, err := json.MarshalIndent(problems, "", "  ")
+	if err != nil {
+		return m, fmt.Errorf("failed to marshal problems for export: %w", err)
+	}
+	if err := writeEntry(bundleProblemsName, data); err != nil {
+		return m, fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	for _, p := range problems {
+		if strings.TrimSpace(p.Notes) != "" {
+			note := renderVaultNote("", vaultFrontmatter(p), vaultBody(p))
+			if err := writeEntry(path.Join(bundleNotesDir, vaultFileName(p.ID)), []byte(note)); err != nil {
+				return m, fmt.Errorf("failed to write bundle: %w", err)
+			}
+			m.Notes++
+		}
+
+		names, err := listAttachments(p.ID)
+		if err != nil {
+			return m, err
+		}
+		dir, err := attachmentsDir(p.ID)
+		if err != nil {
+			return m, err
+		}
+		for _, name := range names {
+			content, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return m, fmt.Errorf("failed to read attachment: %w", err)
+			}
+			if err := writeEntry(path.Join(attachmentsDirName, p.ID, name), content); err != nil {
+				return m, fmt.Errorf("failed to write bundle: %w", err)
+			}
+			m.Attachments++
+		}
+	}
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return m, err
+	}
+	if err := writeEntry(bundleManifestName, manifest); err != nil {
+		return m, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return m, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return m, f.Close()
+}
+
+// problemBundle is a bundle opened for import.
+type problemBundle struct {
+	zr          *zip.ReadCloser
+	Manifest    bundleManifest
+	Problems    []Problem
+	attachments map[string][]*zip.File // by problem ID
+}
+
+// openBundle reads the manifest and the problems of a bundle. The attachments are read
+// later by restoreAttachments, once the import is confirmed.
+func openBundle(filename string) (*problemBundle, error) {
+	zr, err := zip.OpenReader(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	b := &problemBundle{zr: zr, attachments: make(map[string][]*zip.File)}
+	readJSON := func(f *zip.File, out any) error {
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		return json.NewDecoder(r).Decode(out)
+	}
+
+	var foundManifest, foundProblems bool
+	for _, f := range zr.File {
+		switch {
+		case f.Name == bundleManifestName:
+			if err := readJSON(f, &b.Manifest); err != nil {
+				zr.Close()
+				return nil, fmt.Errorf("failed to parse the bundle manifest: %w", err)
+			}
+			foundManifest = true
+		case f.Name == bundleProblemsName:
+			if err := readJSON(f, &b.Problems); err != nil {
+				zr.Close()
+				return nil, fmt.Errorf("failed to parse the problems of the bundle: %w", err)
+			}
+			foundProblems = true
+		case strings.HasPrefix(f.Name, attachmentsDirName+"/") && !f.FileInfo().IsDir():
+			// attachments/<ID>/<name>; anything deeper or oddly named is ignored.
+			parts := strings.Split(strings.TrimPrefix(f.Name, attachmentsDirName+"/"), "/")
+			if len(parts) == 2 && parts[0] != "" && validAttachmentName(parts[1]) {
+				b.attachments[parts[0]] = append(b.attachments[parts[0]], f)
+			}
+		}
+	}
+	switch {
+	case !foundManifest || b.Manifest.Format != bundleFormat:
+		zr.Close()
+		return nil, fmt.Errorf("%s is not a saitama bundle (no %s)", filename, bundleManifestName)
+	case b.Manifest.Version > bundleVersion:
+		zr.Close()
+		return nil, fmt.Errorf("the bundle has version %d, this saitama reads up to version %d (try: saitama upgrade)", b.Manifest.Version, bundleVersion)
+	case !foundProblems:
+		zr.Close()
+		return nil, fmt.Errorf("the bundle has no %s", bundleProblemsName)
+	}
+	for i, p := range b.Problems {
+		if err := validateImportedProblem(p); err != nil {
+			zr.Close()
+			return nil, fmt.Errorf("invalid problem at index %d (%v)", i, err)
+		}
+	}
+	return b, nil
+}
+
+func (b *problemBundle) Close() error {
+	return b.zr.Close()
+}
+
+// restoreAttachments copies the attachments of the bundle to the problems they belong to
+// after the import. ids maps the IDs of the bundle to the IDs they were imported as
+// (duplicates merge into problems with another ID); problems left out keep nothing.
+// Existing files with the same name and different content are kept and counted, unless
+// overwrite is set (--on-conflict theirs).
+func (b *problemBundle) restoreAttachments(ids map[string]string, overwrite bool) (restored, kept int, err error) {
+	for bundleID, files := range b.attachments {
+		id, ok := ids[bundleID]
+		if !ok {
+			continue
+		}
+		dir, err := attachmentsDir(id)
+		if err != nil {
+			return restored, kept, err
+		}
+		for _, f := range files {
+			name := path.Base(f.Name)
+			if existing, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+				same, err := zipFileEquals(f, existing)
+				if err != nil {
+					return restored, kept, err
+				}
+				if same {
+					continue
+				}
+				if !overwrite {
+					kept++
+					continue
+				}
+			}
+			r, err := f.Open()
+			if err != nil {
+				return restored, kept, fmt.Errorf("failed to read %s from the bundle: %w", f.Name, err)
+			}
+			err = writeAttachment(id, name, r)
+			r.Close()
+			if err != nil {
+				return restored, kept, err
+			}
+			restored++
+		}
+	}
+	return restored, kept, nil
+}
+
+// zipFileEquals reports whether a file of a zip has the given content.
+func zipFileEquals(f *zip.File, content []byte) (bool, error) {
+	if f.UncompressedSize64 != uint64(len(content)) {
+		return false, nil
+	}
+	r, err := f.Open()
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	return string(data) == string(content), nil
+}
@@ -0,0 +1,278 @@
+// cache.go
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// cacheDirName is the folder of the data directory holding fetched platform metadata.
+const cacheDirName = "cache"
+
+// CacheConfig controls the on-disk cache of platform metadata.
+type CacheConfig struct {
+	Enabled  bool `json:"enabled"`
+	TTLHours int  `json:"ttl_hours"` // how long fetched data is used before fetching it again
+}
+
+// forceRefetch makes cached fetches always go to the network, without falling back to the
+// cache when offline. Set by refresh, which is about catching changes on the platforms.
+var forceRefetch bool
+
+// cacheEntry is one cached response.
+type cacheEntry struct {
+	Key     string          `json:"key"` // the URL, or a description of the request
+	Fetched time.Time       `json:"fetched"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func getCacheDir() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, cacheDirName), nil
+}
+
+// cachePath returns the file of a cache key.
+func cachePath(key string) (string, error) {
+	dir, err := getCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, hex.EncodeToString(sum[:16])+".json"), nil
+}
+
+func readCache(key string) (cacheEntry, bool) {
+	path, err := cachePath(key)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		slog.Debug("ignoring unreadable cache entry", "path", path, "err", err)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// writeCache stores a response. Failing to is not an error: the data was fetched anyway.
+func writeCache(key string, data []byte) {
+	if isReadOnly() {
+		return
+	}
+	path, err := cachePath(key)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+	}
+	var entry []byte
+	if err == nil {
+		entry, err = json.Marshal(cacheEntry{Key: key, Fetched: time.Now(), Data: data})
+	}
+	if err == nil {
+		tmp := path + ".tmp"
+		if err = os.WriteFile(tmp, entry, 0644); err == nil {
+			err = os.Rename(tmp, path)
+		}
+	}
+	if err != nil {
+		slog.Debug("failed to cache response", "key", key, "err", err)
+	}
+}
+
+// cacheTTL returns how long cached data is fresh, or 0 when the cache is off.
+func cacheTTL() time.Duration {
+	cfg, err := loadConfig()
+	if err != nil || !cfg.Cache.Enabled {
+		return 0
+	}
+	return time.Duration(cfg.Cache.TTLHours) * time.Hour
+}
+
+// cachedJSON decodes the JSON response of fetch into out, answering from the cache while
+// it is fresh. When fetching fails, stale cached data is used instead, so lookups keep
+// working offline.
+func cachedJSON(key string, out any, fetch func() ([]byte, error)) error {
+	ttl := cacheTTL()
+	if ttl == 0 {
+		data, err := fetch()
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, out)
+	}
+
+	entry, cached := readCache(key)
+	if cached && !forceRefetch && time.Since(entry.Fetched) < ttl {
+		if err := json.Unmarshal(entry.Data, out); err == nil {
+			slog.Debug("cache hit", "key", key, "age", time.Since(entry.Fetched).Round(time.Second))
+			return nil
+		}
+	}
+
+	data, err := fetch()
+	if err == nil {
+		if err = json.Unmarshal(data, out); err == nil {
+			writeCache(key, data)
+			return nil
+		}
+		err = fmt.Errorf("failed to parse response: %w", err)
+	}
+	if cached && !forceRefetch && json.Unmarshal(entry.Data, out) == nil {
+		slog.Warn("using stale cached data", "key", key, "age", time.Since(entry.Fetched).Round(time.Second), "err", err)
+		return nil
+	}
+	return err
+}
+
+// cacheFile is a cache entry as listed by 'saitama cache'.
+type cacheFile struct {
+	Key     string    `json:"key"`
+	Fetched time.Time `json:"fetched"`
+	Size    int64     `json:"size"`
+	Stale   bool      `json:"stale"`
+}
+
+// listCache returns the cache entries, most recently fetched first.
+func listCache(ttl time.Duration) ([]cacheFile, error) {
+	dir, err := getCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	names, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+	var files []cacheFile
+	for _, name := range names {
+		if !strings.HasSuffix(name.Name(), ".json") {
+			continue
+		}
+		path := filepath.Join(dir, name.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var entry cacheEntry
+		if json.Unmarshal(data, &entry) != nil {
+			continue
+		}
+		files = append(files, cacheFile{Key: entry.Key, Fetched: entry.Fetched, Size: int64(len(data)), Stale: time.Since(entry.Fetched) >= ttl})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Fetched.After(files[j].Fetched) })
+	return files, nil
+}
+
+// formatBytes prints a size for humans, e.g. 1.4 MB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+func cacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Show the cached platform metadata and its size",
+		Long: "Problem lists, difficulties, tags and hints fetched from LeetCode and Codeforces are cached in the data " +
+			"directory for \"ttl_hours\" (24 by default, in the \"cache\" section of the config), so enrich, import --urls " +
+			"and hint fetch don't download them again. When a platform can't be reached, older cached data is used. " +
+			"refresh always fetches again, and updates the cache.",
+		Example: `  saitama cache
+  saitama cache clear`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			ttl := time.Duration(cfg.Cache.TTLHours) * time.Hour
+			files, err := listCache(ttl)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if jsonOutput() {
+				printJSON(files)
+				return
+			}
+			dir, _ := getCacheDir()
+			var total int64
+			for _, f := range files {
+				total += f.Size
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("🗄️  Cache: %s"), dir)
+			if !cfg.Cache.Enabled {
+				color.Yellow(tr("   Off (cache.enabled is false in the config file)"))
+			} else {
+				color.White(tr("   Entries are used for %d hour(s)"), cfg.Cache.TTLHours)
+			}
+			printResult(color.New(color.FgWhite), tr("📦 %d entr(ies), %s"), len(files), formatBytes(total))
+			for _, f := range files {
+				c, state := color.New(color.FgGreen), tr("fresh")
+				if f.Stale {
+					c, state = color.New(color.FgHiBlack), tr("stale")
+				}
+				printResult(c, "   %-9s %8s  %s  %s", state, formatBytes(f.Size), f.Fetched.Local().Format("2006-01-02 15:04"), f.Key)
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear",
+		Annotations: mutates(),
+		Short:       "Delete the cached platform metadata",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			files, err := listCache(0)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			dir, err := getCacheDir()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if err := os.RemoveAll(dir); err != nil {
+				printError(tr("❌ Error clearing the cache: %v"), err)
+				return
+			}
+			var total int64
+			for _, f := range files {
+				total += f.Size
+			}
+			color.Green(tr("🧹 Cleared %d cache entr(ies), %s."), len(files), formatBytes(total))
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,255 @@
+// calibrate.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// difficultyLevels are the difficulty labels, in increasing order (see labelRank).
+var difficultyLevels = []string{"easy", "medium", "hard"}
+
+// defaultSolveMinutes are the typical solve times per level, used until there are
+// minBaselineSolves timed solves of a level to learn the user's own.
+var defaultSolveMinutes = []float64{15, 35, 60}
+
+const minBaselineSolves = 3
+
+// calibration is the difficulty a problem seems to have for the user, from their attempts.
+type calibration struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Label     string  `json:"label"`
+	Estimate  string  `json:"estimate"`
+	Minutes   float64 `json:"minutes,omitempty"` // median time of the timed solves
+	Attempts  int     `json:"attempts"`
+	Failures  int     `json:"failures"`
+	Reason    string  `json:"reason"`
+	Confident bool    `json:"-"`
+}
+
+// effectiveDifficulty returns the difficulty of a problem for the user: the personal one
+// stored by 'calibrate --apply', or the label.
+func effectiveDifficulty(p Problem) string {
+	if p.PersonalDifficulty != "" {
+		return p.PersonalDifficulty
+	}
+	return p.Difficulty
+}
+
+// median returns the median of the values, which must not be empty.
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// solveMinutes returns the durations of the timed solves of a problem, in minutes.
+func solveMinutes(p Problem) []float64 {
+	var minutes []float64
+	for _, a := range p.Attempts {
+		if a.Solved && a.DurationSeconds > 0 {
+			minutes = append(minutes, float64(a.DurationSeconds)/60)
+		}
+	}
+	return minutes
+}
+
+// solveBaselines returns the typical solve time of every level: the median over the
+// problems of that level of their own median solve time, so that one problem solved many
+// times doesn't skew it, or the default while there are too few of them.
+func solveBaselines(problems []Problem) []float64 {
+	samples := make([][]float64, len(difficultyLevels))
+	for _, p := range problems {
+		level := labelRank(p.Difficulty)
+		if minutes := solveMinutes(p); level >= 0 && len(minutes) > 0 {
+			samples[level] = append(samples[level], median(minutes))
+		}
+	}
+	baselines := append([]float64(nil), defaultSolveMinutes...)
+	for level, s := range samples {
+		if len(s) >= minBaselineSolves {
+			baselines[level] = median(s)
+		}
+		// A harder level is never typically faster, whatever a few odd labels say.
+		if level > 0 {
+			baselines[level] = max(baselines[level], baselines[level-1])
+		}
+	}
+	return baselines
+}
+
+// calibrateProblem estimates the level of a labeled problem from its attempts: the level
+// whose typical solve time is closest to its own, raised one level above the label when
+// most attempts failed. It returns false for problems without a label or attempts.
+func calibrateProblem(p Problem, baselines []float64) (calibration, bool) {
+	label := labelRank(p.Difficulty)
+	if label == -1 || len(p.Attempts) == 0 {
+		return calibration{}, false
+	}
+	c := calibration{ID: p.ID, Name: p.Name, Label: difficultyLevels[label], Attempts: len(p.Attempts)}
+	for _, a := range p.Attempts {
+		if !a.Solved {
+			c.Failures++
+		}
+	}
+
+	estimate := label
+	var reasons []string
+	if minutes := solveMinutes(p); len(minutes) > 0 {
+		c.Minutes = median(minutes)
+		closest := math.Inf(1)
+		for level, typical := range baselines {
+			// Compared on a log scale: 30 minutes is as far from 15 as from 60.
+			if d := math.Abs(math.Log(c.Minutes / typical)); d < closest {
+				closest, estimate = d, level
+			}
+		}
+		switch {
+		case estimate > label:
+			reasons = append(reasons, fmt.Sprintf("took %.0f minutes (typical: %s %.0f, %s %.0f)",
+				c.Minutes, c.Label, baselines[label], difficultyLevels[estimate], baselines[estimate]))
+		case estimate < label:
+			reasons = append(reasons, fmt.Sprintf("solved in %.0f minutes (typical: %s %.0f, %s %.0f)",
+				c.Minutes, c.Label, baselines[label], difficultyLevels[estimate], baselines[estimate]))
+		default:
+			reasons = append(reasons, fmt.Sprintf("took %.0f minutes (typical: %.0f)", c.Minutes, baselines[label]))
+		}
+		c.Confident = len(minutes) > 1
+	}
+	if c.Attempts >= 2 && float64(c.Failures)/float64(c.Attempts) >= 0.5 {
+		estimate = max(estimate, min(label+1, len(difficultyLevels)-1))
+		reasons = append(reasons, fmt.Sprintf("failed %d of %d attempts", c.Failures, c.Attempts))
+		c.Confident = true
+	}
+	c.Estimate = difficultyLevels[estimate]
+	c.Reason = fmt.Sprintf("marked %s, %s", c.Label, strings.Join(reasons, ", "))
+	return c, true
+}
+
+// calibrateProblems returns the problems whose label doesn't match the user's attempts,
+// biggest gap first.
+func calibrateProblems(problems []Problem) []calibration {
+	baselines := solveBaselines(problems)
+	var off []calibration
+	for _, p := range problems {
+		if c, ok := calibrateProblem(p, baselines); ok && c.Estimate != c.Label {
+			off = append(off, c)
+		}
+	}
+	sort.SliceStable(off, func(i, j int) bool {
+		gi := abs(labelRank(off[i].Estimate) - labelRank(off[i].Label))
+		gj := abs(labelRank(off[j].Estimate) - labelRank(off[j].Label))
+		if gi != gj {
+			return gi > gj
+		}
+		return off[i].ID < off[j].ID
+	})
+	return off
+}
+
+func calibrateCmd() *cobra.Command {
+	var apply, reset bool
+	cmd := &cobra.Command{
+		Use:         "calibrate",
+		Annotations: mutates("apply", "reset"),
+		Short:       "Find problems whose difficulty label seems wrong for you",
+		Long: "Compares the labeled difficulty of every attempted problem with your own data: how long your timed " +
+			"solves took, against your typical time for each difficulty, and how often you failed. With --apply, " +
+			"the estimates are stored as a personal difficulty, used instead of the label by the mock interview mix.",
+		Example: `  saitama calibrate
+  saitama calibrate --apply
+  saitama calibrate --reset`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			if reset {
+				cleared := 0
+				for i := range problems {
+					if problems[i].PersonalDifficulty != "" {
+						problems[i].PersonalDifficulty = ""
+						cleared++
+					}
+				}
+				if cleared == 0 {
+					color.Yellow(tr("Nothing to change."))
+					return
+				}
+				if err := saveProblems(problems); err != nil {
+					printError(tr("❌ Error saving: %v"), err)
+					return
+				}
+				color.Green(tr("🧹 Cleared the personal difficulty of %d problem(s)."), cleared)
+				return
+			}
+
+			off := calibrateProblems(problems)
+			if jsonOutput() {
+				printJSON(off)
+			} else if len(off) == 0 {
+				color.Green(tr("🎯 Every label matches your attempts so far."))
+			} else {
+				fmt.Fprintln(stderr)
+				color.HiCyan(tr("⚖️  %d problem(s) seem mislabeled for you:"), len(off))
+				fmt.Fprintln(stderr)
+				for _, c := range off {
+					arrow := color.New(color.FgRed)
+					if labelRank(c.Estimate) < labelRank(c.Label) {
+						arrow = color.New(color.FgGreen)
+					}
+					printResult(arrow, "%s - %s: %s → %s", c.ID, c.Name, c.Label, c.Estimate)
+					printResult(color.New(color.FgWhite), "   %s", c.Reason)
+					if !c.Confident {
+						printResult(color.New(color.FgHiBlack), tr("   (a single timed solve, take it with a grain of salt)"))
+					}
+				}
+				fmt.Fprintln(stderr)
+			}
+
+			if !apply {
+				if len(off) > 0 && !jsonOutput() {
+					color.Cyan(tr("💡 Store these as your personal difficulty with: saitama calibrate --apply"))
+				}
+				return
+			}
+			estimates := make(map[string]string, len(off))
+			for _, c := range off {
+				estimates[c.ID] = c.Estimate
+			}
+			changed := 0
+			for i := range problems {
+				// Problems whose label now matches lose a stale personal difficulty.
+				if estimate := estimates[problems[i].ID]; problems[i].PersonalDifficulty != estimate {
+					problems[i].PersonalDifficulty = estimate
+					changed++
+				}
+			}
+			if changed == 0 {
+				color.Yellow(tr("Nothing to change."))
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Updated the personal difficulty of %d problem(s)."), changed)
+		},
+	}
+	cmd.Flags().BoolVar(&apply, "apply", false, "store the estimates as personal difficulties")
+	cmd.Flags().BoolVar(&reset, "reset", false, "clear every personal difficulty")
+	return cmd
+}
@@ -0,0 +1,433 @@
+// challenge.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// challengesName is the file of the data directory logging the daily challenges.
+const challengesName = "challenges.json"
+
+// Statuses of a daily challenge.
+const (
+	challengeProposed = "proposed"
+	challengeAccepted = "accepted"
+	challengeSkipped  = "skipped"
+)
+
+// ChallengeConfig holds the settings of the daily challenge.
+type ChallengeConfig struct {
+	Enabled bool   `json:"enabled"` // the daemon proposes the challenge in a notification
+	Time    string `json:"time"`    // HH:MM, local time
+	Minutes int    `json:"minutes"` // timer of challenge accept
+}
+
+// dailyChallenge is the problem proposed for a day and what became of it.
+type dailyChallenge struct {
+	Day       string    `json:"day"`
+	ProblemID string    `json:"problem_id"`
+	Proposed  time.Time `json:"proposed"`
+	Status    string    `json:"status"`
+	Reason    string    `json:"reason,omitempty"` // of a skip
+	Answered  time.Time `json:"answered,omitempty"`
+	Solved    bool      `json:"solved,omitempty"` // an accepted challenge was solved in time
+}
+
+func challengesPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, challengesName), nil
+}
+
+// loadChallenges returns the past challenges, oldest first.
+func loadChallenges() ([]dailyChallenge, error) {
+	path, err := challengesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read challenges: %w", err)
+	}
+	var challenges []dailyChallenge
+	if err := json.Unmarshal(data, &challenges); err != nil {
+		return nil, fmt.Errorf("failed to parse challenges: %w", err)
+	}
+	return challenges, nil
+}
+
+func saveChallenges(challenges []dailyChallenge) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := challengesPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(challenges, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write challenges: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// recordChallengeResult saves the outcome of an accepted challenge: the attempt on its
+// problem and the acceptance, in one transaction on the current content of both files,
+// so nothing is recorded when the timer is cancelled and nothing written meanwhile by
+// another command is lost.
+func recordChallengeResult(day, problemID string, accepted time.Time, solved bool, elapsed time.Duration) error {
+	tx, err := store.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	err = tx.Update(func(problems []Problem) ([]Problem, error) {
+		_, index := findProblemByID(problems, problemID)
+		if index == -1 {
+			return nil, fmt.Errorf("problem with ID '%s' not found", problemID)
+		}
+		recordAttempt(&problems[index], solved, elapsed, time.Now())
+		return problems, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	challenges, err := loadChallenges()
+	if err != nil {
+		return err
+	}
+	found := false
+	for i := range challenges {
+		if challenges[i].Day == day {
+			c := &challenges[i]
+			c.Status, c.Reason, c.Answered, c.Solved = challengeAccepted, "", accepted, solved
+			found = true
+		}
+	}
+	if !found {
+		challenges = append(challenges, dailyChallenge{Day: day, ProblemID: problemID, Proposed: accepted,
+			Status: challengeAccepted, Answered: accepted, Solved: solved})
+	}
+	path, err := challengesPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(challenges, "", "  ")
+	if err != nil {
+		return err
+	}
+	tx.WriteFile(path, data)
+	return tx.Apply()
+}
+
+// chooseChallenge draws the problem of the day, the same all day long: a due review
+// when there is one, else an unsolved problem, else any. Problems already solved that
+// day are left out.
+func chooseChallenge(problems []Problem, now time.Time) (Problem, bool) {
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+	tier := func(p Problem) int {
+		if at, ok := nextReview(p); ok && at.Before(tomorrow) {
+			return 0
+		}
+		if !isSolved(p) {
+			return 1
+		}
+		return 2
+	}
+	var pool []Problem
+	for _, p := range activeProblems(problems) {
+		if p.LastSolved.IsZero() || dayKey(p.LastSolved) != dayKey(now) {
+			pool = append(pool, p)
+		}
+	}
+	if len(pool) == 0 {
+		return Problem{}, false
+	}
+	rng := rand.New(rand.NewSource(daySeed(now)))
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	sort.SliceStable(pool, func(i, j int) bool { return tier(pool[i]) < tier(pool[j]) })
+	return pool[0], true
+}
+
+// todayChallenge returns the challenge of the day, proposing one when there is none
+// yet. The index is that of the challenge in the returned log; proposing saves the log,
+// unless the data directory is read-only.
+func todayChallenge(problems []Problem, now time.Time) ([]dailyChallenge, int, error) {
+	challenges, err := loadChallenges()
+	if err != nil {
+		return nil, -1, err
+	}
+	for i, c := range challenges {
+		if c.Day == dayKey(now) {
+			return challenges, i, nil
+		}
+	}
+	p, ok := chooseChallenge(problems, now)
+	if !ok {
+		return challenges, -1, nil
+	}
+	challenges = append(challenges, dailyChallenge{Day: dayKey(now), ProblemID: p.ID, Proposed: now, Status: challengeProposed})
+	if err := saveChallenges(challenges); err != nil && err != errReadOnly {
+		return nil, -1, err
+	}
+	return challenges, len(challenges) - 1, nil
+}
+
+// challengeStats sums up the daily challenges for stats.
+type challengeStats struct {
+	Proposed int `json:"proposed"`
+	Accepted int `json:"accepted"`
+	Skipped  int `json:"skipped"`
+	Solved   int `json:"solved"`
+}
+
+// computeChallengeStats counts the challenges. Today's is only counted once answered,
+// earlier ones left unanswered count as declined.
+func computeChallengeStats(challenges []dailyChallenge, now time.Time) challengeStats {
+	var s challengeStats
+	for _, c := range challenges {
+		if c.Status == challengeProposed && c.Day == dayKey(now) {
+			continue
+		}
+		s.Proposed++
+		switch c.Status {
+		case challengeAccepted:
+			s.Accepted++
+			if c.Solved {
+				s.Solved++
+			}
+		case challengeSkipped:
+			s.Skipped++
+		}
+	}
+	return s
+}
+
+// printChallengeStats shows the acceptance rate of the daily challenges in stats.
+func printChallengeStats(now time.Time) {
+	challenges, err := loadChallenges()
+	if err != nil {
+		return
+	}
+	s := computeChallengeStats(challenges, now)
+	if s.Proposed == 0 {
+		return
+	}
+	color.HiYellow(tr("🎲 Daily challenges: %d/%d accepted (%d%%), %d solved, %d skipped"),
+		s.Accepted, s.Proposed, s.Accepted*100/s.Proposed, s.Solved, s.Skipped)
+}
+
+// challengeMessage is the notification proposing the challenge.
+func challengeMessage(p Problem) string {
+	return fmt.Sprintf(tr("🎲 Today's challenge: %s - %s. Take it with: saitama challenge accept"), p.ID, p.Name)
+}
+
+// printChallengeProblem shows the problem of the challenge.
+func printChallengeProblem(p Problem) {
+	fmt.Fprintln(stderr)
+	color.HiMagenta(tr("🎲 Today's challenge"))
+	printResult(color.New(color.FgHiYellow), "   %s - %s", p.ID, hyperlink(p.Name, p.URL))
+	if p.Difficulty != "" || p.Rating > 0 {
+		details := p.Difficulty
+		if p.Rating > 0 {
+			details = strings.TrimSpace(fmt.Sprintf("%s %d", details, p.Rating))
+		}
+		printResult(color.New(color.FgWhite), "   %s", details)
+	}
+	if len(p.Tags) > 0 {
+		printResult(color.New(color.FgGreen), "   🏷️  %s", strings.Join(p.Tags, " • "))
+	}
+	fmt.Fprintln(stderr)
+}
+
+// printChallenge shows the challenge of the day and what became of it.
+func printChallenge(c dailyChallenge, p Problem) {
+	printChallengeProblem(p)
+	switch c.Status {
+	case challengeAccepted:
+		if c.Solved {
+			color.Green(tr("✅ Accepted and solved. See you tomorrow!"))
+		} else {
+			color.Cyan(tr("🥊 Accepted. Record the outcome with: saitama solve %s"), p.ID)
+		}
+	case challengeSkipped:
+		if c.Reason != "" {
+			color.HiBlack(tr("⏭️  Skipped: %s"), c.Reason)
+		} else {
+			color.HiBlack(tr("⏭️  Skipped"))
+		}
+	default:
+		color.Cyan(tr("💡 Take it with: saitama challenge accept, or pass with: saitama challenge skip --reason \"...\""))
+	}
+}
+
+// loadTodayChallenge loads the problems and the challenge of the day for the subcommands,
+// printing what went wrong.
+func loadTodayChallenge(now time.Time) ([]Problem, []dailyChallenge, int, bool) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return nil, nil, -1, false
+	}
+	challenges, i, err := todayChallenge(problems, now)
+	if err != nil {
+		printError("❌ %v", err)
+		return nil, nil, -1, false
+	}
+	if i == -1 {
+		color.Yellow(tr("📝 No problem to challenge you with! Add some with: saitama add"))
+		return nil, nil, -1, false
+	}
+	return problems, challenges, i, true
+}
+
+func challengeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "challenge",
+		Short: "Show today's challenge, one problem drawn for the day",
+		Long: "Every day one problem is drawn as the challenge: a due review when there is one, else an unsolved " +
+			"problem. The daemon proposes it in a notification each morning (\"challenge\" section of the config: " +
+			"enabled, time, minutes). Accept it to start the timer, or skip it with a reason; stats shows how " +
+			"many you took on.",
+		Example: `  saitama challenge
+  saitama challenge accept
+  saitama challenge skip --reason "travelling"`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			problems, challenges, i, ok := loadTodayChallenge(now)
+			if !ok {
+				return
+			}
+			c := challenges[i]
+			p, index := findProblemByID(problems, c.ProblemID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), c.ProblemID)
+				return
+			}
+			if jsonOutput() {
+				printJSON(struct {
+					dailyChallenge
+					Problem Problem `json:"problem"`
+				}{c, *p})
+				return
+			}
+			printChallenge(c, *p)
+		},
+	}
+
+	var minutes int
+	var noTimer bool
+	accept := &cobra.Command{
+		Use:         "accept",
+		Annotations: mutates(),
+		Short:       "Accept today's challenge and start the timer",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			problems, challenges, i, ok := loadTodayChallenge(now)
+			if !ok {
+				return
+			}
+			c := &challenges[i]
+			if c.Status == challengeAccepted {
+				color.Yellow(tr("🥊 Today's challenge is already accepted."))
+				return
+			}
+			p, index := findProblemByID(problems, c.ProblemID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), c.ProblemID)
+				return
+			}
+			printChallengeProblem(*p)
+			if noTimer {
+				c.Status, c.Reason, c.Answered = challengeAccepted, "", now
+				if err := saveChallenges(challenges); err != nil {
+					printError(tr("❌ Error saving: %v"), err)
+					return
+				}
+				color.HiGreen(tr("💪 Go get it, hero! ONE PUNCH! 🥊"))
+				return
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if minutes <= 0 {
+				minutes = cfg.Challenge.Minutes
+			}
+			elapsed, _ := runCountdown(time.Duration(minutes)*time.Minute, tr("⏳ Challenge:"))
+
+			// The acceptance is saved with the result only: after a cancel the challenge
+			// is still open, and accept starts it again.
+			solved := false
+			if err := survey.AskOne(&survey.Confirm{Message: "👊 Did you solve it?"}, &solved); err != nil {
+				color.Yellow(tr("👋 Result not recorded. Take the challenge again with: saitama challenge accept"))
+				return
+			}
+			if err := recordChallengeResult(c.Day, c.ProblemID, now, solved, elapsed); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			if solved {
+				color.HiGreen(tr("🎉 Challenge beaten in %s! ONE PUNCH! 🥊"), formatClock(elapsed))
+			} else {
+				color.Yellow(tr("😤 Not this time. The attempt is logged, tomorrow brings a new one."))
+			}
+		},
+	}
+	accept.Flags().IntVarP(&minutes, "minutes", "m", 0, "timer in minutes (default from config, 45)")
+	accept.Flags().BoolVar(&noTimer, "no-timer", false, "accept without starting the timer")
+	cmd.AddCommand(accept)
+
+	var reason string
+	skip := &cobra.Command{
+		Use:         "skip",
+		Annotations: mutates(),
+		Short:       "Pass on today's challenge, with a reason",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			now := time.Now()
+			_, challenges, i, ok := loadTodayChallenge(now)
+			if !ok {
+				return
+			}
+			c := &challenges[i]
+			if c.Status == challengeAccepted {
+				color.Yellow(tr("🥊 Today's challenge is already accepted."))
+				return
+			}
+			c.Status, c.Reason, c.Answered = challengeSkipped, strings.TrimSpace(reason), now
+			if err := saveChallenges(challenges); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.HiBlack(tr("⏭️  Skipped today's challenge (%s). A new one comes tomorrow."), c.ProblemID)
+		},
+	}
+	skip.Flags().StringVar(&reason, "reason", "", "why you pass, e.g. \"travelling\"")
+	cmd.AddCommand(skip)
+	return cmd
+}
@@ -0,0 +1,237 @@
+// codeforces.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// CodeforcesConfig holds the account synced by 'saitama sync codeforces'.
+type CodeforcesConfig struct {
+	Handle string `json:"handle,omitempty"`
+}
+
+// codeforcesSubmission is a submission of the account, as returned by user.status.
+type codeforcesSubmission struct {
+	ID      int64 `json:"id"`
+	Created int64 `json:"creationTimeSeconds"`
+	Problem struct {
+		ContestID int      `json:"contestId"`
+		Index     string   `json:"index"`
+		Name      string   `json:"name"`
+		Rating    int      `json:"rating"`
+		Tags      []string `json:"tags"`
+	} `json:"problem"`
+	Verdict string `json:"verdict"` // missing while the submission is in the queue
+}
+
+// fetchCodeforcesSubmissions returns every submission of a handle. The API is public,
+// and user data isn't cached since it is what changes between syncs.
+func fetchCodeforcesSubmissions(handle string) ([]codeforcesSubmission, error) {
+	req, err := http.NewRequest(http.MethodGet, "https://codeforces.com/api/user.status?handle="+url.QueryEscape(handle), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "saitama-cli")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status  string                 `json:"status"`
+		Comment string                 `json:"comment"` // why the call failed, e.g. an unknown handle
+		Result  []codeforcesSubmission `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("codeforces responded with %s", resp.Status)
+		}
+		return nil, fmt.Errorf("failed to parse the Codeforces submissions: %w", err)
+	}
+	if result.Status != "OK" {
+		return nil, fmt.Errorf("codeforces API returned status %s: %s", result.Status, result.Comment)
+	}
+	slog.Debug("fetched codeforces submissions", "handle", handle, "count", len(result.Result))
+	return result.Result, nil
+}
+
+// codeforcesProblemURL returns the URL of a problem, in the problemset or in the gym.
+func codeforcesProblemURL(contestID int, index string) string {
+	if contestID >= 100000 {
+		return fmt.Sprintf("https://codeforces.com/gym/%d/problem/%s", contestID, index)
+	}
+	return fmt.Sprintf("https://codeforces.com/problemset/problem/%d/%s", contestID, index)
+}
+
+// newProblem returns the problem of a submission, as added to the collection.
+func (s codeforcesSubmission) newProblem(now time.Time) Problem {
+	cp := s.Problem
+	return Problem{
+		ID:         "CF" + strconv.Itoa(cp.ContestID) + strings.ToUpper(cp.Index),
+		Name:       cp.Name,
+		Tags:       canonicalTags(cp.Tags),
+		Difficulty: difficultyFromRating("codeforces", cp.Rating),
+		Rating:     cp.Rating,
+		Platform:   "codeforces",
+		URL:        codeforcesProblemURL(cp.ContestID, cp.Index),
+		DateAdded:  now,
+	}
+}
+
+// judged returns the submission as recorded in attempts.
+func (s codeforcesSubmission) judged() judgeSubmission {
+	return judgeSubmission{
+		ID:      "cf:" + strconv.FormatInt(s.ID, 10),
+		Date:    time.Unix(s.Created, 0),
+		Verdict: normalizeVerdict(s.Verdict),
+	}
+}
+
+// findCodeforcesProblem returns the index of the local problem for a Codeforces problem,
+// matched by URL or by its CF<contest><index> ID, or -1.
+func findCodeforcesProblem(problems []Problem, contestID int, index string) int {
+	key := fmt.Sprintf("codeforces.com/problem/%d/%s", contestID, strings.ToUpper(index))
+	for i, p := range problems {
+		if strings.EqualFold(normalizeURL(p.URL), key) {
+			return i
+		}
+	}
+	_, i := findProblemByID(problems, "CF"+strconv.Itoa(contestID)+strings.ToUpper(index))
+	return i
+}
+
+func syncCodeforcesCmd() *cobra.Command {
+	var handle string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:         "codeforces",
+		Annotations: mutates(),
+		Short:       "Import your Codeforces submissions and their verdicts",
+		Long: "Logs every submission of a Codeforces account as an attempt with its verdict (AC, WA, TLE, ...) and " +
+			"date, and adds the problems you don't track yet. Submissions already imported are skipped, so syncing " +
+			"again only brings the new ones. The handle comes from --handle or \"codeforces\": {\"handle\": ...} " +
+			"in the config. See the first-attempt AC rate in 'saitama stats'.",
+		Example: `  saitama sync codeforces --handle tourist --dry-run
+  saitama sync codeforces`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if handle == "" {
+				cfg, err := loadConfig()
+				if err != nil {
+					printError(tr("❌ Error loading config: %v"), err)
+					return
+				}
+				handle = cfg.Codeforces.Handle
+			}
+			if handle == "" {
+				printError(tr("❌ No Codeforces handle: pass --handle or set codeforces.handle in the config"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			archive, err := loadAttemptArchive()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			color.Cyan(tr("🔄 Fetching the submissions of %s..."), handle)
+			submissions, err := fetchCodeforcesSubmissions(handle)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			// Group by problem, keeping the order in which problems were first seen.
+			type problemKey struct {
+				contest int
+				index   string
+			}
+			var order []problemKey
+			byProblem := make(map[problemKey][]codeforcesSubmission)
+			for _, s := range submissions {
+				if s.Problem.ContestID == 0 {
+					continue // problems outside contests can't be linked to
+				}
+				k := problemKey{s.Problem.ContestID, strings.ToUpper(s.Problem.Index)}
+				if _, ok := byProblem[k]; !ok {
+					order = append(order, k)
+				}
+				byProblem[k] = append(byProblem[k], s)
+			}
+
+			now := time.Now()
+			var created, updated []string
+			recorded := 0
+			verdicts := make(map[string]int)
+			for _, k := range order {
+				subs := byProblem[k]
+				index := findCodeforcesProblem(problems, k.contest, k.index)
+				if index < 0 {
+					problems = append(problems, subs[0].newProblem(now))
+					index = len(problems) - 1
+					created = append(created, problems[index].ID)
+				}
+
+				judged := make([]judgeSubmission, 0, len(subs))
+				for _, s := range subs {
+					if s.Verdict == "" {
+						continue
+					}
+					judged = append(judged, s.judged())
+				}
+				p := &problems[index]
+				added := recordSubmissions(p, archive.Attempts[p.ID], judged)
+				if len(added) == 0 {
+					continue
+				}
+				recorded += len(added)
+				updated = append(updated, p.ID)
+				for _, s := range added {
+					verdicts[s.Verdict]++
+				}
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("👤 %s: %d submission(s) on %d problem(s)"), handle, len(submissions), len(order))
+			color.White(tr("   ➕ %d new problem(s)"), len(created))
+			color.White(tr("   📥 %d new submission(s) on %d problem(s)"), recorded, len(updated))
+			if len(verdicts) > 0 {
+				color.HiBlack("      %s", formatVerdictCounts(verdicts))
+			}
+			if dryRun {
+				for _, id := range created {
+					color.HiBlack("      + %s", id)
+				}
+				color.Yellow(tr("🔍 Dry run: nothing was changed."))
+				return
+			}
+			if recorded+len(created) == 0 {
+				color.Green(tr("✅ Already up to date."))
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Codeforces sync complete."))
+		},
+	}
+	cmd.Flags().StringVar(&handle, "handle", "", "Codeforces handle (default from config)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show what would change")
+	return cmd
+}
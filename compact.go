@@ -0,0 +1,225 @@
+// compact.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// attemptArchiveName is the file of the data directory holding the attempts moved out of
+// the database by compact.
+const attemptArchiveName = "attempts-archive.json"
+
+// fullHistoryFlag is set by the persistent --full-history flag: the archived attempts are
+// read back into the problems, which are then read-only so they don't land in the
+// database again.
+var fullHistoryFlag bool
+
+// CompactConfig holds the settings of compact.
+type CompactConfig struct {
+	Horizon string `json:"horizon"` // attempts older than this are archived, e.g. 1y or 6m
+}
+
+// attemptArchive is the content of the archive file.
+type attemptArchive struct {
+	Compacted time.Time            `json:"compacted"`
+	Attempts  map[string][]Attempt `json:"attempts"` // by problem ID, oldest first
+}
+
+func attemptArchivePath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, attemptArchiveName), nil
+}
+
+// loadAttemptArchive reads the archive, empty when nothing was compacted yet.
+func loadAttemptArchive() (attemptArchive, error) {
+	archive := attemptArchive{Attempts: make(map[string][]Attempt)}
+	path, err := attemptArchivePath()
+	if err != nil {
+		return archive, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return archive, nil
+	}
+	if err != nil {
+		return archive, fmt.Errorf("failed to read the attempt archive: %w", err)
+	}
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return archive, fmt.Errorf("failed to parse the attempt archive: %w", err)
+	}
+	if archive.Attempts == nil {
+		archive.Attempts = make(map[string][]Attempt)
+	}
+	return archive, nil
+}
+
+// encodeAttemptArchive returns the path and content of the archive, to be staged on the
+// transaction that changes the problems.
+func encodeAttemptArchive(archive attemptArchive) (string, []byte, error) {
+	path, err := attemptArchivePath()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+	return path, data, nil
+}
+
+// compactAttempts moves the attempts before the cutoff from the problems to the archive.
+// The latest attempt of a problem always stays, so that its last outcome, feeling and
+// verdict are at hand. Archive entries of problems that no longer exist are dropped.
+// It returns the number of attempts moved and of problems they came from.
+func compactAttempts(problems []Problem, archive *attemptArchive, cutoff time.Time) (moved, touched int) {
+	exists := make(map[string]bool, len(problems))
+	for i := range problems {
+		p := &problems[i]
+		exists[p.ID] = true
+		sort.SliceStable(p.Attempts, func(a, b int) bool { return p.Attempts[a].Date.Before(p.Attempts[b].Date) })
+		n := 0
+		for n < len(p.Attempts)-1 && p.Attempts[n].Date.Before(cutoff) {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		archive.Attempts[p.ID] = append(archive.Attempts[p.ID], p.Attempts[:n]...)
+		p.Attempts = append([]Attempt(nil), p.Attempts[n:]...)
+		moved += n
+		touched++
+	}
+	for id := range archive.Attempts {
+		if !exists[id] {
+			delete(archive.Attempts, id)
+		}
+	}
+	return moved, touched
+}
+
+// mergeArchivedAttempts puts the archived attempts back in front of the attempts of
+// their problems, for --full-history.
+func mergeArchivedAttempts(problems []Problem, archive attemptArchive) {
+	for i := range problems {
+		if old := archive.Attempts[problems[i].ID]; len(old) > 0 {
+			problems[i].Attempts = append(append([]Attempt(nil), old...), problems[i].Attempts...)
+		}
+	}
+}
+
+// withArchivedAttempts returns the problems with their archived attempts, for the
+// computations that replay the whole history such as the rating estimate. The problems
+// are returned as they are when nothing is archived or --full-history merged it already.
+func withArchivedAttempts(problems []Problem) []Problem {
+	if fullHistoryFlag {
+		return problems
+	}
+	archive, err := loadAttemptArchive()
+	if err != nil || len(archive.Attempts) == 0 {
+		return problems
+	}
+	out := append([]Problem(nil), problems...)
+	mergeArchivedAttempts(out, archive)
+	return out
+}
+
+func compactCmd() *cobra.Command {
+	var olderThan string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:         "compact",
+		Annotations: mutates(),
+		Short:       "Move old attempts to an archive file to keep the database small",
+		Long: "Moves the attempts older than the horizon (--older-than, or \"horizon\" in the \"compact\" section of " +
+			"the config, 1y by default) from the database to " + attemptArchiveName + " in the data directory, so " +
+			"that every command loads less. The latest attempt of each problem stays. Solve counts, review " +
+			"schedules and the rating estimate are unaffected; statistics over the old history, e.g. the heatmap " +
+			"of past years, need --full-history, which reads the archive back in (and is read-only).",
+		Example: `  saitama compact --dry-run
+  saitama compact --older-than 6m
+  saitama heatmap --full-history`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if olderThan == "" {
+				olderThan = cfg.Compact.Horizon
+			}
+			cutoff, err := shiftBySpan(startOfDay(time.Now()), olderThan, -1)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			dbPath, err := getDbPath()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			sizeBefore := int64(0)
+			if info, err := os.Stat(dbPath); err == nil {
+				sizeBefore = info.Size()
+			}
+
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+			archive, err := loadAttemptArchive()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			var moved, touched int
+			err = tx.Update(func(problems []Problem) ([]Problem, error) {
+				moved, touched = compactAttempts(problems, &archive, cutoff)
+				return problems, nil
+			})
+			if err != nil {
+				printError(tr("❌ Error compacting: %v"), err)
+				return
+			}
+			if moved == 0 {
+				color.Green(tr("✅ Nothing to compact: no attempts before %s besides the latest of each problem."), cutoff.Format("Jan 2, 2006"))
+				return
+			}
+			if dryRun {
+				color.Cyan(tr("🔍 Dry run: %d attempt(s) of %d problem(s) before %s would move to %s."), moved, touched, cutoff.Format("Jan 2, 2006"), attemptArchiveName)
+				return
+			}
+
+			archive.Compacted = time.Now()
+			path, data, err := encodeAttemptArchive(archive)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			tx.WriteFile(path, data)
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("📦 Moved %d attempt(s) of %d problem(s) before %s to %s."), moved, touched, cutoff.Format("Jan 2, 2006"), attemptArchiveName)
+			if info, err := os.Stat(dbPath); err == nil {
+				color.HiBlack(tr("   %s: %s → %s"), filepath.Base(dbPath), formatBytes(sizeBefore), formatBytes(info.Size()))
+			}
+		},
+	}
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "archive the attempts older than this, e.g. 6m or 2y (default: the config's horizon)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "count the attempts to archive without moving them")
+	return cmd
+}
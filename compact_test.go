@@ -0,0 +1,64 @@
+// compact_test.go
+package main
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// compactedProblem returns a problem with one submission a month over a year, compacted
+// with a cutoff that archives all but the last two, and the submissions.
+func compactedProblem(t *testing.T) (Problem, attemptArchive, []judgeSubmission) {
+	t.Helper()
+	start := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	p := Problem{ID: "CF1A", Name: "Theatre Square"}
+	var subs []judgeSubmission
+	for i := range 12 {
+		verdict := "WA"
+		if i%3 == 2 {
+			verdict = "AC"
+		}
+		subs = append(subs, judgeSubmission{ID: fmt.Sprintf("cf:%d", 1000+i), Date: start.AddDate(0, i, 0), Verdict: verdict})
+	}
+	if added := recordSubmissions(&p, nil, subs); len(added) != len(subs) {
+		t.Fatalf("first sync recorded %d submissions, want %d", len(added), len(subs))
+	}
+	problems := []Problem{p}
+	archive := attemptArchive{Attempts: make(map[string][]Attempt)}
+	if moved, _ := compactAttempts(problems, &archive, start.AddDate(0, 10, 0)); moved != 10 {
+		t.Fatalf("compact moved %d attempts, want 10", moved)
+	}
+	return problems[0], archive, subs
+}
+
+func TestSyncAfterCompact(t *testing.T) {
+	p, archive, subs := compactedProblem(t)
+	solves := p.SolveCount
+	if added := recordSubmissions(&p, archive.Attempts[p.ID], subs); len(added) != 0 {
+		t.Errorf("sync after compact recorded %d submissions, want none", len(added))
+	}
+	if len(p.Attempts) != 2 || p.SolveCount != solves {
+		t.Errorf("sync after compact left %d attempts and %d solves, want 2 and %d", len(p.Attempts), p.SolveCount, solves)
+	}
+
+	problems, result := applySubmissions([]Problem{p}, archive, []archivedSubmission{{Problem: p, judgeSubmission: subs[0]}}, false)
+	if result.Recorded != 0 || len(problems[0].Attempts) != 2 {
+		t.Errorf("submission import after compact recorded %d, want none", result.Recorded)
+	}
+}
+
+func TestMergeAfterCompact(t *testing.T) {
+	p, archive, subs := compactedProblem(t)
+	uncompacted := p
+	uncompacted.Attempts, uncompacted.SolveCount = nil, 0
+	recordSubmissions(&uncompacted, nil, subs)
+
+	solves := p.SolveCount
+	if added := mergeHistory(&p, archive.Attempts[p.ID], uncompacted); added != 0 {
+		t.Errorf("merge of an uncompacted copy added %d attempts, want none", added)
+	}
+	if len(p.Attempts) != 2 || p.SolveCount != solves {
+		t.Errorf("merge left %d attempts and %d solves, want 2 and %d", len(p.Attempts), p.SolveCount, solves)
+	}
+}
@@ -0,0 +1,318 @@
+// complexity.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// complexitySize is the value given to every variable when comparing complexities:
+// large enough for the growth rates to be told apart.
+const complexitySize = 1e6
+
+var (
+	bigO             = regexp.MustCompile(`^[oO]\s*\((.+)\)$`)
+	complexitySpaces = regexp.MustCompile(`\s+`)
+	// Shorthands rewritten before parsing, e.g. "nlogn" and "n²".
+	complexityShorthands = strings.NewReplacer("²", "^2", "³", "^3", "**", "^", "·", "*", "×", "*",
+		"nlogn", "n log n", "logn", "log n", "√", "sqrt ")
+)
+
+// normalizeComplexity validates a Big-O expression like "O(n log n)", "O(n^2)" or
+// "O(V + E)" and returns it in a canonical spelling.
+func normalizeComplexity(s string) (string, error) {
+	m := bigO.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return "", fmt.Errorf("invalid complexity '%s': use Big-O notation, e.g. O(n log n)", s)
+	}
+	inner := complexitySpaces.ReplaceAllString(strings.TrimSpace(complexityShorthands.Replace(m[1])), " ")
+	if _, err := complexityGrowth(inner); err != nil {
+		return "", fmt.Errorf("invalid complexity '%s': %v", s, err)
+	}
+	return "O(" + inner + ")", nil
+}
+
+// complexityRank returns the natural logarithm of a complexity evaluated with every
+// variable set to complexitySize, so that a lower rank means a faster solution.
+func complexityRank(s string) (float64, error) {
+	normalized, err := normalizeComplexity(s)
+	if err != nil {
+		return 0, err
+	}
+	return complexityGrowth(strings.TrimSuffix(strings.TrimPrefix(normalized, "O("), ")"))
+}
+
+// sameComplexity reports whether two complexities grow alike, e.g. O(n*n) and O(n^2).
+func sameComplexity(a, b string) bool {
+	ra, errA := complexityRank(a)
+	rb, errB := complexityRank(b)
+	return errA == nil && errB == nil && math.Abs(ra-rb) < 1e-9
+}
+
+// complexityParser evaluates the inside of a Big-O expression in log space:
+//
+//	expr   = term { ("+" | "-") term }
+//	term   = factor { ["*" | "/"] factor }       juxtaposition multiplies: "n log n"
+//	factor = unary [ "^" factor ] [ "!" ]
+//	unary  = number | variable | ("log" | "sqrt") [ "^" number ] unary | "(" expr ")"
+type complexityParser struct {
+	tokens []string
+	pos    int
+}
+
+// complexityGrowth returns the log of the expression's value, or an error when it
+// doesn't parse.
+func complexityGrowth(expr string) (float64, error) {
+	tokens, err := tokenizeComplexity(strings.ToLower(expr))
+	if err != nil {
+		return 0, err
+	}
+	p := &complexityParser{tokens: tokens}
+	g, err := p.expr()
+	if err != nil {
+		return 0, err
+	}
+	if p.pos < len(p.tokens) {
+		return 0, fmt.Errorf("unexpected '%s'", p.tokens[p.pos])
+	}
+	if g.constant {
+		return 0, nil // O(5) is O(1)
+	}
+	return g.log, nil
+}
+
+// growth is the log of a (sub)expression's value, and whether it is a constant:
+// constant factors are dropped, so that O(n/2) is O(n).
+type growth struct {
+	log      float64
+	constant bool
+}
+
+func tokenizeComplexity(s string) ([]string, error) {
+	var tokens []string
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && unicode.IsLetter(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case unicode.IsDigit(r) || r == '.':
+			j := i
+			for j < len(runes) && (unicode.IsDigit(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case strings.ContainsRune("+-*/^!()", r):
+			tokens = append(tokens, string(r))
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected '%c'", r)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty expression")
+	}
+	return tokens, nil
+}
+
+func (p *complexityParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *complexityParser) expr() (growth, error) {
+	g, err := p.term()
+	if err != nil {
+		return growth{}, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		p.pos++
+		other, err := p.term()
+		if err != nil {
+			return growth{}, err
+		}
+		// The dominant term decides: O(n^2 + n) is O(n^2), and O(n - 1) is O(n).
+		switch {
+		case g.constant:
+			g = other
+		case !other.constant:
+			g.log = math.Max(g.log, other.log)
+		}
+	}
+	return g, nil
+}
+
+func (p *complexityParser) term() (growth, error) {
+	g, err := p.factor()
+	if err != nil {
+		return growth{}, err
+	}
+	for {
+		next := p.peek()
+		if next == "" || next == "+" || next == "-" || next == ")" {
+			return g, nil
+		}
+		if next == "*" || next == "/" {
+			p.pos++
+		}
+		other, err := p.factor()
+		if err != nil {
+			return growth{}, err
+		}
+		switch {
+		case other.constant && !g.constant:
+		case g.constant && !other.constant:
+			g = other
+			if next == "/" {
+				g.log = -g.log
+			}
+		case next == "/":
+			g.log -= other.log
+		default:
+			g.log += other.log
+		}
+	}
+}
+
+func (p *complexityParser) factor() (growth, error) {
+	g, err := p.unary()
+	if err != nil {
+		return growth{}, err
+	}
+	if p.peek() == "^" {
+		p.pos++
+		exponent, err := p.factor()
+		if err != nil {
+			return growth{}, err
+		}
+		g = growth{log: g.log * math.Exp(exponent.log), constant: g.constant && exponent.constant}
+	}
+	if p.peek() == "!" {
+		p.pos++
+		// Stirling: log(x!) ≈ x log x - x
+		g.log = math.Max(math.Exp(g.log)*(g.log-1), 0)
+	}
+	return g, nil
+}
+
+func (p *complexityParser) unary() (growth, error) {
+	token := p.peek()
+	if token == "" {
+		return growth{}, fmt.Errorf("unexpected end of expression")
+	}
+	p.pos++
+	switch {
+	case token == "(":
+		g, err := p.expr()
+		if err != nil {
+			return growth{}, err
+		}
+		if p.peek() != ")" {
+			return growth{}, fmt.Errorf("missing ')'")
+		}
+		p.pos++
+		return g, nil
+	case token == "log" || token == "lg" || token == "ln" || token == "sqrt":
+		power := 1.0
+		if p.peek() == "^" { // log^2 n
+			p.pos++
+			n, err := strconv.ParseFloat(p.peek(), 64)
+			if err != nil {
+				return growth{}, fmt.Errorf("expected a number after '%s^'", token)
+			}
+			p.pos++
+			power = n
+		}
+		arg, err := p.unary()
+		if err != nil {
+			return growth{}, err
+		}
+		if token == "sqrt" {
+			return growth{log: power * arg.log / 2, constant: arg.constant}, nil
+		}
+		return growth{log: power * math.Log(math.Max(arg.log, 1)), constant: arg.constant}, nil
+	case unicode.IsDigit([]rune(token)[0]) || token[0] == '.':
+		n, err := strconv.ParseFloat(token, 64)
+		if err != nil || n <= 0 {
+			return growth{}, fmt.Errorf("invalid number '%s'", token)
+		}
+		return growth{log: math.Log(n), constant: true}, nil
+	case unicode.IsLetter([]rune(token)[0]):
+		// "nm" is n*m, "ve" is v*e
+		return growth{log: float64(len([]rune(token))) * math.Log(complexitySize)}, nil
+	}
+	return growth{}, fmt.Errorf("unexpected '%s'", token)
+}
+
+// complexityImprovements counts the re-solves logged with a time complexity, and how
+// many of them beat the best complexity logged before for the same problem.
+func complexityImprovements(problems []Problem) (resolves, improved int) {
+	for _, p := range problems {
+		best := math.Inf(1)
+		for _, a := range p.Attempts {
+			if !a.Solved || a.TimeComplexity == "" {
+				continue
+			}
+			rank, err := complexityRank(a.TimeComplexity)
+			if err != nil {
+				continue
+			}
+			if !math.IsInf(best, 1) {
+				resolves++
+				if rank < best-1e-9 {
+					improved++
+				}
+			}
+			best = math.Min(best, rank)
+		}
+	}
+	return resolves, improved
+}
+
+// recordComplexity stores the complexities of a solve on its attempt and keeps the
+// best ones on the problem. It returns the previous best time complexity when the new
+// one beats it.
+func recordComplexity(p *Problem, attempt *Attempt, timeC, spaceC string) string {
+	attempt.TimeComplexity, attempt.SpaceComplexity = timeC, spaceC
+	beaten := ""
+	if timeC != "" {
+		if better, was := betterComplexity(timeC, p.TimeComplexity); better {
+			beaten = was
+			p.TimeComplexity = timeC
+		}
+	}
+	if spaceC != "" {
+		if better, _ := betterComplexity(spaceC, p.SpaceComplexity); better {
+			p.SpaceComplexity = spaceC
+		}
+	}
+	return beaten
+}
+
+// betterComplexity reports whether candidate improves on best (or there is no best
+// yet), and returns best when it was beaten.
+func betterComplexity(candidate, best string) (bool, string) {
+	if best == "" {
+		return true, ""
+	}
+	rc, errC := complexityRank(candidate)
+	rb, errB := complexityRank(best)
+	if errC != nil || errB != nil || rc >= rb-1e-9 {
+		return false, ""
+	}
+	return true, best
+}
@@ -0,0 +1,229 @@
+// config.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Config holds user-tunable settings stored next to the problems file.
+type Config struct {
+	Language         string                     `json:"language,omitempty"`   // output language, e.g. "es"; defaults to LANG
+	Plain            bool                       `json:"plain,omitempty"`      // like --plain
+	Theme            string                     `json:"theme,omitempty"`      // like --theme, see 'saitama theme'
+	Hyperlinks       string                     `json:"hyperlinks,omitempty"` // link problem names to their URLs: auto, always or never
+	Pager            string                     `json:"pager,omitempty"`      // pager of long list and search output, or "never"; defaults to $PAGER
+	Platforms        []string                   `json:"platforms,omitempty"`
+	Pick             PickConfig                 `json:"pick"`
+	Reminders        ReminderConfig             `json:"reminders"`
+	Daemon           DaemonConfig               `json:"daemon"`
+	Notifications    NotificationConfig         `json:"notifications"`
+	Cache            CacheConfig                `json:"cache"`
+	Backup           BackupConfig               `json:"backup"`
+	Boss             BossConfig                 `json:"boss"`
+	Webhooks         []WebhookConfig            `json:"webhooks,omitempty"`
+	Digest           DigestConfig               `json:"digest"`
+	Report           ReportConfig               `json:"report"`
+	Publish          PublishConfig              `json:"publish"`
+	Usage            UsageConfig                `json:"usage"`
+	Gist             GistConfig                 `json:"gist"`
+	Notion           NotionConfig               `json:"notion"`
+	LeetCode         LeetCodeConfig             `json:"leetcode"`
+	Codeforces       CodeforcesConfig           `json:"codeforces"`
+	Notes            NotesConfig                `json:"notes"`
+	GSheet           GSheetConfig               `json:"gsheet"`
+	Mastery          MasteryConfig              `json:"mastery"`
+	Focus            FocusConfig                `json:"focus"`
+	Goals            GoalsConfig                `json:"goals"`
+	Mock             MockConfig                 `json:"mock"`
+	Load             LoadConfig                 `json:"load"`
+	SessionTemplates map[string]SessionTemplate `json:"session_templates,omitempty"`
+	Journal          JournalConfig              `json:"journal"`
+	Compact          CompactConfig              `json:"compact"`
+	Challenge        ChallengeConfig            `json:"challenge"`
+	PlatformDefs     []PlatformDef              `json:"platform_defs,omitempty"` // custom or overridden platforms
+	IDPrefixes       map[string]string          `json:"id_prefixes,omitempty"`   // ID prefix -> platform, e.g. {"AT": "atcoder"}
+	TagAliases       map[string]string          `json:"tag_aliases,omitempty"`   // tag -> canonical tag, added to the built-in aliases
+}
+
+// PickConfig holds the defaults used by the pick command.
+type PickConfig struct {
+	Count                  int            `json:"count"`
+	CooldownDays           int            `json:"cooldown_days"`                      // a picked problem isn't suggested again within this window, unless the pool runs out
+	DifficultyCooldownDays map[string]int `json:"difficulty_cooldown_days,omitempty"` // per-difficulty override, e.g. {"hard": 3}
+}
+
+// BossConfig holds the settings of the boss command.
+type BossConfig struct {
+	CooldownDays int `json:"cooldown_days"` // a boss is not repeated within this window
+	TimerMinutes int `json:"timer_minutes"`
+}
+
+// MockConfig holds the settings of mock interviews.
+type MockConfig struct {
+	Mix     []string `json:"mix"`     // difficulty of each question, in order
+	Minutes int      `json:"minutes"` // time slot per question
+}
+
+// ReminderConfig holds the daily reminder preferences.
+type ReminderConfig struct {
+	Enabled      bool   `json:"enabled"`
+	Time         string `json:"time,omitempty"` // HH:MM, local time
+	Nudges       bool   `json:"nudges"`         // remind after inactive_days without a solve, see 'saitama nag'
+	InactiveDays int    `json:"inactive_days"`
+}
+
+// MasteryConfig holds the weights used to compute per-tag mastery scores.
+type MasteryConfig struct {
+	SolvesWeight      float64 `json:"solves_weight"`
+	RecencyWeight     float64 `json:"recency_weight"`
+	DifficultyWeight  float64 `json:"difficulty_weight"`
+	TargetSolves      int     `json:"target_solves"`      // solves needed for full volume credit
+	HalfLifeDays      float64 `json:"half_life_days"`     // recency credit halves after this many days
+	WeakThreshold     float64 `json:"weak_threshold"`     // tags scoring below this are "weak"
+	MasteredThreshold float64 `json:"mastered_threshold"` // tags scoring this much are left out of the focus rotation
+}
+
+// defaultConfig returns the configuration used when no config file exists.
+func defaultConfig() Config {
+	return Config{
+		Pick:          PickConfig{Count: 5, CooldownDays: 7},
+		Reminders:     ReminderConfig{Nudges: true, InactiveDays: 3},
+		Notifications: NotificationConfig{Enabled: true},
+		Usage:         UsageConfig{Enabled: true},
+		Cache:         CacheConfig{Enabled: true, TTLHours: 24},
+		Backup:        BackupConfig{Daily: true, KeepDaily: 30},
+		Boss:          BossConfig{CooldownDays: 14, TimerMinutes: 60},
+		Mock:          MockConfig{Mix: []string{"medium", "hard"}, Minutes: 45},
+		Compact:       CompactConfig{Horizon: "1y"},
+		Challenge:     ChallengeConfig{Enabled: true, Time: "08:00", Minutes: 45},
+		Load:          LoadConfig{ShortDays: 7, LongDays: 28, SpikeRatio: 1.5, DropRatio: 0.5, MinPace: 0.3},
+		Mastery: MasteryConfig{
+			SolvesWeight:      0.5,
+			RecencyWeight:     0.3,
+			DifficultyWeight:  0.2,
+			TargetSolves:      5,
+			HalfLifeDays:      30,
+			WeakThreshold:     40,
+			MasteredThreshold: 80,
+		},
+	}
+}
+
+// getConfigPath returns the path of the config file in the app's config folder.
+func getConfigPath() (string, error) {
+	dbPath, err := getDbPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(dbPath), "config.json"), nil
+}
+
+// loadConfig reads the config file, falling back to defaults for anything not set.
+func loadConfig() (Config, error) {
+	cfg := defaultConfig()
+
+	path, err := getConfigPath()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return defaultConfig(), fmt.Errorf("failed to parse config file: %w", err)
+	}
+	slog.Debug("loaded config", "path", path)
+	return cfg, nil
+}
+
+// saveConfig writes the config file.
+func saveConfig(cfg Config) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := getConfigPath()
+	if err != nil {
+		return err
+	}
+
+	release, err := acquireLock(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	slog.Info("saved config", "path", path)
+	return nil
+}
+
+func configCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or initialize the configuration file",
+		Example: `  saitama config                      # Print the settings in effect and the file they come from
+  saitama config init                 # Write them out, to edit the file from there
+  saitama config | jq .publish        # The settings are printed to stdout as JSON`,
+		Run: func(cmd *cobra.Command, args []string) {
+			path, err := getConfigPath()
+			if err != nil {
+				printError(tr("❌ Error locating config: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+
+			data, err := json.MarshalIndent(cfg, "", "  ")
+			if err != nil {
+				printError(tr("❌ Error formatting config: %v"), err)
+				return
+			}
+			color.Cyan(tr("⚙️  Config file: %s"), path)
+			fmt.Fprintln(stdout, string(data))
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "init",
+		Annotations: mutates(),
+		Short:       "Write the current (or default) configuration to the config file",
+		Example: `  saitama config init
+  saitama --data-dir /mnt/shared/saitama config init`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			path, _ := getConfigPath()
+			color.Green(tr("✅ Config written to %s"), path)
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,155 @@
+// conflicts.go
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+// Conflict policies of import --on-conflict. "ask" resolves every differing field
+// interactively, the others resolve them all the same way.
+const (
+	conflictAsk    = "ask"
+	conflictMine   = "mine"
+	conflictTheirs = "theirs"
+	conflictMerge  = "merge" // combine tags and notes, fill in empty fields, keep mine otherwise
+)
+
+var conflictPolicies = []string{conflictAsk, conflictMine, conflictTheirs, conflictMerge}
+
+// conflictField is a field of a problem the user edits, compared when an imported
+// problem has the ID of an existing one. Attempts and review history are never touched.
+type conflictField struct {
+	Name  string
+	get   func(p Problem) string
+	take  func(dst *Problem, src Problem)
+	merge func(dst *Problem, src Problem) // nil: fill in when empty, keep mine otherwise
+}
+
+var conflictFields = []conflictField{
+	{Name: "name", get: func(p Problem) string { return p.Name }, take: func(d *Problem, s Problem) { d.Name = s.Name }},
+	{
+		Name:  "tags",
+		get:   func(p Problem) string { return strings.Join(p.Tags, ", ") },
+		take:  func(d *Problem, s Problem) { d.Tags = append([]string{}, s.Tags...) },
+		merge: func(d *Problem, s Problem) { d.Tags = mergeTags(d.Tags, s.Tags) },
+	},
+	{Name: "difficulty", get: func(p Problem) string { return p.Difficulty }, take: func(d *Problem, s Problem) { d.Difficulty = s.Difficulty }},
+	{Name: "platform", get: func(p Problem) string { return p.Platform }, take: func(d *Problem, s Problem) { d.Platform = s.Platform }},
+	{Name: "url", get: func(p Problem) string { return p.URL }, take: func(d *Problem, s Problem) { d.URL = s.URL }},
+	{
+		Name: "rating",
+		get: func(p Problem) string {
+			if p.Rating == 0 {
+				return ""
+			}
+			return strconv.Itoa(p.Rating)
+		},
+		take: func(d *Problem, s Problem) { d.Rating = s.Rating },
+	},
+	{
+		Name: "notes",
+		get:  func(p Problem) string { return p.Notes },
+		take: func(d *Problem, s Problem) { d.Notes = s.Notes },
+		merge: func(d *Problem, s Problem) {
+			if !strings.Contains(d.Notes, s.Notes) {
+				d.Notes = strings.TrimLeft(d.Notes+"\n\n"+s.Notes, "\n")
+			}
+		},
+	},
+}
+
+// conflictingFields returns the fields whose values differ between a problem and its
+// imported version. Fields the import leaves empty are not conflicts.
+func conflictingFields(mine, theirs Problem) []conflictField {
+	var fields []conflictField
+	for _, f := range conflictFields {
+		if value := f.get(theirs); value != "" && value != f.get(mine) {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// applyConflictPolicy resolves a field with one of the batch policies.
+func applyConflictPolicy(f conflictField, policy string, dst *Problem, src Problem) {
+	switch policy {
+	case conflictTheirs:
+		f.take(dst, src)
+	case conflictMerge:
+		if f.merge != nil {
+			f.merge(dst, src)
+		} else if f.get(*dst) == "" {
+			f.take(dst, src)
+		}
+	}
+}
+
+// conflictResolver resolves the conflicts of an import, asking field by field until
+// the user chooses a policy for all the remaining ones.
+type conflictResolver struct {
+	policy string
+}
+
+// resolve updates dst with the chosen values of src and reports whether it changed.
+func (r *conflictResolver) resolve(dst *Problem, src Problem) (bool, error) {
+	fields := conflictingFields(*dst, src)
+	if len(fields) == 0 {
+		return false, nil
+	}
+	before := problemFingerprint(*dst)
+
+	if r.policy == conflictAsk {
+		fmt.Fprintln(stderr)
+		color.HiYellow(tr("⚔️  %s - %s differs from the imported version:"), dst.ID, dst.Name)
+	}
+	for _, f := range fields {
+		if r.policy != conflictAsk {
+			applyConflictPolicy(f, r.policy, dst, src)
+			continue
+		}
+		color.White("   %s", f.Name)
+		color.Cyan(tr("      mine:   %s"), orNone(f.get(*dst)))
+		color.Magenta(tr("      theirs: %s"), orNone(f.get(src)))
+
+		const (
+			keepMine    = "Keep mine"
+			takeTheirs  = "Take theirs"
+			combineTags = "Merge tags"
+			allMine     = "Keep mine for all remaining conflicts"
+			allTheirs   = "Take theirs for all remaining conflicts"
+			allMerge    = "Merge all remaining conflicts (combine tags and notes, fill in empty fields)"
+		)
+		options := []string{keepMine, takeTheirs}
+		if f.Name == "tags" {
+			options = append(options, combineTags)
+		}
+		options = append(options, allMine, allTheirs, allMerge)
+		choice := ""
+		if err := survey.AskOne(&survey.Select{Message: fmt.Sprintf("%s %s:", dst.ID, f.Name), Options: options}, &choice); err != nil {
+			return false, err
+		}
+		switch choice {
+		case takeTheirs:
+			f.take(dst, src)
+		case combineTags:
+			f.merge(dst, src)
+		case allMine, allTheirs, allMerge:
+			r.policy = map[string]string{allMine: conflictMine, allTheirs: conflictTheirs, allMerge: conflictMerge}[choice]
+			applyConflictPolicy(f, r.policy, dst, src)
+		}
+	}
+	return problemFingerprint(*dst) != before, nil
+}
+
+// orNone shows an empty value as "(none)".
+func orNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
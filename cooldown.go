@@ -0,0 +1,53 @@
+// cooldown.go
+package main
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cooldownFor returns how long a problem is kept out of picks after being suggested,
+// using the per-difficulty window when there is one.
+func (c PickConfig) cooldownFor(p Problem) time.Duration {
+	days := c.CooldownDays
+	if d, ok := c.DifficultyCooldownDays[strings.ToLower(p.Difficulty)]; ok {
+		days = d
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// onCooldown reports whether a problem was picked too recently to be suggested again.
+func (c PickConfig) onCooldown(p Problem, now time.Time) bool {
+	return !p.LastPicked.IsZero() && now.Sub(p.LastPicked) < c.cooldownFor(p)
+}
+
+// maxSeed bounds the generated seeds, short enough to be read out to a study group.
+const maxSeed = 1_000_000
+
+// selectionRand returns the random source of a selection and its seed: the given one, or
+// a fresh one when given is false, so that it can be shared and the selection replayed.
+func selectionRand(seed int64, given bool) (*rand.Rand, int64) {
+	if !given {
+		seed = rand.Int63n(maxSeed)
+	}
+	return rand.New(rand.NewSource(seed)), seed
+}
+
+// pickCandidates orders the problems for picking: the ones off cooldown first, shuffled
+// with rng and leaning toward high priorities, then the ones picked recently, least recently picked first, so they only fill
+// in when the pool runs out. It returns how many problems are off cooldown.
+func pickCandidates(problems []Problem, cfg PickConfig, now time.Time, rng *rand.Rand) ([]Problem, int) {
+	var fresh, cooling []Problem
+	for _, p := range problems {
+		if cfg.onCooldown(p, now) {
+			cooling = append(cooling, p)
+		} else {
+			fresh = append(fresh, p)
+		}
+	}
+	priorityShuffle(fresh, rng)
+	sort.SliceStable(cooling, func(i, j int) bool { return cooling[i].LastPicked.Before(cooling[j].LastPicked) })
+	return append(fresh, cooling...), len(fresh)
+}
@@ -0,0 +1,114 @@
+// cooldown_test.go
+package main
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOnCooldown(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	cfg := PickConfig{CooldownDays: 7, DifficultyCooldownDays: map[string]int{"hard": 2}}
+	tests := []struct {
+		name       string
+		difficulty string
+		picked     time.Duration // before now, 0 for never
+		want       bool
+	}{
+		{"never picked", "medium", 0, false},
+		{"picked yesterday", "medium", 24 * time.Hour, true},
+		{"picked past the window", "medium", 8 * 24 * time.Hour, false},
+		{"hard override, inside", "Hard", 36 * time.Hour, true},
+		{"hard override, past", "hard", 3 * 24 * time.Hour, false},
+		{"easy uses the default", "easy", 3 * 24 * time.Hour, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Problem{ID: "LC1", Difficulty: tt.difficulty}
+			if tt.picked > 0 {
+				p.LastPicked = now.Add(-tt.picked)
+			}
+			if got := cfg.onCooldown(p, now); got != tt.want {
+				t.Errorf("onCooldown = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if (PickConfig{}).onCooldown(Problem{LastPicked: now.Add(-time.Minute)}, now) {
+		t.Error("a cooldown of 0 days should keep nothing out")
+	}
+}
+
+func TestPickCandidates(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	cfg := PickConfig{CooldownDays: 7}
+	problems := []Problem{
+		{ID: "A", LastPicked: now.Add(-24 * time.Hour)},
+		{ID: "B"},
+		{ID: "C", LastPicked: now.Add(-72 * time.Hour)},
+		{ID: "D", LastPicked: now.Add(-30 * 24 * time.Hour)},
+		{ID: "E", LastPicked: now.Add(-48 * time.Hour)},
+	}
+	candidates, fresh := pickCandidates(problems, cfg, now, rand.New(rand.NewSource(1)))
+	if fresh != 2 || len(candidates) != len(problems) {
+		t.Fatalf("got %d candidates with %d fresh, want 5 with 2", len(candidates), fresh)
+	}
+	for _, p := range candidates[:fresh] {
+		if p.ID != "B" && p.ID != "D" {
+			t.Errorf("%s is on cooldown but comes first", p.ID)
+		}
+	}
+	var cooling []string
+	for _, p := range candidates[fresh:] {
+		cooling = append(cooling, p.ID)
+	}
+	if want := []string{"C", "E", "A"}; len(cooling) != 3 || cooling[0] != want[0] || cooling[1] != want[1] || cooling[2] != want[2] {
+		t.Errorf("problems on cooldown = %v, want least recently picked first %v", cooling, want)
+	}
+}
+
+func TestRecordPicks(t *testing.T) {
+	writeTestDatabase(t, 3)
+	dbPath, err := getDbPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems, err := loadProblems()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Truncate(time.Second)
+	if err := recordPicks(problems[1:2], now); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.Stat(dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !after.ModTime().Equal(before.ModTime()) || after.Size() != before.Size() {
+		t.Error("recording a pick rewrote the database")
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dbPath), ".saitama_backups")); !os.IsNotExist(err) {
+		t.Error("recording a pick created a backup")
+	}
+
+	for name, reload := range map[string]func() ([]Problem, error){
+		"cache":     loadProblems,
+		"from disk": func() ([]Problem, error) { store = &Store{}; return loadProblems() },
+	} {
+		problems, err := reload()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !problems[1].LastPicked.Equal(now) || !problems[0].LastPicked.IsZero() {
+			t.Errorf("%s: LastPicked = %v and %v, want only the second problem picked at %v", name, problems[0].LastPicked, problems[1].LastPicked, now)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// csv.go
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+// csvSkipColumn is the mapping choice of a column that isn't imported.
+const csvSkipColumn = "(skip)"
+
+// csvTagDelimiters are the tag separators guessed from the data, most likely first.
+var csvTagDelimiters = []string{";", "|", ",", "/"}
+
+// csvMapping maps the columns of a CSV file to problem fields. Mappings are remembered by
+// the signature of the header row, so the next export of the same spreadsheet imports
+// without asking.
+type csvMapping struct {
+	Source       string            `json:"source"`  // file the mapping was made for, for reference
+	Columns      map[string]string `json:"columns"` // header -> field of sheetColumns
+	TagDelimiter string            `json:"tag_delimiter,omitempty"`
+}
+
+// isCSVFile reports whether a file name looks like CSV or TSV, optionally gzipped.
+func isCSVFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(strings.ToLower(filename), ".gz")))
+	return ext == ".csv" || ext == ".tsv"
+}
+
+// readCSVRows reads every row of a CSV file. The separator is a tab for .tsv files and
+// otherwise whichever of comma, semicolon or tab the header row uses most.
+func readCSVRows(filename string) ([][]string, error) {
+	in, err := openImportFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	buffered := bufio.NewReader(in)
+	header, _ := buffered.Peek(4096)
+	r := csv.NewReader(buffered)
+	r.Comma = ','
+	if strings.HasSuffix(strings.TrimSuffix(strings.ToLower(filename), ".gz"), ".tsv") {
+		r.Comma = '\t'
+	} else {
+		first, _, _ := strings.Cut(string(header), "\n")
+		for _, sep := range []rune{';', '\t'} {
+			if strings.Count(first, string(sep)) > strings.Count(first, string(r.Comma)) {
+				r.Comma = sep
+			}
+		}
+	}
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff") // spreadsheet byte order mark
+	}
+	return rows, nil
+}
+
+// csvSignature identifies a spreadsheet layout by its header row.
+func csvSignature(header []string) string {
+	normalized := make([]string, len(header))
+	for i, h := range header {
+		normalized[i] = strings.ToLower(strings.TrimSpace(h))
+	}
+	sum := sha256.Sum256([]byte(strings.Join(normalized, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// csvHeadersMatch reports whether the header row names the problem fields directly, at
+// least the id and name columns, so that no mapping is needed.
+func csvHeadersMatch(header []string) bool {
+	found := 0
+	for _, h := range header {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "id", "name":
+			found++
+		}
+	}
+	return found == 2
+}
+
+func csvMappingsPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "csv_mappings.json"), nil
+}
+
+// loadCSVMappings returns the remembered mappings by header signature.
+func loadCSVMappings() (map[string]csvMapping, error) {
+	path, err := csvMappingsPath()
+	if err != nil {
+		return nil, err
+	}
+	mappings := make(map[string]csvMapping)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return mappings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV mappings: %w", err)
+	}
+	if err := json.Unmarshal(data, &mappings); err != nil {
+		return nil, fmt.Errorf("failed to parse CSV mappings: %w", err)
+	}
+	return mappings, nil
+}
+
+// saveCSVMappings writes the remembered mappings.
+func saveCSVMappings(mappings map[string]csvMapping) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := csvMappingsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(mappings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal CSV mappings: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write CSV mappings: %w", err)
+	}
+	return nil
+}
+
+// guessCSVField suggests the problem field of a column from its header.
+func guessCSVField(header string) string {
+	h := strings.ToLower(strings.TrimSpace(header))
+	if slices.Contains(sheetColumns, h) {
+		return h
+	}
+	synonyms := map[string][]string{
+		"id":          {"problem id", "problem_id", "problem #", "problem number", "#", "number", "no", "code"},
+		"name":        {"title", "problem", "question", "problem name"},
+		"tags":        {"tag", "topics", "topic", "category", "categories", "pattern"},
+		"difficulty":  {"level", "diff"},
+		"url":         {"link", "href", "problem link"},
+		"notes":       {"note", "comments", "comment", "remarks"},
+		"platform":    {"site", "judge", "source"},
+		"starred":     {"star", "favorite", "favourite"},
+		"last_solved": {"solved", "solved on", "date solved"},
+	}
+	for field, names := range synonyms {
+		if slices.Contains(names, h) {
+			return field
+		}
+	}
+	return csvSkipColumn
+}
+
+// guessTagDelimiter returns the separator found most often in the values of a column.
+func guessTagDelimiter(rows [][]string, col int) string {
+	best, bestCount := csvTagDelimiters[0], 0
+	for _, d := range csvTagDelimiters {
+		count := 0
+		for _, row := range rows {
+			if col < len(row) {
+				count += strings.Count(row[col], d)
+			}
+		}
+		if count > bestCount {
+			best, bestCount = d, count
+		}
+	}
+	return best
+}
+
+// askCSVMapping asks which problem field every column holds, and the tag separator.
+func askCSVMapping(filename string, rows [][]string) (csvMapping, error) {
+	header := rows[0]
+	mapping := csvMapping{Source: filepath.Base(filename), Columns: make(map[string]string)}
+	options := append([]string{csvSkipColumn}, sheetColumns...)
+	var tagColumn int
+	for {
+		used := make(map[string]bool)
+		tagColumn = -1
+		for i, h := range header {
+			sample := ""
+			if len(rows) > 1 && i < len(rows[1]) {
+				sample = fmt.Sprintf(" (e.g. %q)", truncateText(rows[1][i], 30))
+			}
+			field := guessCSVField(h)
+			if used[field] {
+				field = csvSkipColumn
+			}
+			if err := survey.AskOne(&survey.Select{
+				Message: fmt.Sprintf("Column %q%s is:", h, sample),
+				Options: options,
+				Default: field,
+			}, &field); err != nil {
+				return csvMapping{}, err
+			}
+			if field == csvSkipColumn {
+				continue
+			}
+			if used[field] {
+				color.Yellow(tr("⚠️  %s is already mapped, skipping column %q."), field, h)
+				continue
+			}
+			used[field] = true
+			mapping.Columns[h] = field
+			if field == "tags" {
+				tagColumn = i
+			}
+		}
+		if used["id"] && used["name"] {
+			break
+		}
+		color.Yellow(tr("⚠️  The id and name of the problems are required, map a column to each."))
+		mapping.Columns = make(map[string]string)
+	}
+
+	if tagColumn >= 0 {
+		mapping.TagDelimiter = guessTagDelimiter(rows[1:], tagColumn)
+		if err := survey.AskOne(&survey.Input{
+			Message: "Separator between the tags of a cell:",
+			Default: mapping.TagDelimiter,
+		}, &mapping.TagDelimiter); err != nil {
+			return csvMapping{}, err
+		}
+	}
+	return mapping, nil
+}
+
+// truncateText shortens s to n runes with an ellipsis.
+func truncateText(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n-1]) + "…"
+	}
+	return s
+}
+
+// importCSV reads problems from a CSV file. Headers naming the problem fields import
+// directly; otherwise the columns are mapped interactively, or with the mapping
+// remembered for the same headers unless remap is set.
+func importCSV(filename string, remap bool) ([]Problem, error) {
+	rows, err := readCSVRows(filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) < 2 {
+		return nil, fmt.Errorf("%s has no rows below the header", filename)
+	}
+	if !remap && csvHeadersMatch(rows[0]) {
+		delimiter := ";"
+		if i := slices.IndexFunc(rows[0], func(h string) bool { return strings.EqualFold(strings.TrimSpace(h), "tags") }); i >= 0 {
+			delimiter = guessTagDelimiter(rows[1:], i)
+		}
+		return rowsToProblems(rows, delimiter)
+	}
+
+	mappings, err := loadCSVMappings()
+	if err != nil {
+		return nil, err
+	}
+	signature := csvSignature(rows[0])
+	mapping, known := mappings[signature]
+	switch {
+	case known && !remap:
+		color.Cyan(tr("🗺️  Using the column mapping saved for these headers (first made for %s, --remap to change it)"), mapping.Source)
+	case !isInteractive():
+		return nil, fmt.Errorf("the columns of %s don't match the problem fields; run the import in a terminal to map them", filename)
+	default:
+		color.Cyan(tr("🗺️  The columns of %s don't match the problem fields, tell which is which:"), filepath.Base(filename))
+		if mapping, err = askCSVMapping(filename, rows); err != nil {
+			return nil, err
+		}
+		mappings[signature] = mapping
+		if err := saveCSVMappings(mappings); err != nil {
+			color.Yellow(tr("⚠️  Could not remember the mapping: %v"), err)
+		}
+	}
+
+	// Rename the mapped headers to the field names and blank out the others.
+	mapped := slices.Clone(rows)
+	mapped[0] = make([]string, len(rows[0]))
+	for i, h := range rows[0] {
+		mapped[0][i] = mapping.Columns[h]
+	}
+	delimiter := mapping.TagDelimiter
+	if delimiter == "" {
+		delimiter = ";"
+	}
+	return rowsToProblems(mapped, delimiter)
+}
@@ -0,0 +1,1021 @@
+// daemon.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	daemonSocketName  = "daemon.sock"
+	daemonStateName   = "daemon.json"
+	daemonDialTimeout = time.Second
+	daemonCallTimeout = 5 * time.Second  // also the idle timeout of a client connection
+	daemonSyncTimeout = 10 * time.Minute // a scheduled sync is killed after this long
+)
+
+// errDaemonNotRunning is returned by callDaemon when nothing listens on the socket.
+var errDaemonNotRunning = errors.New("the daemon is not running (start it with: saitama daemon start)")
+
+// DaemonConfig holds the jobs of the background daemon, besides the reminders.
+type DaemonConfig struct {
+	Syncs        map[string]string `json:"syncs,omitempty"`         // sync target -> interval, e.g. {"codeforces": "6h"}
+	WeeklyReport string            `json:"weekly_report,omitempty"` // when to email the weekly report, e.g. "sun 18:00"
+}
+
+// daemonReminder is a desktop notification the daemon shows at a given time.
+type daemonReminder struct {
+	ID      int       `json:"id"`
+	Key     string    `json:"key,omitempty"` // a reminder added with the key of another replaces it, e.g. "session"
+	Message string    `json:"message"`
+	At      time.Time `json:"at"`
+	Daily   bool      `json:"daily,omitempty"`
+}
+
+// daemonState is the part of the daemon that survives restarts, kept in daemon.json.
+type daemonState struct {
+	NextID        int                  `json:"next_id"`
+	Reminders     []daemonReminder     `json:"reminders"`
+	LastSyncs     map[string]time.Time `json:"last_syncs,omitempty"`
+	LastDaily     string               `json:"last_daily,omitempty"`     // day of the last daily training reminder
+	LastChallenge string               `json:"last_challenge,omitempty"` // day of the last daily challenge proposed
+	LastReport    string               `json:"last_report,omitempty"`    // day of the last weekly report sent
+}
+
+// daemonSyncStatus describes a scheduled sync in the status of the daemon.
+type daemonSyncStatus struct {
+	Target  string    `json:"target"`
+	Every   string    `json:"every"`
+	Last    time.Time `json:"last,omitempty"`
+	Next    time.Time `json:"next,omitempty"`
+	Running bool      `json:"running,omitempty"`
+	Error   string    `json:"error,omitempty"` // a bad target or interval in the config
+}
+
+// daemonStatus is the result of the "status" method.
+type daemonStatus struct {
+	PID            int                `json:"pid"`
+	Version        string             `json:"version"`
+	Started        time.Time          `json:"started"`
+	DataDir        string             `json:"data_dir"`
+	DailyReminder  string             `json:"daily_reminder,omitempty"`  // HH:MM, from the reminders section of the config
+	DailyChallenge string             `json:"daily_challenge,omitempty"` // HH:MM, from the challenge section of the config
+	WeeklyReport   string             `json:"weekly_report,omitempty"`   // e.g. "sun 18:00", from the daemon section of the config
+	Reminders      []daemonReminder   `json:"reminders"`
+	Syncs          []daemonSyncStatus `json:"syncs"`
+}
+
+func daemonSocketPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, daemonSocketName), nil
+}
+
+func daemonStatePath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, daemonStateName), nil
+}
+
+// loadDaemonState returns the saved state of the daemon, or an empty one.
+func loadDaemonState() (daemonState, error) {
+	state := daemonState{NextID: 1}
+	path, err := daemonStatePath()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read daemon state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse daemon state: %w", err)
+	}
+	return state, nil
+}
+
+// saveDaemonState writes the state of the daemon atomically.
+func saveDaemonState(state daemonState) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := daemonStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write daemon state: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// syncTargets returns the names of the sync subcommands, e.g. "codeforces".
+func syncTargets() []string {
+	var targets []string
+	for _, c := range syncCmd().Commands() {
+		targets = append(targets, c.Name())
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+// daemonSyncJob is a valid entry of the syncs section of the daemon config.
+type daemonSyncJob struct {
+	target string
+	spec   string // the interval as written in the config
+	every  time.Duration
+}
+
+// daemonSyncJobs reads the scheduled syncs of the config. Entries with an unknown target
+// or a bad interval are returned as statuses with an error, so that they can be shown.
+func daemonSyncJobs(cfg DaemonConfig) ([]daemonSyncJob, []daemonSyncStatus) {
+	targets := syncTargets()
+	var jobs []daemonSyncJob
+	var invalid []daemonSyncStatus
+	for target, every := range cfg.Syncs {
+		d, err := time.ParseDuration(every)
+		switch {
+		case !slices.Contains(targets, target):
+			invalid = append(invalid, daemonSyncStatus{Target: target, Every: every,
+				Error: fmt.Sprintf("unknown sync target (use %s)", strings.Join(targets, ", "))})
+		case err != nil || d < time.Minute:
+			invalid = append(invalid, daemonSyncStatus{Target: target, Every: every,
+				Error: "invalid interval (use a duration of at least 1m, e.g. 6h)"})
+		default:
+			jobs = append(jobs, daemonSyncJob{target: target, spec: every, every: d})
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].target < jobs[j].target })
+	return jobs, invalid
+}
+
+// parseWeeklyTime parses a day of the week and a time of day, e.g. "sun 18:00", into the
+// weekday and the time since midnight.
+func parseWeeklyTime(spec string) (time.Weekday, time.Duration, error) {
+	fields := strings.Fields(strings.ToLower(spec))
+	if len(fields) != 2 || len(fields[0]) < 3 {
+		return 0, 0, fmt.Errorf("invalid weekly time '%s' (use a day and a time, e.g. sun 18:00)", spec)
+	}
+	day := -1
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if strings.HasPrefix(strings.ToLower(wd.String()), fields[0]) {
+			day = int(wd)
+		}
+	}
+	at, err := time.Parse("15:04", fields[1])
+	if day < 0 || err != nil {
+		return 0, 0, fmt.Errorf("invalid weekly time '%s' (use a day and a time, e.g. sun 18:00)", spec)
+	}
+	return time.Weekday(day), time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute, nil
+}
+
+// daemon is the background process behind 'saitama daemon run'. Clients are served
+// concurrently, so everything below mu is shared with the scheduler.
+type daemon struct {
+	started time.Time
+	quit    chan struct{}
+	stop    sync.Once
+
+	mu      sync.Mutex
+	state   daemonState
+	cfg     Config
+	cfgMod  time.Time // modification time of the loaded config file, zero before the first load
+	jobs    []daemonSyncJob
+	invalid []daemonSyncStatus
+	running map[string]bool // syncs in flight, by target
+}
+
+// daemonMethods are the methods served on the socket, by name.
+var daemonMethods = map[string]func(d *daemon, params json.RawMessage) (any, error){
+	"status":        (*daemon).status,
+	"remind.add":    (*daemon).remindAdd,
+	"remind.list":   (*daemon).remindList,
+	"remind.cancel": (*daemon).remindCancel,
+	"stop":          (*daemon).shutdown,
+}
+
+// saveLocked saves the state, logging failures. d.mu must be held.
+func (d *daemon) saveLocked() {
+	if err := saveDaemonState(d.state); err != nil {
+		slog.Warn("could not save the daemon state", "err", err)
+	}
+}
+
+// reloadConfig reloads the config when its file changed since the last load.
+func (d *daemon) reloadConfig() {
+	path, err := getConfigPath()
+	if err != nil {
+		return
+	}
+	mod := d.started // a missing config file is loaded once, as the defaults
+	if info, err := os.Stat(path); err == nil {
+		mod = info.ModTime()
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.cfgMod.IsZero() && mod.Equal(d.cfgMod) {
+		return
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		slog.Warn("daemon could not load the config", "err", err)
+	}
+	d.cfg, d.cfgMod = cfg, mod
+	d.jobs, d.invalid = daemonSyncJobs(cfg.Daemon)
+	for _, s := range d.invalid {
+		slog.Warn("skipping scheduled sync", "target", s.Target, "every", s.Every, "err", s.Error)
+	}
+	if spec := cfg.Daemon.WeeklyReport; spec != "" {
+		if _, _, err := parseWeeklyTime(spec); err != nil {
+			slog.Warn("skipping the weekly report", "err", err)
+		}
+	}
+	slog.Info("daemon config loaded", "syncs", len(d.jobs), "daily_reminder", cfg.Reminders.Enabled, "daily_challenge", cfg.Challenge.Enabled)
+}
+
+func (d *daemon) status(raw json.RawMessage) (any, error) {
+	if err := decodeParams(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	appDir, _ := getAppDir()
+	st := daemonStatus{
+		PID:       os.Getpid(),
+		Version:   version,
+		Started:   d.started,
+		DataDir:   appDir,
+		Reminders: append([]daemonReminder{}, d.state.Reminders...),
+		Syncs:     append([]daemonSyncStatus{}, d.invalid...),
+	}
+	if d.cfg.Reminders.Enabled && d.cfg.Reminders.Time != "" {
+		st.DailyReminder = d.cfg.Reminders.Time
+	}
+	if d.cfg.Challenge.Enabled {
+		st.DailyChallenge = d.cfg.Challenge.Time
+	}
+	if _, _, err := parseWeeklyTime(d.cfg.Daemon.WeeklyReport); err == nil {
+		st.WeeklyReport = d.cfg.Daemon.WeeklyReport
+	}
+	for _, job := range d.jobs {
+		s := daemonSyncStatus{Target: job.target, Every: job.spec, Running: d.running[job.target]}
+		if last, ok := d.state.LastSyncs[job.target]; ok {
+			s.Last, s.Next = last, last.Add(job.every)
+		}
+		st.Syncs = append(st.Syncs, s)
+	}
+	return st, nil
+}
+
+// remindAdd schedules a reminder: {"message", "at", "daily", "key"} → the reminder.
+func (d *daemon) remindAdd(raw json.RawMessage) (any, error) {
+	var params struct {
+		Message string    `json:"message"`
+		At      time.Time `json:"at"`
+		Daily   bool      `json:"daily"`
+		Key     string    `json:"key"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.Message) == "" || params.At.IsZero() {
+		return nil, newRPCError(rpcInvalidParams, "a reminder needs a message and a time")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if params.Key != "" {
+		d.cancelLocked(0, params.Key)
+	}
+	r := daemonReminder{ID: d.state.NextID, Key: params.Key, Message: params.Message, At: params.At, Daily: params.Daily}
+	d.state.NextID++
+	d.state.Reminders = append(d.state.Reminders, r)
+	sort.SliceStable(d.state.Reminders, func(i, j int) bool { return d.state.Reminders[i].At.Before(d.state.Reminders[j].At) })
+	d.saveLocked()
+	slog.Info("reminder added", "id", r.ID, "key", r.Key, "at", r.At, "daily", r.Daily)
+	return r, nil
+}
+
+func (d *daemon) remindList(raw json.RawMessage) (any, error) {
+	if err := decodeParams(raw, &struct{}{}); err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]daemonReminder{}, d.state.Reminders...), nil
+}
+
+// remindCancel cancels reminders: {"id"} or {"key"} → {"cancelled"}.
+func (d *daemon) remindCancel(raw json.RawMessage) (any, error) {
+	var params struct {
+		ID  int    `json:"id"`
+		Key string `json:"key"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.ID == 0 && params.Key == "" {
+		return nil, newRPCError(rpcInvalidParams, "give the id or the key of the reminder")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	n := d.cancelLocked(params.ID, params.Key)
+	if n > 0 {
+		d.saveLocked()
+	}
+	return map[string]int{"cancelled": n}, nil
+}
+
+// cancelLocked drops the reminders with the ID or the key. d.mu must be held.
+func (d *daemon) cancelLocked(id int, key string) int {
+	kept := d.state.Reminders[:0]
+	for _, r := range d.state.Reminders {
+		if (id != 0 && r.ID == id) || (key != "" && r.Key == key) {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	n := len(d.state.Reminders) - len(kept)
+	d.state.Reminders = kept
+	return n
+}
+
+func (d *daemon) shutdown(raw json.RawMessage) (any, error) {
+	d.stop.Do(func() { close(d.quit) })
+	return map[string]int{"pid": os.Getpid()}, nil
+}
+
+// tick fires the due reminders and starts the due syncs.
+func (d *daemon) tick(now time.Time) {
+	d.reloadConfig()
+
+	d.mu.Lock()
+	var due []string
+	changed := false
+	kept := d.state.Reminders[:0]
+	for _, r := range d.state.Reminders {
+		if r.At.After(now) {
+			kept = append(kept, r)
+			continue
+		}
+		due = append(due, r.Message)
+		changed = true
+		if r.Daily {
+			for !r.At.After(now) {
+				r.At = r.At.AddDate(0, 0, 1)
+			}
+			kept = append(kept, r)
+		}
+	}
+	d.state.Reminders = kept
+	sort.SliceStable(d.state.Reminders, func(i, j int) bool { return d.state.Reminders[i].At.Before(d.state.Reminders[j].At) })
+
+	dailyDue := false
+	if rc := d.cfg.Reminders; rc.Enabled && rc.Time != "" && d.state.LastDaily != dayKey(now) {
+		if at, err := time.ParseInLocation("15:04", rc.Time, now.Location()); err == nil {
+			at = startOfDay(now).Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)
+			if !now.Before(at) {
+				d.state.LastDaily, dailyDue, changed = dayKey(now), true, true
+			}
+		}
+	}
+
+	challengeDue := false
+	if cc := d.cfg.Challenge; cc.Enabled && d.state.LastChallenge != dayKey(now) {
+		if at, err := time.ParseInLocation("15:04", cc.Time, now.Location()); err == nil {
+			at = startOfDay(now).Add(time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute)
+			if !now.Before(at) {
+				d.state.LastChallenge, challengeDue, changed = dayKey(now), true, true
+			}
+		}
+	}
+
+	reportDue := false
+	if spec := d.cfg.Daemon.WeeklyReport; spec != "" && d.state.LastReport != dayKey(now) {
+		if day, at, err := parseWeeklyTime(spec); err == nil && now.Weekday() == day && !now.Before(startOfDay(now).Add(at)) {
+			d.state.LastReport, reportDue, changed = dayKey(now), true, true
+		}
+	}
+
+	var syncs []string
+	for _, job := range d.jobs {
+		if d.running[job.target] || now.Sub(d.state.LastSyncs[job.target]) < job.every {
+			continue
+		}
+		if d.state.LastSyncs == nil {
+			d.state.LastSyncs = map[string]time.Time{}
+		}
+		d.state.LastSyncs[job.target] = now
+		d.running[job.target] = true
+		syncs = append(syncs, job.target)
+		changed = true
+	}
+	if changed {
+		d.saveLocked()
+	}
+	reminders := d.cfg.Reminders
+	d.mu.Unlock()
+
+	for _, msg := range due {
+		slog.Info("reminder due", "message", msg)
+		if err := showNotification("saitama", msg); err != nil {
+			slog.Warn("reminder notification failed", "err", err)
+		}
+	}
+	if dailyDue {
+		d.dailyReminder(reminders, now)
+	}
+	if challengeDue {
+		d.proposeChallenge(now)
+	}
+	if reportDue {
+		go sendScheduledReport()
+	}
+	for _, target := range syncs {
+		go d.runSync(target)
+	}
+}
+
+// dailyReminder shows the daily training reminder, unless something was solved today.
+func (d *daemon) dailyReminder(cfg ReminderConfig, now time.Time) {
+	problems, err := loadProblems()
+	if err != nil {
+		slog.Warn("daily reminder could not load the problems", "err", err)
+		return
+	}
+	if days, ok := daysSinceLastSolve(problems, now); ok && days == 0 {
+		slog.Info("daily reminder skipped, already trained today")
+		return
+	}
+	msg := tr("👊 Time to train! Pick a problem with: saitama next")
+	if days, ok := inactivityNudge(problems, cfg, now); ok {
+		msg = fmt.Sprintf(tr("⏰ No solve in %d days. Even one problem today keeps you sharp: saitama next"), days)
+	}
+	slog.Info("daily reminder", "message", msg)
+	if err := showNotification("saitama", msg); err != nil {
+		slog.Warn("daily reminder notification failed", "err", err)
+	}
+}
+
+// proposeChallenge draws the daily challenge and proposes it in a notification, unless
+// it was answered already.
+func (d *daemon) proposeChallenge(now time.Time) {
+	problems, err := loadProblems()
+	if err != nil {
+		slog.Warn("daily challenge could not load the problems", "err", err)
+		return
+	}
+	challenges, i, err := todayChallenge(problems, now)
+	if err != nil || i == -1 {
+		slog.Warn("no daily challenge", "err", err)
+		return
+	}
+	c := challenges[i]
+	p, index := findProblemByID(problems, c.ProblemID)
+	if c.Status != challengeProposed || index == -1 {
+		return
+	}
+	slog.Info("daily challenge", "problem", p.ID)
+	if err := showNotification("saitama", challengeMessage(*p)); err != nil {
+		slog.Warn("daily challenge notification failed", "err", err)
+	}
+}
+
+// runScheduled runs saitama with the arguments in a child process, on the data
+// directory of the daemon, and returns the last line of its output. The command failed
+// when it exits with an error.
+func runScheduled(args ...string) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), daemonSyncTimeout)
+	defer cancel()
+	if dir := dataDir(); dir != "" {
+		args = append(args, "--data-dir", dir)
+	}
+	output, err := exec.CommandContext(ctx, exe, args...).CombinedOutput()
+	lines := strings.Split(strings.TrimSpace(ansiEscape.ReplaceAllString(string(output), "")), "\n")
+	return lines[len(lines)-1], err
+}
+
+// sendScheduledReport runs 'saitama report weekly --email' and logs its outcome.
+func sendScheduledReport() {
+	slog.Info("weekly report started")
+	last, err := runScheduled("report", "weekly", "--email")
+	if err != nil {
+		slog.Warn("weekly report failed", "err", err, "output", last)
+		if nerr := showNotification("saitama", fmt.Sprintf(tr("❌ Weekly report failed: %s"), last)); nerr != nil {
+			slog.Debug("notification failed", "err", nerr)
+		}
+		return
+	}
+	slog.Info("weekly report sent", "output", last)
+}
+
+// runSync runs 'saitama sync <target>' in a child process and logs its outcome.
+func (d *daemon) runSync(target string) {
+	defer func() {
+		d.mu.Lock()
+		delete(d.running, target)
+		d.mu.Unlock()
+	}()
+	slog.Info("scheduled sync started", "target", target)
+	last, err := runScheduled("sync", target)
+	if err != nil {
+		slog.Warn("scheduled sync failed", "target", target, "err", err, "output", last)
+		if nerr := showNotification("saitama", fmt.Sprintf(tr("❌ Scheduled %s sync failed: %s"), target, last)); nerr != nil {
+			slog.Debug("notification failed", "err", nerr)
+		}
+		return
+	}
+	slog.Info("scheduled sync finished", "target", target, "output", last)
+}
+
+// serveConn answers the requests of one client, one per line, until it disconnects or
+// stays idle for daemonCallTimeout.
+func (d *daemon) serveConn(conn net.Conn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(conn)
+	for {
+		conn.SetDeadline(time.Now().Add(daemonCallTimeout))
+		if !scanner.Scan() {
+			return
+		}
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		resp := handleRPC(line, func(name string) (func(json.RawMessage) (any, error), bool) {
+			method, ok := daemonMethods[name]
+			if !ok {
+				return nil, false
+			}
+			return func(params json.RawMessage) (any, error) { return method(d, params) }, true
+		})
+		if resp != nil {
+			if err := encoder.Encode(resp); err != nil {
+				slog.Debug("daemon client gone", "err", err)
+				return
+			}
+		}
+	}
+}
+
+// runDaemon listens on the socket and runs the scheduler until it is stopped by the
+// "stop" method, Ctrl+C or SIGTERM.
+func runDaemon() error {
+	var st daemonStatus
+	if err := callDaemon("status", nil, &st); err == nil {
+		return fmt.Errorf("the daemon is already running (pid %d)", st.PID)
+	}
+	path, err := daemonSocketPath()
+	if err != nil {
+		return err
+	}
+	// Nobody answered, so a socket left here belongs to a daemon that died.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+
+	state, err := loadDaemonState()
+	if err != nil {
+		slog.Warn("starting with an empty daemon state", "err", err)
+	}
+	d := &daemon{started: time.Now(), quit: make(chan struct{}), state: state, running: map[string]bool{}}
+	d.tick(time.Now())
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	// Keep running when the terminal that started the daemon is closed.
+	signal.Ignore(syscall.SIGHUP)
+
+	var clients sync.WaitGroup
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			clients.Add(1)
+			go func() {
+				defer clients.Done()
+				d.serveConn(conn)
+			}()
+		}
+	}()
+	slog.Info("daemon started", "pid", os.Getpid(), "socket", path)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			d.tick(now)
+		case sig := <-signals:
+			slog.Info("daemon stopping", "signal", sig.String())
+			d.stop.Do(func() { close(d.quit) })
+		case <-d.quit:
+			listener.Close()
+			clients.Wait()
+			slog.Info("daemon stopped")
+			return nil
+		}
+	}
+}
+
+// callDaemon calls a method of the running daemon and decodes its result, if any.
+func callDaemon(method string, params, result any) error {
+	path, err := daemonSocketPath()
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("unix", path, daemonDialTimeout)
+	if err != nil {
+		slog.Debug("daemon unreachable", "socket", path, "err", err)
+		return errDaemonNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(daemonCallTimeout))
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req := rpcRequest{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: raw}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to reach the daemon: %w", err)
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return fmt.Errorf("failed to read the daemon's answer: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result != nil && len(resp.Result) > 0 {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
+
+// daemonRemind schedules a reminder replacing the one with the same key, or cancels it
+// when at is zero. It does nothing when the daemon isn't running.
+func daemonRemind(key, message string, at time.Time) {
+	var err error
+	if at.IsZero() {
+		err = callDaemon("remind.cancel", map[string]string{"key": key}, nil)
+	} else {
+		err = callDaemon("remind.add", map[string]any{"key": key, "message": message, "at": at}, nil)
+	}
+	if err != nil && !errors.Is(err, errDaemonNotRunning) {
+		slog.Warn("could not update the daemon reminder", "key", key, "err", err)
+	}
+}
+
+// printDaemonStatus shows what the daemon is doing.
+func printDaemonStatus(st daemonStatus, now time.Time) {
+	printResult(color.New(color.FgGreen), tr("🤖 Daemon running (pid %d, %s, up %s)"), st.PID, st.Version, formatClock(now.Sub(st.Started)))
+	printResult(color.New(color.FgHiBlack), tr("   Data: %s"), st.DataDir)
+	if st.DailyReminder != "" {
+		printResult(color.New(color.FgWhite), tr("🔔 Daily training reminder at %s"), st.DailyReminder)
+	}
+	if st.DailyChallenge != "" {
+		printResult(color.New(color.FgWhite), tr("🎲 Daily challenge at %s"), st.DailyChallenge)
+	}
+	if st.WeeklyReport != "" {
+		printResult(color.New(color.FgWhite), tr("📧 Weekly report emailed on %s"), st.WeeklyReport)
+	}
+	if len(st.Reminders) == 0 {
+		printResult(color.New(color.FgWhite), tr("⏰ No reminders."))
+	} else {
+		printResult(color.New(color.FgWhite), tr("⏰ Reminders:"))
+		printReminders(st.Reminders, now)
+	}
+	if len(st.Syncs) == 0 {
+		printResult(color.New(color.FgHiBlack), tr("🔄 No scheduled syncs (set them in the \"daemon\" section of the config)."))
+		return
+	}
+	printResult(color.New(color.FgWhite), tr("🔄 Scheduled syncs:"))
+	for _, s := range st.Syncs {
+		switch {
+		case s.Error != "":
+			printResult(color.New(color.FgYellow), "   %-12s every %-6s ⚠️  %s", s.Target, s.Every, s.Error)
+		case s.Running:
+			printResult(color.New(color.FgCyan), tr("   %-12s every %-6s running now"), s.Target, s.Every)
+		case s.Last.IsZero():
+			printResult(color.New(color.FgWhite), tr("   %-12s every %-6s never run"), s.Target, s.Every)
+		default:
+			printResult(color.New(color.FgWhite), tr("   %-12s every %-6s last %s, next %s"), s.Target, s.Every,
+				s.Last.Format("Jan 02 15:04"), s.Next.Format("Jan 02 15:04"))
+		}
+	}
+}
+
+// printReminders lists reminders with the time left before each.
+func printReminders(reminders []daemonReminder, now time.Time) {
+	for _, r := range reminders {
+		when := r.At.Format("Jan 02 15:04")
+		if r.Daily {
+			when = tr("daily at ") + r.At.Format("15:04")
+		}
+		printResult(color.New(color.FgWhite), "   #%-3d %-20s in %-9s %s", r.ID, when, formatClock(r.At.Sub(now)), r.Message)
+	}
+}
+
+func daemonCmd() *cobra.Command {
+	showStatus := func(cmd *cobra.Command, args []string) {
+		var st daemonStatus
+		if err := callDaemon("status", nil, &st); err != nil {
+			if errors.Is(err, errDaemonNotRunning) && !jsonOutput() {
+				color.Yellow(tr("💤 The daemon is not running. Start it with: saitama daemon start"))
+				return
+			}
+			printError("❌ %v", err)
+			return
+		}
+		if jsonOutput() {
+			printJSON(st)
+			return
+		}
+		printDaemonStatus(st, time.Now())
+	}
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Run a background daemon for reminders, scheduled syncs and the weekly report",
+		Long: "The daemon is a background process owning reminders ('saitama remind'), the daily training reminder " +
+			"(the \"reminders\" section of the config) and scheduled syncs, so that no cron job is needed. The CLI " +
+			"talks to it over a socket in the data directory. While it runs, a session also leaves it a reminder for " +
+			"the end of its time limit, so the end is announced even if the terminal was closed. The session itself " +
+			"(its clock and its results) stays in the terminal that runs it.\n\n" +
+			"Scheduled syncs go in the config, by target and interval, and the weekly report ('report weekly " +
+			"--email') by day and time:\n" +
+			"  \"daemon\": {\"syncs\": {\"codeforces\": \"6h\", \"leetcode\": \"12h\"}, \"weekly_report\": \"sun 18:00\"}\n\n" +
+			"Add --log-file to 'daemon start' and its log goes to the logs folder of the data directory.",
+		Example: `  saitama daemon start
+  saitama daemon status
+  saitama daemon stop`,
+		Args: cobra.NoArgs,
+		Run:  showStatus,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the reminders and scheduled syncs of the daemon",
+		Args:  cobra.NoArgs,
+		Run:   showStatus,
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "start",
+		Annotations: mutates(),
+		Short:       "Start the daemon in the background",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var st daemonStatus
+			if err := callDaemon("status", nil, &st); err == nil {
+				color.Yellow(tr("🤖 The daemon is already running (pid %d)."), st.PID)
+				return
+			}
+			exe, err := os.Executable()
+			if err != nil {
+				printError(tr("❌ Could not start the daemon: %v"), err)
+				return
+			}
+			childArgs := []string{"daemon", "run"}
+			if dir := dataDir(); dir != "" {
+				childArgs = append(childArgs, "--data-dir", dir)
+			}
+			if logFileFlag {
+				childArgs = append(childArgs, "--log-file")
+			}
+			if debugFlag {
+				childArgs = append(childArgs, "--debug")
+			}
+			// No standard streams: the daemon outlives this terminal, and logs to the file with --log-file.
+			child := exec.Command(exe, childArgs...)
+			if err := child.Start(); err != nil {
+				printError(tr("❌ Could not start the daemon: %v"), err)
+				return
+			}
+			pid := child.Process.Pid
+			exited := make(chan error, 1)
+			go func() { exited <- child.Wait() }()
+
+			deadline := time.Now().Add(3 * time.Second)
+			for time.Now().Before(deadline) {
+				select {
+				case err := <-exited:
+					printError(tr("❌ The daemon exited right away (%v). Run it in the foreground to see why: saitama daemon run"), err)
+					return
+				case <-time.After(100 * time.Millisecond):
+				}
+				if err := callDaemon("status", nil, &st); err == nil {
+					color.Green(tr("🤖 Daemon started (pid %d)."), pid)
+					color.HiBlack(tr("   Stop it with: saitama daemon stop"))
+					return
+				}
+			}
+			color.Yellow(tr("⚠️  The daemon (pid %d) doesn't answer yet. Check it with: saitama daemon status"), pid)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stop",
+		Short: "Stop the daemon",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var result struct {
+				PID int `json:"pid"`
+			}
+			if err := callDaemon("stop", nil, &result); err != nil {
+				if errors.Is(err, errDaemonNotRunning) {
+					color.Yellow(tr("💤 The daemon is not running."))
+					return
+				}
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🤖 Daemon stopped (pid %d)."), result.PID)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "run",
+		Annotations: mutates(),
+		Short:       "Run the daemon in the foreground",
+		Long:        "Runs the daemon in the foreground until Ctrl+C, e.g. under systemd or launchd. Add --verbose to see what it does.",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runDaemon(); err != nil {
+				printError("❌ %v", err)
+				os.Exit(1)
+			}
+		},
+	})
+	return cmd
+}
+
+// parseRemindAt returns the next time of day given as HH:MM: today, or tomorrow when it
+// has passed.
+func parseRemindAt(s string, now time.Time) (time.Time, error) {
+	t, err := time.ParseInLocation("15:04", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s' (use HH:MM, e.g. 18:30)", s)
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
+}
+
+func remindCmd() *cobra.Command {
+	add := func(message string, at time.Time, daily bool) {
+		var r daemonReminder
+		if err := callDaemon("remind.add", map[string]any{"message": message, "at": at, "daily": daily}, &r); err != nil {
+			printError("❌ %v", err)
+			return
+		}
+		if jsonOutput() {
+			printJSON(r)
+			return
+		}
+		if r.Daily {
+			color.Green(tr("⏰ Reminder #%d set for every day at %s."), r.ID, r.At.Format("15:04"))
+			return
+		}
+		color.Green(tr("⏰ Reminder #%d set for %s (in %s)."), r.ID, r.At.Format("Jan 02 15:04"), formatClock(time.Until(r.At)))
+	}
+	messageOf := func(args []string) string {
+		if len(args) == 0 {
+			return tr("⏰ Time's up!")
+		}
+		return strings.Join(args, " ")
+	}
+
+	cmd := &cobra.Command{
+		Use:   "remind",
+		Short: "Get a desktop notification later, from the daemon",
+		Long: "Reminders are kept by the daemon ('saitama daemon start'), so they fire even after the terminal " +
+			"that set them is closed, and survive restarts of the daemon.",
+		Example: `  saitama remind in 25m "Stop and write down the idea"
+  saitama remind at 18:30 "Contest time" --daily
+  saitama remind list
+  saitama remind cancel 3`,
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "in <duration> [message]",
+		Short: "Remind me after a duration, e.g. 25m or 1h30m",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			d, err := time.ParseDuration(args[0])
+			if err != nil || d <= 0 {
+				printError(tr("❌ Invalid duration '%s' (use e.g. 25m or 1h30m)"), args[0])
+				return
+			}
+			add(messageOf(args[1:]), time.Now().Add(d).Truncate(time.Second), false)
+		},
+	})
+	var daily bool
+	at := &cobra.Command{
+		Use:   "at <HH:MM> [message]",
+		Short: "Remind me at a time of day",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			when, err := parseRemindAt(args[0], time.Now())
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			add(messageOf(args[1:]), when, daily)
+		},
+	}
+	at.Flags().BoolVar(&daily, "daily", false, "repeat every day")
+	cmd.AddCommand(at)
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List the pending reminders",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			var reminders []daemonReminder
+			if err := callDaemon("remind.list", nil, &reminders); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if jsonOutput() {
+				printJSON(reminders)
+				return
+			}
+			if len(reminders) == 0 {
+				color.Yellow(tr("⏰ No reminders. Add one with: saitama remind in 25m"))
+				return
+			}
+			printReminders(reminders, time.Now())
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a reminder",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var id int
+			if _, err := fmt.Sscanf(strings.TrimPrefix(args[0], "#"), "%d", &id); err != nil || id <= 0 {
+				printError(tr("❌ Invalid reminder ID '%s'"), args[0])
+				return
+			}
+			var result struct {
+				Cancelled int `json:"cancelled"`
+			}
+			if err := callDaemon("remind.cancel", map[string]int{"id": id}, &result); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if result.Cancelled == 0 {
+				printError(tr("❌ No reminder #%d"), id)
+				return
+			}
+			color.Green(tr("🗑️  Reminder #%d cancelled."), id)
+		},
+	})
+	return cmd
+}
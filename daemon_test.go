@@ -0,0 +1,32 @@
+// daemon_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseWeeklyTime(t *testing.T) {
+	tests := []struct {
+		spec string
+		day  time.Weekday
+		at   time.Duration
+		ok   bool
+	}{
+		{"sun 18:00", time.Sunday, 18 * time.Hour, true},
+		{"Monday 07:30", time.Monday, 7*time.Hour + 30*time.Minute, true},
+		{"  thu   9:05 ", time.Thursday, 9*time.Hour + 5*time.Minute, true},
+		{"sat 00:00", time.Saturday, 0, true},
+		{"su 18:00", 0, 0, false},
+		{"sunday", 0, 0, false},
+		{"sun 25:00", 0, 0, false},
+		{"18:00 sun", 0, 0, false},
+		{"", 0, 0, false},
+	}
+	for _, tt := range tests {
+		day, at, err := parseWeeklyTime(tt.spec)
+		if (err == nil) != tt.ok || tt.ok && (day != tt.day || at != tt.at) {
+			t.Errorf("parseWeeklyTime(%q) = %v, %v, %v", tt.spec, day, at, err)
+		}
+	}
+}
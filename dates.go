@@ -0,0 +1,201 @@
+// dates.go
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// dateFilter is a parsed date expression: a range [Start, End) where a zero bound is
+// open, or Never for a date that isn't set.
+type dateFilter struct {
+	Never bool
+	Start time.Time
+	End   time.Time
+}
+
+// spanPattern matches lengths of time such as 30d, 2w, 6m or 1y.
+var spanPattern = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+// dateExprHelp lists the forms of a date expression, for error and help messages.
+const dateExprHelp = "use e.g. 2024-01-01, >2024-01, <=2023, 2024-01-01..2024-03-31, today, this-month, last-30d, never or ever"
+
+// parseDateExpr parses a date expression:
+//
+//	2024-06-01, 2024-06, 2024          that day, month or year
+//	today, yesterday, this-week, this-month, this-year
+//	>D, >=D, <D, <=D                   after, from, before or up to a date of the forms above
+//	D..E                               from D to E, both included
+//	last-30d, last-2w, last-6m, last-1y  within the last days, weeks, months or years
+//	never, ever                        not set, or set at all
+func parseDateExpr(expr string, now time.Time) (dateFilter, error) {
+	expr = strings.ToLower(strings.TrimSpace(expr))
+	switch expr {
+	case "never":
+		return dateFilter{Never: true}, nil
+	case "ever":
+		return dateFilter{}, nil
+	}
+
+	if span, ok := strings.CutPrefix(expr, "last-"); ok && spanPattern.MatchString(span) {
+		start, _ := shiftBySpan(startOfDay(now).AddDate(0, 0, 1), span, -1)
+		return dateFilter{Start: start}, nil
+	}
+
+	if from, to, ok := strings.Cut(expr, ".."); ok {
+		start, _, err := parseDatePeriod(from, now)
+		if err != nil {
+			return dateFilter{}, err
+		}
+		_, end, err := parseDatePeriod(to, now)
+		if err != nil {
+			return dateFilter{}, err
+		}
+		if !start.Before(end) {
+			return dateFilter{}, fmt.Errorf("empty date range '%s'", expr)
+		}
+		return dateFilter{Start: start, End: end}, nil
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		rest, ok := strings.CutPrefix(expr, op)
+		if !ok {
+			continue
+		}
+		start, end, err := parseDatePeriod(rest, now)
+		if err != nil {
+			return dateFilter{}, err
+		}
+		switch op {
+		case ">=":
+			return dateFilter{Start: start}, nil
+		case "<=":
+			return dateFilter{End: end}, nil
+		case ">":
+			return dateFilter{Start: end}, nil
+		}
+		return dateFilter{End: start}, nil
+	}
+
+	start, end, err := parseDatePeriod(expr, now)
+	if err != nil {
+		return dateFilter{}, err
+	}
+	return dateFilter{Start: start, End: end}, nil
+}
+
+// parseDatePeriod parses a day, month, year or named period into its range [start, end).
+func parseDatePeriod(s string, now time.Time) (time.Time, time.Time, error) {
+	today := startOfDay(now)
+	switch s {
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week":
+		return weekStart(now), today.AddDate(0, 0, 1), nil
+	case "this-month":
+		return today.AddDate(0, 0, 1-today.Day()), today.AddDate(0, 0, 1), nil
+	case "this-year":
+		return today.AddDate(0, 0, 1-today.YearDay()), today.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.ParseInLocation("2006-01", s, time.Local); err == nil {
+		return t, t.AddDate(0, 1, 0), nil
+	}
+	if t, err := time.ParseInLocation("2006", s, time.Local); err == nil {
+		return t, t.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date '%s' (%s)", s, dateExprHelp)
+}
+
+// shiftBySpan moves t forward (sign 1) or back (sign -1) by a span such as 30d, 2w, 6m
+// or 1y.
+func shiftBySpan(t time.Time, span string, sign int) (time.Time, error) {
+	m := spanPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(span)))
+	if m == nil {
+		return time.Time{}, fmt.Errorf("invalid length of time '%s' (use e.g. 30d, 2w, 6m or 1y)", span)
+	}
+	n, _ := strconv.Atoi(m[1])
+	n *= sign
+	switch m[2] {
+	case "d":
+		return t.AddDate(0, 0, n), nil
+	case "w":
+		return t.AddDate(0, 0, 7*n), nil
+	case "m":
+		return t.AddDate(0, n, 0), nil
+	}
+	return t.AddDate(n, 0, 0), nil
+}
+
+// matches reports whether a date, zero when not set, passes the filter.
+func (f dateFilter) matches(t time.Time) bool {
+	if f.Never || t.IsZero() {
+		return f.Never == t.IsZero()
+	}
+	return (f.Start.IsZero() || !t.Before(f.Start)) && (f.End.IsZero() || t.Before(f.End))
+}
+
+// matchesDateExpr reports whether a date passes a validated date expression.
+func matchesDateExpr(expr string, t time.Time) bool {
+	f, err := parseDateExpr(expr, time.Now())
+	return err == nil && f.matches(t)
+}
+
+// dateFields are the problem dates that date expressions can filter on, as query terms
+// such as added:>2024-01-01 or solved:last-30d.
+var dateFields = []string{"added", "solved"}
+
+// parseQueryTerms moves field:expression terms of the arguments into the query and
+// returns the other arguments. Besides the dates, tag:dp keeps the problems with a tag
+// and -tag:math leaves out the ones with it.
+func parseQueryTerms(args []string, q *ProblemQuery) ([]string, error) {
+	var rest []string
+	for _, arg := range args {
+		field, expr, ok := strings.Cut(arg, ":")
+		switch {
+		case !ok:
+			rest = append(rest, arg)
+		case strings.EqualFold(field, "added"):
+			q.Added = expr
+		case strings.EqualFold(field, "solved"):
+			q.Solved = expr
+		case strings.EqualFold(field, "tag"):
+			q.Tags = append(q.Tags, expr)
+		case strings.EqualFold(field, "-tag"):
+			q.NotTags = append(q.NotTags, expr)
+		default:
+			return nil, fmt.Errorf("unknown filter '%s:' (use %s, tag: or -tag:)", field, strings.Join(dateFields, ":, ")+":")
+		}
+	}
+	return rest, nil
+}
+
+// expandNegatedTerms turns the -tag:math terms of the command line into --not-tag=math
+// for the commands taking query terms, which all have that flag: left as is, the flag
+// parser would read -tag:math as -t with the value "ag:math". The arguments of other
+// commands, e.g. a note starting with -tag:, and those after "--" are left alone.
+func expandNegatedTerms(root *cobra.Command, args []string) []string {
+	if cmd, _, err := root.Find(args); err != nil || cmd.Flags().Lookup("not-tag") == nil {
+		return args
+	}
+	out := make([]string, 0, len(args))
+	for i, arg := range args {
+		if arg == "--" {
+			return append(out, args[i:]...)
+		}
+		if len(arg) > len("-tag:") && strings.EqualFold(arg[:len("-tag:")], "-tag:") {
+			arg = "--not-tag=" + arg[len("-tag:"):]
+		}
+		out = append(out, arg)
+	}
+	return out
+}
@@ -0,0 +1,30 @@
+// dates_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestExpandNegatedTerms(t *testing.T) {
+	root := &cobra.Command{Use: "saitama"}
+	root.AddCommand(listCmd(), addCmd())
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"list", "-tag:math", "tag:dp"}, []string{"list", "--not-tag=math", "tag:dp"}},
+		{[]string{"list", "-TAG:math"}, []string{"list", "--not-tag=math"}},
+		{[]string{"list", "--", "-tag:math"}, []string{"list", "--", "-tag:math"}},
+		{[]string{"list", "-tag:"}, []string{"list", "-tag:"}},
+		{[]string{"add", "--notes", "-tag:math is a trap"}, []string{"add", "--notes", "-tag:math is a trap"}},
+		{[]string{"nope", "-tag:math"}, []string{"nope", "-tag:math"}},
+	}
+	for _, tt := range tests {
+		if got := expandNegatedTerms(root, tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("expandNegatedTerms(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// dedupe.go
+package main
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+var (
+	leetcodePath   = regexp.MustCompile(`^/problems/([^/]+)`)
+	codeforcesPath = regexp.MustCompile(`^/(?:problemset/problem|contest|gym)/(\d+)/(?:problem/)?([a-z]\d?)`)
+	hackerrankPath = regexp.MustCompile(`^/challenges/([^/]+)`)
+	atcoderPath    = regexp.MustCompile(`^/contests/([^/]+)/tasks/([^/]+)`)
+)
+
+// normalizeURL reduces a problem URL to a canonical form so that different links to
+// the same problem compare equal: scheme, "www.", query, fragment and trailing slashes
+// are dropped, and known platforms are reduced to the part identifying the problem.
+func normalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(raw)
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	path := strings.TrimRight(strings.ToLower(u.Path), "/")
+
+	switch host {
+	case "leetcode.com", "leetcode.cn":
+		// /problems/two-sum/description/ and leetcode.cn are the same problem
+		if m := leetcodePath.FindStringSubmatch(path); m != nil {
+			return "leetcode.com/problems/" + m[1]
+		}
+	case "codeforces.com", "m1.codeforces.com", "m2.codeforces.com", "m3.codeforces.com":
+		// /contest/1234/problem/A and /problemset/problem/1234/A are the same problem
+		if m := codeforcesPath.FindStringSubmatch(path); m != nil {
+			return "codeforces.com/problem/" + m[1] + "/" + m[2]
+		}
+	case "hackerrank.com":
+		if m := hackerrankPath.FindStringSubmatch(path); m != nil {
+			return "hackerrank.com/challenges/" + m[1]
+		}
+	case "atcoder.jp":
+		if m := atcoderPath.FindStringSubmatch(path); m != nil {
+			return "atcoder.jp/contests/" + m[1] + "/tasks/" + m[2]
+		}
+	}
+	return host + path
+}
+
+// findProblemByURL returns the problem whose normalized URL matches, if any.
+func findProblemByURL(problems []Problem, rawURL string) (*Problem, int) {
+	key := normalizeURL(rawURL)
+	if key == "" {
+		return nil, -1
+	}
+	for i := range problems {
+		if normalizeURL(problems[i].URL) == key {
+			return &problems[i], i
+		}
+	}
+	return nil, -1
+}
+
+// mergeProblemInto folds src into dst: tags, relations and attempts are combined,
+// empty fields are filled in and notes are appended. dst keeps its ID.
+func mergeProblemInto(dst *Problem, src Problem) {
+	dst.Tags = mergeTags(dst.Tags, src.Tags)
+
+	if dst.Difficulty == "" {
+		dst.Difficulty = src.Difficulty
+	}
+	if dst.Platform == "" {
+		dst.Platform = src.Platform
+	}
+	if dst.URL == "" {
+		dst.URL = src.URL
+	}
+	if dst.Rating == 0 {
+		dst.Rating = src.Rating
+	}
+	dst.Starred = dst.Starred || src.Starred
+	mergeComplexity(dst, src)
+	if src.Notes != "" && !strings.Contains(dst.Notes, src.Notes) {
+		if dst.Notes != "" {
+			dst.Notes += "\n\n"
+		}
+		dst.Notes += src.Notes
+	}
+
+	mergeRelations(dst, src)
+	dst.Attempts = append(dst.Attempts, src.Attempts...)
+	dst.SolveCount += src.SolveCount
+	if src.LastSolved.After(dst.LastSolved) {
+		dst.LastSolved = src.LastSolved
+	}
+	if !src.DateAdded.IsZero() && (dst.DateAdded.IsZero() || src.DateAdded.Before(dst.DateAdded)) {
+		dst.DateAdded = src.DateAdded
+	}
+}
+
+// mergeRelations adds the relations of src missing from dst, leaving out those that
+// would point dst to itself.
+func mergeRelations(dst *Problem, src Problem) {
+	for _, r := range src.Relations {
+		if r.Target != dst.ID && !hasRelation(*dst, r) {
+			dst.Relations = append(dst.Relations, r)
+		}
+	}
+}
+
+// mergeComplexity keeps the better of the time and space complexities of dst and src.
+func mergeComplexity(dst *Problem, src Problem) {
+	if better, _ := betterComplexity(src.TimeComplexity, dst.TimeComplexity); src.TimeComplexity != "" && better {
+		dst.TimeComplexity = src.TimeComplexity
+	}
+	if better, _ := betterComplexity(src.SpaceComplexity, dst.SpaceComplexity); src.SpaceComplexity != "" && better {
+		dst.SpaceComplexity = src.SpaceComplexity
+	}
+}
+
+// mergeTags appends the tags of src missing from dst.
+func mergeTags(dst, src []string) []string {
+	seen := make(map[string]bool)
+	for _, tag := range dst {
+		seen[tag] = true
+	}
+	for _, tag := range src {
+		if !seen[tag] {
+			seen[tag] = true
+			dst = append(dst, tag)
+		}
+	}
+	return dst
+}
+
+// hasRelation reports whether p already carries the given relation.
+func hasRelation(p Problem, r Relation) bool {
+	for _, existing := range p.Relations {
+		if existing == r {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmMerge warns about a URL duplicate and asks whether to merge into it.
+func confirmMerge(incoming Problem, existing Problem) (bool, error) {
+	color.Yellow(tr("⚠️  %s - %s has the same URL as %s - %s"), incoming.ID, incoming.Name, existing.ID, existing.Name)
+	merge := true
+	prompt := &survey.Confirm{Message: "Merge it into " + existing.ID + " instead of adding a duplicate?", Default: true}
+	err := survey.AskOne(prompt, &merge)
+	return merge, err
+}
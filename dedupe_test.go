@@ -0,0 +1,34 @@
+// dedupe_test.go
+package main
+
+import "testing"
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		name, in, want string
+	}{
+		{"empty", "  ", ""},
+		{"no scheme", "leetcode.com/problems/two-sum", "leetcode.com/problems/two-sum"},
+		{"http", "http://leetcode.com/problems/two-sum", "leetcode.com/problems/two-sum"},
+		{"www", "https://www.leetcode.com/problems/two-sum", "leetcode.com/problems/two-sum"},
+		{"trailing slash", "https://leetcode.com/problems/two-sum/", "leetcode.com/problems/two-sum"},
+		{"query and fragment", "https://leetcode.com/problems/two-sum/?envType=daily#solution", "leetcode.com/problems/two-sum"},
+		{"leetcode description", "https://leetcode.com/problems/two-sum/description/", "leetcode.com/problems/two-sum"},
+		{"leetcode solutions", "https://leetcode.com/problems/two-sum/solutions/123/abc/", "leetcode.com/problems/two-sum"},
+		{"leetcode.cn", "https://leetcode.cn/problems/two-sum/", "leetcode.com/problems/two-sum"},
+		{"case", "HTTPS://LeetCode.com/Problems/Two-Sum", "leetcode.com/problems/two-sum"},
+		{"codeforces contest", "https://codeforces.com/contest/1234/problem/A", "codeforces.com/problem/1234/a"},
+		{"codeforces problemset", "https://codeforces.com/problemset/problem/1234/A", "codeforces.com/problem/1234/a"},
+		{"codeforces mirror", "https://m1.codeforces.com/contest/1234/problem/B1?locale=en", "codeforces.com/problem/1234/b1"},
+		{"hackerrank", "https://www.hackerrank.com/challenges/ctci-array-left-rotation/problem", "hackerrank.com/challenges/ctci-array-left-rotation"},
+		{"atcoder", "https://atcoder.jp/contests/abc300/tasks/abc300_a?lang=en", "atcoder.jp/contests/abc300/tasks/abc300_a"},
+		{"other site", "https://www.spoj.com/problems/PRIME1/", "spoj.com/problems/prime1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeURL(tt.in); got != tt.want {
+				t.Errorf("normalizeURL(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
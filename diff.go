@@ -0,0 +1,273 @@
+// diff.go
+package main
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// diffFields are the fields compared between two snapshots: the ones a user edits, then
+// the progress tracked by saitama.
+var diffFields = append(append([]conflictField{}, conflictFields...),
+	conflictField{Name: "solves", get: func(p Problem) string { return strconv.Itoa(p.SolveCount) }},
+	conflictField{Name: "attempts", get: func(p Problem) string { return strconv.Itoa(len(p.Attempts)) }},
+	conflictField{Name: "starred", get: func(p Problem) string { return strconv.FormatBool(p.Starred) }},
+	conflictField{Name: "abandoned", get: func(p Problem) string { return strconv.FormatBool(p.Abandoned) }},
+	conflictField{Name: "archived", get: func(p Problem) string { return strconv.FormatBool(p.Archived) }},
+	conflictField{Name: "hints", get: func(p Problem) string { return strconv.Itoa(len(p.Hints)) }},
+	conflictField{Name: "time complexity", get: func(p Problem) string { return p.TimeComplexity }},
+	conflictField{Name: "space complexity", get: func(p Problem) string { return p.SpaceComplexity }},
+)
+
+// problemChange lists the changed fields of a problem present in both snapshots.
+type problemChange struct {
+	ID      string        `json:"id"`
+	Name    string        `json:"name"`
+	Changes []fieldChange `json:"changes"`
+}
+
+// snapshotDiff is the difference between an old and a new snapshot of the collection.
+type snapshotDiff struct {
+	Added   []Problem       `json:"added"`
+	Removed []Problem       `json:"removed"`
+	Changed []problemChange `json:"changed"`
+}
+
+// diffProblems compares two snapshots by ID. Problems that differ only in fields outside
+// diffFields, such as the review schedule, are reported with an "other" change.
+func diffProblems(old, current []Problem) snapshotDiff {
+	var d snapshotDiff
+	before := make(map[string]Problem, len(old))
+	for _, p := range old {
+		before[p.ID] = p
+	}
+	after := make(map[string]bool, len(current))
+	for _, p := range current {
+		after[p.ID] = true
+		prev, ok := before[p.ID]
+		if !ok {
+			d.Added = append(d.Added, p)
+			continue
+		}
+		if problemFingerprint(prev) == problemFingerprint(p) {
+			continue
+		}
+		change := problemChange{ID: p.ID, Name: p.Name}
+		for _, f := range diffFields {
+			if o, n := f.get(prev), f.get(p); o != n {
+				change.Changes = append(change.Changes, fieldChange{Field: f.Name, Old: o, New: n})
+			}
+		}
+		if len(change.Changes) == 0 {
+			change.Changes = append(change.Changes, fieldChange{Field: "other"})
+		}
+		d.Changed = append(d.Changed, change)
+	}
+	for _, p := range old {
+		if !after[p.ID] {
+			d.Removed = append(d.Removed, p)
+		}
+	}
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].ID < d.Added[j].ID })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].ID < d.Removed[j].ID })
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].ID < d.Changed[j].ID })
+	return d
+}
+
+// isStateArchive reports whether a file name looks like a 'backup export' archive.
+func isStateArchive(filename string) bool {
+	name := strings.ToLower(filename)
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+// loadSnapshot reads the problems of a snapshot: a JSON export or per-save backup, an
+// NDJSON export, or the problems.json inside a state archive.
+func loadSnapshot(filename string) ([]Problem, error) {
+	if isNDJSONFile(filename) {
+		var problems []Problem
+		_, err := streamNDJSON(filename, func(p Problem) { problems = append(problems, p) })
+		return problems, err
+	}
+
+	in, err := openImportFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	var r io.Reader = in
+	if isStateArchive(filename) {
+		archive := tar.NewReader(in)
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				return nil, fmt.Errorf("%s contains no problems.json", filename)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read archive: %w", err)
+			}
+			if header.Name == "problems.json" {
+				r = archive
+				break
+			}
+		}
+	}
+
+	var problems []Problem
+	if err := json.NewDecoder(r).Decode(&problems); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return problems, nil
+}
+
+// findBackup returns the per-save or pre-import backup of the backup directory whose
+// timestamp starts with ts, e.g. "20260115" or "20260115_0930". "latest" is the newest.
+// The state archives are left out: backup restore can't take them, and diff --backup
+// must show what a restore of the same timestamp would bring back. They are still
+// compared by path.
+func findBackup(ts string) (string, error) {
+	backupDir, err := getBackupDir()
+	if err != nil {
+		return "", err
+	}
+	entries, err := os.ReadDir(backupDir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	var names, matches []string
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !(strings.HasPrefix(name, "problems_") || strings.HasPrefix(name, preImportPrefix)) || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		names = append(names, name)
+		stamp := strings.SplitN(name, "_", 2)[1]
+		if strings.HasPrefix(stamp, ts) {
+			matches = append(matches, name)
+		}
+	}
+	// Backups sort by timestamp: problems_20260115_093000.json
+	byStamp := func(s []string) {
+		sort.Slice(s, func(i, j int) bool { return strings.SplitN(s[i], "_", 2)[1] < strings.SplitN(s[j], "_", 2)[1] })
+	}
+	byStamp(names)
+	byStamp(matches)
+
+	switch {
+	case len(names) == 0:
+		return "", fmt.Errorf("no backups in %s yet", backupDir)
+	case ts == "latest":
+		return filepath.Join(backupDir, names[len(names)-1]), nil
+	case len(matches) == 0:
+		return "", fmt.Errorf("no backup matches '%s' (available: %s)", ts, strings.Join(names, ", "))
+	case len(matches) > 1:
+		return "", fmt.Errorf("'%s' matches several backups: %s", ts, strings.Join(matches, ", "))
+	}
+	return filepath.Join(backupDir, matches[0]), nil
+}
+
+// printSnapshotDiff shows a diff for humans: + added, - removed, ~ changed.
+func printSnapshotDiff(d snapshotDiff) {
+	for _, p := range d.Added {
+		printResult(color.New(color.FgGreen), "+ %s - %s", p.ID, p.Name)
+	}
+	for _, p := range d.Removed {
+		printResult(color.New(color.FgRed), "- %s - %s", p.ID, p.Name)
+	}
+	for _, c := range d.Changed {
+		printResult(color.New(color.FgYellow), "~ %s - %s", c.ID, c.Name)
+		for _, f := range c.Changes {
+			if f.Field == "other" {
+				printResult(color.New(color.FgHiBlack), tr("    other fields (review schedule, history...)"))
+				continue
+			}
+			printResult(color.New(color.FgWhite), "    %s: %s → %s", f.Field, shortValue(f.Old), shortValue(f.New))
+		}
+	}
+}
+
+// shortValue keeps a value on one line for the diff, e.g. multi-line notes.
+func shortValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if runes := []rune(value); len(runes) > 60 {
+		value = string(runes[:57]) + "..."
+	}
+	return strconv.Quote(value)
+}
+
+func diffCmd() *cobra.Command {
+	var backup string
+	cmd := &cobra.Command{
+		Use:   "diff [old-file] [new-file]",
+		Short: "Show the problems added, removed and changed between two snapshots",
+		Long: "Compare two exports, per-save backups or 'backup export' archives. With a single file, or with " +
+			"--backup, the snapshot is compared to your current problems: check a backup before restoring it, " +
+			"or see what a sync changed.",
+		Example: `  saitama diff before.json after.json
+  saitama diff ~/saitama-state.tar.gz
+  saitama diff --backup 20260115_0930
+  saitama diff --backup latest`,
+		Args: cobra.RangeArgs(0, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if (backup == "") == (len(args) == 0) || backup != "" && len(args) > 1 {
+				printError(tr("❌ Give one or two files, or --backup with at most one file"))
+				return
+			}
+			if backup != "" {
+				path, err := findBackup(backup)
+				if err != nil {
+					printError("❌ %v", err)
+					return
+				}
+				args = append([]string{path}, args...)
+			}
+
+			old, err := loadSnapshot(args[0])
+			if err != nil {
+				printError(tr("❌ Error reading snapshot: %v"), err)
+				return
+			}
+			newName := tr("your current problems")
+			var current []Problem
+			if len(args) == 2 {
+				newName = args[1]
+				current, err = loadSnapshot(args[1])
+			} else {
+				current, err = loadProblems()
+			}
+			if err != nil {
+				printError(tr("❌ Error reading snapshot: %v"), err)
+				return
+			}
+
+			d := diffProblems(old, current)
+			if jsonOutput() {
+				printJSON(d)
+				return
+			}
+			color.HiBlack(tr("--- %s (%d problems)"), args[0], len(old))
+			color.HiBlack(tr("+++ %s (%d problems)"), newName, len(current))
+			if len(d.Added)+len(d.Removed)+len(d.Changed) == 0 {
+				color.Green(tr("✅ No differences."))
+				return
+			}
+			fmt.Fprintln(stderr)
+			printSnapshotDiff(d)
+			fmt.Fprintln(stderr)
+			color.Cyan(tr("📊 %d added, %d removed, %d changed"), len(d.Added), len(d.Removed), len(d.Changed))
+		},
+	}
+	cmd.Flags().StringVar(&backup, "backup", "", "compare a backup to your current problems, by timestamp prefix or 'latest'")
+	return cmd
+}
@@ -0,0 +1,48 @@
+// diff_test.go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindBackup(t *testing.T) {
+	useTempDataDir(t)
+	backupDir, err := getBackupDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"problems_20260115_093000.json",
+		"pre-import_20260116_080000.json",
+		"state_20260117_120000.tar.gz",
+		"daily_20260118.tar.gz",
+		"problems_20260115_093000.json.sha256",
+	} {
+		if err := os.WriteFile(filepath.Join(backupDir, name), []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		ts, want string
+	}{
+		{"latest", "pre-import_20260116_080000.json"},
+		{"20260115", "problems_20260115_093000.json"},
+		{"20260116_08", "pre-import_20260116_080000.json"},
+		{"20260117", ""},
+	}
+	for _, tt := range tests {
+		path, err := findBackup(tt.ts)
+		switch {
+		case tt.want == "" && err == nil:
+			t.Errorf("findBackup(%q) = %s, want an error", tt.ts, path)
+		case tt.want != "" && (err != nil || filepath.Base(path) != tt.want):
+			t.Errorf("findBackup(%q) = %s, %v, want %s", tt.ts, path, err, tt.want)
+		}
+	}
+}
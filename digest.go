@@ -0,0 +1,224 @@
+// digest.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// DigestConfig holds the chat webhooks and message template of the daily digest.
+type DigestConfig struct {
+	SlackURL   string `json:"slack_url,omitempty"`
+	DiscordURL string `json:"discord_url,omitempty"`
+	Template   string `json:"template,omitempty"` // Go text/template, see defaultDigestTemplate
+}
+
+// DigestData is the data available to digest templates.
+type DigestData struct {
+	Date            time.Time
+	Picks           []Problem
+	Streak          int
+	YesterdaySolves []Problem
+	TotalProblems   int
+	TotalSolved     int
+}
+
+const defaultDigestTemplate = `🥊 *Saitama daily digest* — {{.Date.Format "Mon, Jan 2"}}
+🔥 Streak: {{.Streak}} day{{if ne .Streak 1}}s{{end}} | ✅ {{.TotalSolved}}/{{.TotalProblems}} solved
+{{if .YesterdaySolves}}
+*Yesterday's solves:*
+{{range .YesterdaySolves}}• {{.ID}} - {{.Name}}
+{{end}}{{else}}
+😴 No solves yesterday. Today is a new day!
+{{end}}
+*Today's picks:*
+{{range $i, $p := .Picks}}{{inc $i}}. {{$p.ID}} - {{$p.Name}}{{if $p.Difficulty}} ({{$p.Difficulty}}){{end}}{{if $p.URL}} {{$p.URL}}{{end}}
+{{end}}`
+
+// daySeed returns a seed that is stable for a calendar day, so every digest sent
+// on the same day suggests the same picks.
+func daySeed(t time.Time) int64 {
+	seed, _ := strconv.ParseInt(t.Local().Format("20060102"), 10, 64)
+	return seed
+}
+
+// dailyPicks deterministically selects count problems for the day, unsolved ones first.
+func dailyPicks(problems []Problem, count int, day time.Time) []Problem {
+	pool := append([]Problem(nil), problems...)
+	rng := rand.New(rand.NewSource(daySeed(day)))
+	rng.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	sort.SliceStable(pool, func(i, j int) bool { return !isSolved(pool[i]) && isSolved(pool[j]) })
+	return pool[:min(count, len(pool))]
+}
+
+// buildDigestData gathers the digest content for the given day.
+func buildDigestData(problems []Problem, count int, now time.Time) DigestData {
+	events := activityLog(problems)
+	yesterday := dayKey(startOfDay(now).AddDate(0, 0, -1))
+
+	data := DigestData{
+		Date:          now,
+		Picks:         dailyPicks(activeProblems(problems), count, now),
+		Streak:        currentStreak(solvesPerDay(events), now),
+		TotalProblems: len(problems),
+	}
+	seen := make(map[string]bool)
+	for _, e := range events {
+		if e.Solved && dayKey(e.Date) == yesterday && !seen[e.ProblemID] {
+			seen[e.ProblemID] = true
+			if p, index := findProblemByID(problems, e.ProblemID); index != -1 {
+				data.YesterdaySolves = append(data.YesterdaySolves, *p)
+			}
+		}
+	}
+	for _, p := range problems {
+		if isSolved(p) {
+			data.TotalSolved++
+		}
+	}
+	return data
+}
+
+// renderDigest renders the digest with the configured template (or the default one).
+func renderDigest(tmpl string, data DigestData) (string, error) {
+	if tmpl == "" {
+		tmpl = defaultDigestTemplate
+	}
+	t, err := template.New("digest").Funcs(template.FuncMap{
+		"inc":  func(i int) int { return i + 1 },
+		"join": strings.Join,
+	}).Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid digest template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render digest: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// postChatMessage posts a message to a Slack or Discord incoming webhook.
+func postChatMessage(target, url, message string) error {
+	field := "text"
+	if target == "discord" {
+		field = "content"
+	}
+	payload, err := json.Marshal(map[string]string{field: message})
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s responded with %s", target, resp.Status)
+	}
+	return nil
+}
+
+// loadDigest loads everything needed and renders today's digest.
+func loadDigest(count int) (string, Config, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return "", cfg, err
+	}
+	problems, err := loadProblems()
+	if err != nil {
+		return "", cfg, err
+	}
+	if count <= 0 {
+		count = cfg.Pick.Count
+	}
+	message, err := renderDigest(cfg.Digest.Template, buildDigestData(problems, count, time.Now()))
+	return message, cfg, err
+}
+
+func digestCmd() *cobra.Command {
+	var count int
+	var targets []string
+
+	cmd := &cobra.Command{
+		Use:   "digest",
+		Short: "Share a daily training digest with Slack or Discord",
+		Long: "Build a digest of today's picks, your streak and yesterday's solves. Configure \"digest\" in the config file with " +
+			"slack_url and/or discord_url (incoming webhooks) and optionally a Go text/template in \"template\".",
+		Example: `  saitama digest preview --count 3
+  saitama digest send --to discord`,
+	}
+
+	preview := &cobra.Command{
+		Use:   "preview",
+		Short: "Print today's digest without sending it",
+		Run: func(cmd *cobra.Command, args []string) {
+			message, _, err := loadDigest(count)
+			if err != nil {
+				printError(tr("❌ Error building digest: %v"), err)
+				return
+			}
+			fmt.Fprintln(stdout, message)
+		},
+	}
+	preview.Flags().IntVarP(&count, "count", "n", 0, "number of picks (default from config)")
+
+	send := &cobra.Command{
+		Use:   "send",
+		Short: "Post today's digest (suitable for cron)",
+		Example: `  saitama digest send --to slack
+  0 8 * * * saitama digest send   # every morning at 8, to every configured target`,
+		Run: func(cmd *cobra.Command, args []string) {
+			message, cfg, err := loadDigest(count)
+			if err != nil {
+				printError(tr("❌ Error building digest: %v"), err)
+				return
+			}
+
+			urls := map[string]string{"slack": cfg.Digest.SlackURL, "discord": cfg.Digest.DiscordURL}
+			if len(targets) == 0 {
+				targets = []string{"slack", "discord"}
+			}
+
+			sent := 0
+			for _, target := range targets {
+				target = strings.ToLower(target)
+				url, known := urls[target]
+				if !known {
+					printError(tr("❌ Unknown target '%s' (use slack or discord)"), target)
+					continue
+				}
+				if url == "" {
+					if cmd.Flags().Changed("to") {
+						printError(tr("❌ No %s_url configured in the digest section of the config file"), target)
+					}
+					continue
+				}
+				if err := postChatMessage(target, url, message); err != nil {
+					printError(tr("❌ Error sending digest to %s: %v"), target, err)
+					continue
+				}
+				sent++
+				color.Green(tr("✅ Digest sent to %s"), target)
+			}
+			if sent == 0 && !cmd.Flags().Changed("to") {
+				color.Yellow(tr("📭 No digest targets configured (set digest.slack_url or digest.discord_url in the config file)"))
+			}
+		},
+	}
+	send.Flags().IntVarP(&count, "count", "n", 0, "number of picks (default from config)")
+	send.Flags().StringSliceVar(&targets, "to", nil, "targets to send to: slack, discord (default: all configured)")
+
+	cmd.AddCommand(preview, send)
+	return cmd
+}
@@ -0,0 +1,58 @@
+// digest_test.go
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderDefaultDigest(t *testing.T) {
+	now := time.Date(2026, 3, 18, 8, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+	problems := []Problem{
+		{ID: "LC1", Name: "Two Sum", Difficulty: "easy", SolveCount: 1, LastSolved: yesterday,
+			Attempts: []Attempt{{Date: yesterday, Solved: true}}},
+		{ID: "LC70", Name: "Climbing Stairs", Difficulty: "easy", SolveCount: 1, LastSolved: now.AddDate(0, 0, -2),
+			Attempts: []Attempt{{Date: now.AddDate(0, 0, -2), Solved: true}}},
+		{ID: "CF1A", Name: "Theatre Square", URL: "https://codeforces.com/problemset/problem/1/A"},
+		{ID: "LC42", Name: "Trapping Rain Water", Difficulty: "hard", Archived: true},
+	}
+
+	got, err := renderDigest("", buildDigestData(problems, 1, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `🥊 *Saitama daily digest* — Wed, Mar 18
+🔥 Streak: 2 days | ✅ 2/4 solved
+
+*Yesterday's solves:*
+• LC1 - Two Sum
+
+*Today's picks:*
+1. CF1A - Theatre Square https://codeforces.com/problemset/problem/1/A`
+	if got != want {
+		t.Errorf("digest:\n%s\n\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderDigestWithoutSolves(t *testing.T) {
+	now := time.Date(2026, 3, 18, 8, 0, 0, 0, time.Local)
+	problems := []Problem{{ID: "LC2", Name: "Add Two Numbers", Difficulty: "medium"}}
+	got, err := renderDigest("", buildDigestData(problems, 3, now))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `🥊 *Saitama daily digest* — Wed, Mar 18
+🔥 Streak: 0 days | ✅ 0/1 solved
+
+😴 No solves yesterday. Today is a new day!
+
+*Today's picks:*
+1. LC2 - Add Two Numbers (medium)`
+	if got != want {
+		t.Errorf("digest:\n%s\n\nwant:\n%s", got, want)
+	}
+	if _, err := renderDigest("{{.Nope}", DigestData{}); err == nil {
+		t.Error("an invalid template should fail")
+	}
+}
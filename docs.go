@@ -0,0 +1,83 @@
+// docs.go
+package main
+
+import (
+	"os"
+	"regexp"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// ansiEscape matches the color codes some help texts (like the banner) are built with.
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// plainHelpTexts strips the color codes from the help texts of a command tree, so they
+// don't end up in generated documentation.
+func plainHelpTexts(cmd *cobra.Command) {
+	cmd.Short = ansiEscape.ReplaceAllString(cmd.Short, "")
+	cmd.Long = ansiEscape.ReplaceAllString(cmd.Long, "")
+	cmd.Example = ansiEscape.ReplaceAllString(cmd.Example, "")
+	for _, sub := range cmd.Commands() {
+		plainHelpTexts(sub)
+	}
+}
+
+// generateDocs writes the documentation of every command to dir with the given generator.
+func generateDocs(cmd *cobra.Command, dir string, generate func(root *cobra.Command, dir string) error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		printError(tr("❌ Error creating %s: %v"), dir, err)
+		return
+	}
+	root := cmd.Root()
+	plainHelpTexts(root)
+	// Leave out the generation date so the output only changes when the commands do.
+	root.DisableAutoGenTag = true
+	if err := generate(root, dir); err != nil {
+		printError(tr("❌ Error generating docs: %v"), err)
+		return
+	}
+	color.Green(tr("✅ Documentation written to %s"), dir)
+}
+
+func docsCmd() *cobra.Command {
+	var outDir string
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate man pages or a Markdown command reference",
+		Long: "Generates one page per command, including its flags and examples, from the commands " +
+			"themselves. Packagers can ship the man pages; the Markdown reference can be published.",
+		Example: `  saitama docs man --output /usr/local/share/man/man1
+  saitama docs markdown --output docs`,
+	}
+	cmd.PersistentFlags().StringVarP(&outDir, "output", "o", "", "output directory (default \"man\" or \"docs\")")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "man",
+		Short: "Write a man page per command (section 1)",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if outDir == "" {
+				outDir = "man"
+			}
+			header := &doc.GenManHeader{Title: "SAITAMA", Section: "1", Source: "Saitama", Manual: "Saitama Manual"}
+			generateDocs(cmd, outDir, func(root *cobra.Command, dir string) error {
+				return doc.GenManTree(root, header, dir)
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "markdown",
+		Short: "Write a Markdown page per command",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if outDir == "" {
+				outDir = "docs"
+			}
+			generateDocs(cmd, outDir, doc.GenMarkdownTree)
+		},
+	})
+	return cmd
+}
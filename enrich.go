@@ -0,0 +1,177 @@
+// enrich.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Tags that usually indicate a harder or intermediate problem, used when nothing better
+// than the tags is known about a problem.
+var (
+	hardTags = map[string]bool{
+		"segment-tree": true, "fenwick-tree": true, "binary-indexed-tree": true, "suffix-array": true,
+		"network-flow": true, "flows": true, "bitmask-dp": true, "digit-dp": true, "fft": true,
+		"heavy-light": true, "centroid-decomposition": true, "2-sat": true, "game-theory": true,
+	}
+	mediumTags = map[string]bool{
+		"dp": true, "dynamic-programming": true, "graph": true, "graphs": true, "bfs": true, "dfs": true,
+		"backtracking": true, "trie": true, "heap": true, "priority-queue": true, "binary-search": true,
+		"union-find": true, "topological-sort": true, "greedy": true, "sliding-window": true,
+		"intervals": true, "tree": true, "trees": true, "bst": true, "monotonic-stack": true,
+		"shortest-path": true, "dijkstra": true, "bit-manipulation": true,
+	}
+)
+
+// difficultyFromRating buckets a platform rating into easy, medium or hard.
+func difficultyFromRating(platform string, rating int) string {
+	if rating <= 0 {
+		return ""
+	}
+	easy, medium := 1400, 2000 // Codeforces-style ratings
+	if platform == "leetcode" {
+		easy, medium = 1500, 2000 // LeetCode contest ratings
+	}
+	switch {
+	case rating < easy:
+		return "easy"
+	case rating < medium:
+		return "medium"
+	}
+	return "hard"
+}
+
+// inferDifficulty guesses a difficulty without network access. It tries, in order, the
+// platform rating, the curated taxonomy and a heuristic over the tags, and returns the
+// difficulty along with the source it came from. Tags of no harder topic say nothing
+// about the difficulty: it is left empty then rather than guessed easy.
+func inferDifficulty(p Problem) (string, string) {
+	if d := difficultyFromRating(p.Platform, p.Rating); d != "" {
+		return d, "rating"
+	}
+	for _, topic := range topicTaxonomy {
+		for _, c := range topic.Curated {
+			if c.ID == p.ID {
+				return c.Difficulty, "curated list"
+			}
+		}
+	}
+
+	level := ""
+	for _, tag := range p.Tags {
+		tag = strings.ToLower(tag)
+		switch {
+		case tag == "easy" || tag == "medium" || tag == "hard":
+			return tag, "tags"
+		case hardTags[tag]:
+			level = "hard"
+		case mediumTags[tag] && level != "hard":
+			level = "medium"
+		}
+	}
+	if level == "" {
+		return "", ""
+	}
+	return level, "tags"
+}
+
+// enrichProblem fills missing difficulty, URL, editorial and platform fields, using the platform
+// adapter when online. It returns a description of every change made.
+func enrichProblem(p *Problem, offline bool, failed map[string]error) []string {
+	var changes []string
+	set := func(field string, target *string, value, source string) {
+		if *target == "" && value != "" {
+			*target = value
+			changes = append(changes, fmt.Sprintf("%s → %s (%s)", field, value, source))
+		}
+	}
+
+	if adapter := adapterFor(*p); adapter != nil {
+		set("platform", &p.Platform, adapter.Platform(), "url/id")
+		if _, down := failed[adapter.Platform()]; !offline && !down && (p.Difficulty == "" || p.URL == "" || p.EditorialURL == "") {
+			meta, ok, err := adapter.Lookup(*p)
+			if err != nil {
+				failed[adapter.Platform()] = err
+			} else if ok {
+				set("difficulty", &p.Difficulty, meta.Difficulty, adapter.Platform())
+				set("url", &p.URL, meta.URL, adapter.Platform())
+				set("editorial", &p.EditorialURL, meta.Editorial, adapter.Platform())
+				if p.Rating == 0 && meta.Rating > 0 {
+					p.Rating = meta.Rating
+					changes = append(changes, fmt.Sprintf("rating → %d (%s)", meta.Rating, adapter.Platform()))
+				}
+			}
+		}
+	}
+
+	if p.Difficulty == "" {
+		difficulty, source := inferDifficulty(*p)
+		set("difficulty", &p.Difficulty, difficulty, source)
+	}
+	return changes
+}
+
+func enrichCmd() *cobra.Command {
+	var dryRun, offline bool
+	cmd := &cobra.Command{
+		Use:         "enrich",
+		Annotations: mutates(),
+		Short:       "Backfill missing difficulty, URL, editorial and platform fields",
+		Long: "Look up problems with missing fields on their platform (LeetCode, Codeforces) and fill them in. " +
+			"When a platform can't be reached, or with --offline, difficulty is inferred from the rating or tags.",
+		Example: `  saitama enrich --dry-run  # Preview the changes
+  saitama enrich
+  saitama enrich --offline  # Heuristics only, no network`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			if !offline {
+				color.Cyan(tr("🔎 Looking up problems on their platforms..."))
+			}
+			failed := make(map[string]error)
+			changed := 0
+			for i := range problems {
+				p := &problems[i]
+				if p.Difficulty != "" && p.URL != "" && p.Platform != "" {
+					continue
+				}
+				changes := enrichProblem(p, offline, failed)
+				if len(changes) == 0 {
+					continue
+				}
+				changed++
+				color.Green("✨ %s - %s", p.ID, p.Name)
+				for _, c := range changes {
+					fmt.Fprintf(stderr, "   %s\n", c)
+				}
+			}
+			for platform, err := range failed {
+				color.Yellow(tr("⚠️  %s lookups skipped: %v"), platform, err)
+			}
+
+			if changed == 0 {
+				color.Green(tr("✅ Nothing to enrich, every problem is complete (or unknown to the platforms)."))
+				return
+			}
+			if dryRun {
+				color.Yellow(tr("🔍 Dry run: %d problem(s) would be updated."), changed)
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Enriched %d problem(s)."), changed)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would change without saving")
+	cmd.Flags().BoolVar(&offline, "offline", false, "don't contact any platform, use heuristics only")
+	return cmd
+}
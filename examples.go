@@ -0,0 +1,308 @@
+// examples.go
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+//go:embed examples.json
+var examplesCatalog []byte
+
+// Scenario is an entry of the examples catalog: copy-pasteable steps showing how the
+// flags of a command combine for a task.
+type Scenario struct {
+	Command     string   `json:"command"` // command path without "saitama", e.g. "stats tags"
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Steps       []string `json:"steps"`
+	Sandbox     bool     `json:"sandbox,omitempty"` // the steps can run on demo data with examples --run
+}
+
+// loadScenarios parses the embedded examples catalog.
+func loadScenarios() ([]Scenario, error) {
+	var scenarios []Scenario
+	if err := json.Unmarshal(examplesCatalog, &scenarios); err != nil {
+		return nil, fmt.Errorf("invalid examples catalog: %w", err)
+	}
+	return scenarios, nil
+}
+
+// scenariosFor returns the scenarios of a command, in the order of the catalog.
+func scenariosFor(scenarios []Scenario, command string) []Scenario {
+	var matching []Scenario
+	for _, s := range scenarios {
+		if strings.EqualFold(s.Command, command) {
+			matching = append(matching, s)
+		}
+	}
+	return matching
+}
+
+// linkExamples adds a pointer to 'saitama examples' to the examples of every command
+// with scenarios in the catalog, so its help shows where to find more.
+func linkExamples(root *cobra.Command) {
+	scenarios, err := loadScenarios()
+	if err != nil {
+		return
+	}
+	counts := make(map[string]int)
+	for _, s := range scenarios {
+		counts[s.Command]++
+	}
+	for command, n := range counts {
+		cmd, _, err := root.Find(strings.Fields(command))
+		if err != nil || cmd == root {
+			continue
+		}
+		line := fmt.Sprintf("  saitama examples %s   # %d scenario(s) combining the flags", command, n)
+		if cmd.Example != "" {
+			line = cmd.Example + "\n" + line
+		}
+		cmd.Example = line
+	}
+}
+
+// splitCommandLine splits a step of a scenario into words like a shell would, honoring
+// single and double quotes and backslash escapes in double quotes.
+func splitCommandLine(line string) ([]string, error) {
+	var words []string
+	var word strings.Builder
+	inWord := false
+	var quote rune
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				word.WriteRune(r)
+			}
+		case quote == '"':
+			switch {
+			case r == '"':
+				quote = 0
+			case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`, runes[i+1]):
+				i++
+				word.WriteRune(runes[i])
+			default:
+				word.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote, inWord = r, true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in: %s", line)
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// demoProblems is the collection of the demo sandbox: a few problems of each platform
+// and difficulty, with solves spread over the last months so that date filters, stats
+// and suggestions have something to show.
+func demoProblems(now time.Time) []Problem {
+	day := func(daysAgo int) time.Time { return startOfDay(now).AddDate(0, 0, -daysAgo).Add(19 * time.Hour) }
+	problem := func(id, name, difficulty, platform, url string, added int, tags ...string) Problem {
+		return Problem{ID: id, Name: name, Tags: tags, Difficulty: difficulty, Platform: platform, URL: url, DateAdded: day(added)}
+	}
+	problems := []Problem{
+		problem("LC1", "Two Sum", "easy", "leetcode", "https://leetcode.com/problems/two-sum/", 200, "array", "hashmap"),
+		problem("LC15", "3Sum", "medium", "leetcode", "https://leetcode.com/problems/3sum/", 150, "array", "two-pointers"),
+		problem("LC70", "Climbing Stairs", "easy", "leetcode", "https://leetcode.com/problems/climbing-stairs/", 120, "dynamic-programming"),
+		problem("LC322", "Coin Change", "medium", "leetcode", "https://leetcode.com/problems/coin-change/", 90, "dynamic-programming"),
+		problem("LC200", "Number of Islands", "medium", "leetcode", "https://leetcode.com/problems/number-of-islands/", 60, "graphs", "bfs"),
+		problem("LC207", "Course Schedule", "medium", "leetcode", "https://leetcode.com/problems/course-schedule/", 20, "graphs", "topological-sort"),
+		problem("LC124", "Binary Tree Maximum Path Sum", "hard", "leetcode", "https://leetcode.com/problems/binary-tree-maximum-path-sum/", 100, "trees", "dp"),
+		problem("CF1915F", "Greetings", "hard", "codeforces", "https://codeforces.com/problemset/problem/1915/F", 10, "data-structures", "sortings"),
+		problem("CF1352C", "K-th Not Divisible by n", "medium", "codeforces", "https://codeforces.com/problemset/problem/1352/C", 45, "math", "binary-search"),
+		problem("CF1547G", "How Many Paths?", "hard", "codeforces", "https://codeforces.com/problemset/problem/1547/G", 5, "graphs", "dfs"),
+		problem("LC1143", "Longest Common Subsequence", "medium", "leetcode", "https://leetcode.com/problems/longest-common-subsequence/", 3, "dp", "strings"),
+	}
+	solves := map[string][]int{ // days ago of each solve
+		"LC1":     {190, 120, 60, 14},
+		"LC15":    {140, 30},
+		"LC70":    {110, 80, 40},
+		"LC322":   {85},
+		"LC200":   {55, 2},
+		"LC124":   {95},
+		"CF1352C": {1},
+	}
+	for i := range problems {
+		for _, d := range solves[problems[i].ID] {
+			recordAttempt(&problems[i], true, 30*time.Minute, day(d))
+		}
+	}
+	ratings := map[string]int{"CF1915F": 1800, "CF1352C": 1200, "CF1547G": 2100}
+	for i := range problems {
+		problems[i].Rating = ratings[problems[i].ID]
+		problems[i].Starred = problems[i].ID == "LC15"
+	}
+	_, index := findProblemByID(problems, "LC70")
+	problems[index].Relations = []Relation{{Type: RelationFollowUp, Target: "LC322"}}
+	return problems
+}
+
+// runScenario runs the steps of a scenario with this binary on a fresh demo database in a
+// temporary data directory, which is removed afterwards unless keep is set.
+func runScenario(s Scenario, keep bool) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the saitama binary: %w", err)
+	}
+	dir, err := os.MkdirTemp("", "saitama-demo-")
+	if err != nil {
+		return fmt.Errorf("failed to create the sandbox: %w", err)
+	}
+	if keep {
+		defer color.HiBlack(tr("🧪 Sandbox kept in %s (use it with --data-dir)"), dir)
+	} else {
+		defer os.RemoveAll(dir)
+	}
+	data, err := json.MarshalIndent(demoProblems(time.Now()), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "problems.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to create the sandbox: %w", err)
+	}
+
+	color.HiCyan(tr("🧪 Running \"%s\" on demo data, your problems are left alone"), s.Title)
+	for _, step := range s.Steps {
+		words, err := splitCommandLine(step)
+		if err != nil {
+			return err
+		}
+		if len(words) == 0 || words[0] != "saitama" {
+			return fmt.Errorf("only saitama commands can run in the sandbox: %s", step)
+		}
+		fmt.Fprintln(stderr)
+		color.HiBlack("$ %s", step)
+		run := exec.Command(exe, append(words[1:], "--data-dir", dir)...)
+		run.Stdin, run.Stdout, run.Stderr = os.Stdin, stdout, stderr
+		if err := run.Run(); err != nil {
+			return fmt.Errorf("step failed: %s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+func examplesCmd() *cobra.Command {
+	var runIndex int
+	var keep bool
+	cmd := &cobra.Command{
+		Use:   "examples [command]",
+		Short: "Show copy-pasteable scenarios for a command, runnable on demo data",
+		Long: "Prints scenarios from the built-in examples catalog: the steps of a task and the flag " +
+			"combinations to use, ready to copy. Without a command, lists the commands with scenarios. " +
+			"Scenarios marked runnable can be tried with --run on a demo database in a temporary folder, " +
+			"without touching your problems.",
+		Example: `  saitama examples
+  saitama examples list
+  saitama examples stats tags
+  saitama examples solve --run 2
+  saitama examples archive --run 1 --keep`,
+		Run: func(cmd *cobra.Command, args []string) {
+			scenarios, err := loadScenarios()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if len(args) == 0 {
+				if runIndex != 0 {
+					printError(tr("❌ Give the command of the scenario to run, e.g. saitama examples list --run 1"))
+					return
+				}
+				var commands []string
+				counts := make(map[string]int)
+				for _, s := range scenarios {
+					if counts[s.Command] == 0 {
+						commands = append(commands, s.Command)
+					}
+					counts[s.Command]++
+				}
+				if jsonOutput() {
+					printJSON(scenarios)
+					return
+				}
+				fmt.Fprintln(stderr)
+				color.HiCyan(tr("📚 Scenarios by command:"))
+				for _, c := range commands {
+					printResult(color.New(color.FgWhite), "   %-12s %d", c, counts[c])
+				}
+				fmt.Fprintln(stderr)
+				color.Cyan(tr("💡 Show them with: saitama examples <command>"))
+				return
+			}
+
+			command := strings.Join(args, " ")
+			matching := scenariosFor(scenarios, command)
+			if len(matching) == 0 {
+				printError(tr("❌ No scenarios for '%s'; see 'saitama %s --help' for its examples"), command, command)
+				return
+			}
+			if runIndex != 0 {
+				if runIndex < 1 || runIndex > len(matching) {
+					printError(tr("❌ No scenario %d for '%s' (there are %d)"), runIndex, command, len(matching))
+					return
+				}
+				s := matching[runIndex-1]
+				if !s.Sandbox {
+					printError(tr("❌ \"%s\" can't run in the sandbox (it is interactive or uses other tools); copy its steps instead"), s.Title)
+					return
+				}
+				if err := runScenario(s, keep); err != nil {
+					printError("❌ %v", err)
+				}
+				return
+			}
+
+			if jsonOutput() {
+				printJSON(matching)
+				return
+			}
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("📚 Scenarios for 'saitama %s':"), command)
+			for i, s := range matching {
+				fmt.Fprintln(stderr)
+				printResult(color.New(color.FgHiYellow, color.Bold), "%2d. %s", i+1, s.Title)
+				if s.Description != "" {
+					color.White("    %s", s.Description)
+				}
+				for _, step := range s.Steps {
+					printResult(color.New(color.FgGreen), "      %s", step)
+				}
+				if s.Sandbox {
+					color.HiBlack(tr("    ▶ try it on demo data: saitama examples %s --run %d"), command, i+1)
+				}
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().IntVar(&runIndex, "run", 0, "run this scenario (by number) on a demo database in a temporary folder")
+	cmd.Flags().BoolVar(&keep, "keep", false, "with --run, keep the demo data directory to explore it further")
+	return cmd
+}
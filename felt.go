@@ -0,0 +1,109 @@
+// felt.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// feltLevels are the answers to "How hard did this feel?", in increasing order.
+var feltLevels = []string{"easy", "ok", "hard"}
+
+// feltRank maps a perceived difficulty to its position in feltLevels, or -1.
+func feltRank(felt string) int {
+	for i, level := range feltLevels {
+		if level == felt {
+			return i
+		}
+	}
+	return -1
+}
+
+// labelRank maps a labeled difficulty onto the same scale as feltRank ("ok" matches
+// "medium"), or -1 for unknown labels.
+func labelRank(difficulty string) int {
+	switch strings.ToLower(difficulty) {
+	case "easy":
+		return 0
+	case "medium":
+		return 1
+	case "hard":
+		return 2
+	}
+	return -1
+}
+
+// parseFelt validates a perceived difficulty given on the command line.
+func parseFelt(felt string) (string, error) {
+	felt = strings.ToLower(strings.TrimSpace(felt))
+	if felt != "" && feltRank(felt) == -1 {
+		return "", fmt.Errorf("invalid perceived difficulty '%s' (use %s)", felt, strings.Join(feltLevels, ", "))
+	}
+	return felt, nil
+}
+
+// askFelt asks how hard a solve felt. Skipping the question returns an empty answer.
+func askFelt() (string, error) {
+	options := append(append([]string{}, feltLevels...), "skip")
+	answer := ""
+	if err := survey.AskOne(&survey.Select{Message: "How hard did this feel?", Options: options, Default: "ok"}, &answer); err != nil {
+		return "", err
+	}
+	if answer == "skip" {
+		return "", nil
+	}
+	return answer, nil
+}
+
+// lastFelt returns the most recent perceived difficulty logged for a problem.
+func lastFelt(p Problem) string {
+	for i := len(p.Attempts) - 1; i >= 0; i-- {
+		if p.Attempts[i].Felt != "" {
+			return p.Attempts[i].Felt
+		}
+	}
+	return ""
+}
+
+// feltDivergence is a problem whose perceived difficulty differs from its label.
+// Delta is positive when it felt harder than labeled.
+type feltDivergence struct {
+	Problem Problem
+	Felt    string
+	Delta   int
+}
+
+// computeFeltDivergence compares the latest perceived difficulty of every rated problem
+// with its label. It returns the number of rated problems and the divergent ones,
+// biggest gap first.
+func computeFeltDivergence(problems []Problem) (int, []feltDivergence) {
+	rated := 0
+	var diverging []feltDivergence
+	for _, p := range problems {
+		felt, label := lastFelt(p), labelRank(p.Difficulty)
+		if felt == "" || label == -1 {
+			continue
+		}
+		rated++
+		if delta := feltRank(felt) - label; delta != 0 {
+			diverging = append(diverging, feltDivergence{Problem: p, Felt: felt, Delta: delta})
+		}
+	}
+	sort.SliceStable(diverging, func(i, j int) bool {
+		if abs(diverging[i].Delta) != abs(diverging[j].Delta) {
+			return abs(diverging[i].Delta) > abs(diverging[j].Delta)
+		}
+		return diverging[i].Problem.ID < diverging[j].Problem.ID
+	})
+	return rated, diverging
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,286 @@
+// find.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// findAction is what the finder was closed with: a command to run on the selected problem.
+type findAction string
+
+const (
+	findQuit   findAction = ""
+	findShow   findAction = "show"
+	findSolve  findAction = "solve"
+	findOpen   findAction = "open"
+	findEdit   findAction = "edit"
+	findDelete findAction = "delete"
+)
+
+// findKeys maps the control keys of the finder to their actions.
+var findKeys = map[byte]findAction{
+	'\r':      findShow,
+	'\n':      findShow,
+	ctrl('s'): findSolve,
+	ctrl('o'): findOpen,
+	ctrl('e'): findEdit,
+	ctrl('d'): findDelete,
+}
+
+// ctrl returns the byte sent by Ctrl and a letter.
+func ctrl(c byte) byte {
+	return c & 0x1f
+}
+
+// findMatch is a problem matching the query of the finder.
+type findMatch struct {
+	Index     int   // in the problems
+	Score     int   // higher is better
+	Positions []int // matched runes of the problem's line, to highlight
+}
+
+// findLine is the line of a problem in the finder, which the query is matched against.
+func findLine(p Problem) string {
+	line := fmt.Sprintf("%-12s %s", p.ID, p.Name)
+	if len(p.Tags) > 0 {
+		line += "  " + strings.Join(p.Tags, ", ")
+	}
+	if p.Difficulty != "" {
+		line += "  · " + p.Difficulty
+	}
+	return line
+}
+
+// fuzzyMatch matches the runes of the query in order, ignoring case, like fzf. Matches
+// score more when consecutive, at the start of a word or as a plain substring, and
+// less the further apart they are.
+func fuzzyMatch(query, text string) (int, []int, bool) {
+	q := []rune(strings.ToLower(query))
+	t := []rune(strings.ToLower(text))
+	if len(q) == 0 {
+		return 0, nil, true
+	}
+	if i := strings.Index(string(t), string(q)); i >= 0 {
+		start := len([]rune(string(t)[:i]))
+		positions := make([]int, len(q))
+		for j := range positions {
+			positions[j] = start + j
+		}
+		score := 10*len(q) + 20
+		if start == 0 || isWordBoundary(t, start) {
+			score += 10
+		}
+		return score - start/10, positions, true
+	}
+
+	score, last := 0, -1
+	positions := make([]int, 0, len(q))
+	for i := 0; i < len(t) && len(positions) < len(q); i++ {
+		if t[i] != q[len(positions)] {
+			continue
+		}
+		score += 10
+		switch {
+		case last >= 0 && i == last+1:
+			score += 8
+		case isWordBoundary(t, i):
+			score += 6
+		case last >= 0:
+			score -= min(i-last, 10)
+		}
+		positions = append(positions, i)
+		last = i
+	}
+	if len(positions) < len(q) {
+		return 0, nil, false
+	}
+	return score, positions, true
+}
+
+// isWordBoundary reports whether the rune at i starts a word.
+func isWordBoundary(t []rune, i int) bool {
+	return i == 0 || !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1])
+}
+
+// rankFind returns the problems matching the query, best first. Ties keep the order of
+// the collection.
+func rankFind(problems []Problem, query string) []findMatch {
+	var matches []findMatch
+	for i, p := range problems {
+		if score, positions, ok := fuzzyMatch(query, findLine(p)); ok {
+			matches = append(matches, findMatch{Index: i, Score: score, Positions: positions})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	return matches
+}
+
+// renderFindLine highlights the matched runes of a line, cut to the width of the screen.
+func renderFindLine(line string, positions []int, width int, selected bool) string {
+	runes := []rune(line)
+	if len(runes) > width && width > 1 {
+		runes = append(runes[:width-1], '…')
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, i := range positions {
+		matched[i] = true
+	}
+	var b strings.Builder
+	for i, r := range runes {
+		if matched[i] {
+			b.WriteString(color.New(color.FgHiGreen, color.Bold).Sprint(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	if selected {
+		return color.New(color.FgHiCyan).Sprint("▌") + color.New(color.Bold).Sprint(b.String())
+	}
+	return " " + b.String()
+}
+
+// runFinder shows the finder on the terminal until a problem is chosen with one of the
+// findKeys, or the finder is closed with Esc or Ctrl+C. It returns the chosen problem's
+// index, or -1.
+func runFinder(problems []Problem, query string) (int, findAction, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return -1, findQuit, fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	defer term.Restore(fd, state)
+	fmt.Fprint(stderr, "\033[?1049h") // alternate screen
+	defer fmt.Fprint(stderr, "\033[?1049l")
+
+	selected, offset := 0, 0
+	matches := rankFind(problems, query)
+	buf := make([]byte, 64)
+	for {
+		width, height, err := term.GetSize(int(os.Stderr.Fd()))
+		if err != nil || width < 20 || height < 5 {
+			width, height = 80, 24 // unknown, or too small to be usable
+		}
+		rows := max(height-3, 1)
+		selected = min(max(selected, 0), max(len(matches)-1, 0))
+		offset = min(max(offset, selected-rows+1), selected)
+
+		var screen strings.Builder
+		screen.WriteString("\033[H\033[2J")
+		for i := offset; i < len(matches) && i < offset+rows; i++ {
+			m := matches[i]
+			screen.WriteString(renderFindLine(findLine(problems[m.Index]), m.Positions, width-2, i == selected) + "\r\n")
+		}
+		screen.WriteString(fmt.Sprintf("\033[%d;1H", height-1))
+		screen.WriteString(color.HiBlackString("  %d/%d · enter show · ^S solve · ^O open · ^E edit · ^D delete · esc quit", len(matches), len(problems)) + "\r\n")
+		screen.WriteString(color.HiCyanString("> ") + query)
+		fmt.Fprint(stderr, screen.String())
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return -1, findQuit, err
+		}
+		key := buf[:n]
+		switch {
+		case key[0] == ctrl('c') || (key[0] == 27 && n == 1):
+			return -1, findQuit, nil
+		case key[0] == 27 && n >= 3 && (key[1] == '[' || key[1] == 'O'):
+			switch key[2] {
+			case 'A':
+				selected--
+			case 'B':
+				selected++
+			case '5': // page up
+				selected -= rows
+			case '6': // page down
+				selected += rows
+			}
+		case key[0] == ctrl('p'):
+			selected--
+		case key[0] == ctrl('n'):
+			selected++
+		case key[0] == 127 || key[0] == ctrl('h'):
+			if r := []rune(query); len(r) > 0 {
+				query = string(r[:len(r)-1])
+				matches, selected, offset = rankFind(problems, query), 0, 0
+			}
+		case key[0] == ctrl('u'):
+			query = ""
+			matches, selected, offset = rankFind(problems, query), 0, 0
+		case findKeys[key[0]] != findQuit:
+			if len(matches) == 0 {
+				continue
+			}
+			return matches[selected].Index, findKeys[key[0]], nil
+		case key[0] >= ' ':
+			query += string(key)
+			matches, selected, offset = rankFind(problems, query), 0, 0
+		}
+	}
+}
+
+func findCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "find [query]",
+		Short: "Fuzzy-find a problem and act on it, like fzf",
+		Long: "Opens a fuzzy finder over every problem: type to match IDs, names, tags and difficulties, move with " +
+			"the arrow keys (or Ctrl+P/Ctrl+N), then press Enter to show the problem, Ctrl+S to solve it, Ctrl+O to " +
+			"open its URL, Ctrl+E to edit it or Ctrl+D to delete it. Esc or Ctrl+C closes it.",
+		Example: `  saitama find
+  saitama find segtree`,
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !isInteractive() || !term.IsTerminal(int(os.Stderr.Fd())) {
+				printError(tr("❌ find needs a terminal; use 'saitama search' or 'saitama list' in scripts"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			if len(problems) == 0 {
+				color.Yellow(tr("📝 No problems found yet!"))
+				color.Cyan(tr("💡 Add your first problem with: saitama add"))
+				return
+			}
+			index, action, err := runFinder(problems, strings.Join(args, " "))
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if action == findQuit {
+				return
+			}
+			p := problems[index]
+			var next *cobra.Command
+			switch action {
+			case findShow:
+				next = showCmd()
+			case findSolve:
+				next = solveCmd()
+			case findEdit:
+				next = editCmd()
+			case findDelete:
+				next = deleteCmd()
+			case findOpen:
+				if p.URL == "" {
+					color.Yellow(tr("🔗 %s has no URL."), p.ID)
+					return
+				}
+				if err := openURL(p.URL); err != nil {
+					printError(tr("❌ Could not open %s: %v"), p.URL, err)
+				}
+				return
+			}
+			next.Run(next, []string{p.ID})
+		},
+	}
+}
@@ -0,0 +1,167 @@
+// focus.go
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// FocusConfig holds the tag of the week settings.
+type FocusConfig struct {
+	Tag string `json:"tag,omitempty"` // set by 'focus set', replaces the weekly rotation
+}
+
+// weekKey identifies the ISO week containing t, e.g. "2026-W42".
+func weekKey(t time.Time) string {
+	year, week := t.Local().ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// rotationTags returns the tags the weekly rotation chooses from: every tag, sorted,
+// except the mastered ones. When every tag is mastered, all of them are used.
+func rotationTags(problems []Problem, cfg MasteryConfig, now time.Time) []string {
+	var tags, all []string
+	for _, m := range computeTagMastery(problems, cfg, now) {
+		all = append(all, m.Tag)
+		if m.Score < cfg.MasteredThreshold {
+			tags = append(tags, m.Tag)
+		}
+	}
+	if len(tags) == 0 {
+		tags = all
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// focusTag returns this week's focus tag and whether it was set by hand. The rotation
+// is seeded by the week, so the tag stays the same all week long and changes with it.
+func focusTag(problems []Problem, cfg Config, now time.Time) (string, bool) {
+	if cfg.Focus.Tag != "" {
+		return cfg.Focus.Tag, true
+	}
+	tags := rotationTags(problems, cfg.Mastery, now)
+	if len(tags) == 0 {
+		return "", false
+	}
+	h := fnv.New32a()
+	h.Write([]byte(weekKey(now)))
+	return tags[h.Sum32()%uint32(len(tags))], false
+}
+
+// biasToward moves up to n problems carrying the tag to the front of the candidates,
+// keeping the order of the others.
+func biasToward(candidates []Problem, tag string, n int) []Problem {
+	var front, rest []Problem
+	for _, p := range candidates {
+		if len(front) < n && hasTag(p, tag) {
+			front = append(front, p)
+		} else {
+			rest = append(rest, p)
+		}
+	}
+	return append(front, rest...)
+}
+
+// hasTag reports whether a problem carries the tag.
+func hasTag(p Problem, tag string) bool {
+	for _, t := range p.Tags {
+		if strings.EqualFold(t, tag) || t == canonicalTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func focusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "focus",
+		Short: "Show the tag of the week that pick and next lean toward",
+		Long: "Every week a different focus tag is chosen among the tags you haven't mastered yet. " +
+			"pick fills about half of its selection with it and next prefers it. 'focus set' picks the tag yourself.",
+		Example: `  saitama focus
+  saitama focus set graphs
+  saitama focus clear`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			now := time.Now()
+			tag, manual := focusTag(problems, cfg, now)
+			if tag == "" {
+				color.Yellow(tr("🏷️  No tags to focus on yet. Tag your problems first."))
+				return
+			}
+			if jsonOutput() {
+				printJSON(map[string]any{"tag": tag, "manual": manual, "week": weekKey(now)})
+				return
+			}
+			printResult(color.New(color.FgHiYellow), tr("🎯 Focus tag: %s"), tag)
+			count := 0
+			for _, p := range problems {
+				if hasTag(p, tag) && !isSolved(p) {
+					count++
+				}
+			}
+			if manual {
+				color.HiBlack(tr("   Set by hand. Go back to the weekly rotation with: saitama focus clear"))
+			} else {
+				color.HiBlack(tr("   Week %s, rotates every Monday"), weekKey(now))
+			}
+			color.White(tr("   %d unsolved problem(s) with this tag"), count)
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "set <tag>",
+		Annotations: mutates(),
+		Short:       "Focus on a tag of your choice instead of the weekly rotation",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Focus.Tag = strings.ToLower(strings.TrimSpace(args[0]))
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			color.Green(tr("🎯 Focusing on '%s' until: saitama focus clear"), cfg.Focus.Tag)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear",
+		Annotations: mutates(),
+		Short:       "Go back to the weekly rotation",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Focus.Tag = ""
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			color.Green(tr("🔄 Back to the weekly focus rotation."))
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,122 @@
+// gaps.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// topicCoverage is how much of the collection falls under a taxonomy topic.
+type topicCoverage struct {
+	Topic    Topic
+	Total    int
+	Solved   int
+	Unsolved []Problem
+}
+
+// computeCoverage maps every problem onto the canonical taxonomy.
+func computeCoverage(problems []Problem) []topicCoverage {
+	coverage := make([]topicCoverage, len(topicTaxonomy))
+	for i, t := range topicTaxonomy {
+		coverage[i].Topic = t
+		for _, p := range problems {
+			if !topicMatches(t, p) {
+				continue
+			}
+			coverage[i].Total++
+			if isSolved(p) {
+				coverage[i].Solved++
+			} else {
+				coverage[i].Unsolved = append(coverage[i].Unsolved, p)
+			}
+		}
+	}
+	return coverage
+}
+
+// suggestForTopic picks up to n problems to practice a topic: unsolved problems from the
+// collection first, then curated problems that are not in the collection yet.
+func suggestForTopic(c topicCoverage, problems []Problem, n int) (fromPool, fromCurated []Problem) {
+	for _, p := range c.Unsolved {
+		if len(fromPool) == n {
+			return fromPool, nil
+		}
+		fromPool = append(fromPool, p)
+	}
+	for _, p := range c.Topic.Curated {
+		if len(fromPool)+len(fromCurated) == n {
+			break
+		}
+		if _, index := findProblemByID(problems, p.ID); index == -1 {
+			fromCurated = append(fromCurated, p)
+		}
+	}
+	return fromPool, fromCurated
+}
+
+func gapsCmd() *cobra.Command {
+	var minProblems, suggestions int
+	cmd := &cobra.Command{
+		Use:   "gaps",
+		Short: "Find under-practiced topics in your collection",
+		Long:  "Compare your tags against a built-in topic taxonomy and suggest what to add or solve next.",
+		Example: `  saitama gaps
+  saitama gaps --min 5 --suggest 3`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			coverage := computeCoverage(problems)
+
+			fmt.Fprintln(stderr)
+			color.HiCyan("═══════════════════════════════════════")
+			color.HiCyan(tr("        🧭 TOPIC COVERAGE GAPS 🧭        "))
+			color.HiCyan("═══════════════════════════════════════")
+			fmt.Fprintln(stderr)
+
+			var gaps []topicCoverage
+			for _, c := range coverage {
+				bar := strings.Repeat("█", min(c.Total, 20))
+				line := fmt.Sprintf("%-20s %3d (%d solved) %s", c.Topic.Name, c.Total, c.Solved, bar)
+				if c.Solved < minProblems {
+					gaps = append(gaps, c)
+					color.Red("⚠️  %s", line)
+				} else {
+					color.Green("✅ %s", line)
+				}
+			}
+			fmt.Fprintln(stderr)
+
+			if len(gaps) == 0 {
+				color.HiGreen(tr("💪 No gaps! Every topic has at least %d solved problems."), minProblems)
+				return
+			}
+
+			color.HiYellow(tr("🎯 %d under-practiced topics (fewer than %d solved). Suggested next steps:"), len(gaps), minProblems)
+			fmt.Fprintln(stderr)
+			for _, c := range gaps {
+				fromPool, fromCurated := suggestForTopic(c, problems, suggestions)
+				color.HiYellow("🏷️  %s", c.Topic.Name)
+				for _, p := range fromPool {
+					color.White(tr("   🥊 Solve %s - %s"), p.ID, p.Name)
+				}
+				for _, p := range fromCurated {
+					color.Cyan(tr("   ➕ Add %s - %s (%s) %s"), p.ID, p.Name, p.Difficulty, p.URL)
+				}
+				if len(fromPool) == 0 && len(fromCurated) == 0 {
+					color.HiBlack(tr("   No suggestions available"))
+				}
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().IntVar(&minProblems, "min", 3, "minimum number of solved problems for a topic to be considered covered")
+	cmd.Flags().IntVar(&suggestions, "suggest", 2, "number of suggestions per under-practiced topic")
+	return cmd
+}
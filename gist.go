@@ -0,0 +1,411 @@
+// gist.go
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	gistAPI           = "https://api.github.com"
+	gistPlainFile     = "problems.json"
+	gistEncryptedFile = "problems.json.enc"
+	pbkdf2Iterations  = 600000
+)
+
+// GistConfig holds the GitHub Gist backup settings. The token may also be supplied
+// through the SAITAMA_GIST_TOKEN or GITHUB_TOKEN environment variables.
+type GistConfig struct {
+	Token string `json:"token,omitempty"`
+	ID    string `json:"id,omitempty"` // set automatically after the first push
+}
+
+// encryptedSnapshot is the on-gist format of an encrypted backup.
+type encryptedSnapshot struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// gistToken returns the GitHub token from the environment or the config file.
+func gistToken(cfg Config) string {
+	for _, env := range []string{"SAITAMA_GIST_TOKEN", "GITHUB_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return token
+		}
+	}
+	return cfg.Gist.Token
+}
+
+// backupPassphrase returns the passphrase from SAITAMA_BACKUP_PASSPHRASE or asks for it.
+func backupPassphrase() (string, error) {
+	if pass := os.Getenv("SAITAMA_BACKUP_PASSPHRASE"); pass != "" {
+		return pass, nil
+	}
+	var pass string
+	err := survey.AskOne(&survey.Password{Message: "🔑 Backup passphrase:"}, &pass, survey.WithValidator(survey.Required))
+	return pass, err
+}
+
+// encryptSnapshot encrypts data with AES-256-GCM using a key derived from the passphrase.
+func encryptSnapshot(data []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(encryptedSnapshot{
+		Version:    1,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, nil),
+	}, "", "  ")
+}
+
+// decryptSnapshot reverses encryptSnapshot.
+func decryptSnapshot(data []byte, passphrase string) ([]byte, error) {
+	var snap encryptedSnapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("failed to parse encrypted snapshot: %w", err)
+	}
+	key, err := pbkdf2.Key(sha256.New, passphrase, snap.Salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := gcm.Open(nil, snap.Nonce, snap.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or corrupted snapshot")
+	}
+	return plain, nil
+}
+
+// gistRequest performs an authenticated GitHub API request and decodes the JSON response.
+func gistRequest(token, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequest(method, gistAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GitHub request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("GitHub rejected the token (check it has the 'gist' scope)")
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GitHub responded with %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// gistFile is a file of a gist as returned by the GitHub API.
+type gistFile struct {
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated"`
+	RawURL    string `json:"raw_url"`
+}
+
+// gistFileContent returns the full content of a gist file, following raw_url for large files.
+func gistFileContent(f gistFile) ([]byte, error) {
+	if !f.Truncated {
+		return []byte(f.Content), nil
+	}
+	resp, err := httpClient.Get(f.RawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download gist file: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("failed to download gist file: GitHub responded with %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// requireGist loads the config and checks a token (and optionally a gist ID) is available.
+func requireGist(needID bool) (Config, string, bool) {
+	cfg, err := loadConfig()
+	if err != nil {
+		printError(tr("❌ Error loading config: %v"), err)
+		return cfg, "", false
+	}
+	token := gistToken(cfg)
+	if token == "" {
+		printError(tr("❌ No GitHub token found."))
+		color.Cyan(tr("💡 Set SAITAMA_GIST_TOKEN (or gist.token in the config file) to a token with the 'gist' scope"))
+		return cfg, "", false
+	}
+	if needID && cfg.Gist.ID == "" {
+		color.Yellow(tr("📭 No gist backup yet. Create one with: saitama backup gist"))
+		return cfg, "", false
+	}
+	return cfg, token, true
+}
+
+func gistCmd() *cobra.Command {
+	var encrypt bool
+	cmd := &cobra.Command{
+		Use:   "gist",
+		Short: "Back up your problems to a private GitHub Gist",
+		Long: "Push a JSON snapshot of your problems to a private GitHub Gist. Every push becomes a gist revision " +
+			"that can be listed and restored. Use --encrypt to protect the snapshot with a passphrase.",
+		Example: `  SAITAMA_GIST_TOKEN=ghp_... saitama backup gist --encrypt
+  saitama backup gist revisions
+  saitama backup gist restore <revision>`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, token, ok := requireGist(false)
+			if !ok {
+				return
+			}
+
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			data, err := json.MarshalIndent(problems, "", "  ")
+			if err != nil {
+				printError(tr("❌ Error preparing snapshot: %v"), err)
+				return
+			}
+
+			fileName, staleFile := gistPlainFile, gistEncryptedFile
+			if encrypt {
+				pass, err := backupPassphrase()
+				if err != nil {
+					color.Yellow(tr("👋 Backup cancelled."))
+					return
+				}
+				if data, err = encryptSnapshot(data, pass); err != nil {
+					printError(tr("❌ Error encrypting snapshot: %v"), err)
+					return
+				}
+				fileName, staleFile = gistEncryptedFile, gistPlainFile
+			}
+
+			files := map[string]interface{}{fileName: map[string]string{"content": string(data)}}
+			body := map[string]interface{}{
+				"description": fmt.Sprintf("Saitama backup (%d problems, %s)", len(problems), time.Now().Format("2006-01-02 15:04")),
+				"files":       files,
+			}
+
+			var result struct {
+				ID      string `json:"id"`
+				HTMLURL string `json:"html_url"`
+			}
+			if cfg.Gist.ID == "" {
+				body["public"] = false
+				err = gistRequest(token, http.MethodPost, "/gists", body, &result)
+			} else {
+				// Drop the snapshot in the other format so restores stay unambiguous.
+				var existing struct {
+					Files map[string]gistFile `json:"files"`
+				}
+				if err := gistRequest(token, http.MethodGet, "/gists/"+cfg.Gist.ID, nil, &existing); err == nil {
+					if _, ok := existing.Files[staleFile]; ok {
+						files[staleFile] = nil
+					}
+				}
+				err = gistRequest(token, http.MethodPatch, "/gists/"+cfg.Gist.ID, body, &result)
+			}
+			if err != nil {
+				printError(tr("❌ Error pushing backup: %v"), err)
+				return
+			}
+
+			if cfg.Gist.ID != result.ID {
+				cfg.Gist.ID = result.ID
+				if err := saveConfig(cfg); err != nil {
+					color.Yellow(tr("⚠️  Backup pushed but the gist ID could not be saved: %v"), err)
+					color.Yellow(tr("   Add \"gist\": {\"id\": \"%s\"} to your config file"), result.ID)
+				}
+			}
+			color.Green(tr("✅ Backed up %d problems to %s"), len(problems), result.HTMLURL)
+		},
+	}
+	cmd.Flags().BoolVar(&encrypt, "encrypt", false, "encrypt the snapshot with a passphrase (or SAITAMA_BACKUP_PASSPHRASE)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "revisions",
+		Aliases: []string{"log"},
+		Short:   "List the revisions of the gist backup",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, token, ok := requireGist(true)
+			if !ok {
+				return
+			}
+
+			var commits []struct {
+				Version     string    `json:"version"`
+				CommittedAt time.Time `json:"committed_at"`
+				Status      struct {
+					Additions int `json:"additions"`
+					Deletions int `json:"deletions"`
+				} `json:"change_status"`
+			}
+			if err := gistRequest(token, http.MethodGet, "/gists/"+cfg.Gist.ID+"/commits", nil, &commits); err != nil {
+				printError(tr("❌ Error listing revisions: %v"), err)
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("🕰️  Gist backup revisions (newest first):"))
+			fmt.Fprintln(stderr)
+			for i, c := range commits {
+				latest := ""
+				if i == 0 {
+					latest = color.HiGreenString(" (latest)")
+				}
+				fmt.Fprintf(stdout, "%s  %s  %s%s\n",
+					color.HiYellowString(c.Version[:min(len(c.Version), 12)]),
+					c.CommittedAt.Local().Format("2006-01-02 15:04"),
+					color.HiBlackString("+%d -%d", c.Status.Additions, c.Status.Deletions),
+					latest)
+			}
+			fmt.Fprintln(stderr)
+			color.Cyan(tr("💡 Restore one with: saitama backup gist restore <revision>"))
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "restore [revision]",
+		Annotations: mutates(),
+		Short:       "Restore problems from the gist backup (latest revision by default)",
+		Args:        cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, token, ok := requireGist(true)
+			if !ok {
+				return
+			}
+
+			path := "/gists/" + cfg.Gist.ID
+			if len(args) > 0 {
+				revision := args[0]
+				if len(revision) < 40 {
+					// Expand abbreviated revisions from the commit list.
+					var commits []struct {
+						Version string `json:"version"`
+					}
+					if err := gistRequest(token, http.MethodGet, path+"/commits", nil, &commits); err != nil {
+						printError(tr("❌ Error listing revisions: %v"), err)
+						return
+					}
+					for _, c := range commits {
+						if strings.HasPrefix(c.Version, revision) {
+							revision = c.Version
+							break
+						}
+					}
+				}
+				path += "/" + revision
+			}
+
+			var gist struct {
+				Files map[string]gistFile `json:"files"`
+			}
+			if err := gistRequest(token, http.MethodGet, path, nil, &gist); err != nil {
+				printError(tr("❌ Error fetching backup: %v"), err)
+				return
+			}
+
+			var data []byte
+			var err error
+			if f, ok := gist.Files[gistEncryptedFile]; ok {
+				if data, err = gistFileContent(f); err == nil {
+					var pass string
+					if pass, err = backupPassphrase(); err != nil {
+						color.Yellow(tr("👋 Restore cancelled."))
+						return
+					}
+					data, err = decryptSnapshot(data, pass)
+				}
+			} else if f, ok := gist.Files[gistPlainFile]; ok {
+				data, err = gistFileContent(f)
+			} else {
+				err = fmt.Errorf("the gist does not contain a saitama backup")
+			}
+			if err != nil {
+				printError(tr("❌ Error reading backup: %v"), err)
+				return
+			}
+
+			var problems []Problem
+			if err := json.Unmarshal(data, &problems); err != nil {
+				printError(tr("❌ Error parsing backup: %v"), err)
+				return
+			}
+
+			confirm := false
+			prompt := &survey.Confirm{Message: fmt.Sprintf("Replace your current problems with %d problems from the gist?", len(problems))}
+			if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+				color.Yellow(tr("Restore cancelled."))
+				return
+			}
+
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving problems: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Restored %d problems from the gist (previous data kept in the local backups)"), len(problems))
+		},
+	})
+	return cmd
+}
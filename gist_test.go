@@ -0,0 +1,31 @@
+// gist_test.go
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGistFileContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.Error(w, "<html>Not Found</html>", http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"id": "LC1"}]`))
+	}))
+	defer srv.Close()
+
+	if data, err := gistFileContent(gistFile{Content: "inline"}); err != nil || string(data) != "inline" {
+		t.Errorf("inline content = %q, %v", data, err)
+	}
+	if data, err := gistFileContent(gistFile{Truncated: true, RawURL: srv.URL + "/raw"}); err != nil || string(data) != `[{"id": "LC1"}]` {
+		t.Errorf("raw content = %q, %v", data, err)
+	}
+	_, err := gistFileContent(gistFile{Truncated: true, RawURL: srv.URL + "/missing"})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("a 404 should be an error, got %v", err)
+	}
+}
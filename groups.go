@@ -0,0 +1,110 @@
+// groups.go
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// listGroupings are the values of list --group-by.
+var listGroupings = []string{"tag", "difficulty", "platform", "status", "priority"}
+
+// problemStatuses are the statuses of a problem, in the order their groups are shown.
+var problemStatuses = []string{"solved", "attempted", "unsolved", "abandoned", "archived"}
+
+// problemGroup is one section of a grouped list.
+type problemGroup struct {
+	Name     string    `json:"group"`
+	Count    int       `json:"count"`
+	Problems []Problem `json:"problems"`
+}
+
+// problemStatus returns where a problem stands: archived or abandoned first, then
+// whether it was ever solved or at least attempted.
+func problemStatus(p Problem) string {
+	switch {
+	case p.Archived:
+		return "archived"
+	case p.Abandoned:
+		return "abandoned"
+	case solveCount(p) > 0:
+		return "solved"
+	case len(p.Attempts) > 0:
+		return "attempted"
+	}
+	return "unsolved"
+}
+
+// groupKeys returns the groups a problem belongs to. A problem is listed under every
+// one of its tags; missing values get a group of their own.
+func groupKeys(p Problem, by string) []string {
+	var keys []string
+	switch by {
+	case "tag":
+		for _, tag := range p.Tags {
+			keys = append(keys, strings.ToLower(tag))
+		}
+	case "difficulty":
+		keys = []string{strings.ToLower(p.Difficulty)}
+	case "platform":
+		keys = []string{p.Platform}
+	case "status":
+		keys = []string{problemStatus(p)}
+	case "priority":
+		keys = []string{priorityName(p.Priority)}
+	}
+	if len(keys) == 0 || keys[0] == "" {
+		return []string{"none"}
+	}
+	return keys
+}
+
+// groupProblems splits the problems into groups, keeping their order within each one.
+// Difficulties go from easy to hard, priorities from the most urgent, and statuses follow
+// problemStatuses; tags and platforms go from the largest group to the smallest. "none"
+// always comes last.
+func groupProblems(problems []Problem, by string) ([]problemGroup, error) {
+	if !slices.Contains(listGroupings, by) {
+		return nil, fmt.Errorf("unknown grouping '%s' (use %s)", by, strings.Join(listGroupings, ", "))
+	}
+	index := make(map[string]int)
+	var groups []problemGroup
+	for _, p := range problems {
+		for _, key := range groupKeys(p, by) {
+			i, ok := index[key]
+			if !ok {
+				i = len(groups)
+				index[key] = i
+				groups = append(groups, problemGroup{Name: key})
+			}
+			groups[i].Problems = append(groups[i].Problems, p)
+			groups[i].Count++
+		}
+	}
+
+	rank := func(g problemGroup) float64 {
+		switch by {
+		case "difficulty":
+			return difficultyValue(g.Name)
+		case "status":
+			return float64(slices.Index(problemStatuses, g.Name))
+		case "priority":
+			priority, _ := parsePriority(g.Name)
+			return -float64(priority)
+		}
+		return -float64(g.Count)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if (a.Name == "none") != (b.Name == "none") {
+			return b.Name == "none"
+		}
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		return a.Name < b.Name
+	})
+	return groups, nil
+}
@@ -0,0 +1,368 @@
+// gsheet.go
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sheetsAPI   = "https://sheets.googleapis.com/v4/spreadsheets/"
+	sheetsScope = "https://www.googleapis.com/auth/spreadsheets"
+)
+
+// GSheetConfig holds the Google Sheets import/export settings.
+type GSheetConfig struct {
+	CredentialsFile string `json:"credentials_file,omitempty"` // service account JSON, or GOOGLE_APPLICATION_CREDENTIALS
+	Sheet           string `json:"sheet,omitempty"`            // tab name, defaults to "Problems"
+	TagDelimiter    string `json:"tag_delimiter,omitempty"`    // defaults to ";"
+}
+
+// sheetColumns are the columns written on export, in order. Imports match headers
+// against these names case-insensitively, so columns may be reordered or omitted.
+var sheetColumns = []string{"id", "name", "tags", "difficulty", "platform", "url", "rating", "solve_count", "last_solved", "date_added", "starred", "notes"}
+
+// serviceAccount is the subset of a Google service account key file we need.
+type serviceAccount struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+func (c GSheetConfig) sheetName() string {
+	if c.Sheet == "" {
+		return "Problems"
+	}
+	return c.Sheet
+}
+
+func (c GSheetConfig) delimiter() string {
+	if c.TagDelimiter == "" {
+		return ";"
+	}
+	return c.TagDelimiter
+}
+
+// loadServiceAccount reads the service account key file from the config or environment.
+func loadServiceAccount(cfg GSheetConfig) (serviceAccount, error) {
+	var sa serviceAccount
+	path := cfg.CredentialsFile
+	if path == "" {
+		path = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	if path == "" {
+		return sa, fmt.Errorf("no service account configured (set gsheet.credentials_file or GOOGLE_APPLICATION_CREDENTIALS)")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return sa, fmt.Errorf("failed to read service account file: %w", err)
+	}
+	if err := json.Unmarshal(data, &sa); err != nil {
+		return sa, fmt.Errorf("failed to parse service account file: %w", err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return sa, fmt.Errorf("service account file is missing client_email or private_key")
+	}
+	if sa.TokenURI == "" {
+		sa.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	return sa, nil
+}
+
+// googleAccessToken exchanges a signed JWT assertion for an OAuth access token.
+func googleAccessToken(sa serviceAccount, scope string) (string, error) {
+	block, _ := pem.Decode([]byte(sa.PrivateKey))
+	if block == nil {
+		return "", fmt.Errorf("invalid private key in service account file")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("invalid private key in service account file: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return "", fmt.Errorf("service account key is not an RSA key")
+	}
+
+	now := time.Now()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss":   sa.ClientEmail,
+		"scope": scope,
+		"aud":   sa.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	enc := base64.RawURLEncoding
+	unsigned := enc.EncodeToString(header) + "." + enc.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token request: %w", err)
+	}
+
+	resp, err := httpClient.PostForm(sa.TokenURI, url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {unsigned + "." + enc.EncodeToString(signature)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("google rejected the service account (%s): %s", resp.Status, token.Error)
+	}
+	return token.AccessToken, nil
+}
+
+// sheetsRequest performs an authenticated Sheets API request.
+func sheetsRequest(token, method, endpoint string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sheets request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("sheets responded with %s: %s", resp.Status, apiErr.Error.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sheetA1 quotes a tab name for A1 notation.
+func sheetA1(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// sheetRange builds the escaped A1 range URL of the configured tab.
+func sheetRange(sheetID, sheet, suffix string) string {
+	return sheetsAPI + url.PathEscape(sheetID) + "/values/" + url.PathEscape(sheetA1(sheet)) + suffix
+}
+
+// problemsToRows converts problems into a header row followed by one row per problem.
+func problemsToRows(problems []Problem, delimiter string) [][]string {
+	rows := [][]string{sheetColumns}
+	for _, p := range problems {
+		lastSolved := ""
+		if !p.LastSolved.IsZero() {
+			lastSolved = p.LastSolved.Format(time.RFC3339)
+		}
+		rating := ""
+		if p.Rating > 0 {
+			rating = strconv.Itoa(p.Rating)
+		}
+		starred := ""
+		if p.Starred {
+			starred = "yes"
+		}
+		rows = append(rows, []string{
+			p.ID, p.Name, strings.Join(p.Tags, delimiter), p.Difficulty, p.Platform, p.URL, rating,
+			strconv.Itoa(p.SolveCount), lastSolved, p.DateAdded.Format(time.RFC3339), starred, p.Notes,
+		})
+	}
+	return rows
+}
+
+// rowsToProblems converts sheet rows (header row first) back into problems.
+func rowsToProblems(rows [][]string, delimiter string) ([]Problem, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	index := make(map[string]int)
+	for i, h := range rows[0] {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	if _, ok := index["id"]; !ok {
+		return nil, fmt.Errorf("the sheet has no 'id' column")
+	}
+	if _, ok := index["name"]; !ok {
+		return nil, fmt.Errorf("the sheet has no 'name' column")
+	}
+
+	var problems []Problem
+	for r, row := range rows[1:] {
+		cell := func(col string) string {
+			if i, ok := index[col]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		p := Problem{
+			ID:         strings.ToUpper(cell("id")),
+			Name:       cell("name"),
+			Tags:       parseTags(strings.ReplaceAll(cell("tags"), delimiter, ",")),
+			Difficulty: strings.ToLower(cell("difficulty")),
+			Platform:   strings.ToLower(cell("platform")),
+			URL:        cell("url"),
+			Notes:      cell("notes"),
+		}
+		switch strings.ToLower(cell("starred")) {
+		case "yes", "true", "1", "x", "★", "⭐":
+			p.Starred = true
+		}
+		if p.ID == "" && p.Name == "" {
+			continue // blank row
+		}
+		if p.ID == "" || p.Name == "" {
+			return nil, fmt.Errorf("row %d: id and name are required", r+2)
+		}
+		if v := cell("rating"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid rating '%s'", r+2, v)
+			}
+			p.Rating = n
+		}
+		if v := cell("solve_count"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid solve_count '%s'", r+2, v)
+			}
+			p.SolveCount = n
+		}
+		for col, target := range map[string]*time.Time{"last_solved": &p.LastSolved, "date_added": &p.DateAdded} {
+			if v := cell(col); v != "" {
+				t, err := time.Parse(time.RFC3339, v)
+				if err != nil {
+					if t, err = time.Parse("2006-01-02", v); err != nil {
+						return nil, fmt.Errorf("row %d: invalid %s '%s'", r+2, col, v)
+					}
+				}
+				*target = t
+			}
+		}
+		problems = append(problems, p)
+	}
+	return problems, nil
+}
+
+// exportToSheet replaces the content of the configured tab with the problems. The rows
+// are written before anything is cleared, so a failed export leaves the previous one in
+// place; only the cells the new export doesn't cover are cleared afterwards.
+func exportToSheet(problems []Problem, sheetID string, cfg GSheetConfig) error {
+	sa, err := loadServiceAccount(cfg)
+	if err != nil {
+		return err
+	}
+	token, err := googleAccessToken(sa, sheetsScope)
+	if err != nil {
+		return err
+	}
+	var current struct {
+		Values [][]string `json:"values"`
+	}
+	if err := sheetsRequest(token, http.MethodGet, sheetRange(sheetID, cfg.sheetName(), ""), nil, &current); err != nil {
+		return err
+	}
+	width := 0
+	for _, row := range current.Values {
+		width = max(width, len(row))
+	}
+
+	rows := problemsToRows(problems, cfg.delimiter())
+	body := map[string]interface{}{"values": rows}
+	slog.Info("exporting to google sheet", "sheet", cfg.sheetName(), "problems", len(problems))
+	if err := sheetsRequest(token, http.MethodPut, sheetRange(sheetID, cfg.sheetName(), "?valueInputOption=RAW"), body, nil); err != nil {
+		return err
+	}
+
+	stale := staleRanges(len(current.Values), width, len(rows), len(sheetColumns))
+	if len(stale) == 0 {
+		return nil
+	}
+	for i, r := range stale {
+		stale[i] = sheetA1(cfg.sheetName()) + "!" + r
+	}
+	endpoint := sheetsAPI + url.PathEscape(sheetID) + "/values:batchClear"
+	if err := sheetsRequest(token, http.MethodPost, endpoint, map[string][]string{"ranges": stale}, nil); err != nil {
+		return fmt.Errorf("problems exported, but the leftover rows of the previous export were not cleared: %w", err)
+	}
+	return nil
+}
+
+// staleRanges returns the A1 ranges of a height x width block of old values that a
+// rows x cols export doesn't overwrite: the rows below it, and the columns to its right.
+func staleRanges(height, width, rows, cols int) []string {
+	var ranges []string
+	if height > rows && width > 0 {
+		ranges = append(ranges, fmt.Sprintf("A%d:%s%d", rows+1, columnName(width), height))
+	}
+	if width > cols && rows > 0 && height > 0 {
+		ranges = append(ranges, fmt.Sprintf("%s1:%s%d", columnName(cols+1), columnName(width), min(height, rows)))
+	}
+	return ranges
+}
+
+// columnName returns the letters of the nth column, counting from 1: A, ..., Z, AA, ...
+func columnName(n int) string {
+	name := ""
+	for n > 0 {
+		n--
+		name = string(rune('A'+n%26)) + name
+		n /= 26
+	}
+	return name
+}
+
+// importFromSheet reads problems from the configured tab.
+func importFromSheet(sheetID string, cfg GSheetConfig) ([]Problem, error) {
+	sa, err := loadServiceAccount(cfg)
+	if err != nil {
+		return nil, err
+	}
+	token, err := googleAccessToken(sa, sheetsScope)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Values [][]string `json:"values"`
+	}
+	if err := sheetsRequest(token, http.MethodGet, sheetRange(sheetID, cfg.sheetName(), ""), nil, &result); err != nil {
+		return nil, err
+	}
+	slog.Info("imported google sheet", "sheet", cfg.sheetName(), "rows", len(result.Values))
+	return rowsToProblems(result.Values, cfg.delimiter())
+}
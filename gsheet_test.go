@@ -0,0 +1,38 @@
+// gsheet_test.go
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestColumnName(t *testing.T) {
+	for n, want := range map[int]string{1: "A", 12: "L", 26: "Z", 27: "AA", 52: "AZ", 53: "BA", 702: "ZZ", 703: "AAA"} {
+		if got := columnName(n); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestStaleRanges(t *testing.T) {
+	tests := []struct {
+		name                      string
+		height, width, rows, cols int
+		want                      []string
+	}{
+		{"empty sheet", 0, 0, 5, 12, nil},
+		{"same size", 5, 12, 5, 12, nil},
+		{"grown", 5, 12, 8, 12, nil},
+		{"shrunk", 8, 12, 5, 12, []string{"A6:L8"}},
+		{"extra columns", 5, 14, 5, 12, []string{"M1:N5"}},
+		{"shrunk with extra columns", 8, 14, 5, 12, []string{"A6:N8", "M1:N5"}},
+		{"short old export", 3, 14, 5, 12, []string{"M1:N3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := staleRanges(tt.height, tt.width, tt.rows, tt.cols); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("staleRanges = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
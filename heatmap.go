@@ -0,0 +1,134 @@
+// heatmap.go
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// heatmapLevels are the cells used for increasing solve intensity.
+var heatmapLevels = []string{
+	color.HiBlackString("·"),
+	color.GreenString("░"),
+	color.GreenString("▒"),
+	color.HiGreenString("▓"),
+	color.HiGreenString("█"),
+}
+
+// heatLevel maps a day's solve count to an intensity level relative to the busiest day.
+func heatLevel(count, busiest int) int {
+	if count <= 0 || busiest <= 0 {
+		return 0
+	}
+	level := (count*4 + busiest - 1) / busiest // ceil(count / busiest * 4)
+	return min(max(level, 1), 4)
+}
+
+// heatmapRange returns the first and last day shown: the given calendar year, or the
+// last 52 weeks when year is zero.
+func heatmapRange(year int, now time.Time) (time.Time, time.Time) {
+	if year != 0 {
+		return time.Date(year, 1, 1, 0, 0, 0, 0, time.Local), time.Date(year, 12, 31, 0, 0, 0, 0, time.Local)
+	}
+	last := startOfDay(now)
+	return last.AddDate(0, 0, -52*7-int(last.Weekday())), last
+}
+
+// renderHeatmap draws one column per week (Sunday on top) with month labels above.
+func renderHeatmap(days map[string]int, first, last time.Time) string {
+	gridStart := first.AddDate(0, 0, -int(first.Weekday()))
+	weeks := int(last.Sub(gridStart).Hours()/24)/7 + 1
+
+	busiest := 0
+	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+		busiest = max(busiest, days[dayKey(d)])
+	}
+
+	var b strings.Builder
+	// Month labels sit above the week containing the 1st of that month.
+	labels := []rune(strings.Repeat(" ", weeks*2+4))
+	for w := 0; w < weeks; w++ {
+		label := ""
+		if w == 0 {
+			label = first.Format("Jan")
+		}
+		for d := 0; d < 7 && w > 0; d++ {
+			if day := gridStart.AddDate(0, 0, w*7+d); day.Day() == 1 && !day.After(last) {
+				label = day.Format("Jan")
+			}
+		}
+		pos := 4 + w*2
+		if label != "" && pos+len(label) <= len(labels) && labels[pos-1] == ' ' {
+			copy(labels[pos:], []rune(label))
+		}
+	}
+	b.WriteString(strings.TrimRight(string(labels), " ") + "\n")
+
+	dayNames := []string{"", "Mon", "", "Wed", "", "Fri", ""}
+	for weekday := 0; weekday < 7; weekday++ {
+		fmt.Fprintf(&b, "%-4s", dayNames[weekday])
+		for w := 0; w < weeks; w++ {
+			d := gridStart.AddDate(0, 0, w*7+weekday)
+			if d.Before(first) || d.After(last) {
+				b.WriteString("  ")
+				continue
+			}
+			b.WriteString(heatmapLevels[heatLevel(days[dayKey(d)], busiest)] + " ")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func heatmapCmd() *cobra.Command {
+	var year int
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Show a calendar heatmap of your solves",
+		Example: `  saitama heatmap              # The last 52 weeks
+  saitama heatmap --year 2024  # A full calendar year`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			now := time.Now()
+			first, last := heatmapRange(year, now)
+			days := solvesPerDay(activityLog(problems))
+
+			total, active := 0, 0
+			for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+				if n := days[dayKey(d)]; n > 0 {
+					total += n
+					active++
+				}
+			}
+
+			title := "the last 52 weeks"
+			if year != 0 {
+				title = fmt.Sprintf("%d", year)
+			}
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("       🔥 TRAINING HEATMAP 🔥          "))
+			color.HiMagenta("═══════════════════════════════════════")
+			fmt.Fprintln(stderr)
+			fmt.Fprint(stdout, renderHeatmap(days, first, last))
+			fmt.Fprintf(stdout, tr("\n    Less %s More\n\n"), strings.Join(heatmapLevels, " "))
+
+			color.Cyan(tr("📅 %d solves on %d days in %s"), total, active, title)
+			color.Yellow(tr("🏆 Longest streak: %d days"), longestStreak(days, first, last.AddDate(0, 0, 1)))
+			if year == 0 || year == now.Year() {
+				color.HiYellow(tr("🔥 Current streak: %d days"), currentStreak(days, now))
+			}
+		},
+	}
+	cmd.Flags().IntVar(&year, "year", 0, "show a calendar year instead of the last 52 weeks")
+	return cmd
+}
@@ -0,0 +1,216 @@
+// hints.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// hintStats summarizes hint usage across all logged attempts.
+type hintStats struct {
+	Used           int // hints revealed in total
+	Attempts       int // attempts that used at least one hint
+	CleanSolves    int // solves without any hint
+	AssistedSolves int // solves that needed hints
+}
+
+func computeHintStats(problems []Problem) hintStats {
+	var s hintStats
+	for _, p := range problems {
+		for _, a := range p.Attempts {
+			s.Used += a.HintsUsed
+			if a.HintsUsed > 0 {
+				s.Attempts++
+			}
+			if a.Solved && a.HintsUsed > 0 {
+				s.AssistedSolves++
+			} else if a.Solved {
+				s.CleanSolves++
+			}
+		}
+	}
+	return s
+}
+
+// printHint prints a single numbered hint.
+func printHint(n, total int, hint string) {
+	color.HiYellow(tr("💡 Hint %d/%d:"), n, total)
+	fmt.Fprintf(stderr, "   %s\n\n", hint)
+}
+
+// revealHints shows the hints of a problem revealed so far and offers the next ones.
+func revealHints(id string) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+	targetID := strings.ToUpper(id)
+	p, index := findProblemByID(problems, targetID)
+	if index == -1 {
+		printError(tr("❌ Problem with ID '%s' not found"), targetID)
+		return
+	}
+	if len(p.Hints) == 0 {
+		color.Yellow(tr("🤷 No hints stored for '%s'."), p.ID)
+		color.Cyan(tr("💡 Add one with: saitama hint add %s \"...\""), p.ID)
+		return
+	}
+
+	fmt.Fprintln(stderr)
+	color.HiCyan("🥊 %s - %s", p.ID, p.Name)
+	fmt.Fprintln(stderr)
+	shown := min(p.HintsShown, len(p.Hints))
+	for i := 0; i < shown; i++ {
+		printHint(i+1, len(p.Hints), p.Hints[i])
+	}
+
+	for shown < len(p.Hints) {
+		reveal := false
+		prompt := &survey.Confirm{Message: fmt.Sprintf("Reveal hint %d/%d?", shown+1, len(p.Hints))}
+		if err := survey.AskOne(prompt, &reveal); err != nil || !reveal {
+			break
+		}
+		printHint(shown+1, len(p.Hints), p.Hints[shown])
+		shown++
+	}
+	if shown == len(p.Hints) {
+		color.HiBlack(tr("No more hints. You've got this! 💪"))
+	}
+
+	if shown != p.HintsShown && !isReadOnly() {
+		p.HintsShown = shown
+		if err := saveProblems(problems); err != nil {
+			printError(tr("❌ Error saving: %v"), err)
+		}
+	}
+}
+
+func hintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "hint <id>",
+		Short: "Reveal the hints of a problem one at a time",
+		Long: "Hints are revealed progressively, asking before each new one. The number of hints you needed " +
+			"is stored with your next logged attempt.",
+		Example: `  saitama hint LC42
+  saitama hint add LC42 "Think about the tallest bar on each side"
+  saitama hint fetch LC42   # Download the official LeetCode hints`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			revealHints(args[0])
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "add <id> <hint>",
+		Annotations: mutates(),
+		Short:       "Append a hint to a problem",
+		Args:        cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+			hint := strings.TrimSpace(strings.Join(args[1:], " "))
+			if hint == "" {
+				printError(tr("❌ The hint cannot be empty"))
+				return
+			}
+			p.Hints = append(p.Hints, hint)
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Added hint %d to '%s'"), len(p.Hints), p.ID)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "fetch <id>",
+		Annotations: mutates(),
+		Short:       "Download the official hints of a LeetCode problem",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+			leetcode, ok := adapterFor(*p).(*leetcodeAdapter)
+			if !ok {
+				printError(tr("❌ Hints can only be fetched for LeetCode problems"))
+				return
+			}
+
+			fetched, err := leetcode.Hints(*p)
+			if err != nil {
+				printError(tr("❌ Error fetching hints: %v"), err)
+				return
+			}
+			known := make(map[string]bool)
+			for _, h := range p.Hints {
+				known[h] = true
+			}
+			added := 0
+			for _, h := range fetched {
+				if h != "" && !known[h] {
+					p.Hints = append(p.Hints, h)
+					added++
+				}
+			}
+			if added == 0 {
+				color.Yellow(tr("🤷 No new hints for '%s'."), p.ID)
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Added %d hint(s) to '%s'. Reveal them with: saitama hint %s"), added, p.ID, p.ID)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear <id>",
+		Annotations: mutates(),
+		Short:       "Remove all hints of a problem",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+			p.Hints, p.HintsShown = nil, 0
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Removed the hints of '%s'"), p.ID)
+		},
+	})
+	return cmd
+}
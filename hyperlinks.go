@@ -0,0 +1,96 @@
+// hyperlinks.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// hyperlinkModes are the values of the "hyperlinks" setting and SAITAMA_HYPERLINKS.
+var hyperlinkModes = []string{"auto", "always", "never"}
+
+// hyperlinksConfig is the "hyperlinks" setting, applied by setupOutput.
+var hyperlinksConfig string
+
+// hyperlinkTerminals are the TERM_PROGRAM values of terminals known to render OSC 8 links.
+var hyperlinkTerminals = map[string]bool{
+	"vscode": true, "iTerm.app": true, "WezTerm": true, "ghostty": true, "Hyper": true, "Tabby": true, "rio": true,
+}
+
+// hyperlinkMode returns the hyperlink mode: SAITAMA_HYPERLINKS, else the setting, else auto.
+func hyperlinkMode() string {
+	if mode := strings.ToLower(os.Getenv("SAITAMA_HYPERLINKS")); mode != "" {
+		return mode
+	}
+	if hyperlinksConfig != "" {
+		return strings.ToLower(hyperlinksConfig)
+	}
+	return "auto"
+}
+
+// validateHyperlinkMode checks the setting and the environment variable.
+func validateHyperlinkMode() error {
+	if mode := hyperlinkMode(); !slices.Contains(hyperlinkModes, mode) {
+		return fmt.Errorf("unknown hyperlinks mode '%s' (use %s)", mode, strings.Join(hyperlinkModes, ", "))
+	}
+	return nil
+}
+
+// terminalSupportsHyperlinks guesses from the environment whether the terminal renders
+// OSC 8 links. Terminals that don't would print the link target or garbage, so unknown
+// ones are assumed not to. tmux and screen only pass links through when configured to.
+func terminalSupportsHyperlinks() bool {
+	term := os.Getenv("TERM")
+	if term == "dumb" || os.Getenv("TMUX") != "" || strings.HasPrefix(term, "screen") {
+		return false
+	}
+	if hyperlinkTerminals[os.Getenv("TERM_PROGRAM")] {
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KONSOLE_VERSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	if vte, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && vte >= 5000 { // GNOME Terminal and friends
+		return true
+	}
+	switch term {
+	case "xterm-kitty", "xterm-ghostty", "foot", "alacritty", "wezterm":
+		return true
+	}
+	return false
+}
+
+// hyperlinksEnabled reports whether problem names are printed as links to their URLs.
+// Plain and JSON output never have them; in auto mode, stdout must also be a terminal
+// known to support them.
+func hyperlinksEnabled() bool {
+	if isPlain() || jsonOutput() {
+		return false
+	}
+	switch hyperlinkMode() {
+	case "always":
+		return true
+	case "auto":
+		info, err := os.Stdout.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0 && terminalSupportsHyperlinks()
+	}
+	return false
+}
+
+// hyperlink returns text as an OSC 8 link to url when links are enabled, else text as is.
+func hyperlink(text, url string) string {
+	if url == "" || !hyperlinksEnabled() {
+		return text
+	}
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
+
+// padHyperlink is hyperlink with text padded to width runes like %-*s, which would count
+// the bytes of the escape sequences. The padding is left out of the link.
+func padHyperlink(text, url string, width int) string {
+	return hyperlink(text, url) + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(text)))
+}
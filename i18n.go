@@ -0,0 +1,79 @@
+// i18n.go
+package main
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Messages are looked up by their English text, gettext style: the English catalog is
+// the source code itself, and a message missing from a translation is shown in English,
+// so catalogs can be completed a little at a time.
+var catalogs = map[string]map[string]string{
+	"es": catalogES,
+}
+
+// locale is the language of the output, "en" unless a translation is selected.
+var locale = "en"
+
+// tr returns the translation of a message in the current locale.
+func tr(msg string) string {
+	if translated, ok := catalogs[locale][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// parseLocale turns a setting like "es", "es_ES.UTF-8" or "pt-BR" into a language code.
+// It returns "" for the "C" and "POSIX" locales, which mean no preference.
+func parseLocale(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, ".@"); i >= 0 {
+		value = value[:i]
+	}
+	if i := strings.IndexAny(value, "_-"); i >= 0 {
+		value = value[:i]
+	}
+	if value == "c" || value == "posix" {
+		return ""
+	}
+	return value
+}
+
+// detectLocale picks the output language from the "language" setting, then
+// SAITAMA_LANG and the usual LC_ALL, LC_MESSAGES and LANG variables. Languages without
+// a catalog fall back to English.
+func detectLocale(configured string) string {
+	for _, value := range []string{configured, os.Getenv("SAITAMA_LANG"), os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")} {
+		lang := parseLocale(value)
+		if lang == "" {
+			continue
+		}
+		if _, ok := catalogs[lang]; ok {
+			return lang
+		}
+		return "en"
+	}
+	return "en"
+}
+
+// availableLocales lists the languages with a catalog, English first.
+func availableLocales() []string {
+	locales := []string{"en"}
+	for lang := range catalogs {
+		locales = append(locales, lang)
+	}
+	sort.Strings(locales[1:])
+	return locales
+}
+
+// setupLocale selects the output language.
+func setupLocale(cfg Config) {
+	locale = detectLocale(cfg.Language)
+	if lang := parseLocale(cfg.Language); lang != "" && lang != "en" && lang != locale {
+		color.Yellow(tr("⚠️  No translation for '%s', showing English (available: %s)"), cfg.Language, strings.Join(availableLocales(), ", "))
+	}
+}
@@ -0,0 +1,126 @@
+// i18n_es.go
+package main
+
+// catalogES is the Spanish translation. Keys are the English messages exactly as they
+// appear in the source, including emoji, padding and format verbs.
+var catalogES = map[string]string{
+	// Shared errors and warnings
+	"❌ Error loading problems: %v":                                 "❌ Error al cargar los problemas: %v",
+	"❌ Error loading current problems: %v":                         "❌ Error al cargar los problemas actuales: %v",
+	"❌ Error loading existing problems: %v":                        "❌ Error al cargar los problemas existentes: %v",
+	"❌ Error loading config: %v":                                   "❌ Error al cargar la configuración: %v",
+	"❌ Error saving: %v":                                           "❌ Error al guardar: %v",
+	"❌ Error saving problem: %v":                                   "❌ Error al guardar el problema: %v",
+	"❌ Error saving config: %v":                                    "❌ Error al guardar la configuración: %v",
+	"❌ Problem with ID '%s' not found":                             "❌ No se encontró el problema con ID '%s'",
+	"❌ '%s' changes your data and is disabled in read-only mode":   "❌ '%s' modifica tus datos y está desactivado en modo de solo lectura",
+	"❌ Could not display help information.":                        "❌ No se pudo mostrar la ayuda.",
+	"⚠️  %v (using default settings)":                              "⚠️  %v (se usa la configuración predeterminada)",
+	"⚠️  %v (using built-in platforms)":                            "⚠️  %v (se usan las plataformas integradas)",
+	"⚠️  %v (ignoring the study plan)":                             "⚠️  %v (se ignora el plan de estudio)",
+	"⚠️  Logging to file disabled: %v":                             "⚠️  Registro en archivo desactivado: %v",
+	"⚠️  No translation for '%s', showing English (available: %s)": "⚠️  No hay traducción para '%s', se muestra en inglés (disponibles: %s)",
+	"Warning: Failed to create backup: %v\n":                       "Aviso: no se pudo crear la copia de seguridad: %v\n",
+	"Warning: could not remove old backup %s: %v\n":                "Aviso: no se pudo borrar la copia antigua %s: %v\n",
+	"🔍 Dry run: nothing was changed.":                              "🔍 Simulación: no se ha cambiado nada.",
+
+	// add, edit, delete
+	"        🥊 ADD NEW PROBLEM 🥊         ":                         "      🥊 AÑADIR NUEVO PROBLEMA 🥊      ",
+	"✅ Problem '%s' added successfully!":                           "✅ ¡Problema '%s' añadido!",
+	"✅ Problem '%s' updated successfully!":                         "✅ ¡Problema '%s' actualizado!",
+	"✅ Problem '%s' deleted successfully!":                         "✅ ¡Problema '%s' eliminado!",
+	"👋 Add operation cancelled.":                                   "👋 Alta cancelada.",
+	"👋 Edit operation cancelled.":                                  "👋 Edición cancelada.",
+	"👋 Delete operation cancelled.":                                "👋 Borrado cancelado.",
+	"❌ Deletion cancelled by user.":                                "❌ Borrado cancelado por el usuario.",
+	"🆔 Using ID %s":                                                "🆔 Usando el ID %s",
+	"❌ Invalid ID prefix '%s' (use letters only, e.g. LC, CF, HR)": "❌ Prefijo de ID '%s' no válido (solo letras, p. ej. LC, CF, HR)",
+	"🎉 ONE PUNCH SUCCESS! 🎉":                                       "🎉 ¡ÉXITO DE UN SOLO GOLPE! 🎉",
+
+	// list, search, show
+	"                            🗂️  YOUR CODING ARSENAL 🗂️                           ": "                           🗂️  TU ARSENAL DE PROGRAMACIÓN 🗂️                      ",
+	"📝 No problems found!":                          "📝 ¡No se encontraron problemas!",
+	"📝 No problems found yet!":                      "📝 ¡Todavía no hay problemas!",
+	"💡 Add your first problem with: saitama add":    "💡 Añade tu primer problema con: saitama add",
+	"💡 Add some problems first with: saitama add":   "💡 Primero añade algunos problemas con: saitama add",
+	"📊 Total: %d problems":                          "📊 Total: %d problemas",
+	"📊 Showing %d of %d matching problems":          "📊 Mostrando %d de %d problemas coincidentes",
+	"🔍 No problems match the given filters.":        "🔍 Ningún problema coincide con los filtros.",
+	"🔍 Found %d problems with an ID matching '%s':": "🔍 Se encontraron %d problemas con un ID que coincide con '%s':",
+	"🔍 No problems found with an ID matching: '%s'": "🔍 Ningún problema tiene un ID que coincida con: '%s'",
+	"🆔 ID: %s":                            "🆔 ID: %s",
+	"🌐 Platform: %s":                      "🌐 Plataforma: %s",
+	"📶 Difficulty: %s":                    "📶 Dificultad: %s",
+	"🏷️  Tags: %s":                        "🏷️  Etiquetas: %s",
+	"🔗 URL: %s":                           "🔗 URL: %s",
+	"📅 Added: %s":                         "📅 Añadido: %s",
+	"🗒️  Notes: %s":                       "🗒️  Notas: %s",
+	"✅ Last solved: %s (%d times)":        "✅ Última resolución: %s (%d veces)",
+	"   Tags: %s":                         "   Etiquetas: %s",
+	"   ... and %d more":                  "   ... y %d más",
+	"⭐ Starred":                           "⭐ Destacado",
+	"⭐ No starred problems yet!":          "⭐ ¡Todavía no hay problemas destacados!",
+	"💡 Star some with: saitama star <id>": "💡 Destaca alguno con: saitama star <id>",
+
+	// pick
+	"           🎯 TODAY'S TRAINING SELECTION! 🎯                 ":   "         🎯 ¡SELECCIÓN DE ENTRENAMIENTO DE HOY! 🎯           ",
+	"⚠️  Not enough problems! You have %d, but requested %d":        "⚠️  ¡No hay suficientes problemas! Tienes %d, pero pediste %d",
+	"🎯 Focusing on %d problems with weak tags":                      "🎯 Centrado en %d problemas con etiquetas débiles",
+	"💪 No weak tags found, picking from all problems.":              "💪 No hay etiquetas débiles, se elige entre todos los problemas.",
+	"🔁 Only %d problem(s) off cooldown, adding %d picked recently.": "🔁 Solo %d problema(s) fuera de la pausa, se añaden %d elegidos hace poco.",
+	"⚠️  Could not record the picks for the cooldown: %v":           "⚠️  No se pudieron registrar las elecciones para la pausa: %v",
+	"💪 Good luck with your training! ONE PUNCH! 🥊":                  "💪 ¡Suerte con tu entrenamiento! ¡UN SOLO GOLPE! 🥊",
+
+	// solve
+	"🥊 ONE PUNCH! '%s - %s' solved (%d times total)": "🥊 ¡UN SOLO GOLPE! '%s - %s' resuelto (%d veces en total)",
+	"😤 Attempt on '%s' logged. Come back stronger!":  "😤 Intento en '%s' registrado. ¡Vuelve más fuerte!",
+	"🔥 %d day streak!":                               "🔥 ¡Racha de %d días!",
+	"⚠️  Note skipped: %v":                           "⚠️  Nota omitida: %v",
+
+	// stats
+	"         📊 SAITAMA STATISTICS 📊        ":                    "       📊 ESTADÍSTICAS DE SAITAMA 📊      ",
+	"🗂️  Total Problems: %d":                                     "🗂️  Problemas totales: %d",
+	"✅ Solved Problems: %d (%d solves total)":                    "✅ Problemas resueltos: %d (%d resoluciones en total)",
+	"🏷️  Unique Tags: %d":                                        "🏷️  Etiquetas únicas: %d",
+	"📈 Average Tags per Problem: %.1f":                           "📈 Media de etiquetas por problema: %.1f",
+	"🔥 Current Streak: %d days":                                  "🔥 Racha actual: %d días",
+	"🧠 Tag Mastery:":                                             "🧠 Dominio por etiqueta:",
+	"💡 %d weak tags. Train them with: saitama pick --focus-weak": "💡 %d etiquetas débiles. Entrénalas con: saitama pick --focus-weak",
+	"🎭 Perceived vs Labeled Difficulty:":                         "🎭 Dificultad percibida frente a la etiquetada:",
+	"💡 Rate your solves with: saitama solve <id> --felt hard":    "💡 Valora tus resoluciones con: saitama solve <id> --felt hard",
+	"💪 You're getting stronger! Keep it up!":                     "💪 ¡Te estás haciendo más fuerte! ¡Sigue así!",
+	"⚖️  Steady as a hero. Push a little further!":               "⚖️  Firme como un héroe. ¡Esfuérzate un poco más!",
+	"😤 Fewer solves than last period. Time to train harder!":     "😤 Menos resoluciones que el periodo anterior. ¡Hora de entrenar más duro!",
+	"        🏷️  TAG ANALYTICS 🏷️         ":                      "     🏷️  ANÁLISIS DE ETIQUETAS 🏷️     ",
+	"🏷️  No tags found":                                          "🏷️  No se encontraron etiquetas",
+
+	// import, export
+	"✅ Successfully imported %d new problems from %s!": "✅ ¡Se importaron %d problemas nuevos desde %s!",
+	"✅ Successfully exported %d problems to %s!":       "✅ ¡Se exportaron %d problemas a %s!",
+	"❌ Error importing problems: %v":                   "❌ Error al importar los problemas: %v",
+	"❌ Error exporting problems: %v":                   "❌ Error al exportar los problemas: %v",
+	"📝 No valid problems to import.":                   "📝 No hay problemas válidos para importar.",
+	"Import cancelled.":                                "Importación cancelada.",
+	"🔀 Merged %d duplicate(s) into existing problems":  "🔀 Se fusionaron %d duplicado(s) con problemas existentes",
+
+	// next
+	"👉 Next up: %s - %s":              "👉 Siguiente: %s - %s",
+	"🤔 Why:":                          "🤔 Por qué:",
+	"Also good:":                      "También buenos:",
+	"💡 Log it with: saitama solve %s": "💡 Regístralo con: saitama solve %s",
+	"🎉 Nothing to do: everything is solved and no review is due.": "🎉 Nada que hacer: todo está resuelto y no hay repasos pendientes.",
+	"💡 Add new problems with: saitama add":                        "💡 Añade problemas nuevos con: saitama add",
+	"   💪 Difficulty: %s":                                         "   💪 Dificultad: %s",
+
+	// session
+	"🏋️ No session in progress.":                                       "🏋️ No hay ninguna sesión en curso.",
+	"🏋️ No session in progress. Start one with: saitama session start": "🏋️ No hay ninguna sesión en curso. Empieza una con: saitama session start",
+	"⚠️  A session is already in progress.":                            "⚠️  Ya hay una sesión en curso.",
+	"💡 Continue with: saitama session resume":                          "💡 Continúa con: saitama session resume",
+	"⏸️  Session paused with %s left.":                                 "⏸️  Sesión en pausa con %s restantes.",
+	"🗑️  Session abandoned.":                                           "🗑️  Sesión abandonada.",
+	"✅ %d/%d solved":                                                   "✅ %d/%d resueltos",
+	"🎯 Problem %d/%d: %s - %s":                                         "🎯 Problema %d/%d: %s - %s",
+	"🔍 Found %d problems matching '%s':":                               "🔍 Se encontraron %d problemas que coinciden con '%s':",
+	"🔍 No problems found matching: '%s'":                               "🔍 Ningún problema coincide con: '%s'",
+}
@@ -0,0 +1,140 @@
+// ics.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// icsEscape escapes a value for use in an iCalendar TEXT property.
+func icsEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
+
+// icsLine writes a content line, folding it at 75 octets as RFC 5545 requires.
+func icsLine(w io.Writer, line string) {
+	for len(line) > 75 {
+		cut := 75
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut-- // never split a UTF-8 sequence
+		}
+		fmt.Fprintf(w, "%s\r\n", line[:cut])
+		line = " " + line[cut:]
+	}
+	fmt.Fprintf(w, "%s\r\n", line)
+}
+
+// stickyWriter remembers the first write error and drops everything after it, so that
+// a long series of writes is checked once at the end.
+type stickyWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (s *stickyWriter) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	n, err := s.w.Write(p)
+	s.err = err
+	return n, err
+}
+
+// writeCalendar writes an iCalendar with one all-day event per due review and, when
+// reminders are enabled, a recurring daily training session. After days without a
+// solve the session's alarm repeats every 30 minutes, once more per day of inactivity.
+// It returns the first write error.
+func writeCalendar(out io.Writer, problems []Problem, reminders ReminderConfig, now time.Time) error {
+	w := &stickyWriter{w: out}
+	stamp := now.UTC().Format("20060102T150405Z")
+	today := startOfDay(now)
+
+	icsLine(w, "BEGIN:VCALENDAR")
+	icsLine(w, "VERSION:2.0")
+	icsLine(w, "PRODID:-//Saitama//Training Schedule//EN")
+	icsLine(w, "CALSCALE:GREGORIAN")
+	icsLine(w, "X-WR-CALNAME:Saitama reviews")
+
+	if reminders.Enabled {
+		start, err := time.ParseInLocation("15:04", reminders.Time, now.Location())
+		if err == nil {
+			icsLine(w, "BEGIN:VEVENT")
+			icsLine(w, "UID:session@saitama")
+			icsLine(w, "DTSTAMP:"+stamp)
+			icsLine(w, "DTSTART:"+today.Format("20060102")+start.Format("T150405"))
+			icsLine(w, "DURATION:PT1H")
+			icsLine(w, "RRULE:FREQ=DAILY")
+			icsLine(w, "SUMMARY:"+icsEscape("🥊 Training session"))
+			icsLine(w, "DESCRIPTION:"+icsEscape("Run 'saitama pick' to get today's problems."))
+			if days, ok := inactivityNudge(problems, reminders, now); ok {
+				icsLine(w, "BEGIN:VALARM")
+				icsLine(w, "ACTION:DISPLAY")
+				icsLine(w, "DESCRIPTION:"+icsEscape(fmt.Sprintf("No solve in %d days. Time to train!", days)))
+				icsLine(w, "TRIGGER:PT0M")
+				icsLine(w, fmt.Sprintf("REPEAT:%d", min(days-reminders.InactiveDays+1, 6)))
+				icsLine(w, "DURATION:PT30M")
+				icsLine(w, "END:VALARM")
+			}
+			icsLine(w, "END:VEVENT")
+		}
+	}
+
+	type review struct {
+		problem Problem
+		due     time.Time
+	}
+	var reviews []review
+	for _, p := range problems {
+		if due, ok := nextReview(p); ok {
+			if due.Before(today) {
+				due = today // overdue reviews land on today
+			}
+			reviews = append(reviews, review{p, due})
+		}
+	}
+	sort.SliceStable(reviews, func(i, j int) bool { return reviews[i].due.Before(reviews[j].due) })
+
+	for _, r := range reviews {
+		p := r.problem
+		description := fmt.Sprintf("Solved %d time(s), last on %s.", solveCount(p), p.LastSolved.Format("2006-01-02"))
+		if len(p.Tags) > 0 {
+			description += "\nTags: " + strings.Join(p.Tags, ", ")
+		}
+		icsLine(w, "BEGIN:VEVENT")
+		icsLine(w, "UID:review-"+strings.ToLower(p.ID)+"@saitama")
+		icsLine(w, "DTSTAMP:"+stamp)
+		icsLine(w, "DTSTART;VALUE=DATE:"+r.due.Format("20060102"))
+		icsLine(w, "DTEND;VALUE=DATE:"+r.due.AddDate(0, 0, 1).Format("20060102"))
+		icsLine(w, "SUMMARY:"+icsEscape(fmt.Sprintf("🔁 Review %s: %s", p.ID, p.Name)))
+		icsLine(w, "DESCRIPTION:"+icsEscape(description))
+		if p.URL != "" {
+			icsLine(w, "URL:"+p.URL)
+		}
+		icsLine(w, "END:VEVENT")
+	}
+	icsLine(w, "END:VCALENDAR")
+	return w.err
+}
+
+// handleCalendarFeed serves GET /calendar.ics as a live calendar subscription.
+func (s *apiServer) handleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	problems, err := loadProblems()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load config: %v", err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	if err := writeCalendar(w, problems, cfg.Reminders, time.Now()); err != nil {
+		slog.Debug("calendar feed not sent", "err", err) // the client went away
+	}
+}
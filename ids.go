@@ -0,0 +1,123 @@
+// ids.go
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var idPrefixPattern = regexp.MustCompile(`^[A-Z]+$`)
+
+// nextSequentialID returns the next free ID for a prefix, e.g. LC42 when LC41 is the
+// highest LC problem so far.
+func nextSequentialID(problems []Problem, prefix string) string {
+	prefix = strings.ToUpper(prefix)
+	highest := 0
+	for _, p := range problems {
+		if !strings.HasPrefix(p.ID, prefix) {
+			continue
+		}
+		if n, err := strconv.Atoi(p.ID[len(prefix):]); err == nil && n > highest {
+			highest = n
+		}
+	}
+	return prefix + strconv.Itoa(highest+1)
+}
+
+// renameProblemID changes a problem's ID and rewrites every relation pointing at it.
+func renameProblemID(problems []Problem, oldID, newID string) {
+	for i := range problems {
+		if problems[i].ID == oldID {
+			problems[i].ID = newID
+		}
+		for j := range problems[i].Relations {
+			if problems[i].Relations[j].Target == oldID {
+				problems[i].Relations[j].Target = newID
+			}
+		}
+	}
+}
+
+func renameIDCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "rename-id <old> <new>",
+		Annotations: mutates(),
+		Short:       "Change a problem's ID, keeping its links intact",
+		Example:     "  saitama rename-id LC0001 LC1",
+		Args:        cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			oldID, newID := strings.ToUpper(args[0]), strings.ToUpper(strings.TrimSpace(args[1]))
+			if newID == "" {
+				printError(tr("❌ The new ID cannot be empty"))
+				return
+			}
+
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+			problems := tx.Problems()
+			if _, index := findProblemByID(problems, oldID); index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), oldID)
+				return
+			}
+			if _, index := findProblemByID(problems, newID); index != -1 {
+				printError(tr("❌ ID '%s' already exists"), newID)
+				return
+			}
+
+			incoming := len(incomingRelations(problems, oldID))
+			err = tx.Update(func(problems []Problem) ([]Problem, error) {
+				renameProblemID(problems, oldID, newID)
+				return problems, nil
+			})
+			if err != nil {
+				printError(tr("❌ Error renaming: %v"), err)
+				return
+			}
+
+			// Keep the Notion page linked to the renamed problem, written together with the
+			// problems so neither is renamed without the other.
+			if state, err := loadNotionState(); err == nil {
+				if entry, ok := state[oldID]; ok {
+					delete(state, oldID)
+					state[newID] = entry
+					if path, data, err := encodeNotionState(state); err == nil {
+						tx.WriteFile(path, data)
+					} else {
+						color.Yellow(tr("⚠️  Could not update Notion sync state: %v"), err)
+					}
+				}
+			}
+			if archive, err := loadAttemptArchive(); err == nil {
+				if old, ok := archive.Attempts[oldID]; ok {
+					delete(archive.Attempts, oldID)
+					archive.Attempts[newID] = old
+					if path, data, err := encodeAttemptArchive(archive); err == nil {
+						tx.WriteFile(path, data)
+					}
+				}
+			} else {
+				color.Yellow(tr("⚠️  Could not update the attempt archive: %v"), err)
+			}
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+
+			if err := moveAttachments(oldID, newID); err != nil {
+				color.Yellow(tr("⚠️  Could not move the attached files: %v"), err)
+			}
+			color.Green(tr("✅ Renamed '%s' to '%s'"), oldID, newID)
+			if incoming > 0 {
+				color.Cyan(tr("🔗 Updated links from %d problem(s)"), incoming)
+			}
+		},
+	}
+}
@@ -0,0 +1,189 @@
+// journal.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// JournalConfig holds the settings of the daily Markdown journal.
+type JournalConfig struct {
+	Dir string `json:"dir,omitempty"` // folder of the daily files, e.g. a notes vault; defaults to "journal" in the data dir
+}
+
+// journalDir returns the configured journal folder, with a leading ~ expanded.
+func journalDir(cfg JournalConfig) (string, error) {
+	dir := cfg.Dir
+	if dir == "" {
+		appDir, err := getAppDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(appDir, "journal"), nil
+	}
+	return expandHome(dir)
+}
+
+// expandHome expands a leading ~ of a path to the home directory.
+func expandHome(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~"); ok && (rest == "" || rest[0] == '/' || rest[0] == filepath.Separator) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, rest), nil
+	}
+	return path, nil
+}
+
+// journalFile returns the daily file of a day in a journal folder, e.g. 2024-06-01.md.
+func journalFile(dir string, day time.Time) string {
+	return filepath.Join(dir, dayKey(day)+".md")
+}
+
+// journalTarget resolves the target of pick --write-to: a Markdown file, or a folder
+// (existing, or written with a trailing slash) in which case it is the day's file there.
+func journalTarget(target string, day time.Time) string {
+	if info, err := os.Stat(target); (err == nil && info.IsDir()) || strings.HasSuffix(target, "/") {
+		return journalFile(target, day)
+	}
+	return target
+}
+
+// pickChecklist renders picks as a Markdown checklist with links to the problems.
+func pickChecklist(picks []Problem, at time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## Training picks, %s\n\n", at.Format("15:04"))
+	for _, p := range picks {
+		title := fmt.Sprintf("%s - %s", p.ID, p.Name)
+		if p.URL != "" {
+			title = fmt.Sprintf("[%s](%s)", title, p.URL)
+		}
+		line := "- [ ] " + title
+		if len(p.Tags) > 0 {
+			line += " · " + strings.Join(p.Tags, ", ")
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// appendJournal appends a block to a journal file, creating it (and its folder) with a
+// title for the day when it doesn't exist. A block starting with a blank line is a new
+// section; a line after a checklist is kept out of it.
+func appendJournal(path, block string, day time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create journal folder: %w", err)
+	}
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var prefix string
+	text := string(existing)
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	last := lines[len(lines)-1]
+	switch {
+	case strings.TrimSpace(text) == "":
+		prefix = fmt.Sprintf("# %s\n", dayKey(day))
+		if !strings.HasPrefix(block, "\n") {
+			prefix += "\n"
+		}
+	case !strings.HasSuffix(text, "\n"):
+		prefix = "\n"
+	}
+	if strings.HasPrefix(last, "- [") && !strings.HasPrefix(block, "\n") && !strings.HasPrefix(block, "- [") {
+		prefix += "\n"
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if _, err := f.WriteString(prefix + block); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+func journalCmd() *cobra.Command {
+	var date string
+	cmd := &cobra.Command{
+		Use:   "journal",
+		Short: "Show today's training journal, a Markdown file of daily notes",
+		Long: "The journal is one Markdown file per day (2024-06-01.md) in the \"journal\" folder of the data dir, " +
+			"or the folder set as \"dir\" in the \"journal\" section of the config, e.g. the daily notes of a notes app. " +
+			"'saitama pick --write-to <folder|file>' appends the picks to it as a checklist.",
+		Example: `  saitama journal
+  saitama journal --date 2024-06-01
+  saitama journal add "Reviewed segment trees, lazy propagation still shaky"
+  saitama pick 3 --write-to ~/notes/daily/`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			dir, err := journalDir(cfg.Journal)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			day := time.Now()
+			if date != "" {
+				if day, err = time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+					printError(tr("❌ Invalid date '%s' (use YYYY-MM-DD)"), date)
+					return
+				}
+			}
+			path := journalFile(dir, day)
+			data, err := os.ReadFile(path)
+			if os.IsNotExist(err) {
+				color.Yellow(tr("📓 Nothing in the journal for %s yet (%s)."), dayKey(day), path)
+				color.Cyan(tr("💡 Add to it with: saitama journal add <text>, or saitama pick --write-to %s"), dir)
+				return
+			}
+			if err != nil {
+				printError(tr("❌ Error reading the journal: %v"), err)
+				return
+			}
+			color.HiBlack("📓 %s", path)
+			fmt.Fprint(stdout, string(data))
+		},
+	}
+	cmd.Flags().StringVar(&date, "date", "", "show the journal of this day (YYYY-MM-DD) instead of today")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "add <text>",
+		Annotations: mutates(),
+		Short:       "Append a line to today's journal",
+		Example:     `  saitama journal add "Solved LC42 without hints"`,
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			dir, err := journalDir(cfg.Journal)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			now := time.Now()
+			path := journalFile(dir, now)
+			line := fmt.Sprintf("- %s %s\n", now.Format("15:04"), strings.Join(args, " "))
+			if err := appendJournal(path, line, now); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("📓 Added to %s"), path)
+		},
+	})
+	return cmd
+}
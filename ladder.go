@@ -0,0 +1,437 @@
+// ladder.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// LadderRung is one problem of a ladder.
+type LadderRung struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Rating     int    `json:"rating,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Curated    bool   `json:"curated,omitempty"` // from the curated taxonomy, not in the collection when generated
+}
+
+// Ladder is a named practice list of one tag, ordered from the easiest problem to the
+// hardest, in the spirit of the ladders of competitive programming communities.
+type Ladder struct {
+	Name    string       `json:"name"`
+	Tag     string       `json:"tag"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Created time.Time    `json:"created"`
+	Rungs   []LadderRung `json:"rungs"`
+}
+
+func laddersPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "ladders.json"), nil
+}
+
+// loadLadders returns the saved ladders by name, empty when there are none.
+func loadLadders() (map[string]*Ladder, error) {
+	ladders := make(map[string]*Ladder)
+	path, err := laddersPath()
+	if err != nil {
+		return ladders, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ladders, nil
+	}
+	if err != nil {
+		return ladders, fmt.Errorf("failed to read ladders: %w", err)
+	}
+	if err := json.Unmarshal(data, &ladders); err != nil {
+		return ladders, fmt.Errorf("failed to parse ladders: %w", err)
+	}
+	return ladders, nil
+}
+
+// saveLadders writes the ladders atomically.
+func saveLadders(ladders map[string]*Ladder) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := laddersPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ladders, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal ladders: %w", err)
+	}
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write ladders: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		return fmt.Errorf("failed to write ladders: %w", err)
+	}
+	return nil
+}
+
+// ladderRank returns the difficulty rank of a problem, from its label or else its rating,
+// or -1 when it has neither.
+func ladderRank(p Problem) int {
+	if rank := labelRank(p.Difficulty); rank >= 0 {
+		return rank
+	}
+	return labelRank(difficultyFromRating(p.Platform, p.Rating))
+}
+
+// ladderMatches reports whether a problem belongs to the ladder's tag: carrying it, or
+// an alias of the same taxonomy topic (so "graph" finds problems tagged "graphs").
+func ladderMatches(p Problem, tag string) bool {
+	if hasTag(p, tag) {
+		return true
+	}
+	for _, t := range topicTaxonomy {
+		if strings.EqualFold(t.Name, tag) || hasAlias(t, tag) {
+			return topicMatches(t, p)
+		}
+	}
+	return false
+}
+
+// hasAlias reports whether a tag is an alias of the topic.
+func hasAlias(t Topic, tag string) bool {
+	for _, alias := range t.Aliases {
+		if strings.EqualFold(alias, tag) || strings.EqualFold(alias+"s", tag) || strings.EqualFold(alias, tag+"s") {
+			return true
+		}
+	}
+	return false
+}
+
+// generateLadder picks count problems of the tag between two difficulty ranks and orders
+// them by difficulty, then rating. The rungs are spread evenly over the levels, taken from
+// the unsolved problems of the collection first and the curated taxonomy when a level
+// runs short; a level without enough problems leaves its rungs to the others.
+func generateLadder(problems []Problem, tag string, from, to, count int) []LadderRung {
+	levels := to - from + 1
+	pools := make([][]LadderRung, levels)
+	for _, p := range problems {
+		rank := ladderRank(p)
+		if rank < from || rank > to || isSolved(p) || p.Archived || p.Abandoned || !ladderMatches(p, tag) {
+			continue
+		}
+		pools[rank-from] = append(pools[rank-from], LadderRung{ID: p.ID, Name: p.Name, Difficulty: p.Difficulty, Rating: p.Rating, URL: p.URL})
+	}
+	for _, t := range topicTaxonomy {
+		if !strings.EqualFold(t.Name, tag) && !hasAlias(t, tag) {
+			continue
+		}
+		for _, p := range t.Curated {
+			rank := ladderRank(p)
+			if _, index := findProblemByID(problems, p.ID); index >= 0 || rank < from || rank > to {
+				continue
+			}
+			pools[rank-from] = append(pools[rank-from], LadderRung{ID: p.ID, Name: p.Name, Difficulty: p.Difficulty, URL: p.URL, Curated: true})
+		}
+	}
+
+	quotas := make([]int, levels)
+	for i := range quotas {
+		quotas[i] = count / levels
+		if i < count%levels {
+			quotas[i]++
+		}
+	}
+	// Hand the rungs of the levels running short to the others, one each in turn.
+	short := 0
+	for i := range quotas {
+		if quotas[i] > len(pools[i]) {
+			short += quotas[i] - len(pools[i])
+			quotas[i] = len(pools[i])
+		}
+	}
+	for given := true; short > 0 && given; {
+		given = false
+		for i := range quotas {
+			if short > 0 && quotas[i] < len(pools[i]) {
+				quotas[i]++
+				short--
+				given = true
+			}
+		}
+	}
+
+	var rungs []LadderRung
+	for i, pool := range pools {
+		// Within a level, the collection comes before the curated problems, then the
+		// ladder climbs by rating.
+		sort.SliceStable(pool, func(a, b int) bool { return pool[a].Curated != pool[b].Curated && !pool[a].Curated })
+		pool = pool[:quotas[i]]
+		sort.SliceStable(pool, func(a, b int) bool { return pool[a].Rating < pool[b].Rating })
+		rungs = append(rungs, pool...)
+	}
+	return rungs
+}
+
+// rungDone reports whether the problem of a rung has been solved.
+func rungDone(problems []Problem, r LadderRung) bool {
+	p, index := findProblemByID(problems, r.ID)
+	return index != -1 && isSolved(*p)
+}
+
+// ladderProgress returns how many rungs are done.
+func ladderProgress(problems []Problem, l *Ladder) int {
+	done := 0
+	for _, r := range l.Rungs {
+		if rungDone(problems, r) {
+			done++
+		}
+	}
+	return done
+}
+
+// printLadder shows the rungs of a ladder, bottom first, pointing at the first one to do.
+func printLadder(problems []Problem, l *Ladder) {
+	done := ladderProgress(problems, l)
+	fmt.Fprintln(stderr)
+	color.HiCyan(tr("🪜 %s: %s, %s to %s  [%d/%d]"), l.Name, l.Tag, l.From, l.To, done, len(l.Rungs))
+	fmt.Fprintln(stderr)
+	next := true
+	for i, r := range l.Rungs {
+		line := fmt.Sprintf("%2d. %s - %s", i+1, r.ID, r.Name)
+		if r.Difficulty != "" {
+			line += " (" + r.Difficulty
+			if r.Rating > 0 {
+				line += fmt.Sprintf(", %d", r.Rating)
+			}
+			line += ")"
+		}
+		switch {
+		case rungDone(problems, r):
+			printResult(color.New(color.FgGreen), "   ✅ %s", line)
+		case next:
+			next = false
+			printResult(color.New(color.FgHiYellow), "👉 ⬜ %s", line)
+		default:
+			printResult(color.New(color.FgWhite), "   ⬜ %s", line)
+		}
+		if _, index := findProblemByID(problems, r.ID); index == -1 && !rungDone(problems, r) {
+			color.Cyan("         ➕ %s", r.URL)
+		}
+	}
+	fmt.Fprintln(stderr)
+	if done == len(l.Rungs) {
+		color.HiGreen(tr("🏆 Ladder complete!"))
+	}
+}
+
+// ladderLevel parses a difficulty bound of the ladder.
+func ladderLevel(s string) (int, error) {
+	rank := labelRank(s)
+	if rank < 0 {
+		return 0, fmt.Errorf("invalid difficulty '%s' (use easy, medium or hard)", s)
+	}
+	return rank, nil
+}
+
+func ladderCmd() *cobra.Command {
+	var tag, from, to, name string
+	var count int
+	var dryRun, force bool
+	cmd := &cobra.Command{
+		Use:         "ladder",
+		Annotations: mutates(),
+		Short:       "Generate a practice ladder of a tag, from easy to hard",
+		Long: "Builds an ordered list of problems of a tag whose difficulty (and rating, when known) ramps up " +
+			"from --from to --to, like the ladders of competitive programming communities. Rungs come from your " +
+			"unsolved problems, completed with curated ones for the common topics. The ladder is saved under a " +
+			"name, by default the tag, and its progress follows your solves.",
+		Example: `  saitama ladder --tag graphs --from easy --to hard --count 15
+  saitama ladder --tag dp --from medium --to hard --count 10 --name dp-push
+  saitama ladder show graphs
+  saitama ladder list`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if tag == "" {
+				printError(tr("❌ Give the tag of the ladder with --tag, e.g. saitama ladder --tag graphs"))
+				return
+			}
+			low, err := ladderLevel(from)
+			high := 0
+			if err == nil {
+				high, err = ladderLevel(to)
+			}
+			switch {
+			case err == nil && high < low:
+				err = fmt.Errorf("--from %s is harder than --to %s", from, to)
+			case err == nil && count <= 0:
+				err = fmt.Errorf("--count must be positive")
+			}
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			tag = strings.ToLower(tag)
+			if name == "" {
+				name = tag
+			}
+
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			ladders, err := loadLadders()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if _, exists := ladders[name]; exists && !force && !dryRun {
+				printError(tr("❌ There is already a ladder named '%s' (replace it with --force, or pick another --name)"), name)
+				return
+			}
+
+			rungs := generateLadder(problems, tag, low, high, count)
+			if len(rungs) == 0 {
+				color.Yellow(tr("🪜 No unsolved %s problems between %s and %s."), tag, from, to)
+				color.Cyan(tr("💡 Add some with: saitama add, or widen the range with --from and --to"))
+				return
+			}
+			ladder := &Ladder{Name: name, Tag: tag, From: difficultyNames[low], To: difficultyNames[high], Created: time.Now(), Rungs: rungs}
+			if jsonOutput() {
+				printJSON(ladder)
+			} else {
+				printLadder(problems, ladder)
+			}
+			if len(rungs) < count {
+				color.Yellow(tr("⚠️  Only %d problem(s) fit, out of the %d requested."), len(rungs), count)
+			}
+			if dryRun {
+				return
+			}
+			ladders[name] = ladder
+			if err := saveLadders(ladders); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🪜 Ladder '%s' saved. Follow it with: saitama ladder show %s"), name, name)
+		},
+	}
+	cmd.Flags().StringVarP(&tag, "tag", "t", "", "tag of the ladder, e.g. graphs")
+	cmd.Flags().StringVar(&from, "from", "easy", "difficulty of the first rungs")
+	cmd.Flags().StringVar(&to, "to", "hard", "difficulty of the last rungs")
+	cmd.Flags().IntVar(&count, "count", 15, "number of problems")
+	cmd.Flags().StringVar(&name, "name", "", "name to save the ladder under (the tag by default)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show the ladder, without saving it")
+	cmd.Flags().BoolVar(&force, "force", false, "replace the ladder of the same name")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Short:   "List the saved ladders and their progress",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			ladders, err := loadLadders()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			var names []string
+			for name := range ladders {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if jsonOutput() {
+				type ladderSummary struct {
+					Name  string `json:"name"`
+					Tag   string `json:"tag"`
+					Done  int    `json:"done"`
+					Rungs int    `json:"rungs"`
+				}
+				summaries := []ladderSummary{}
+				for _, name := range names {
+					l := ladders[name]
+					summaries = append(summaries, ladderSummary{name, l.Tag, ladderProgress(problems, l), len(l.Rungs)})
+				}
+				printJSON(summaries)
+				return
+			}
+			if len(names) == 0 {
+				color.Yellow(tr("🪜 No ladders yet. Build one with: saitama ladder --tag graphs"))
+				return
+			}
+			for _, name := range names {
+				l := ladders[name]
+				done := ladderProgress(problems, l)
+				printResult(color.New(color.FgWhite), "🪜 %-20s %-15s %-20s %d/%d", name, l.Tag, strings.Repeat("█", 20*done/len(l.Rungs)), done, len(l.Rungs))
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the rungs of a ladder and the next one to climb",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			ladders, err := loadLadders()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			l, ok := ladders[args[0]]
+			if !ok {
+				printError(tr("❌ No ladder named '%s' (see saitama ladder list)"), args[0])
+				return
+			}
+			if jsonOutput() {
+				printJSON(l)
+				return
+			}
+			printLadder(problems, l)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "delete <name>",
+		Annotations: mutates(),
+		Short:       "Delete a ladder (the problems stay)",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ladders, err := loadLadders()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if _, ok := ladders[args[0]]; !ok {
+				printError(tr("❌ No ladder named '%s' (see saitama ladder list)"), args[0])
+				return
+			}
+			delete(ladders, args[0])
+			if err := saveLadders(ladders); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🗑️  Ladder '%s' deleted."), args[0])
+		},
+	})
+	return cmd
+}
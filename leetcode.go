@@ -0,0 +1,385 @@
+// leetcode.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// LeetCodeConfig holds the credentials of 'saitama sync leetcode'. LeetCode has no API
+// tokens, so the LEETCODE_SESSION cookie of a logged-in browser is used.
+type LeetCodeConfig struct {
+	Session string `json:"session,omitempty"` // or SAITAMA_LEETCODE_SESSION
+}
+
+// errLeetCodeAuth means LeetCode didn't accept the session cookie.
+var errLeetCodeAuth = errors.New("LeetCode did not accept the session: it is invalid or has expired")
+
+// leetcodeAccountProblem is a problem the account has submitted to.
+type leetcodeAccountProblem struct {
+	Number     int
+	Title      string
+	Slug       string
+	Difficulty string
+	Status     string // "ac" once accepted, "notac" if only attempted
+	Editorial  bool   // LeetCode publishes an official solution
+}
+
+// leetcodeSyncState remembers the statuses seen at the last sync, so that the next one
+// only applies what changed since.
+type leetcodeSyncState struct {
+	User     string            `json:"user"`
+	SyncedAt time.Time         `json:"synced_at"`
+	Status   map[string]string `json:"status"` // slug -> status
+}
+
+// fetchLeetCodeAccount returns the user name and the attempted problems of the account
+// the session cookie belongs to.
+func fetchLeetCodeAccount(session string) (string, []leetcodeAccountProblem, error) {
+	req, err := http.NewRequest(http.MethodGet, "https://leetcode.com/api/problems/all/", nil)
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("User-Agent", "saitama-cli")
+	req.AddCookie(&http.Cookie{Name: "LEETCODE_SESSION", Value: session})
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return "", nil, errLeetCodeAuth
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", nil, fmt.Errorf("leetcode responded with %s", resp.Status)
+	}
+
+	var list struct {
+		User  string `json:"user_name"`
+		Pairs []struct {
+			Stat struct {
+				Number    int    `json:"frontend_question_id"`
+				Title     string `json:"question__title"`
+				Slug      string `json:"question__title_slug"`
+				Editorial bool   `json:"question__article__live"`
+			} `json:"stat"`
+			Status     *string `json:"status"`
+			Difficulty struct {
+				Level int `json:"level"`
+			} `json:"difficulty"`
+		} `json:"stat_status_pairs"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return "", nil, fmt.Errorf("failed to parse the LeetCode problem list: %w", err)
+	}
+	// LeetCode answers anonymously rather than failing when the cookie is stale.
+	if list.User == "" {
+		return "", nil, errLeetCodeAuth
+	}
+
+	levels := map[int]string{1: "easy", 2: "medium", 3: "hard"}
+	var attempted []leetcodeAccountProblem
+	for _, pair := range list.Pairs {
+		if pair.Status == nil || *pair.Status == "" {
+			continue
+		}
+		attempted = append(attempted, leetcodeAccountProblem{
+			Number:     pair.Stat.Number,
+			Title:      pair.Stat.Title,
+			Slug:       pair.Stat.Slug,
+			Difficulty: levels[pair.Difficulty.Level],
+			Status:     *pair.Status,
+			Editorial:  pair.Stat.Editorial,
+		})
+	}
+	slog.Debug("fetched leetcode account", "user", list.User, "attempted", len(attempted))
+	return list.User, attempted, nil
+}
+
+// fetchLeetCodeSubmissions returns the submission history of the account on a problem.
+func fetchLeetCodeSubmissions(session, slug string) ([]judgeSubmission, error) {
+	const query = `query submissionList($offset: Int!, $limit: Int!, $questionSlug: String!) {
+  questionSubmissionList(offset: $offset, limit: $limit, questionSlug: $questionSlug) {
+    hasNext
+    submissions { id statusDisplay timestamp }
+  }
+}`
+	var subs []judgeSubmission
+	for offset := 0; ; offset += 20 {
+		payload, _ := json.Marshal(map[string]interface{}{
+			"query":     query,
+			"variables": map[string]interface{}{"offset": offset, "limit": 20, "questionSlug": slug},
+		})
+		req, err := http.NewRequest(http.MethodPost, "https://leetcode.com/graphql", bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "saitama-cli")
+		req.Header.Set("Referer", "https://leetcode.com/problems/"+slug+"/submissions/")
+		req.AddCookie(&http.Cookie{Name: "LEETCODE_SESSION", Value: session})
+		body, err := fetchBody(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Data struct {
+				List *struct {
+					HasNext     bool `json:"hasNext"`
+					Submissions []struct {
+						ID        string `json:"id"`
+						Status    string `json:"statusDisplay"`
+						Timestamp string `json:"timestamp"` // seconds since the epoch
+					} `json:"submissions"`
+				} `json:"questionSubmissionList"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to parse the LeetCode submissions: %w", err)
+		}
+		if result.Data.List == nil {
+			return nil, errLeetCodeAuth
+		}
+		for _, s := range result.Data.List.Submissions {
+			seconds, err := strconv.ParseInt(s.Timestamp, 10, 64)
+			if err != nil {
+				continue
+			}
+			subs = append(subs, judgeSubmission{ID: "lc:" + s.ID, Date: time.Unix(seconds, 0), Verdict: normalizeVerdict(s.Status)})
+		}
+		if !result.Data.List.HasNext {
+			return subs, nil
+		}
+	}
+}
+
+func leetcodeStatePath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "leetcode_sync.json"), nil
+}
+
+func loadLeetCodeState() (leetcodeSyncState, error) {
+	state := leetcodeSyncState{}
+	path, err := leetcodeStatePath()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read leetcode sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse leetcode sync state: %w", err)
+	}
+	return state, nil
+}
+
+func saveLeetCodeState(state leetcodeSyncState) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := leetcodeStatePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// findLeetCodeProblem returns the index of the local problem for a LeetCode problem,
+// matched by URL or by its LC<number> ID, or -1.
+func findLeetCodeProblem(problems []Problem, lc leetcodeAccountProblem) int {
+	key := "leetcode.com/problems/" + lc.Slug
+	for i, p := range problems {
+		if normalizeURL(p.URL) == key {
+			return i
+		}
+	}
+	_, index := findProblemByID(problems, "LC"+strconv.Itoa(lc.Number))
+	return index
+}
+
+func syncLeetCodeCmd() *cobra.Command {
+	var session string
+	var dryRun, full, withSubmissions bool
+	cmd := &cobra.Command{
+		Use:         "leetcode",
+		Annotations: mutates(),
+		Short:       "Import your solved and attempted problems from your LeetCode account",
+		Long: "Marks the problems accepted on LeetCode as solved and adds the ones you don't track yet. " +
+			"Authentication uses the LEETCODE_SESSION cookie of a logged-in browser (--session, \"leetcode\": " +
+			"{\"session\": ...} in the config or SAITAMA_LEETCODE_SESSION). Only what changed since the last " +
+			"sync is applied; new solves are logged with the sync date, since the problem list doesn't tell when they " +
+			"happened. With --submissions, the submission history of every changed problem is fetched instead, and " +
+			"each submission is logged with its date and verdict (AC, WA, TLE, ...).",
+		Example: `  saitama sync leetcode --session <cookie> --dry-run
+  saitama sync leetcode
+  saitama sync leetcode --full
+  saitama sync leetcode --full --submissions`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			if session == "" {
+				session = os.Getenv("SAITAMA_LEETCODE_SESSION")
+			}
+			if session == "" {
+				session = cfg.LeetCode.Session
+			}
+			if session == "" {
+				printError(tr("❌ No LeetCode session: pass --session, set leetcode.session in the config or SAITAMA_LEETCODE_SESSION"))
+				color.Cyan(tr("💡 Copy the LEETCODE_SESSION cookie from your browser while logged in to leetcode.com"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			archive, err := loadAttemptArchive()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			state, err := loadLeetCodeState()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			color.Cyan(tr("🔄 Fetching your LeetCode account..."))
+			user, attempted, err := fetchLeetCodeAccount(session)
+			if errors.Is(err, errLeetCodeAuth) {
+				printError("❌ %v", err)
+				color.Cyan(tr("💡 Log in to leetcode.com again and copy the new LEETCODE_SESSION cookie"))
+				return
+			}
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			// Without a previous sync of this account the solve dates are unknown: solves
+			// are counted without logging an attempt.
+			incremental := !full && state.User == user && !state.SyncedAt.IsZero()
+			if state.User != "" && state.User != user {
+				color.Yellow(tr("⚠️  Last sync was for %s, doing a full sync for %s."), state.User, user)
+			}
+			if incremental {
+				color.HiBlack(tr("Changes since %s"), state.SyncedAt.Format("2006-01-02 15:04"))
+			}
+
+			now := time.Now()
+			var created, solved, failed, judged []string
+			statuses := make(map[string]string, len(attempted))
+			for _, lc := range attempted {
+				statuses[lc.Slug] = lc.Status
+				if incremental && state.Status[lc.Slug] == lc.Status {
+					continue
+				}
+				accepted := lc.Status == "ac"
+
+				index := findLeetCodeProblem(problems, lc)
+				if index < 0 {
+					problems = append(problems, Problem{
+						ID:         "LC" + strconv.Itoa(lc.Number),
+						Name:       lc.Title,
+						Tags:       []string{},
+						Difficulty: lc.Difficulty,
+						Platform:   "leetcode",
+						URL:        "https://leetcode.com/problems/" + lc.Slug + "/",
+						DateAdded:  now,
+					})
+					index = len(problems) - 1
+					created = append(created, problems[index].ID)
+				}
+				if lc.Editorial && problems[index].EditorialURL == "" {
+					problems[index].EditorialURL = "https://leetcode.com/problems/" + lc.Slug + "/editorial/"
+				}
+				p := &problems[index]
+				if withSubmissions {
+					subs, err := fetchLeetCodeSubmissions(session, lc.Slug)
+					if err == nil {
+						if len(recordSubmissions(p, archive.Attempts[p.ID], subs)) > 0 {
+							judged = append(judged, p.ID)
+						}
+						continue
+					}
+					slog.Warn("failed to fetch leetcode submissions, using the problem status", "slug", lc.Slug, "err", err)
+				}
+				switch {
+				case accepted && !isSolved(*p) && incremental:
+					recordAttempt(p, true, 0, now)
+					solved = append(solved, p.ID)
+				case accepted && !isSolved(*p):
+					p.SolveCount = 1
+					solved = append(solved, p.ID)
+				case !accepted && incremental:
+					recordAttempt(p, false, 0, now)
+					failed = append(failed, p.ID)
+				}
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("👤 %s: %d problem(s) attempted on LeetCode"), user, len(attempted))
+			color.White(tr("   ➕ %d new problem(s)"), len(created))
+			color.White(tr("   ✅ %d marked solved"), len(solved))
+			if incremental {
+				color.White(tr("   ❌ %d failed attempt(s) logged"), len(failed))
+			}
+			if withSubmissions {
+				color.White(tr("   📥 %d problem(s) with new submissions"), len(judged))
+			}
+			if dryRun {
+				for _, id := range created {
+					color.HiBlack("      + %s", id)
+				}
+				for _, id := range solved {
+					color.HiBlack("      ✓ %s", id)
+				}
+				color.Yellow(tr("🔍 Dry run: nothing was changed."))
+				return
+			}
+
+			if len(created)+len(solved)+len(failed)+len(judged) > 0 {
+				if err := saveProblems(problems); err != nil {
+					printError(tr("❌ Error saving: %v"), err)
+					return
+				}
+			}
+			if err := saveLeetCodeState(leetcodeSyncState{User: user, SyncedAt: now, Status: statuses}); err != nil {
+				printError(tr("❌ Error saving sync state: %v"), err)
+				return
+			}
+			color.Green(tr("✅ LeetCode sync complete."))
+		},
+	}
+	cmd.Flags().StringVar(&session, "session", "", "LEETCODE_SESSION cookie (default from config or SAITAMA_LEETCODE_SESSION)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show what would change")
+	cmd.Flags().BoolVar(&full, "full", false, "look at every problem again instead of the changes since the last sync")
+	cmd.Flags().BoolVar(&withSubmissions, "submissions", false, "log the submissions of changed problems with their dates and verdicts (one request per problem)")
+	return cmd
+}
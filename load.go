@@ -0,0 +1,176 @@
+// load.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// LoadConfig holds the thresholds of the training load insights of 'saitama today'.
+type LoadConfig struct {
+	ShortDays  int     `json:"short_days"`  // recent window, e.g. the last week
+	LongDays   int     `json:"long_days"`   // baseline window the recent one is compared to
+	SpikeRatio float64 `json:"spike_ratio"` // recent pace this many times the baseline is a spike
+	DropRatio  float64 `json:"drop_ratio"`  // recent pace this fraction of the baseline is a drop
+	MinPace    float64 `json:"min_pace"`    // solves per day below which the baseline is too thin to judge
+}
+
+// Training load states.
+const (
+	loadBuilding = "building" // not enough history yet
+	loadSteady   = "steady"
+	loadSpike    = "spike"
+	loadDrop     = "drop"
+)
+
+// trainingLoad compares the recent pace of solves with the usual one. Windows end with
+// yesterday, since today isn't over.
+type trainingLoad struct {
+	Today    int     `json:"today"`
+	Recent   float64 `json:"recent"`   // solves per day over the short window
+	Baseline float64 `json:"baseline"` // solves per day over the long window
+	Ratio    float64 `json:"ratio"`
+	State    string  `json:"state"`
+	Daily    []int   `json:"daily"` // solves per day over the long window, oldest first
+}
+
+// computeTrainingLoad rates the solves of the last days against the configured thresholds.
+func computeTrainingLoad(events []activityEvent, cfg LoadConfig, now time.Time) trainingLoad {
+	days := solvesPerDay(events)
+	today := startOfDay(now)
+	load := trainingLoad{Today: days[dayKey(today)], State: loadBuilding}
+	if cfg.ShortDays <= 0 || cfg.LongDays <= cfg.ShortDays {
+		return load
+	}
+
+	short, long := 0, 0
+	for i := cfg.LongDays; i >= 1; i-- {
+		n := days[dayKey(today.AddDate(0, 0, -i))]
+		load.Daily = append(load.Daily, n)
+		long += n
+		if i <= cfg.ShortDays {
+			short += n
+		}
+	}
+	load.Recent = float64(short) / float64(cfg.ShortDays)
+	load.Baseline = float64(long) / float64(cfg.LongDays)
+	if load.Baseline < cfg.MinPace {
+		return load
+	}
+	load.Ratio = load.Recent / load.Baseline
+	switch {
+	case load.Ratio >= cfg.SpikeRatio:
+		load.State = loadSpike
+	case load.Ratio <= cfg.DropRatio:
+		load.State = loadDrop
+	default:
+		load.State = loadSteady
+	}
+	return load
+}
+
+// sparkline draws counts as a row of block elements scaled to the largest one.
+func sparkline(counts []int) string {
+	blocks := []rune("▁▂▃▄▅▆▇█")
+	peak := 0
+	for _, n := range counts {
+		peak = max(peak, n)
+	}
+	var b strings.Builder
+	for _, n := range counts {
+		if peak == 0 || n == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(blocks[(n*(len(blocks)-1)+peak-1)/peak])
+	}
+	return b.String()
+}
+
+// printTrainingLoad prints the load and what to do about it.
+func printTrainingLoad(load trainingLoad, cfg LoadConfig) {
+	if load.State == loadBuilding {
+		color.HiBlack(tr("📈 Training load: not enough history yet (%.1f solves/day over %d days)"), load.Baseline, cfg.LongDays)
+		return
+	}
+	color.HiCyan(tr("📈 Training load: %.1f solves/day over %d days, usually %.1f (×%.1f)"), load.Recent, cfg.ShortDays, load.Baseline, load.Ratio)
+	color.HiBlack("   %s", sparkline(load.Daily))
+	switch load.State {
+	case loadSpike:
+		color.Yellow(tr("⚠️  Your activity spiked well above your usual pace. Consider a rest day, or a light review session: saitama quiz"))
+	case loadDrop:
+		target := max(1, int(math.Ceil(load.Baseline)))
+		color.Yellow(tr("📉 Your activity dropped well below your usual pace. Ramp back up gently: %d solve(s) today gets you on track (saitama next)"), target)
+	default:
+		color.Green(tr("✅ Steady pace, keep it up."))
+	}
+}
+
+func todayCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "today",
+		Short: "Show today's solves, due reviews, weekly quotas and training load",
+		Long: "Shows what you did today, what is due and how far the weekly quotas are (see 'saitama goal'), and " +
+			"compares your pace over the last week with the last four weeks: when it spikes, take a rest day; when it craters, ramp back up. Windows and thresholds are set in " +
+			"the \"load\" section of the config (short_days, long_days, spike_ratio, drop_ratio, min_pace).",
+		Example: "  saitama today\n  saitama today -o json",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+
+			now := time.Now()
+			events := activityLog(problems)
+			streak := currentStreak(solvesPerDay(events), now)
+			load := computeTrainingLoad(events, cfg.Load, now)
+			quotas := weeklyQuotas(problems, cfg.Goals.Quotas, now)
+			tomorrow := startOfDay(now).AddDate(0, 0, 1)
+			due := 0
+			for _, p := range activeProblems(problems) {
+				if at, ok := nextReview(p); ok && at.Before(tomorrow) {
+					due++
+				}
+			}
+
+			if jsonOutput() {
+				printJSON(struct {
+					Date       string          `json:"date"`
+					DailyGoal  int             `json:"daily_goal,omitempty"`
+					Streak     int             `json:"streak"`
+					ReviewsDue int             `json:"reviews_due"`
+					Quotas     []quotaProgress `json:"quotas,omitempty"`
+					Load       trainingLoad    `json:"load"`
+				}{dayKey(now), cfg.Goals.Daily, streak, due, quotas, load})
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta(tr("📅 Today, %s"), now.Format("Monday 2 January"))
+			if cfg.Goals.Daily > 0 {
+				printResult(color.New(color.FgWhite), tr("   ✅ %d/%d solve(s) today, 🔥 %d day streak"), load.Today, cfg.Goals.Daily, streak)
+			} else {
+				printResult(color.New(color.FgWhite), tr("   ✅ %d solve(s) today, 🔥 %d day streak"), load.Today, streak)
+			}
+			printResult(color.New(color.FgWhite), tr("   🔁 %d review(s) due"), due)
+			fmt.Fprintln(stderr)
+			if len(quotas) > 0 {
+				printQuotas(quotas, now)
+				fmt.Fprintln(stderr)
+			}
+			printTrainingLoad(load, cfg.Load)
+			fmt.Fprintln(stderr)
+		},
+	}
+}
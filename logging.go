@@ -0,0 +1,155 @@
+// logging.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	logDirName    = "logs"
+	logFileName   = "saitama.log"
+	maxLogSize    = 1 << 20 // rotate the log file after 1 MiB
+	maxLogBackups = 3       // keep saitama.log.1 to saitama.log.3
+)
+
+var (
+	// verboseFlag, debugFlag and logFileFlag are set by the persistent logging flags.
+	verboseFlag bool
+	debugFlag   bool
+	logFileFlag bool
+)
+
+// setupLogging installs the default slog logger. Nothing is logged unless --verbose or
+// --debug print logs to stderr, or --log-file (or SAITAMA_LOG_FILE=1) appends them to a
+// rotating file in the data directory.
+func setupLogging() error {
+	level := slog.LevelInfo
+	if debugFlag {
+		level = slog.LevelDebug
+	}
+
+	var writers []io.Writer
+	if verboseFlag || debugFlag {
+		writers = append(writers, os.Stderr)
+	}
+	var fileErr error
+	if logFileFlag || os.Getenv("SAITAMA_LOG_FILE") == "1" {
+		if f, err := openLogFile(); err != nil {
+			fileErr = err
+		} else {
+			writers = append(writers, f)
+		}
+	}
+	if len(writers) == 0 {
+		slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		return fileErr
+	}
+
+	handler := slog.NewTextHandler(io.MultiWriter(writers...), &slog.HandlerOptions{Level: level})
+	slog.SetDefault(slog.New(handler))
+	return fileErr
+}
+
+// openLogFile opens the rotating log file in the logs folder of the data directory. In
+// read-only mode nothing is written there, not even the log.
+func openLogFile() (*rotatingFile, error) {
+	if isReadOnly() {
+		return nil, errReadOnly
+	}
+	appDir, err := getAppDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(appDir, logDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	r := &rotatingFile{path: filepath.Join(dir, logFileName), maxSize: maxLogSize, backups: maxLogBackups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// rotatingFile is an append-only log file that is renamed to .1 (shifting older files up
+// to .N) once it grows past maxSize.
+type rotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	r.file, r.size = f, info.Size()
+	return nil
+}
+
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+	_ = os.Remove(fmt.Sprintf("%s.%d", r.path, r.backups))
+	for n := r.backups - 1; n >= 1; n-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", r.path, n), fmt.Sprintf("%s.%d", r.path, n+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// loggingTransport logs every outgoing HTTP request made through httpClient. Only the
+// host is logged: webhook URLs carry their secret in the path, and some APIs take
+// credentials in the query string.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	host := req.URL.Host
+	slog.Debug("http request", "method", req.Method, "host", host)
+
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		slog.Warn("http request failed", "method", req.Method, "host", host, "duration", elapsed, "err", err)
+		return nil, err
+	}
+	level := slog.LevelInfo
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	slog.Log(req.Context(), level, "http response", "method", req.Method, "host", host, "status", resp.StatusCode, "duration", elapsed)
+	return resp, nil
+}
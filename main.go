@@ -2,9 +2,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
-	"math/rand"
+	"log/slog"
+	"maps"
+	"math"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,10 +38,13 @@ func main() {
 		Long: color.HiCyanString(banner) + "\n" +
 			color.WhiteString("A powerful CLI tool to manage, organize, and randomly select coding problems.\n") +
 			color.YellowString("Train like a hero! 💪"),
+		Version: version,
 		Example: `  saitama add           # Add a new problem interactively
   saitama list          # List all problems
   saitama pick          # Get 5 random problems
   saitama search dp     # Search problems by tag
+  saitama show LC1      # Show a problem and its related problems
+  saitama solve LC1     # Log a solve
   saitama stats         # View problem statistics`,
 	}
 
@@ -48,42 +55,164 @@ func main() {
 		pickCmd(),
 		tagsCmd(),
 		searchCmd(),
+		showCmd(),
 		deleteCmd(),
 		editCmd(),
 		statsCmd(),
 		importCmd(),
 		exportCmd(),
 		wikiCmd(),
+		linkCmd(),
+		unlinkCmd(),
+		gapsCmd(),
+		configCmd(),
+		setupCmd(),
+		bossCmd(),
+		solveCmd(),
+		backupCmd(),
+		watchCmd(),
+		serveCmd(),
+		webhookCmd(),
+		digestCmd(),
+		syncCmd(),
+		replaceCmd(),
+		renameIDCmd(),
+		heatmapCmd(),
+		enrichCmd(),
+		hintCmd(),
+		starCmd(),
+		unstarCmd(),
+		qrCmd(),
+		quizCmd(),
+		planCmd(),
+		mockCmd(),
+		platformsCmd(),
+		refreshCmd(),
+		sessionCmd(),
+		docsCmd(),
+		whereisCmd(),
+		nextCmd(),
+		nagCmd(),
+		stuckCmd(),
+		focusCmd(),
+		diffCmd(),
+		themeCmd(),
+		notifyCmd(),
+		calibrateCmd(),
+		schemaCmd(),
+		reportCmd(),
+		cacheCmd(),
+		archiveCmd(),
+		unarchiveCmd(),
+		snoozeCmd(),
+		unsnoozeCmd(),
+		snoozedCmd(),
+		prioritizeCmd(),
+		openCmd(),
+		ladderCmd(),
+		retroCmd(),
+		rpcCmd(),
+		attachCmd(),
+		daemonCmd(),
+		remindCmd(),
+		todayCmd(),
+		agendaCmd(),
+		promptStatusCmd(),
+		compactCmd(),
+		challengeCmd(),
+		sheetCmd(),
+		mergeCmd(),
+		goalCmd(),
+		journalCmd(),
+		publishCmd(),
+		findCmd(),
+		examplesCmd(),
+		upgradeCmd(),
+		usageCmd(),
 	)
+	linkExamples(rootCmd)
+
+	rootCmd.PersistentFlags().StringVar(&dataDirFlag, "data-dir", "", "use this data directory, e.g. a shared folder (default: user config dir, or $SAITAMA_DATA_DIR)")
+	rootCmd.PersistentFlags().BoolVar(&readOnlyFlag, "read-only", false, "browse the database without changing it (or set SAITAMA_READ_ONLY=1)")
+	rootCmd.PersistentFlags().BoolVar(&fullHistoryFlag, "full-history", false, "include the attempts archived by compact, read-only")
+	rootCmd.SetOut(stdout)
+	rootCmd.PersistentFlags().BoolVar(&plainFlag, "plain", false, "print without colors, emoji or box drawing (or set SAITAMA_PLAIN=1)")
+	rootCmd.PersistentFlags().StringVar(&themeFlag, "theme", "", "color theme: "+strings.Join(themeNames(), ", ")+" (or set SAITAMA_THEME)")
+	rootCmd.PersistentFlags().BoolVar(&noPagerFlag, "no-pager", false, "print long list and search output directly instead of through the pager (or set SAITAMA_PAGER=never)")
+	rootCmd.PersistentFlags().BoolVarP(&quietFlag, "quiet", "q", false, "only print results and errors")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", "text", "format of the results of list, search, show, tags, pick, next and stats: text or json")
+
+	rootCmd.PersistentFlags().BoolVar(&verboseFlag, "verbose", false, "log what saitama does (storage, sync, network) to stderr")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "like --verbose, with detailed debug logs")
+	rootCmd.PersistentFlags().BoolVar(&logFileFlag, "log-file", false, "also append logs to a rotating file in the data dir (or set SAITAMA_LOG_FILE=1)")
+
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		// A broken config is reported by the commands that need it.
+		cfg, _ := loadConfig()
+		setupLocale(cfg)
+		setupTagAliases(cfg)
+		if err := setupOutput(cfg); err != nil {
+			printError("❌ %v", err)
+			os.Exit(1)
+		}
+		if err := setupLogging(); err != nil {
+			color.Yellow(tr("⚠️  Logging to file disabled: %v"), err)
+		}
+		slog.Debug("command started", "command", cmd.CommandPath(), "args", args, "data_dir", dataDir(), "read_only", isReadOnly())
+		if isReadOnly() && mutatesState(cmd) {
+			printError(tr("❌ '%s' changes your data and is disabled in read-only mode"), cmd.CommandPath())
+			os.Exit(1)
+		}
+		recordUsage(cfg, cmd, time.Now())
+		maybeRunOnboarding(cmd)
+	}
 
-	if err := rootCmd.Execute(); err != nil {
+	rootCmd.SetArgs(expandNegatedTerms(rootCmd, os.Args[1:]))
+	if err := rootCmd.Execute(); err != nil || errorPrinted {
 		os.Exit(1)
 	}
 }
 
 // addCmd creates the "add" command with improved UX
 func addCmd() *cobra.Command {
+	var autoID string
 	cmd := &cobra.Command{
-		Use:   "add",
-		Short: "Add a new coding problem interactively",
-		Long:  color.HiGreenString("🔥 ONE PUNCH ADD! ") + "Add a new coding problem with an interactive questionnaire.",
+		Use:         "add",
+		Annotations: mutates(),
+		Short:       "Add a new coding problem interactively",
+		Long:        color.HiGreenString("🔥 ONE PUNCH ADD! ") + "Add a new coding problem with an interactive questionnaire.",
+		Example: `  saitama add               # Choose the ID yourself
+  saitama add --auto-id CF  # Use the next free CF ID, e.g. CF13`,
 		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Println()
+			autoID = strings.ToUpper(strings.TrimSpace(autoID))
+			if autoID != "" && !idPrefixPattern.MatchString(autoID) {
+				printError(tr("❌ Invalid ID prefix '%s' (use letters only, e.g. LC, CF, HR)"), autoID)
+				return
+			}
+
+			fmt.Fprintln(stderr)
 			color.HiMagenta("═══════════════════════════════════════")
-			color.HiMagenta("        🥊 ADD NEW PROBLEM 🥊         ")
+			color.HiMagenta(tr("        🥊 ADD NEW PROBLEM 🥊         "))
 			color.HiMagenta("═══════════════════════════════════════")
-			fmt.Println()
+			fmt.Fprintln(stderr)
 
 			existingProblems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading existing problems: %v", err)
+				printError(tr("❌ Error loading existing problems: %v"), err)
 				return
 			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using built-in platforms)"), err)
+			}
+			platforms := platformRegistry(cfg)
 
 			answers := struct {
-				ID   string
-				Name string
-				Tags string
+				ID      string
+				Name    string
+				Tags    []string
+				NewTags string `survey:"new_tags"`
+				URL     string
 			}{}
 
 			questions := []*survey.Question{
@@ -103,122 +232,301 @@ func addCmd() *cobra.Command {
 					Prompt:   &survey.Input{Message: "📝 Problem Name:"},
 					Validate: survey.Required,
 				},
+			}
+			questions = append(questions, tagQuestions(existingProblems, nil)...)
+			questions = append(questions, []*survey.Question{
 				{
-					Name:   "tags",
-					Prompt: &survey.Input{Message: "🏷️  Tags (comma-separated):", Help: "e.g., array,hashmap,easy"},
+					Name:   "url",
+					Prompt: &survey.Input{Message: "🔗 URL (optional):", Help: "Left empty, it is built from the ID when the platform allows it"},
+					Validate: func(ans interface{}) error {
+						return resolvePlatform(platforms, &Problem{ID: strings.ToUpper(answers.ID), URL: strings.TrimSpace(ans.(string))})
+					},
 				},
+			}...)
+
+			if autoID != "" {
+				answers.ID = nextSequentialID(existingProblems, autoID)
+				questions = questions[1:]
+				color.Cyan(tr("🆔 Using ID %s"), answers.ID)
 			}
 
 			// FIX: The correct way to handle survey errors/interrupts is to check for err != nil.
 			err = survey.Ask(questions, &answers)
 			if err != nil {
-				color.Yellow("👋 Add operation cancelled.")
+				color.Yellow(tr("👋 Add operation cancelled."))
 				return
 			}
 
 			// Process tags
-			var tags []string
-			if answers.Tags != "" {
-				tagList := strings.Split(answers.Tags, ",")
-				for _, tag := range tagList {
-					cleaned := strings.TrimSpace(strings.ToLower(tag))
-					if cleaned != "" {
-						tags = append(tags, cleaned)
-					}
-				}
-			}
+			tags := answeredTags(answers.Tags, answers.NewTags)
 
 			// Create and save the problem
 			newProblem := Problem{
 				ID:        strings.ToUpper(answers.ID),
 				Name:      answers.Name,
 				Tags:      tags,
+				URL:       strings.TrimSpace(answers.URL),
 				DateAdded: time.Now(),
 			}
+			if err := resolvePlatform(platforms, &newProblem); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			if existing, index := findProblemByURL(existingProblems, newProblem.URL); index != -1 {
+				merge, err := confirmMerge(newProblem, *existing)
+				if err != nil {
+					color.Yellow(tr("👋 Add operation cancelled."))
+					return
+				}
+				if merge {
+					mergeProblemInto(&existingProblems[index], newProblem)
+					if err := saveProblems(existingProblems); err != nil {
+						printError(tr("❌ Error saving problem: %v"), err)
+						return
+					}
+					color.Green(tr("✅ Merged into '%s - %s'"), existing.ID, existing.Name)
+					return
+				}
+			}
 
 			problems := append(existingProblems, newProblem)
 
 			if err := saveProblems(problems); err != nil {
-				color.Red("❌ Error saving problem: %v", err)
+				printError(tr("❌ Error saving problem: %v"), err)
 				return
 			}
 
-			fmt.Println()
-			color.HiGreen("🎉 ONE PUNCH SUCCESS! 🎉")
-			color.Green("✅ Problem '%s' added successfully!", answers.Name)
-			color.Cyan("🆔 ID: %s", newProblem.ID)
+			fmt.Fprintln(stderr)
+			color.HiGreen(tr("🎉 ONE PUNCH SUCCESS! 🎉"))
+			color.Green(tr("✅ Problem '%s' added successfully!"), answers.Name)
+			color.Cyan(tr("🆔 ID: %s"), newProblem.ID)
+			if newProblem.Platform != "" {
+				color.Cyan(tr("🌐 Platform: %s"), newProblem.Platform)
+			}
 			if len(tags) > 0 {
-				color.Yellow("🏷️  Tags: %s", strings.Join(tags, ", "))
+				color.Yellow(tr("🏷️  Tags: %s"), strings.Join(tags, ", "))
 			}
-			fmt.Println()
+			fmt.Fprintln(stderr)
 		},
 	}
+	cmd.Flags().StringVar(&autoID, "auto-id", "", "generate the next sequential ID for this prefix (LC, CF, HR, ...)")
 	return cmd
 }
 
 // ... (listCmd, pickCmd, searchCmd functions remain the same) ...
 func listCmd() *cobra.Command {
+	var q ProblemQuery
+	var archived, collapsed bool
+	var groupBy string
 	cmd := &cobra.Command{
-		Use:   "list",
+		Use:   "list [filter...]",
 		Short: "List all saved coding problems",
-		Long:  "Display all your coding problems in a beautiful table format",
+		Long: "Display all your coding problems in a beautiful table format. Filters on dates are terms such as " +
+			"added:>2024-01-01, solved:last-30d or solved:never (" + dateExprHelp + "). Tags are filtered with " +
+			"tag:dp, or left out with -tag:geometry (same as --not-tag geometry).",
+		Example: `  saitama list                          # Everything
+  saitama list --tag dp --sort -added    # Newest DP problems first
+  saitama list --difficulty hard --limit 10
+  saitama list --complexity "O(n^2)"     # Best solution still quadratic
+  saitama list --archived                # Problems taken out of the active pool
+  saitama list --group-by status         # Solved, attempted, unsolved, ... sections
+  saitama list --group-by tag --collapsed
+  saitama list added:>2024-01-01         # Added this year
+  saitama list solved:never --tag graphs # Graph problems never solved
+  saitama list -tag:math -tag:geometry   # Anything but math and geometry
+  saitama list --sort -priority          # Most urgent first
+  saitama list solved:2024-03..2024-05 --sort solved`,
 		Run: func(cmd *cobra.Command, args []string) {
-			problems, err := loadProblems()
+			if archived {
+				q.Archived = "only"
+			}
+			rest, err := parseQueryTerms(args, &q)
+			if err == nil && len(rest) > 0 {
+				err = fmt.Errorf("unexpected argument '%s' (filters are field:expression, e.g. solved:last-30d)", rest[0])
+			}
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError("❌ %v", err)
 				return
 			}
-			if len(problems) == 0 {
-				color.Yellow("📝 No problems found yet!")
-				color.Cyan("💡 Add your first problem with: saitama add")
+			if err := q.Validate(); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			count, err := store.Count()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			if count == 0 && !jsonOutput() {
+				color.Yellow(tr("📝 No problems found yet!"))
+				color.Cyan(tr("💡 Add your first problem with: saitama add"))
+				return
+			}
+
+			problems, total, err := store.Query(q)
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
+			var groups []problemGroup
+			if groupBy != "" {
+				if groups, err = groupProblems(problems, groupBy); err != nil {
+					printError("❌ %v", err)
+					return
+				}
+			}
+			if jsonOutput() {
+				if groupBy != "" {
+					printJSON(groups)
+				} else {
+					printJSON(problems)
+				}
+				return
+			}
+			if total == 0 {
+				color.Yellow(tr("🔍 No problems match the given filters."))
+				return
+			}
+			defer startPager()()
 
-			fmt.Println()
+			fmt.Fprintln(stderr)
 			color.HiCyan("═══════════════════════════════════════════════════════════════════════════════")
-			color.HiCyan("                            🗂️  YOUR CODING ARSENAL 🗂️                           ")
+			color.HiCyan(tr("                            🗂️  YOUR CODING ARSENAL 🗂️                           "))
 			color.HiCyan("═══════════════════════════════════════════════════════════════════════════════")
-			fmt.Println()
+			fmt.Fprintln(stderr)
 
-			fmt.Printf("%-15s %-50s %-30s\n", color.HiYellowString("🆔 ID"), color.HiWhiteString("📝 NAME"), color.HiGreenString("🏷️ TAGS"))
-			color.HiBlack("---------------------------------------------------------------------------------------------------")
-
-			for i, p := range problems {
-				tagStr := "none"
-				if len(p.Tags) > 0 {
-					tagStr = strings.Join(p.Tags, ", ")
+			// The priority column only shows up once some problem has one.
+			withPriority := false
+			for _, p := range problems {
+				withPriority = withPriority || p.Priority > 0
+			}
+			if !collapsed {
+				if withPriority {
+					fmt.Fprintf(stderr, "%-15s %-8s %-50s %-30s\n", color.HiYellowString("🆔 ID"), color.HiRedString("🚨 PRIO"), color.HiWhiteString("📝 NAME"), color.HiGreenString("🏷️ TAGS"))
+				} else {
+					fmt.Fprintf(stderr, "%-15s %-50s %-30s\n", color.HiYellowString("🆔 ID"), color.HiWhiteString("📝 NAME"), color.HiGreenString("🏷️ TAGS"))
 				}
+				color.HiBlack("---------------------------------------------------------------------------------------------------")
+			}
 
-				if i%2 == 0 {
-					fmt.Printf("%-15s %-50s %-30s\n", color.CyanString(p.ID), color.WhiteString(p.Name), color.GreenString(tagStr))
-				} else {
-					fmt.Printf("%-15s %-50s %-30s\n", color.HiCyanString(p.ID), color.HiWhiteString(p.Name), color.HiGreenString(tagStr))
+			if groupBy == "" {
+				for i, p := range problems {
+					printListRow(i, p, withPriority)
+				}
+			}
+			for i, g := range groups {
+				if collapsed {
+					printResult(color.New(color.FgHiMagenta), "▸ %-30s %d", g.Name, g.Count)
+					continue
+				}
+				if i > 0 {
+					fmt.Fprintln(stdout)
+				}
+				printResult(color.New(color.FgHiMagenta, color.Bold), "▾ %s (%d)", g.Name, g.Count)
+				for j, p := range g.Problems {
+					printListRow(j, p, withPriority)
 				}
 			}
 
-			fmt.Println()
+			fmt.Fprintln(stderr)
 			color.HiBlack("---------------------------------------------------------------------------------------------------")
-			color.Magenta("📊 Total: %d problems", len(problems))
-			fmt.Println()
+			if len(problems) < total {
+				color.Magenta(tr("📊 Showing %d of %d matching problems"), len(problems), total)
+			} else {
+				color.Magenta(tr("📊 Total: %d problems"), len(problems))
+			}
+			if groupBy != "" {
+				color.Magenta(tr("🗂️  %d group(s) by %s"), len(groups), groupBy)
+			}
+			fmt.Fprintln(stderr)
+			if all, err := loadProblems(); err == nil {
+				printInactivityNudge(all)
+			}
 		},
 	}
+	cmd.Flags().StringSliceVarP(&q.Tags, "tag", "t", nil, "only list problems with one of these tags")
+	cmd.Flags().StringSliceVar(&q.NotTags, "not-tag", nil, "leave out problems with one of these tags (like the -tag: filter)")
+	cmd.Flags().StringVar(&q.Difficulty, "difficulty", "", "only list problems of this difficulty")
+	cmd.Flags().StringVar(&q.Platform, "platform", "", "only list problems from this platform")
+	cmd.Flags().StringVar(&q.Sort, "sort", "", "sort by "+strings.Join(sortKeyNames(), ", ")+" (prefix with - for descending)")
+	cmd.Flags().IntVar(&q.Limit, "limit", 0, "maximum number of problems to show")
+	cmd.Flags().BoolVar(&q.Starred, "starred", false, "only list starred problems")
+	cmd.Flags().BoolVar(&archived, "archived", false, "only list archived problems, hidden otherwise")
+	cmd.Flags().StringVar(&q.Complexity, "complexity", "", "only list problems whose best solution has this time complexity, e.g. \"O(n^2)\"")
+	cmd.Flags().StringVar(&q.Added, "added", "", "only list problems added on these dates, e.g. \">2024-01-01\" (like the added: filter)")
+	cmd.Flags().StringVar(&q.Solved, "solved", "", "only list problems last solved on these dates, e.g. last-30d or never (like the solved: filter)")
+	cmd.Flags().StringVar(&groupBy, "group-by", "", "show the problems in sections by "+strings.Join(listGroupings, ", "))
+	cmd.Flags().BoolVar(&collapsed, "collapsed", false, "with --group-by, only show the sections and their counts")
 	return cmd
 }
 
+// printListRow prints a problem as a row of the list table, alternating colors, with
+// a priority column when withPriority is set.
+func printListRow(i int, p Problem, withPriority bool) {
+	tagStr := "none"
+	if len(p.Tags) > 0 {
+		tagStr = strings.Join(p.Tags, ", ")
+	}
+	id := p.ID
+	if p.Starred {
+		id += " ★"
+	}
+
+	name := padHyperlink(p.Name, p.URL, 50)
+	if withPriority {
+		prio := ""
+		if p.Priority > 0 {
+			prio = priorityName(p.Priority)
+		}
+		name = color.RedString("%-8s", prio) + " " + name
+	}
+	if i%2 == 0 {
+		fmt.Fprintf(stdout, "%-15s %s %-30s\n", color.CyanString(id), color.WhiteString(name), color.GreenString(tagStr))
+	} else {
+		fmt.Fprintf(stdout, "%-15s %s %-30s\n", color.HiCyanString(id), color.HiWhiteString(name), color.HiGreenString(tagStr))
+	}
+}
+
 func pickCmd() *cobra.Command {
+	var withFollowUps, focusWeak, starred, showQR, feltHard, noCooldown, noFocus, aroundRating bool
+	var seed int64
+	var writeTo string
+	var notTags []string
 	cmd := &cobra.Command{
 		Use:   "pick [number]",
 		Short: "Pick random problems to solve",
-		Long:  "Get a random selection of problems for your training session",
-		Args:  cobra.MaximumNArgs(1),
+		Long: "Get a random selection of problems for your training session (5 by default, configurable via 'saitama setup'). " +
+			"Problems picked within the last 7 days (\"cooldown_days\" in the config, optionally per difficulty) are only suggested when nothing else is left. " +
+			"Problems with a priority are drawn more often, see 'saitama prioritize'.",
+		Example: `  saitama pick
+  saitama pick 3 --starred
+  saitama pick --not-tag geometry   # Anything but geometry today
+  saitama pick --focus-weak --with-followups
+  saitama pick --around-my-rating   # Rated problems just above your estimated rating, see 'saitama stats'
+  saitama pick 3 --seed 4242   # Same selection for everyone with the same database
+  saitama pick --write-to journal/2024-06-01.md  # Also append the picks to a Markdown checklist
+  saitama pick --write-to ~/notes/daily/          # Today's file in that folder, see 'saitama journal'`,
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
+			all := problems
+			problems = activeProblems(problems)
 
-			count := 5
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+
+			count := cfg.Pick.Count
+			if count <= 0 {
+				count = 5
+			}
 			if len(args) > 0 {
 				if c, err := strconv.Atoi(args[0]); err == nil && c > 0 {
 					count = c
@@ -226,94 +534,344 @@ func pickCmd() *cobra.Command {
 			}
 
 			if len(problems) == 0 {
-				color.Yellow("📝 No problems found!")
-				color.Cyan("💡 Add some problems first with: saitama add")
+				color.Yellow(tr("📝 No problems found!"))
+				color.Cyan(tr("💡 Add some problems first with: saitama add"))
 				return
 			}
 
+			if len(notTags) > 0 {
+				problems, _ = ProblemQuery{NotTags: notTags, Archived: "include"}.Apply(problems)
+				if len(problems) == 0 {
+					color.Yellow(tr("🏷️  Every problem has one of the tags left out: %s"), strings.Join(notTags, ", "))
+					return
+				}
+			}
+
+			if starred {
+				problems, _ = ProblemQuery{Starred: true}.Apply(problems)
+				if len(problems) == 0 {
+					color.Yellow(tr("⭐ No starred problems yet!"))
+					color.Cyan(tr("💡 Star some with: saitama star <id>"))
+					return
+				}
+			}
+
+			if feltHard {
+				var hard []Problem
+				for _, p := range problems {
+					if lastFelt(p) == "hard" {
+						hard = append(hard, p)
+					}
+				}
+				if len(hard) == 0 {
+					color.Yellow(tr("😌 Nothing felt hard lately!"))
+					color.Cyan(tr("💡 Rate your solves with: saitama solve <id> --felt hard"))
+					return
+				}
+				problems = hard
+			}
+
+			if aroundRating {
+				rating, ok := currentRating(estimateRating(all))
+				if !ok {
+					color.Yellow(tr("📐 No rating estimate yet: it needs attempts at problems with a rating."))
+					color.Cyan(tr("💡 Fill in the ratings of your problems with: saitama enrich"))
+					return
+				}
+				low, high := ratingWindow(rating)
+				var around []Problem
+				for _, p := range problems {
+					if p.Rating >= low && p.Rating <= high {
+						around = append(around, p)
+					}
+				}
+				if len(around) == 0 {
+					color.Yellow(tr("📐 No problems rated %d-%d, around your estimated rating of %d."), low, high, int(math.Round(rating)))
+					return
+				}
+				color.Cyan(tr("📐 Estimated rating %d: picking from %d problem(s) rated %d-%d"), int(math.Round(rating)), len(around), low, high)
+				problems = around
+			}
+
+			if focusWeak {
+				weak := weakTags(computeTagMastery(problems, cfg.Mastery, time.Now()), cfg.Mastery)
+				var focused []Problem
+				for _, p := range problems {
+					for _, tag := range p.Tags {
+						if weak[tag] {
+							focused = append(focused, p)
+							break
+						}
+					}
+				}
+				if len(focused) == 0 {
+					color.Yellow(tr("💪 No weak tags found, picking from all problems."))
+				} else {
+					color.Cyan(tr("🎯 Focusing on %d problems with weak tags"), len(focused))
+					problems = focused
+				}
+			}
+
 			if len(problems) < count {
-				color.Yellow("⚠️  Not enough problems! You have %d, but requested %d", len(problems), count)
-				color.Cyan("💡 Showing all %d problems instead:", len(problems))
+				color.Yellow(tr("⚠️  Not enough problems! You have %d, but requested %d"), len(problems), count)
+				color.Cyan(tr("💡 Showing all %d problems instead:"), len(problems))
 				count = len(problems)
 			}
 
-			rand.Shuffle(len(problems), func(i, j int) { problems[i], problems[j] = problems[j], problems[i] })
+			now := time.Now()
+			pickCfg := cfg.Pick
+			if noCooldown {
+				pickCfg.CooldownDays, pickCfg.DifficultyCooldownDays = 0, nil
+			}
+			rng, seed := selectionRand(seed, cmd.Flags().Changed("seed"))
+			candidates, fresh := pickCandidates(problems, pickCfg, now, rng)
+			if fresh < count {
+				color.Yellow(tr("🔁 Only %d problem(s) off cooldown, adding %d picked recently."), fresh, count-fresh)
+			}
+			if !noFocus {
+				if tag, _ := focusTag(all, cfg, now); tag != "" {
+					// Only the problems off cooldown are reordered, so the focus tag doesn't
+					// bring back recent picks.
+					candidates = append(biasToward(candidates[:fresh], tag, (count+1)/2), candidates[fresh:]...)
+					color.Cyan(tr("🎯 This week's focus: %s"), tag)
+				}
+			}
+			if unmet := unmetQuotas(weeklyQuotas(all, cfg.Goals.Quotas, now)); len(unmet) > 0 && lateInWeek(now) {
+				candidates = append(biasTowardQuotas(candidates[:fresh], unmet, count), candidates[fresh:]...)
+				color.Cyan(tr("⏳ Weekly quotas still to go: %s"), formatQuotas(unmet))
+			}
 
-			fmt.Println()
-			color.HiMagenta("═══════════════════════════════════════════════════════════════")
-			color.HiMagenta("           🎯 TODAY'S TRAINING SELECTION! 🎯                 ")
-			color.HiMagenta("═══════════════════════════════════════════════════════════════")
-			fmt.Println()
+			if jsonOutput() {
+				printJSON(candidates[:count])
+			} else {
+				fmt.Fprintln(stderr)
+				color.HiMagenta("═══════════════════════════════════════════════════════════════")
+				color.HiMagenta(tr("           🎯 TODAY'S TRAINING SELECTION! 🎯                 "))
+				color.HiMagenta("═══════════════════════════════════════════════════════════════")
+				fmt.Fprintln(stderr)
+
+				for i := 0; i < count; i++ {
+					p := candidates[i]
+					tagStr := "No tags"
+					if len(p.Tags) > 0 {
+						tagStr = strings.Join(p.Tags, " • ")
+					}
+					printResult(color.New(color.FgHiYellow), "🥊 %d. %s", i+1, p.ID)
+					printResult(color.New(color.FgWhite), "   📝 %s", hyperlink(p.Name, p.URL))
+					printResult(color.New(color.FgGreen), "   🏷️  %s", tagStr)
+					if showQR && p.URL != "" {
+						printQR(p.URL, "   ", false)
+					}
+					if withFollowUps {
+						for _, f := range followUpsOf(problems, p.ID) {
+							printResult(color.New(color.FgCyan), tr("   ➡️  Follow-up: %s - %s"), f.ID, f.Name)
+						}
+					}
+					fmt.Fprintln(stdout)
+				}
+				color.HiGreen(tr("💪 Good luck with your training! ONE PUNCH! 🥊"))
+				color.HiBlack(tr("🎲 Seed %d: pick with --seed %d to get the same selection"), seed, seed)
+				fmt.Fprintln(stderr)
+			}
 
-			for i := 0; i < count; i++ {
-				p := problems[i]
-				tagStr := "No tags"
-				if len(p.Tags) > 0 {
-					tagStr = strings.Join(p.Tags, " • ")
+			if writeTo != "" {
+				path := journalTarget(writeTo, now)
+				if err := appendJournal(path, pickChecklist(candidates[:count], now), now); err != nil {
+					printError("❌ %v", err)
+				} else {
+					color.Green(tr("📓 Picks added to %s"), path)
 				}
-				color.HiYellow("🥊 %d. %s", i+1, p.ID)
-				color.White("   📝 %s", p.Name)
-				color.Green("   🏷️  %s", tagStr)
-				fmt.Println()
 			}
-			color.HiGreen("💪 Good luck with your training! ONE PUNCH! 🥊")
-			fmt.Println()
+
+			if err := recordPicks(candidates[:count], now); err != nil && !errors.Is(err, errReadOnly) {
+				color.Yellow(tr("⚠️  Could not record the picks for the cooldown: %v"), err)
+			}
 		},
 	}
+	cmd.Flags().BoolVar(&noFocus, "no-focus", false, "don't lean toward the focus tag of the week (see 'saitama focus')")
+	cmd.Flags().Int64Var(&seed, "seed", 0, "seed of the random selection, to reproduce a pick (random by default)")
+	cmd.Flags().BoolVar(&noCooldown, "no-cooldown", false, "also pick problems suggested recently (see \"cooldown_days\" in the config)")
+	cmd.Flags().BoolVar(&withFollowUps, "with-followups", false, "bundle follow-up problems of each pick into the session")
+	cmd.Flags().BoolVar(&focusWeak, "focus-weak", false, "only pick problems tagged with your weakest tags")
+	cmd.Flags().BoolVar(&starred, "starred", false, "only pick starred problems")
+	cmd.Flags().StringSliceVar(&notTags, "not-tag", nil, "don't pick problems with one of these tags, e.g. --not-tag math,geometry")
+	cmd.Flags().BoolVar(&showQR, "qr", false, "show a QR code for each pick's URL")
+	cmd.Flags().BoolVar(&feltHard, "felt-hard", false, "only pick problems whose last solve felt hard")
+	cmd.Flags().BoolVar(&aroundRating, "around-my-rating", false, "only pick rated problems from your estimated rating to 200 above it")
+	cmd.Flags().StringVar(&writeTo, "write-to", "", "append the picks as a Markdown checklist to this file, or to today's file (YYYY-MM-DD.md) in this folder")
 	return cmd
 }
 
 // searchCmd now searches for a problem by its ID
 func searchCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "search <id>",
+	var complexity string
+	var notTags []string
+	cmd := &cobra.Command{
+		Use:   "search <id|filter>...",
 		Short: "Search for a problem by its ID",
-		Args:  cobra.ExactArgs(1),
+		Long: "Search for problems whose ID contains the given text, optionally narrowed down by date filters such as " +
+			"added:>2024-01-01, solved:last-30d or solved:never (" + dateExprHelp + "), and by tags with tag:dp or " +
+			"-tag:geometry to leave a tag out.",
+		Example: `  saitama search LC1
+  saitama search LC --complexity "O(n^2)"
+  saitama search CF solved:last-30d
+  saitama search added:2024-06 solved:never
+  saitama search CF -tag:geometry`,
+		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			q := ProblemQuery{Complexity: complexity, NotTags: notTags, Archived: "include"}
+			rest, err := parseQueryTerms(args, &q)
+			if err == nil && len(rest) > 1 {
+				err = fmt.Errorf("search takes a single ID, got '%s'", strings.Join(rest, " "))
+			}
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if len(rest) == 1 {
+				q.ID = strings.ToLower(rest[0])
+			}
+			if err := q.Validate(); err != nil {
+				printError("❌ %v", err)
+				return
+			}
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
 
-			queryID := strings.ToLower(args[0])
-			var matches []Problem
+			queryID := q.ID
+			matches, _ := q.Apply(problems)
+			filtered := q.Added != "" || q.Solved != "" || len(q.Tags) > 0 || len(q.NotTags) > 0
 
-			// Loop through all problems and find IDs that contain the query string
-			for _, p := range problems {
-				if strings.Contains(strings.ToLower(p.ID), queryID) {
-					matches = append(matches, p)
-				}
+			if jsonOutput() {
+				printJSON(matches)
+				return
 			}
-
 			if len(matches) == 0 {
-				color.Yellow("🔍 No problems found with an ID matching: '%s'", queryID)
+				if filtered {
+					color.Yellow(tr("🔍 No problems found matching: '%s'"), strings.Join(args, " "))
+				} else {
+					color.Yellow(tr("🔍 No problems found with an ID matching: '%s'"), queryID)
+				}
 				return
 			}
+			defer startPager()()
 
-			fmt.Println()
-			color.HiCyan("🔍 Found %d problems with an ID matching '%s':", len(matches), queryID)
-			fmt.Println()
+			fmt.Fprintln(stderr)
+			if filtered {
+				color.HiCyan(tr("🔍 Found %d problems matching '%s':"), len(matches), strings.Join(args, " "))
+			} else {
+				color.HiCyan(tr("🔍 Found %d problems with an ID matching '%s':"), len(matches), queryID)
+			}
+			fmt.Fprintln(stderr)
 
 			for i, p := range matches {
 				tagStr := strings.Join(p.Tags, ", ")
-				color.Yellow("%d. %s - %s", i+1, p.ID, p.Name)
-				color.Green("   Tags: %s", tagStr)
-				fmt.Println()
+				printResult(color.New(color.FgYellow), "%d. %s - %s", i+1, p.ID, hyperlink(p.Name, p.URL))
+				printResult(color.New(color.FgGreen), tr("   Tags: %s"), tagStr)
+				if p.TimeComplexity != "" {
+					printResult(color.New(color.FgWhite), tr("   Best: %s"), p.TimeComplexity)
+				}
+				fmt.Fprintln(stdout)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&complexity, "complexity", "", "only match problems whose best solution has this time complexity")
+	cmd.Flags().StringSliceVar(&notTags, "not-tag", nil, "leave out problems with one of these tags (like the -tag: filter)")
+	return cmd
+}
+
+// showCmd displays every stored detail of a single problem
+func showCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "show <id>",
+		Short:   "Show the details of a problem",
+		Example: `  saitama show LC1`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+
+			if jsonOutput() {
+				printJSON(p)
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			printResult(color.New(color.FgHiYellow), "🥊 %s - %s", p.ID, p.Name)
+			tagStr := "No tags"
+			if len(p.Tags) > 0 {
+				tagStr = strings.Join(p.Tags, " • ")
+			}
+			printResult(color.New(color.FgGreen), "🏷️  %s", tagStr)
+			if p.Starred {
+				printResult(color.New(color.FgHiYellow), tr("⭐ Starred"))
+			}
+			if p.Archived {
+				printResult(color.New(color.FgHiBlack), tr("📦 Archived"))
+			}
+			if isSnoozed(*p, time.Now()) {
+				printResult(color.New(color.FgHiBlack), tr("💤 Snoozed until %s"), p.SnoozedUntil.Format("Mon Jan 2, 2006"))
+			}
+			if p.Priority > 0 {
+				printResult(color.New(color.FgHiRed), tr("🚨 Priority: %s"), priorityName(p.Priority))
+			}
+			if p.Difficulty != "" {
+				printResult(color.New(color.FgWhite), tr("📶 Difficulty: %s"), p.Difficulty)
+			}
+			if p.PersonalDifficulty != "" {
+				printResult(color.New(color.FgWhite), tr("⚖️  Personal difficulty: %s"), p.PersonalDifficulty)
+			}
+			if p.Platform != "" {
+				printResult(color.New(color.FgWhite), tr("🌐 Platform: %s"), p.Platform)
+			}
+			if p.URL != "" {
+				printResult(color.New(color.FgWhite), tr("🔗 URL: %s"), p.URL)
+			}
+			printReferences(*p)
+			printAttachments(p.ID)
+			printResult(color.New(color.FgWhite), tr("📅 Added: %s"), p.DateAdded.Format("2006-01-02"))
+			if !p.LastSolved.IsZero() {
+				printResult(color.New(color.FgWhite), tr("✅ Last solved: %s (%d times)"), p.LastSolved.Format("2006-01-02"), p.SolveCount)
+			}
+			if p.TimeComplexity != "" || p.SpaceComplexity != "" {
+				printResult(color.New(color.FgWhite), tr("⏱️  Best complexity: time %s, space %s"), orNone(p.TimeComplexity), orNone(p.SpaceComplexity))
 			}
+			if p.Notes != "" {
+				printResult(color.New(color.FgWhite), tr("🗒️  Notes: %s"), p.Notes)
+			}
+			if len(p.Hints) > 0 {
+				printResult(color.New(color.FgWhite), tr("💡 Hints: %d (reveal with: saitama hint %s)"), len(p.Hints), p.ID)
+			}
+			printRelations(problems, p)
+			fmt.Fprintln(stderr)
 		},
 	}
 }
 
 func deleteCmd() *cobra.Command {
 	return &cobra.Command{
-		Use:   "delete <id>",
-		Short: "Delete a problem by ID",
-		Args:  cobra.ExactArgs(1),
+		Use:         "delete <id>",
+		Annotations: mutates(),
+		Short:       "Delete a problem by ID",
+		Example:     `  saitama delete CF1000A`,
+		Args:        cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
 
@@ -321,48 +879,67 @@ func deleteCmd() *cobra.Command {
 			problem, index := findProblemByID(problems, targetID)
 
 			if index == -1 {
-				color.Red("❌ Problem with ID '%s' not found", targetID)
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
 				return
 			}
 
+			incoming := incomingRelations(problems, problem.ID)
+			if len(incoming) > 0 {
+				color.Yellow(tr("⚠️  %d other problem(s) link to %s; those relations will be removed too:"), len(incoming), problem.ID)
+				for _, id := range slices.Sorted(maps.Keys(incoming)) {
+					color.Yellow("   • %s", id)
+				}
+			}
+
 			confirm := false
 			prompt := &survey.Confirm{
 				Message: fmt.Sprintf("Delete problem '%s - %s'?", problem.ID, problem.Name),
 			}
-			
+
 			// FIX: Correct error handling for survey.
 			err = survey.AskOne(prompt, &confirm)
 			if err != nil {
-				color.Yellow("👋 Delete operation cancelled.")
+				color.Yellow(tr("👋 Delete operation cancelled."))
 				return
 			}
 
 			if !confirm {
-				color.Yellow("❌ Deletion cancelled by user.")
+				color.Yellow(tr("❌ Deletion cancelled by user."))
 				return
 			}
 
+			deletedID := problem.ID
 			newProblems := append(problems[:index], problems[index+1:]...)
+			removeRelationsTo(newProblems, deletedID)
 
 			if err := saveProblems(newProblems); err != nil {
-				color.Red("❌ Error saving: %v", err)
+				printError(tr("❌ Error saving: %v"), err)
 				return
 			}
 
-			color.Green("✅ Problem '%s' deleted successfully!", problem.ID)
+			color.Green(tr("✅ Problem '%s' deleted successfully!"), deletedID)
 		},
 	}
 }
 
 func editCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "edit <id>",
-		Short: "Edit a problem by ID",
-		Args:  cobra.ExactArgs(1),
+	var editorial string
+	var refs, removedRefs []string
+	cmd := &cobra.Command{
+		Use:         "edit <id>",
+		Annotations: mutates(),
+		Short:       "Edit a problem by ID",
+		Long: "Asks for the new name, tags, platform, URL and editorial of a problem. With --editorial, --ref " +
+			"or --remove-ref, only changes those links, without questions.",
+		Example: `  saitama edit LC1
+  saitama edit LC42 --editorial https://leetcode.com/problems/trapping-rain-water/editorial/
+  saitama edit CF1915F --ref blog=https://codeforces.com/blog/entry/123922 --ref video=https://youtu.be/xyz
+  saitama edit CF1915F --remove-ref video`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
 
@@ -370,13 +947,40 @@ func editCmd() *cobra.Command {
 			problem, index := findProblemByID(problems, targetID)
 
 			if index == -1 {
-				color.Red("❌ Problem with ID '%s' not found", targetID)
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
 				return
 			}
 
+			if editorial != "" || len(refs) > 0 || len(removedRefs) > 0 {
+				if err := editLinks(problem, editorial, refs, removedRefs); err != nil {
+					printError("❌ %v", err)
+					return
+				}
+				if err := saveProblems(problems); err != nil {
+					printError(tr("❌ Error saving: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Links of '%s' updated"), problem.ID)
+				return
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using built-in platforms)"), err)
+			}
+			platforms := platformRegistry(cfg)
+			platform := problem.Platform
+			if def, ok := platformForID(platforms, problem.ID); ok && platform == "" {
+				platform = def.Name
+			}
+
 			answers := struct {
-				Name string
-				Tags string
+				Name      string
+				Tags      []string
+				NewTags   string `survey:"new_tags"`
+				Platform  string
+				URL       string
+				Editorial string
 			}{}
 
 			questions := []*survey.Question{
@@ -384,96 +988,148 @@ func editCmd() *cobra.Command {
 					Name:   "name",
 					Prompt: &survey.Input{Message: "📝 New name:", Default: problem.Name},
 				},
+			}
+			questions = append(questions, tagQuestions(problems, problem.Tags)...)
+			questions = append(questions, []*survey.Question{
 				{
-					Name:   "tags",
-					Prompt: &survey.Input{Message: "🏷️  New tags:", Default: strings.Join(problem.Tags, ", ")},
+					Name:   "platform",
+					Prompt: &survey.Input{Message: "🌐 Platform:", Default: platform, Help: strings.Join(platformNames(platforms), ", ")},
 				},
-			}
+				{
+					Name:   "url",
+					Prompt: &survey.Input{Message: "🔗 URL:", Default: problem.URL},
+					Validate: func(ans interface{}) error {
+						return resolvePlatform(platforms, &Problem{ID: problem.ID, Platform: answers.Platform, URL: strings.TrimSpace(ans.(string))})
+					},
+				},
+				{
+					Name:   "editorial",
+					Prompt: &survey.Input{Message: "📖 Editorial URL:", Default: problem.EditorialURL, Help: "Official solution or write-up, opened by saitama open --editorial"},
+					Validate: func(ans interface{}) error {
+						if link := strings.TrimSpace(ans.(string)); link != "" {
+							return validateLink(link)
+						}
+						return nil
+					},
+				},
+			}...)
 
 			// FIX: Correct error handling for survey.
 			err = survey.Ask(questions, &answers)
 			if err != nil {
-				color.Yellow("👋 Edit operation cancelled.")
+				color.Yellow(tr("👋 Edit operation cancelled."))
 				return
 			}
 
 			problems[index].Name = answers.Name
 
-			var tags []string
-			if answers.Tags != "" {
-				tagList := strings.Split(answers.Tags, ",")
-				for _, tag := range tagList {
-					cleaned := strings.TrimSpace(strings.ToLower(tag))
-					if cleaned != "" {
-						tags = append(tags, cleaned)
-					}
-				}
+			problems[index].Tags = answeredTags(answers.Tags, answers.NewTags)
+			problems[index].Platform = answers.Platform
+			problems[index].URL = strings.TrimSpace(answers.URL)
+			problems[index].EditorialURL = strings.TrimSpace(answers.Editorial)
+			if err := resolvePlatform(platforms, &problems[index]); err != nil {
+				printError("❌ %v", err)
+				return
 			}
-			problems[index].Tags = tags
 
 			if err := saveProblems(problems); err != nil {
-				color.Red("❌ Error saving: %v", err)
+				printError(tr("❌ Error saving: %v"), err)
 				return
 			}
-			color.Green("✅ Problem '%s' updated successfully!", problem.ID)
+			color.Green(tr("✅ Problem '%s' updated successfully!"), problem.ID)
 		},
 	}
+	cmd.Flags().StringVar(&editorial, "editorial", "", "set the editorial URL (\"none\" removes it), without questions")
+	cmd.Flags().StringArrayVar(&refs, "ref", nil, "add or replace a labeled reference, as label=url (repeatable)")
+	cmd.Flags().StringArrayVar(&removedRefs, "remove-ref", nil, "remove the reference with this label (repeatable)")
+	return cmd
 }
 
 // ... (tagsCmd, statsCmd, importCmd, exportCmd, wikiCmd functions remain the same) ...
 func tagsCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "tags",
 		Short: "List all tags with problem counts",
 		Run: func(cmd *cobra.Command, args []string) {
-			problems, err := loadProblems()
+			count, err := store.Count()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
-			if len(problems) == 0 {
-				color.Yellow("📝 No problems found!")
+			if count == 0 && !jsonOutput() {
+				color.Yellow(tr("📝 No problems found!"))
 				return
 			}
 
-			tagCounts := make(map[string]int)
-			for _, p := range problems {
-				for _, tag := range p.Tags {
-					tagCounts[tag]++
-				}
+			tagCounts, err := store.TagCounts()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			if jsonOutput() {
+				printJSON(tagCounts)
+				return
 			}
 
-			fmt.Println()
+			fmt.Fprintln(stderr)
 			color.HiCyan("═══════════════════════════════════")
-			color.HiCyan("        🏷️  TAG ANALYTICS 🏷️         ")
+			color.HiCyan(tr("        🏷️  TAG ANALYTICS 🏷️         "))
 			color.HiCyan("═══════════════════════════════════")
-			fmt.Println()
+			fmt.Fprintln(stderr)
 
 			if len(tagCounts) == 0 {
-				color.Yellow("🏷️  No tags found")
+				color.Yellow(tr("🏷️  No tags found"))
 				return
 			}
 
 			for tag, count := range tagCounts {
-				fmt.Printf("%-20s %s\n", color.HiYellowString("🏷️  "+tag), color.GreenString("(%d problems)", count))
+				fmt.Fprintf(stdout, "%-20s %s\n", color.HiYellowString("🏷️  "+tag), color.GreenString("(%d problems)", count))
 			}
-			fmt.Println()
+			fmt.Fprintln(stderr)
 		},
 	}
+	cmd.AddCommand(tagsCanonicalizeCmd())
+	return cmd
 }
 
 func statsCmd() *cobra.Command {
-	return &cobra.Command{
+	var compare, export string
+	cmd := &cobra.Command{
 		Use:   "stats",
 		Short: "Show detailed statistics",
+		Example: `  saitama stats                        # Overall statistics
+  saitama stats --compare last-month   # Last 30 days vs the 30 days before
+  saitama stats --export prometheus    # Metrics for dashboards (also served at /metrics by 'saitama serve')
+  saitama stats tags --trend           # Solves per tag, month over month`,
 		Run: func(cmd *cobra.Command, args []string) {
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems: %v", err)
+				printError(tr("❌ Error loading problems: %v"), err)
 				return
 			}
+			if export == "" && jsonOutput() {
+				export = "json"
+			}
+			switch strings.ToLower(export) {
+			case "":
+			case "prometheus":
+				writePrometheus(stdout, computeMetrics(problems, time.Now()))
+				return
+			case "json":
+				printJSON(computeMetrics(problems, time.Now()))
+				return
+			default:
+				printError(tr("❌ Unknown export format '%s' (use prometheus or json)"), export)
+				return
+			}
+
 			if len(problems) == 0 {
-				color.Yellow("📝 No problems found!")
+				color.Yellow(tr("📝 No problems found!"))
+				return
+			}
+
+			if compare != "" {
+				printStatsComparison(problems, compare)
 				return
 			}
 
@@ -486,94 +1142,657 @@ func statsCmd() *cobra.Command {
 				}
 			}
 
-			fmt.Println()
+			fmt.Fprintln(stderr)
 			color.HiMagenta("═══════════════════════════════════════")
-			color.HiMagenta("         📊 SAITAMA STATISTICS 📊        ")
+			color.HiMagenta(tr("         📊 SAITAMA STATISTICS 📊        "))
 			color.HiMagenta("═══════════════════════════════════════")
-			fmt.Println()
+			fmt.Fprintln(stderr)
 
-			color.HiYellow("🗂️  Total Problems: %d", len(problems))
-			color.HiYellow("🏷️  Unique Tags: %d", len(tagCounts))
+			color.HiYellow(tr("🗂️  Total Problems: %d"), len(problems))
+			color.HiYellow(tr("🏷️  Unique Tags: %d"), len(tagCounts))
 			if len(problems) > 0 {
-				color.HiYellow("📈 Average Tags per Problem: %.1f", float64(totalTags)/float64(len(problems)))
+				color.HiYellow(tr("📈 Average Tags per Problem: %.1f"), float64(totalTags)/float64(len(problems)))
 			}
-			fmt.Println()
+
+			solved, totalSolves := 0, 0
+			for _, p := range problems {
+				if isSolved(p) {
+					solved++
+				}
+				totalSolves += solveCount(p)
+			}
+			color.HiYellow(tr("✅ Solved Problems: %d (%d solves total)"), solved, totalSolves)
+			color.HiYellow(tr("🔥 Current Streak: %d days"), currentStreak(solvesPerDay(activityLog(problems)), time.Now()))
+			if hints := computeHintStats(problems); hints.Used > 0 {
+				color.HiYellow(tr("💡 Hints: %d used on %d attempts (%d solves without hints, %d with)"),
+					hints.Used, hints.Attempts, hints.CleanSolves, hints.AssistedSolves)
+			}
+			if resolves, improved := complexityImprovements(problems); resolves > 0 {
+				color.HiYellow(tr("⏱️  Complexity: beat your previous best on %d of %d re-solve(s)"), improved, resolves)
+			}
+			printChallengeStats(time.Now())
+			printRating(estimateRating(problems), time.Now())
+			fmt.Fprintln(stderr)
+
+			if rated, diverging := computeFeltDivergence(problems); rated > 0 {
+				harder := 0
+				for _, d := range diverging {
+					if d.Delta > 0 {
+						harder++
+					}
+				}
+				color.HiCyan(tr("🎭 Perceived vs Labeled Difficulty:"))
+				color.White(tr("   %d rated: %d as labeled, %d felt harder, %d felt easier"),
+					rated, rated-len(diverging), harder, len(diverging)-harder)
+				for i, d := range diverging {
+					if i == 5 {
+						color.HiBlack(tr("   ... and %d more"), len(diverging)-i)
+						break
+					}
+					line := fmt.Sprintf("%s - %s: labeled %s, felt %s", d.Problem.ID, d.Problem.Name, strings.ToLower(d.Problem.Difficulty), d.Felt)
+					if d.Delta > 0 {
+						color.Red("   🔺 %s", line)
+					} else {
+						color.Green("   🔻 %s", line)
+					}
+				}
+				if harder > 0 {
+					color.Yellow(tr("💡 Revisit what felt hard with: saitama pick --felt-hard"))
+				}
+				fmt.Fprintln(stderr)
+			}
+
+			printVerdictStats(computeVerdictStats(problems))
+
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default mastery weights)"), err)
+			}
+			mastery := computeTagMastery(problems, cfg.Mastery, time.Now())
+			if len(mastery) == 0 {
+				return
+			}
+
+			color.HiCyan(tr("🧠 Tag Mastery:"))
+			for _, m := range mastery {
+				line := fmt.Sprintf("%-20s %5.1f  %-20s (%d problems, %d solves)", m.Tag, m.Score, strings.Repeat("█", int(m.Score/5)), m.Problems, m.Solves)
+				if m.Score < cfg.Mastery.WeakThreshold {
+					color.Red("   ⚠️  %s", line)
+				} else {
+					color.Green("   ✅ %s", line)
+				}
+			}
+
+			weak := weakTags(mastery, cfg.Mastery)
+			if len(weak) > 0 {
+				fmt.Fprintln(stderr)
+				color.Yellow(tr("💡 %d weak tags. Train them with: saitama pick --focus-weak"), len(weak))
+			}
+			fmt.Fprintln(stderr)
 		},
 	}
+	cmd.Flags().StringVar(&compare, "compare", "", "compare two periods: last-week, last-month, last-quarter, last-year or Nd")
+	cmd.Flags().StringVar(&export, "export", "", "print metrics for dashboards instead: prometheus or json")
+	cmd.AddCommand(statsTagsCmd())
+	return cmd
+}
+
+// printStatsComparison prints the activity of the current period next to the previous one.
+func printStatsComparison(problems []Problem, expr string) {
+	previous, current, err := parseComparePeriods(expr, time.Now())
+	if err != nil {
+		printError("❌ %v", err)
+		return
+	}
+
+	events := activityLog(problems)
+	before := summarizePeriod(problems, events, previous)
+	after := summarizePeriod(problems, events, current)
+
+	fmt.Fprintln(stderr)
+	color.HiMagenta("═══════════════════════════════════════════════════════")
+	color.HiMagenta(tr("              📊 PROGRESS COMPARISON 📊                "))
+	color.HiMagenta("═══════════════════════════════════════════════════════")
+	fmt.Fprintln(stderr)
+	color.HiBlack(tr("Previous: %s → %s"), previous.Start.Format("2006-01-02"), previous.End.AddDate(0, 0, -1).Format("2006-01-02"))
+	color.HiBlack(tr("Current:  %s → %s"), current.Start.Format("2006-01-02"), current.End.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Fprintln(stderr)
+
+	fmt.Fprintf(stderr, "%-22s %18s %18s %10s\n", "", previous.Label, current.Label, "change")
+	rows := []struct {
+		label         string
+		before, after int
+	}{
+		{"✅ Solves", before.Solves, after.Solves},
+		{"🥊 Attempts", before.Attempts, after.Attempts},
+		{"➕ New problems", before.NewProblems, after.NewProblems},
+		{"🏷️  Tags covered", before.TagsCovered, after.TagsCovered},
+		{"🔥 Longest streak", before.Streak, after.Streak},
+	}
+	for _, r := range rows {
+		delta := r.after - r.before
+		deltaStr := color.HiBlackString("%10s", "±0")
+		if delta > 0 {
+			deltaStr = color.GreenString("%10s", fmt.Sprintf("▲ +%d", delta))
+		} else if delta < 0 {
+			deltaStr = color.RedString("%10s", fmt.Sprintf("▼ %d", delta))
+		}
+		fmt.Fprintf(stderr, "%-22s %18d %18d %s\n", r.label, r.before, r.after, deltaStr)
+	}
+	fmt.Fprintln(stderr)
+
+	switch {
+	case after.Solves > before.Solves:
+		color.HiGreen(tr("💪 You're getting stronger! Keep it up!"))
+	case after.Solves < before.Solves:
+		color.Yellow(tr("😤 Fewer solves than last period. Time to train harder!"))
+	default:
+		color.Cyan(tr("⚖️  Steady as a hero. Push a little further!"))
+	}
+	fmt.Fprintln(stderr)
 }
 
 func importCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "import <file>",
-		Short: "Import problems from a JSON backup file",
-		Args:  cobra.ExactArgs(1),
+	var format, reportFile, onConflict string
+	var urls, strict, remap, submissions, createMissing, overwrite bool
+	var workers int
+	cmd := &cobra.Command{
+		Use:         "import <file|sheet-id>",
+		Annotations: mutates(),
+		Short:       "Import problems from a JSON, NDJSON or CSV file (optionally gzipped), a bundle, browser bookmarks, a list of URLs or a Google Sheet",
+		Example: `  saitama import backup.json
+  saitama import study.zip        # Bundle from 'saitama export study.zip', with notes and attached files
+  saitama import bookmarks.html   # Links to LeetCode, Codeforces, ... exported from a browser
+  saitama import codeforces.ndjson.gz --error-report skipped.ndjson
+  saitama import --urls links.txt  # One problem URL per line, metadata fetched from the platforms
+  saitama import --format gsheet 1AbC...xyz  # Reads the tab configured in gsheet.sheet
+  saitama import backup.json --on-conflict merge
+  saitama import fixed.json --overwrite  # Replace problems by ID, after a backup and a typed confirmation
+  saitama import --strict generated.json  # Check the file against 'saitama schema' first
+  saitama import tracker.csv      # Asks which column is the ID, name, tags, ... unless the headers say so
+  saitama import tracker.csv --remap  # Map the columns again instead of reusing the saved mapping
+  saitama import --submissions cf_submissions.json  # Codeforces user.status export as attempt history
+  saitama import --submissions atcoder.json --create-missing`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			filePath := args[0]
-
-			confirm := false
-			prompt := &survey.Confirm{Message: "This will merge imported problems with your current list. Continue?"}
-			if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
-				color.Yellow("Import cancelled.")
+			if createMissing && !submissions {
+				printError(tr("❌ --create-missing only applies to --submissions"))
+				return
+			}
+			if overwrite && (submissions || cmd.Flags().Changed("on-conflict")) {
+				printError(tr("❌ --overwrite replaces existing problems as a whole; it doesn't go with --submissions or --on-conflict"))
+				return
+			}
+			if submissions {
+				importSubmissions(filePath, createMissing)
+				return
+			}
+			if !slices.Contains(conflictPolicies, onConflict) {
+				printError(tr("❌ Unknown conflict policy '%s' (use %s)"), onConflict, strings.Join(conflictPolicies, ", "))
 				return
 			}
 
-			importedProblems, err := importProblems(filePath)
-			if err != nil {
-				color.Red("❌ Error importing problems: %v", err)
+			if urls {
+				format = "urls"
+			}
+			if format == "" {
+				format = "json"
+				switch {
+				case isBundleFile(filePath):
+					format = "bundle"
+				case isNDJSONFile(filePath):
+					format = "ndjson"
+				case isBookmarksFile(filePath):
+					format = "bookmarks"
+				case isCSVFile(filePath):
+					format = "csv"
+				}
+			}
+
+			if strict {
+				var ndjson bool
+				switch strings.ToLower(format) {
+				case "json":
+				case "ndjson", "jsonl":
+					ndjson = true
+				default:
+					printError(tr("❌ --strict only applies to JSON and NDJSON files, not %s"), format)
+					return
+				}
+				violations, err := validateImportFile(filePath, ndjson)
+				if err != nil {
+					printError(tr("❌ Error importing problems: %v"), err)
+					return
+				}
+				if len(violations) > 0 {
+					printError(tr("❌ %s does not match the schema, %d error(s):"), filePath, len(violations))
+					for i, v := range violations {
+						if i == 10 {
+							color.HiBlack(tr("   ... and %d more"), len(violations)-i)
+							break
+						}
+						color.Yellow("   %v", v)
+					}
+					if reportFile != "" {
+						report := make([]importError, len(violations))
+						for i, v := range violations {
+							report[i] = importError{Line: v.Line, Column: v.Column, Field: v.Path, Error: v.Message}
+						}
+						if err := writeImportReport(reportFile, report); err != nil {
+							printError("❌ %v", err)
+						} else {
+							color.Cyan(tr("📄 Error report written to %s"), reportFile)
+						}
+					}
+					return
+				}
+				color.Green(tr("✅ %s matches the schema."), filePath)
+			}
+
+			var importedProblems []Problem
+			var bundle *problemBundle
+			var err error
+			switch strings.ToLower(format) {
+			case "bundle", "zip":
+				if bundle, err = openBundle(filePath); err != nil {
+					printError(tr("❌ Error importing problems: %v"), err)
+					return
+				}
+				defer bundle.Close()
+				importedProblems = bundle.Problems
+				color.Cyan(tr("📦 Bundle of %d problem(s) with %d note(s) and %d attached file(s), exported %s"),
+					len(bundle.Problems), bundle.Manifest.Notes, bundle.Manifest.Attachments, bundle.Manifest.Exported.Format("2006-01-02"))
+			case "ndjson", "jsonl":
+				skipped, err := streamNDJSON(filePath, func(p Problem) {
+					importedProblems = append(importedProblems, p)
+				})
+				if err != nil {
+					printError(tr("❌ Error importing problems: %v"), err)
+					return
+				}
+				if len(skipped) > 0 {
+					color.Yellow(tr("⚠️  Skipped %d invalid record(s):"), len(skipped))
+					for i, e := range skipped {
+						if i == 10 {
+							color.HiBlack(tr("   ... and %d more"), len(skipped)-i)
+							break
+						}
+						color.Yellow(tr("   line %d: %s"), e.Line, e.Error)
+					}
+					if reportFile != "" {
+						if err := writeImportReport(reportFile, skipped); err != nil {
+							printError("❌ %v", err)
+						} else {
+							color.Cyan(tr("📄 Error report written to %s"), reportFile)
+						}
+					}
+				}
+				if len(importedProblems) == 0 {
+					color.Yellow(tr("📝 No valid problems to import."))
+					return
+				}
+			case "json":
+				if importedProblems, err = importProblems(filePath); err != nil {
+					printError(tr("❌ Error importing problems: %v"), err)
+					return
+				}
+			case "csv", "tsv":
+				if importedProblems, err = importCSV(filePath, remap); err != nil {
+					printError(tr("❌ Error importing problems: %v"), err)
+					return
+				}
+			case "gsheet":
+				cfg, cfgErr := loadConfig()
+				if cfgErr != nil {
+					printError(tr("❌ Error loading config: %v"), cfgErr)
+					return
+				}
+				color.Cyan(tr("📥 Reading problems from Google Sheet %s..."), filePath)
+				if importedProblems, err = importFromSheet(filePath, cfg.GSheet); err != nil {
+					printError(tr("❌ Error importing from Google Sheets: %v"), err)
+					return
+				}
+			case "bookmarks":
+				cfg, cfgErr := loadConfig()
+				if cfgErr != nil {
+					color.Yellow(tr("⚠️  %v (using built-in platforms)"), cfgErr)
+				}
+				current, loadErr := loadProblems()
+				if loadErr != nil {
+					printError(tr("❌ Error loading current problems: %v"), loadErr)
+					return
+				}
+				var skipped int
+				if importedProblems, skipped, err = importBookmarks(filePath, platformRegistry(cfg), current); err != nil {
+					printError(tr("❌ Error importing bookmarks: %v"), err)
+					return
+				}
+				color.Cyan(tr("🔖 Found %d problem link(s), ignored %d other bookmark(s)"), len(importedProblems), skipped)
+				if len(importedProblems) == 0 {
+					return
+				}
+			case "urls":
+				cfg, cfgErr := loadConfig()
+				if cfgErr != nil {
+					color.Yellow(tr("⚠️  %v (using built-in platforms)"), cfgErr)
+				}
+				current, loadErr := loadProblems()
+				if loadErr != nil {
+					printError(tr("❌ Error loading current problems: %v"), loadErr)
+					return
+				}
+				var failed []urlImport
+				var known int
+				if importedProblems, failed, known, err = importURLs(filePath, platformRegistry(cfg), current, workers); err != nil {
+					printError(tr("❌ Error importing URLs: %v"), err)
+					return
+				}
+				color.Cyan(tr("🔗 %d problem(s) ready to import, %d already in your collection, %d failed"), len(importedProblems), known, len(failed))
+				for i, f := range failed {
+					if i == 10 {
+						color.HiBlack(tr("   ... and %d more"), len(failed)-i)
+						break
+					}
+					color.Yellow(tr("   line %d: %s: %v"), f.Line, f.Problem.URL, f.Err)
+				}
+				if reportFile != "" && len(failed) > 0 {
+					report := make([]importError, len(failed))
+					for i, f := range failed {
+						report[i] = importError{Line: f.Line, URL: f.Problem.URL, Error: f.Err.Error()}
+					}
+					if err := writeImportReport(reportFile, report); err != nil {
+						printError("❌ %v", err)
+					} else {
+						color.Cyan(tr("📄 Error report written to %s"), reportFile)
+					}
+				}
+				if len(importedProblems) == 0 {
+					return
+				}
+			default:
+				printError(tr("❌ Unknown import format '%s' (use json, ndjson, csv, bundle, bookmarks, urls or gsheet)"), format)
 				return
 			}
 
-			currentProblems, err := loadProblems()
+			cfg, err := loadConfig()
 			if err != nil {
-				color.Red("❌ Error loading current problems: %v", err)
-				return
+				color.Yellow(tr("⚠️  %v (using built-in platforms)"), err)
+			}
+			platforms := platformRegistry(cfg)
+			valid := importedProblems[:0]
+			var rejected []string
+			for _, p := range importedProblems {
+				if err := resolvePlatform(platforms, &p); err != nil {
+					rejected = append(rejected, fmt.Sprintf("%s: %v", p.ID, err))
+					continue
+				}
+				valid = append(valid, p)
+			}
+			importedProblems = valid
+			if len(rejected) > 0 {
+				color.Yellow(tr("⚠️  Skipped %d problem(s) with inconsistent platform data:"), len(rejected))
+				for i, r := range rejected {
+					if i == 10 {
+						color.HiBlack(tr("   ... and %d more"), len(rejected)-i)
+						break
+					}
+					color.Yellow("   %s", r)
+				}
 			}
 
-			existingIDs := make(map[string]bool)
-			for _, p := range currentProblems {
-				existingIDs[p.ID] = true
+			confirmImport := func() bool {
+				confirm := false
+				prompt := &survey.Confirm{Message: "This will merge imported problems with your current list. Continue?"}
+				return survey.AskOne(prompt, &confirm) == nil && confirm
+			}
+			// --overwrite asks once it knows what would be replaced.
+			if !overwrite && !confirmImport() {
+				color.Yellow(tr("Import cancelled."))
+				return
 			}
 
-			var mergedProblems []Problem
-			mergedCount := 0
-			for _, p := range importedProblems {
-				if !existingIDs[p.ID] {
-					mergedProblems = append(mergedProblems, p)
+			// The duplicates and conflicts are merged in a transaction: cancelling halfway
+			// through the questions leaves the database as it was.
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading current problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+			before := tx.Problems()
+
+			mergedCount, duplicateCount, updatedCount := 0, 0, 0
+			resolver := &conflictResolver{policy: onConflict}
+			finalIDs := make(map[string]string) // imported ID -> ID in the collection, for the attachments of bundles
+			err = tx.Update(func(finalProblems []Problem) ([]Problem, error) {
+				existingIDs := make(map[string]bool)
+				for _, p := range finalProblems {
+					existingIDs[p.ID] = true
+				}
+				for _, p := range importedProblems {
+					if existingIDs[p.ID] {
+						finalIDs[p.ID] = p.ID
+						_, index := findProblemByID(finalProblems, p.ID)
+						if overwrite {
+							if problemFingerprint(finalProblems[index]) != problemFingerprint(p) {
+								finalProblems[index] = p
+								updatedCount++
+							}
+							continue
+						}
+						resolved := finalProblems[index]
+						changed, err := resolver.resolve(&resolved, p)
+						if err != nil {
+							return nil, err
+						}
+						if !changed {
+							continue
+						}
+						if err := resolvePlatform(platforms, &resolved); err != nil {
+							color.Yellow(tr("⚠️  Kept %s as it was: %v"), p.ID, err)
+							continue
+						}
+						finalProblems[index] = resolved
+						updatedCount++
+						continue
+					}
+					if existing, index := findProblemByURL(finalProblems, p.URL); index != -1 {
+						merge, err := confirmMerge(p, *existing)
+						if err != nil {
+							return nil, err
+						}
+						if merge {
+							mergeProblemInto(&finalProblems[index], p)
+							finalIDs[p.ID] = finalProblems[index].ID
+							duplicateCount++
+							continue
+						}
+					}
+					finalIDs[p.ID] = p.ID
+					finalProblems = append(finalProblems, p)
+					existingIDs[p.ID] = true
 					mergedCount++
 				}
+				return finalProblems, nil
+			})
+			if err != nil {
+				color.Yellow(tr("Import cancelled."))
+				return
 			}
 
-			finalProblems := append(currentProblems, mergedProblems...)
+			preImport := ""
+			if overwrite {
+				changed := diffProblems(before, tx.Problems()).Changed
+				if len(changed) == 0 {
+					if !confirmImport() {
+						color.Yellow(tr("Import cancelled."))
+						return
+					}
+				} else {
+					printOverwriteSummary(changed)
+					if !confirmOverwrite(len(changed)) {
+						color.Yellow(tr("Import cancelled."))
+						return
+					}
+					if preImport, err = writePreImportBackup(); err != nil {
+						printError("❌ %v", err)
+						return
+					}
+				}
+			}
 
-			if err := saveProblems(finalProblems); err != nil {
-				color.Red("❌ Error saving merged list: %v", err)
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving merged list: %v"), err)
 				return
 			}
-			color.Green("✅ Successfully imported %d new problems from %s!", mergedCount, filePath)
+			color.Green(tr("✅ Successfully imported %d new problems from %s!"), mergedCount, filePath)
+			if duplicateCount > 0 {
+				color.Cyan(tr("🔀 Merged %d duplicate(s) into existing problems"), duplicateCount)
+			}
+			if updatedCount > 0 {
+				color.Cyan(tr("✏️  Updated %d existing problem(s) from the import"), updatedCount)
+			}
+			if preImport != "" {
+				color.Cyan(tr("💾 Your problems before the import were saved to %s"), preImport)
+				color.HiBlack(tr("   Undo the import with: saitama backup restore %s"), preImport)
+			}
+			if bundle != nil {
+				restored, kept, err := bundle.restoreAttachments(finalIDs, overwrite || onConflict == conflictTheirs)
+				if err != nil {
+					printError(tr("❌ Error restoring attached files: %v"), err)
+				}
+				if restored > 0 {
+					color.Cyan(tr("🗂️  Restored %d attached file(s)"), restored)
+				}
+				if kept > 0 {
+					color.Yellow(tr("⚠️  Kept your version of %d attached file(s) that differ in the bundle"), kept)
+				}
+			}
 		},
 	}
+	cmd.Flags().StringVarP(&format, "format", "f", "", "import format: json, ndjson, csv, bundle, bookmarks, urls or gsheet (default: from the file extension)")
+	cmd.Flags().BoolVar(&urls, "urls", false, "the file is a plain text list of problem URLs, one per line (same as --format urls)")
+	cmd.Flags().IntVarP(&workers, "workers", "w", 8, "number of concurrent metadata lookups for --urls")
+	cmd.Flags().StringVar(&onConflict, "on-conflict", conflictAsk, "when an imported problem has the ID of an existing one with different fields: ask, mine, theirs or merge")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace existing problems by ID with the imported version, after a summary, a typed confirmation and a backup")
+	cmd.Flags().BoolVar(&strict, "strict", false, "check a JSON or NDJSON file against the schema first, and import nothing if it doesn't match")
+	cmd.Flags().BoolVar(&remap, "remap", false, "map the columns of a CSV file again instead of reusing the mapping saved for its headers")
+	cmd.Flags().BoolVar(&submissions, "submissions", false, "the file is a judge's submission export (Codeforces user.status or AtCoder Problems JSON), recorded as attempts on your problems")
+	cmd.Flags().BoolVar(&createMissing, "create-missing", false, "with --submissions, add the problems you don't track yet instead of leaving them out")
+	cmd.Flags().StringVar(&reportFile, "error-report", "", "write the skipped NDJSON records or URLs and their errors, or the --strict errors, to this file")
+	return cmd
 }
 
 func exportCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "export <file>",
-		Short: "Export all problems to a JSON file",
-		Args:  cobra.ExactArgs(1),
+	var format string
+	var pdfOpts PDFOptions
+	var notTags []string
+	cmd := &cobra.Command{
+		Use:   "export <file|dir|sheet-id>",
+		Short: "Export problems to JSON, a bundle, a Markdown vault, a Google Sheet, a calendar or a PDF",
+		Long: "Exports to JSON by default. A bundle (--format bundle, or a .zip file) is a study pack to share: the problems " +
+			"as in JSON, their notes as Markdown and their attached files ('saitama attach'), all restored by 'saitama import'.",
+		Example: `  saitama export backup.json                       # JSON backup
+  saitama export study.zip                         # Bundle with notes and attached files
+  saitama export --format markdown ~/vault/saitama  # One note per problem (Obsidian)
+  saitama export --format gsheet 1AbC...xyz         # Shared study spreadsheet
+  saitama export --format ics reviews.ics           # Review schedule for your calendar
+  saitama export --format pdf sheet.pdf --qr        # Printable practice sheet
+  saitama export no-math.json --not-tag math        # Everything but the math problems`,
+		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			filePath := args[0]
 			problems, err := loadProblems()
 			if err != nil {
-				color.Red("❌ Error loading problems for export: %v", err)
+				printError(tr("❌ Error loading problems for export: %v"), err)
 				return
 			}
+			if len(notTags) > 0 {
+				problems, _ = ProblemQuery{NotTags: notTags, Archived: "include"}.Apply(problems)
+			}
+			if !cmd.Flags().Changed("format") && isBundleFile(filePath) {
+				format = "bundle"
+			}
 
-			if err := exportProblems(problems, filePath); err != nil {
-				color.Red("❌ Error exporting problems: %v", err)
-				return
+			switch strings.ToLower(format) {
+			case "json":
+				if err := exportProblems(problems, filePath); err != nil {
+					printError(tr("❌ Error exporting problems: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Successfully exported %d problems to %s!"), len(problems), filePath)
+				attached := 0
+				for _, p := range problems {
+					names, _ := listAttachments(p.ID)
+					attached += len(names)
+				}
+				if attached > 0 {
+					color.HiBlack(tr("   %d attached file(s) are not in JSON exports. Include them with a bundle: saitama export study.zip"), attached)
+				}
+			case "bundle", "zip":
+				m, err := exportBundle(problems, filePath)
+				if err != nil {
+					printError(tr("❌ Error exporting bundle: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Exported %d problems to %s (%d note(s), %d attached file(s))"), m.Problems, filePath, m.Notes, m.Attachments)
+			case "markdown", "md", "obsidian":
+				result, err := exportVault(problems, filePath)
+				if err != nil {
+					printError(tr("❌ Error exporting vault: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Exported %d problems to %s (%d created, %d updated, %d unchanged)"),
+					len(problems), filePath, result.Created, result.Updated, result.Unchanged)
+			case "gsheet":
+				cfg, err := loadConfig()
+				if err != nil {
+					printError(tr("❌ Error loading config: %v"), err)
+					return
+				}
+				if err := exportToSheet(problems, filePath, cfg.GSheet); err != nil {
+					printError(tr("❌ Error exporting to Google Sheets: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Exported %d problems to Google Sheet %s (tab '%s')"), len(problems), filePath, cfg.GSheet.sheetName())
+			case "ics", "ical":
+				cfg, err := loadConfig()
+				if err != nil {
+					printError(tr("❌ Error loading config: %v"), err)
+					return
+				}
+				f, err := os.Create(filePath)
+				if err != nil {
+					printError(tr("❌ Error creating calendar file: %v"), err)
+					return
+				}
+				err = writeCalendar(f, problems, cfg.Reminders, time.Now())
+				if closeErr := f.Close(); err == nil {
+					err = closeErr
+				}
+				if err != nil {
+					printError(tr("❌ Error writing calendar file: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Exported review schedule to %s"), filePath)
+				color.HiBlack(tr("   Import it into Google Calendar, or run 'saitama serve --feed' for a live subscription URL."))
+			case "pdf":
+				if err := exportPDF(problems, filePath, pdfOpts); err != nil {
+					printError(tr("❌ Error exporting PDF: %v"), err)
+					return
+				}
+				color.Green(tr("✅ Exported a practice sheet with %d problems to %s"), len(problems), filePath)
+			default:
+				printError(tr("❌ Unknown export format '%s' (use json, bundle, markdown, gsheet, ics or pdf)"), format)
 			}
-			color.Green("✅ Successfully exported %d problems to %s!", len(problems), filePath)
 		},
 	}
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "export format: json, bundle, markdown, gsheet, ics or pdf (bundle for a .zip file)")
+	cmd.Flags().StringSliceVar(&notTags, "not-tag", nil, "leave out problems with one of these tags")
+	cmd.Flags().IntVar(&pdfOpts.PerPage, "per-page", 3, "problems per page (pdf)")
+	cmd.Flags().BoolVar(&pdfOpts.QR, "qr", false, "print QR codes linking to problem URLs (pdf)")
+	return cmd
 }
 
 func wikiCmd() *cobra.Command {
@@ -582,9 +1801,8 @@ func wikiCmd() *cobra.Command {
 		Short: "Show all available commands",
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := cmd.Root().Help(); err != nil {
-				color.Red("❌ Could not display help information.")
+				printError(tr("❌ Could not display help information."))
 			}
 		},
 	}
 }
-
@@ -0,0 +1,109 @@
+// mastery.go
+package main
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TagMastery is the computed mastery score (0-100) for a single tag.
+type TagMastery struct {
+	Tag      string
+	Score    float64
+	Problems int
+	Solves   int
+}
+
+// difficultyValue maps a difficulty label to a 0-1 value used for scoring.
+func difficultyValue(difficulty string) float64 {
+	switch strings.ToLower(difficulty) {
+	case "easy":
+		return 1.0 / 3
+	case "medium":
+		return 2.0 / 3
+	case "hard":
+		return 1.0
+	}
+	return 0.5
+}
+
+// solveCount returns the number of recorded solves, counting legacy records
+// that only have LastSolved set as a single solve.
+func solveCount(p Problem) int {
+	if p.SolveCount == 0 && !p.LastSolved.IsZero() {
+		return 1
+	}
+	return p.SolveCount
+}
+
+// computeTagMastery scores every tag from solve volume, recency and difficulty of the
+// solved problems carrying it. The result is sorted from weakest to strongest.
+func computeTagMastery(problems []Problem, cfg MasteryConfig, now time.Time) []TagMastery {
+	type accumulator struct {
+		problems   int
+		solves     int
+		solved     int
+		recency    float64
+		difficulty float64
+	}
+	acc := make(map[string]*accumulator)
+
+	for _, p := range problems {
+		solves := solveCount(p)
+		for _, tag := range p.Tags {
+			a, ok := acc[tag]
+			if !ok {
+				a = &accumulator{}
+				acc[tag] = a
+			}
+			a.problems++
+			if solves == 0 {
+				continue
+			}
+			a.solves += solves
+			a.solved++
+			a.difficulty += difficultyValue(p.Difficulty)
+			if !p.LastSolved.IsZero() && cfg.HalfLifeDays > 0 {
+				days := now.Sub(p.LastSolved).Hours() / 24
+				a.recency += math.Pow(0.5, math.Max(days, 0)/cfg.HalfLifeDays)
+			}
+		}
+	}
+
+	totalWeight := cfg.SolvesWeight + cfg.RecencyWeight + cfg.DifficultyWeight
+	var result []TagMastery
+	for tag, a := range acc {
+		m := TagMastery{Tag: tag, Problems: a.problems, Solves: a.solves}
+		if a.solved > 0 && totalWeight > 0 {
+			volume := 1.0
+			if cfg.TargetSolves > 0 {
+				volume = math.Min(1, float64(a.solves)/float64(cfg.TargetSolves))
+			}
+			recency := a.recency / float64(a.solved)
+			difficulty := a.difficulty / float64(a.solved)
+			m.Score = 100 * (cfg.SolvesWeight*volume + cfg.RecencyWeight*recency + cfg.DifficultyWeight*difficulty) / totalWeight
+		}
+		result = append(result, m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score < result[j].Score
+		}
+		return result[i].Tag < result[j].Tag
+	})
+	return result
+}
+
+// weakTags returns the tags whose mastery score is below the configured threshold.
+func weakTags(mastery []TagMastery, cfg MasteryConfig) map[string]bool {
+	weak := make(map[string]bool)
+	for _, m := range mastery {
+		if m.Score < cfg.WeakThreshold {
+			weak[m.Tag] = true
+		}
+	}
+	return weak
+}
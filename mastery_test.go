@@ -0,0 +1,72 @@
+// mastery_test.go
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestComputeTagMastery(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	cfg := MasteryConfig{SolvesWeight: 1, RecencyWeight: 1, DifficultyWeight: 1, TargetSolves: 4, HalfLifeDays: 10}
+	problems := []Problem{
+		{ID: "A", Tags: []string{"graphs", "trees"}, Difficulty: "hard", SolveCount: 4, LastSolved: now},
+		{ID: "B", Tags: []string{"trees"}},
+		{ID: "C", Tags: []string{"dp"}, Difficulty: "medium", SolveCount: 2, LastSolved: daysAgo(10)},
+		{ID: "D", Tags: []string{"greedy"}, Difficulty: "easy", LastSolved: daysAgo(20)}, // legacy: one solve
+		{ID: "E", Tags: []string{"math"}, Difficulty: "hard"},
+		{ID: "F", Tags: []string{"bits"}, Difficulty: "hard"},
+	}
+	want := []struct {
+		tag      string
+		score    float64
+		problems int
+		solves   int
+	}{
+		// Weakest first, ties by name.
+		{"bits", 0, 1, 0},
+		{"math", 0, 1, 0},
+		{"greedy", 100 * (0.25 + 0.25 + 1.0/3) / 3, 1, 1},
+		{"dp", 100 * (0.5 + 0.5 + 2.0/3) / 3, 1, 2},
+		{"graphs", 100, 1, 4},
+		{"trees", 100, 2, 4}, // unsolved problems count in Problems only
+	}
+
+	got := computeTagMastery(problems, cfg, now)
+	if len(got) != len(want) {
+		t.Fatalf("got %d tags, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		m := got[i]
+		if m.Tag != w.tag || math.Abs(m.Score-w.score) > 1e-9 || m.Problems != w.problems || m.Solves != w.solves {
+			t.Errorf("#%d = %+v, want %s scoring %.2f over %d problem(s) and %d solve(s)", i, m, w.tag, w.score, w.problems, w.solves)
+		}
+	}
+}
+
+func TestComputeTagMasteryWeights(t *testing.T) {
+	now := time.Date(2026, 3, 18, 12, 0, 0, 0, time.UTC)
+	problems := []Problem{{ID: "A", Tags: []string{"dp"}, Difficulty: "easy", SolveCount: 1, LastSolved: now.AddDate(0, 0, -30)}}
+	tests := []struct {
+		name string
+		cfg  MasteryConfig
+		want float64
+	}{
+		{"volume only", MasteryConfig{SolvesWeight: 1, TargetSolves: 2}, 50},
+		{"no target gives full volume", MasteryConfig{SolvesWeight: 1}, 100},
+		{"recency only", MasteryConfig{RecencyWeight: 1, HalfLifeDays: 15}, 25},
+		{"no half-life gives no recency", MasteryConfig{RecencyWeight: 1}, 0},
+		{"difficulty only", MasteryConfig{DifficultyWeight: 2}, 100.0 / 3},
+		{"no weights", MasteryConfig{TargetSolves: 2, HalfLifeDays: 15}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeTagMastery(problems, tt.cfg, now)
+			if len(got) != 1 || math.Abs(got[0].Score-tt.want) > 1e-9 {
+				t.Errorf("computeTagMastery = %+v, want a score of %.2f", got, tt.want)
+			}
+		})
+	}
+}
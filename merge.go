@@ -0,0 +1,267 @@
+// merge.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// mergeFlagFields are the settings of a problem compared on top of conflictFields when
+// merging databases. They only follow the most recently updated copy.
+var mergeFlagFields = []conflictField{
+	{Name: "starred", get: func(p Problem) string { return flagValue(p.Starred) }, take: func(d *Problem, s Problem) { d.Starred = s.Starred }},
+	{Name: "abandoned", get: func(p Problem) string { return flagValue(p.Abandoned) }, take: func(d *Problem, s Problem) { d.Abandoned = s.Abandoned }},
+	{Name: "archived", get: func(p Problem) string { return flagValue(p.Archived) }, take: func(d *Problem, s Problem) { d.Archived = s.Archived }},
+	{Name: "personal difficulty", get: func(p Problem) string { return p.PersonalDifficulty }, take: func(d *Problem, s Problem) { d.PersonalDifficulty = s.PersonalDifficulty }},
+}
+
+func flagValue(set bool) string {
+	if set {
+		return "yes"
+	}
+	return ""
+}
+
+// mergeHistory adds the attempts, recalls and hints of src missing from dst, and
+// returns how many attempts were added. Copies of the same database share most of
+// their history, so entries are matched by date rather than appended; the archived
+// attempts of dst count as present, for copies made before a compact.
+func mergeHistory(dst *Problem, archived []Attempt, src Problem) int {
+	type attemptKey struct {
+		unix       int64
+		solved     bool
+		submission string
+	}
+	key := func(a Attempt) attemptKey { return attemptKey{a.Date.UnixNano(), a.Solved, a.Submission} }
+	seen := make(map[attemptKey]bool)
+	for _, a := range append(slices.Clone(archived), dst.Attempts...) {
+		seen[key(a)] = true
+	}
+	added := 0
+	for _, a := range src.Attempts {
+		if seen[key(a)] {
+			continue
+		}
+		seen[key(a)] = true
+		dst.Attempts = append(dst.Attempts, a)
+		added++
+		if a.Solved {
+			dst.SolveCount++
+		}
+	}
+	sort.SliceStable(dst.Attempts, func(i, j int) bool { return dst.Attempts[i].Date.Before(dst.Attempts[j].Date) })
+	if added == 0 && src.SolveCount > dst.SolveCount {
+		dst.SolveCount = src.SolveCount // solves from before attempts were tracked
+	}
+	if src.LastSolved.After(dst.LastSolved) {
+		dst.LastSolved = src.LastSolved
+	}
+	if !src.DateAdded.IsZero() && (dst.DateAdded.IsZero() || src.DateAdded.Before(dst.DateAdded)) {
+		dst.DateAdded = src.DateAdded
+	}
+	if src.LastBoss.After(dst.LastBoss) {
+		dst.LastBoss = src.LastBoss
+	}
+	if src.LastPicked.After(dst.LastPicked) {
+		dst.LastPicked = src.LastPicked
+	}
+
+	dst.Review = mergeReviews(dst.Review, src.Review)
+	dst.Hints = mergeTags(dst.Hints, src.Hints)
+	dst.HintsShown = max(dst.HintsShown, src.HintsShown)
+	mergeRelations(dst, src)
+	mergeComplexity(dst, src)
+	return added
+}
+
+// mergeReviews combines the recalls of two review states. The schedule is the one of the
+// copy with the latest recall, mine when they tie.
+func mergeReviews(mine, theirs *Review) *Review {
+	if theirs == nil {
+		return mine
+	}
+	if mine == nil {
+		copied := *theirs
+		copied.Recalls = slices.Clone(theirs.Recalls)
+		return &copied
+	}
+	lastRecall := func(r *Review) int64 {
+		if len(r.Recalls) == 0 {
+			return 0
+		}
+		return r.Recalls[len(r.Recalls)-1].Date.UnixNano()
+	}
+	merged := *mine
+	if lastRecall(theirs) > lastRecall(mine) {
+		merged = *theirs
+	}
+	merged.Recalls = slices.Clone(mine.Recalls)
+	for _, r := range theirs.Recalls {
+		if !slices.ContainsFunc(merged.Recalls, func(m Recall) bool { return m.Date.Equal(r.Date) }) {
+			merged.Recalls = append(merged.Recalls, r)
+		}
+	}
+	sort.SliceStable(merged.Recalls, func(i, j int) bool { return merged.Recalls[i].Date.Before(merged.Recalls[j].Date) })
+	return &merged
+}
+
+// mergeResult summarizes a database merge.
+type mergeResult struct {
+	Added    []string
+	Updated  []string
+	Attempts int // attempts added to problems both databases have
+}
+
+// mergeDatabases merges their problems into mine. Problems are matched by ID, then by
+// URL. The history of both copies is kept; for the fields the user edits, the copy
+// updated last wins, and the resolver decides when that can't be told.
+func mergeDatabases(mine, theirs []Problem, archive attemptArchive, resolver *conflictResolver) ([]Problem, mergeResult, error) {
+	var result mergeResult
+	for _, src := range theirs {
+		_, index := findProblemByID(mine, src.ID)
+		if index == -1 {
+			_, index = findProblemByURL(mine, src.URL)
+		}
+		if index == -1 {
+			mine = append(mine, src)
+			result.Added = append(result.Added, src.ID)
+			continue
+		}
+
+		dst := mine[index]
+		before := problemFingerprint(dst)
+		result.Attempts += mergeHistory(&dst, archive.Attempts[dst.ID], src)
+		switch {
+		case !dst.UpdatedAt.IsZero() && !src.UpdatedAt.IsZero() && !dst.UpdatedAt.Equal(src.UpdatedAt):
+			if src.UpdatedAt.After(dst.UpdatedAt) {
+				for _, f := range append(slices.Clone(conflictFields), mergeFlagFields...) {
+					if f.get(src) != f.get(dst) {
+						f.take(&dst, src)
+					}
+				}
+			}
+		default:
+			if _, err := resolver.resolve(&dst, src); err != nil {
+				return nil, result, err
+			}
+		}
+		if problemFingerprint(dst) != before {
+			mine[index] = dst
+			result.Updated = append(result.Updated, dst.ID)
+		}
+	}
+	return mine, result, nil
+}
+
+// readDatabase reads the problems of another database: a problems file, optionally
+// gzipped, or a data directory holding one.
+func readDatabase(path string) ([]Problem, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "problems.json")
+	}
+	in, err := openImportFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+	var problems []Problem
+	if err := json.NewDecoder(in).Decode(&problems); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	for i, p := range problems {
+		if strings.TrimSpace(p.ID) == "" {
+			return nil, fmt.Errorf("problem #%d of %s has no ID", i+1, path)
+		}
+	}
+	return problems, nil
+}
+
+func mergeCmd() *cobra.Command {
+	var onConflict string
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:         "merge <problems.json|data-dir>",
+		Annotations: mutates(),
+		Short:       "Merge another copy of your database, history included",
+		Long: "Merges the problems of another saitama database, e.g. the one of another machine, into this one. " +
+			"Unlike import, which only reads problem fields, the attempts, recalls, hints and relations of both " +
+			"copies are combined. For the fields you edit (name, tags, difficulty, notes, stars, ...) the copy " +
+			"updated last wins; when that can't be told, --on-conflict decides, asking field by field by default.",
+		Example: `  saitama merge ~/laptop/problems.json --dry-run
+  saitama merge /mnt/backup/saitama --on-conflict merge`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if !slices.Contains(conflictPolicies, onConflict) {
+				printError(tr("❌ Unknown conflict policy '%s' (use %s)"), onConflict, strings.Join(conflictPolicies, ", "))
+				return
+			}
+			theirs, err := readDatabase(args[0])
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			archive, err := loadAttemptArchive()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+
+			var result mergeResult
+			err = tx.Update(func(mine []Problem) ([]Problem, error) {
+				merged, r, err := mergeDatabases(mine, theirs, archive, &conflictResolver{policy: onConflict})
+				result = r
+				return merged, err
+			})
+			if err != nil {
+				color.Yellow(tr("Merge cancelled."))
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("🔀 %d problem(s) in %s"), len(theirs), args[0])
+			color.White(tr("   ➕ %d new problem(s)"), len(result.Added))
+			color.White(tr("   ✏️  %d problem(s) updated, %d attempt(s) added"), len(result.Updated), result.Attempts)
+			for i, id := range append(slices.Clone(result.Added), result.Updated...) {
+				if i == 20 {
+					color.HiBlack(tr("   ... and %d more"), len(result.Added)+len(result.Updated)-i)
+					break
+				}
+				mark := "~"
+				if i < len(result.Added) {
+					mark = "+"
+				}
+				printResult(color.New(color.FgWhite), "   %s %s", mark, id)
+			}
+			if dryRun {
+				color.Yellow(tr("🔍 Dry run: nothing was changed."))
+				return
+			}
+			if len(result.Added)+len(result.Updated) == 0 {
+				color.Green(tr("✅ Already up to date."))
+				return
+			}
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Merged %s."), args[0])
+		},
+	}
+	cmd.Flags().StringVar(&onConflict, "on-conflict", conflictAsk, "when it can't be told which copy of a field is newer: ask, mine, theirs or merge")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would change without changing anything")
+	return cmd
+}
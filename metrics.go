@@ -0,0 +1,105 @@
+// metrics.go
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// statsMetrics are the numbers exported for external dashboards.
+type statsMetrics struct {
+	TotalProblems        int            `json:"total_problems"`
+	SolvedProblems       int            `json:"solved_problems"`
+	SolvesTotal          int            `json:"solves_total"`
+	SolvesByTag          map[string]int `json:"solves_by_tag"`
+	SolvesByDifficulty   map[string]int `json:"solves_by_difficulty"`
+	StreakDays           int            `json:"streak_days"`
+	JudgedProblems       int            `json:"judged_problems"`  // problems with synced submissions
+	FirstAttemptAC       int            `json:"first_attempt_ac"` // judged problems accepted on the first submission
+	SubmissionsByVerdict map[string]int `json:"submissions_by_verdict"`
+	EstimatedRating      int            `json:"estimated_rating,omitempty"` // see estimateRating, 0 without rated attempts
+}
+
+// computeMetrics gathers the exported metrics. Problems without a difficulty are
+// counted as "unknown".
+func computeMetrics(problems []Problem, now time.Time) statsMetrics {
+	m := statsMetrics{
+		TotalProblems:      len(problems),
+		SolvesByTag:        make(map[string]int),
+		SolvesByDifficulty: make(map[string]int),
+		StreakDays:         currentStreak(solvesPerDay(activityLog(problems)), now),
+	}
+	verdicts := computeVerdictStats(problems)
+	m.JudgedProblems, m.FirstAttemptAC = verdicts.Overall.Problems, verdicts.Overall.FirstAC
+	m.SubmissionsByVerdict = verdicts.Verdicts
+	if rating, ok := currentRating(estimateRating(problems)); ok {
+		m.EstimatedRating = int(math.Round(rating))
+	}
+	for _, p := range problems {
+		solves := solveCount(p)
+		if solves == 0 {
+			continue
+		}
+		m.SolvedProblems++
+		m.SolvesTotal += solves
+		for _, tag := range p.Tags {
+			m.SolvesByTag[tag] += solves
+		}
+		difficulty := strings.ToLower(p.Difficulty)
+		if difficulty == "" {
+			difficulty = "unknown"
+		}
+		m.SolvesByDifficulty[difficulty] += solves
+	}
+	return m
+}
+
+// promLabel escapes a Prometheus label value.
+var promLabel = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// writePrometheus writes the metrics in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, m statsMetrics) {
+	single := func(name, kind, help string, value int) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %d\n", name, help, name, kind, name, value)
+	}
+	labeled := func(name, label, help string, values map[string]int) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "%s{%s=\"%s\"} %d\n", name, label, promLabel.Replace(k), values[k])
+		}
+	}
+
+	single("saitama_total_problems", "gauge", "Number of problems in the collection.", m.TotalProblems)
+	single("saitama_solved_problems", "gauge", "Number of problems solved at least once.", m.SolvedProblems)
+	single("saitama_solves_total", "counter", "Number of solves of all problems.", m.SolvesTotal)
+	labeled("saitama_tag_solves_total", "tag", "Number of solves of problems with the tag.", m.SolvesByTag)
+	labeled("saitama_difficulty_solves_total", "difficulty", "Number of solves by problem difficulty.", m.SolvesByDifficulty)
+	single("saitama_streak_days", "gauge", "Consecutive days with at least one solve.", m.StreakDays)
+	single("saitama_judged_problems", "gauge", "Number of problems with submissions synced from a judge.", m.JudgedProblems)
+	single("saitama_first_attempt_ac_problems", "gauge", "Number of judged problems accepted on the first submission.", m.FirstAttemptAC)
+	labeled("saitama_submissions_total", "verdict", "Number of synced judge submissions by verdict.", m.SubmissionsByVerdict)
+	if m.EstimatedRating > 0 {
+		single("saitama_estimated_rating", "gauge", "Personal rating estimated from the attempts at rated problems.", m.EstimatedRating)
+	}
+}
+
+// handleMetrics serves the metrics for Prometheus to scrape.
+func (s *apiServer) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	problems, err := loadProblems()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writePrometheus(w, computeMetrics(problems, time.Now()))
+}
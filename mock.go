@@ -0,0 +1,336 @@
+// mock.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// mockRubric lists the criteria an interviewer would grade, in the order they are asked.
+var mockRubric = []string{"Communication", "Problem solving", "Coding", "Testing"}
+
+// mockGrades are the self-assessment options for every rubric criterion, best first.
+var mockGrades = []string{
+	"4 - Strong hire",
+	"3 - Hire",
+	"2 - Lean no hire",
+	"1 - No hire",
+}
+
+// MockQuestion is one question of a mock interview.
+type MockQuestion struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Difficulty string `json:"difficulty,omitempty"`
+	Solved     bool   `json:"solved"`
+	Seconds    int    `json:"seconds"`
+}
+
+// MockResult is a finished mock interview. Results are kept in mocks.json, apart from
+// the problems, so they can be compared over time.
+type MockResult struct {
+	Date      time.Time      `json:"date"`
+	Questions []MockQuestion `json:"questions"`
+	Scores    map[string]int `json:"scores"` // rubric criterion -> 1..4
+	Notes     string         `json:"notes,omitempty"`
+}
+
+// average returns the mean rubric score of the mock.
+func (m MockResult) average() float64 {
+	if len(m.Scores) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, s := range m.Scores {
+		sum += s
+	}
+	return float64(sum) / float64(len(m.Scores))
+}
+
+func mocksPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "mocks.json"), nil
+}
+
+func loadMocks() ([]MockResult, error) {
+	path, err := mocksPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock results: %w", err)
+	}
+	var mocks []MockResult
+	if err := json.Unmarshal(data, &mocks); err != nil {
+		return nil, fmt.Errorf("failed to parse mock results: %w", err)
+	}
+	return mocks, nil
+}
+
+func saveMocks(mocks []MockResult) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := mocksPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(mocks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal mock results: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write mock results: %w", err)
+	}
+	return nil
+}
+
+// pickMockQuestions picks one problem per difficulty of the mix, preferring unsolved
+// problems. The personal difficulty from calibrate takes precedence over the label. Difficulties without any problem are returned as missing.
+func pickMockQuestions(problems []Problem, mix []string) (picked []Problem, missing []string) {
+	used := make(map[string]bool)
+	for _, difficulty := range mix {
+		var unsolved, solved []Problem
+		for _, p := range problems {
+			if used[p.ID] || !strings.EqualFold(effectiveDifficulty(p), difficulty) {
+				continue
+			}
+			if isSolved(p) {
+				solved = append(solved, p)
+			} else {
+				unsolved = append(unsolved, p)
+			}
+		}
+		pool := unsolved
+		if len(pool) == 0 {
+			pool = solved
+		}
+		if len(pool) == 0 {
+			missing = append(missing, difficulty)
+			continue
+		}
+		p := pool[rand.Intn(len(pool))]
+		used[p.ID] = true
+		picked = append(picked, p)
+	}
+	return picked, missing
+}
+
+func mockCmd() *cobra.Command {
+	var mix []string
+	var minutes int
+	var noTimer bool
+	cmd := &cobra.Command{
+		Use:         "mock",
+		Annotations: mutates(),
+		Short:       "Simulate a coding interview",
+		Long: "Picks a mix of questions (1 medium + 1 hard by default, see \"mock\" in the config), runs a " +
+			"timed slot per question with warnings at 10 and 5 minutes left, then asks for a self-assessment. " +
+			"Results are stored for 'saitama mock history'.",
+		Example: `  saitama mock
+  saitama mock --mix easy,medium --minutes 30
+  saitama mock history`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if len(mix) == 0 {
+				mix = cfg.Mock.Mix
+			}
+			if minutes <= 0 {
+				minutes = cfg.Mock.Minutes
+			}
+
+			questions, missing := pickMockQuestions(activeProblems(problems), mix)
+			for _, d := range missing {
+				color.Yellow(tr("⚠️  No %s problem available, skipping that question."), d)
+			}
+			if len(questions) == 0 {
+				color.Yellow(tr("📝 No questions for the interview! Add problems with a difficulty first."))
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("       🎤 MOCK INTERVIEW 🎤             "))
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiBlack(tr("%d question(s), %d minutes each. Think out loud!"), len(questions), minutes)
+
+			result := MockResult{Date: time.Now(), Scores: make(map[string]int)}
+			var attempts []timedAttempt
+			for n, q := range questions {
+				fmt.Fprintln(stderr)
+				color.HiYellow(tr("❓ Question %d/%d: %s - %s"), n+1, len(questions), q.ID, q.Name)
+				if q.Difficulty != "" {
+					color.White(tr("   💪 Difficulty: %s"), q.Difficulty)
+				}
+				if q.URL != "" {
+					color.Blue("   🔗 %s", q.URL)
+				}
+
+				ready := false
+				if err := survey.AskOne(&survey.Confirm{Message: "Start the clock?", Default: true}, &ready); err != nil {
+					color.Yellow(tr("Mock interview cancelled."))
+					return
+				}
+				if !ready {
+					color.HiBlack(tr("⏭️  Question skipped, it won't count in the results."))
+					continue
+				}
+
+				slot := time.Duration(minutes) * time.Minute
+				var spent time.Duration
+				if noTimer {
+					start := time.Now()
+					done := false
+					_ = survey.AskOne(&survey.Confirm{Message: "Press enter when you're done", Default: true}, &done)
+					spent = time.Since(start)
+				} else {
+					spent, _ = runCountdown(slot, "⏳", 10*time.Minute, 5*time.Minute)
+				}
+
+				solved := false
+				if err := survey.AskOne(&survey.Confirm{Message: "Did you solve it?"}, &solved); err != nil {
+					color.Yellow(tr("Mock interview cancelled."))
+					return
+				}
+				result.Questions = append(result.Questions, MockQuestion{
+					ID: q.ID, Name: q.Name, Difficulty: q.Difficulty, Solved: solved, Seconds: int(spent.Seconds()),
+				})
+				attempts = append(attempts, timedAttempt{ID: q.ID, Solved: solved, Spent: spent, At: time.Now()})
+			}
+
+			if len(result.Questions) == 0 {
+				color.Yellow(tr("Mock interview cancelled: every question was skipped."))
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("📋 Self-assessment: how would an interviewer grade you?"))
+			for _, criterion := range mockRubric {
+				grade := ""
+				if err := survey.AskOne(&survey.Select{Message: criterion + ":", Options: mockGrades}, &grade); err != nil {
+					color.Yellow(tr("Mock interview cancelled."))
+					return
+				}
+				result.Scores[criterion] = int(grade[0] - '0')
+			}
+			_ = survey.AskOne(&survey.Input{Message: "📝 Notes (what to improve):"}, &result.Notes)
+
+			mocks, err := loadMocks()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if err := saveMocks(append(mocks, result)); err != nil {
+				printError(tr("❌ Error saving mock result: %v"), err)
+				return
+			}
+			if err := recordTimedAttempts(attempts); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+
+			solved := 0
+			for _, q := range result.Questions {
+				if q.Solved {
+					solved++
+				}
+			}
+			fmt.Fprintln(stderr)
+			color.HiGreen(tr("🎉 Mock done: %d/%d solved, average score %.1f/4"), solved, len(result.Questions), result.average())
+			color.Cyan(tr("💡 See your trend with: saitama mock history"))
+
+			summary := SessionSummary{Kind: "mock", Started: result.Date}
+			for _, q := range result.Questions {
+				summary.Problems = append(summary.Problems, SessionProblem{ID: q.ID, Name: q.Name, Done: true, Solved: q.Solved, Seconds: q.Seconds})
+			}
+			fireSessionCompleted(summary)
+		},
+	}
+	cmd.Flags().StringSliceVar(&mix, "mix", nil, "difficulty of each question, e.g. medium,hard (default from config)")
+	cmd.Flags().IntVarP(&minutes, "minutes", "m", 0, "minutes per question (default from config)")
+	cmd.Flags().BoolVar(&noTimer, "no-timer", false, "don't run the countdown")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "history",
+		Short: "Show past mock interviews and how your scores evolve",
+		Run: func(cmd *cobra.Command, args []string) {
+			mocks, err := loadMocks()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if len(mocks) == 0 {
+				color.Yellow(tr("🎤 No mock interviews yet! Start one with: saitama mock"))
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("       🎤 MOCK INTERVIEW HISTORY 🎤     "))
+			color.HiMagenta("═══════════════════════════════════════")
+			fmt.Fprintln(stderr)
+			for _, m := range mocks {
+				solved := 0
+				var ids []string
+				for _, q := range m.Questions {
+					ids = append(ids, q.ID)
+					if q.Solved {
+						solved++
+					}
+				}
+				color.White(tr("%s  %d/%d solved  ⭐ %.1f/4  %s"), m.Date.Format("2006-01-02"), solved, len(m.Questions), m.average(), strings.Join(ids, ", "))
+			}
+
+			// Compare the last three mocks with the three before them.
+			if len(mocks) < 2 {
+				return
+			}
+			window := min(3, len(mocks)/2)
+			recent, earlier := mocks[len(mocks)-window:], mocks[len(mocks)-2*window:len(mocks)-window]
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("📈 Trend (last %d vs the %d before):"), window, window)
+			for _, criterion := range mockRubric {
+				before, after := 0.0, 0.0
+				for i := range recent {
+					before += float64(earlier[i].Scores[criterion])
+					after += float64(recent[i].Scores[criterion])
+				}
+				before, after = before/float64(window), after/float64(window)
+				line := fmt.Sprintf("%-16s %.1f → %.1f", criterion, before, after)
+				switch {
+				case after > before:
+					color.Green("   ▲ %s", line)
+				case after < before:
+					color.Red("   ▼ %s", line)
+				default:
+					color.HiBlack("   = %s", line)
+				}
+			}
+		},
+	})
+	return cmd
+}
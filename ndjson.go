@@ -0,0 +1,151 @@
+// ndjson.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// importError describes one record of an import file that was skipped.
+type importError struct {
+	Line   int    `json:"line"`
+	Column int    `json:"column,omitempty"`
+	ID     string `json:"id,omitempty"`
+	URL    string `json:"url,omitempty"`   // the line of an import --urls list
+	Field  string `json:"field,omitempty"` // where an import --strict error is, e.g. [3].attempts[0].date
+	Error  string `json:"error"`
+}
+
+// countingReader counts the bytes read from the underlying file, so progress can be
+// reported on compressed input too.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// importFile is an opened import file, decompressed if it is gzipped.
+type importFile struct {
+	io.Reader
+	raw   *countingReader
+	size  int64
+	close func()
+}
+
+// openImportFile opens a file for streaming. Gzip is detected by its magic bytes, so a
+// compressed file doesn't need a .gz extension.
+func openImportFile(filename string) (*importFile, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	raw := &countingReader{r: f}
+	buffered := bufio.NewReaderSize(raw, 64*1024)
+	in := &importFile{Reader: buffered, raw: raw, size: info.Size(), close: func() { f.Close() }}
+	if magic, _ := buffered.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("failed to read gzipped import file: %w", err)
+		}
+		in.Reader = gz
+		in.close = func() {
+			gz.Close()
+			f.Close()
+		}
+	}
+	return in, nil
+}
+
+// isNDJSONFile reports whether a file name looks like newline-delimited JSON,
+// optionally gzipped (problems.ndjson, dump.jsonl.gz).
+func isNDJSONFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(strings.ToLower(filename), ".gz")))
+	return ext == ".ndjson" || ext == ".jsonl"
+}
+
+// validateImportedProblem checks the fields every imported problem needs.
+func validateImportedProblem(p Problem) error {
+	if p.ID == "" || p.Name == "" {
+		return errors.New("ID or Name is empty")
+	}
+	return nil
+}
+
+// streamNDJSON decodes an NDJSON file one record at a time and hands every valid problem
+// to fn. Invalid records are reported instead of failing the whole import.
+func streamNDJSON(filename string, fn func(Problem)) ([]importError, error) {
+	in, err := openImportFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+
+	bar := newProgressBar("📥 Importing", in.size)
+	reader := bufio.NewReader(in)
+	var skipped []importError
+	line, records := 0, 0
+	for {
+		data, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return skipped, fmt.Errorf("failed to read import file at line %d: %w", line+1, readErr)
+		}
+		line++
+
+		if data = bytes.TrimSpace(data); len(data) > 0 {
+			var p Problem
+			if err := json.Unmarshal(data, &p); err != nil {
+				skipped = append(skipped, importError{Line: line, Error: err.Error()})
+			} else if err := validateImportedProblem(p); err != nil {
+				skipped = append(skipped, importError{Line: line, ID: p.ID, Error: err.Error()})
+			} else {
+				fn(p)
+				records++
+			}
+			bar.Update(in.raw.n, fmt.Sprintf("%d record(s)", records))
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+	bar.Finish(in.raw.n, fmt.Sprintf("%d record(s)", records))
+	return skipped, nil
+}
+
+// writeImportReport writes the skipped records as NDJSON, one error per line.
+func writeImportReport(filename string, skipped []importError) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return fmt.Errorf("failed to create error report: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, e := range skipped {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("failed to write error report: %w", err)
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,232 @@
+// next.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Weights of the signals combined by next. A due review outranks everything else,
+// since forgetting undoes past work; the plan comes next as the user committed to it.
+const (
+	nextWeightReview     = 4.0
+	nextWeightPlan       = 3.0
+	nextWeightWeakTag    = 2.0
+	nextWeightDifficulty = 1.5
+	nextWeightFocus      = 1.0
+	nextWeightPriority   = 2.0 // for high priority, scaled by the level
+	nextWeightUnsolved   = 1.0
+)
+
+// difficultyNames maps labelRank values back to difficulty labels.
+var difficultyNames = []string{"easy", "medium", "hard"}
+
+// nextCandidate is a problem scored by next, with the reasons behind its score.
+type nextCandidate struct {
+	Problem Problem  `json:"problem"`
+	Score   float64  `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// targetDifficulty returns the difficulty rank to practice at: the level of the last
+// five labeled solves, one step up when at least three of them went without feeling
+// hard. It returns -1 without any labeled solve.
+func targetDifficulty(problems []Problem) (int, string) {
+	type solve struct {
+		date time.Time
+		rank int
+		felt string
+	}
+	var solves []solve
+	for _, p := range problems {
+		rank := labelRank(p.Difficulty)
+		if rank < 0 {
+			continue
+		}
+		for _, a := range p.Attempts {
+			if a.Solved {
+				solves = append(solves, solve{a.Date, rank, a.Felt})
+			}
+		}
+	}
+	if len(solves) == 0 {
+		return -1, ""
+	}
+	sort.Slice(solves, func(i, j int) bool { return solves[i].date.After(solves[j].date) })
+	recent := solves[:min(5, len(solves))]
+
+	sum, hard := 0, 0
+	for _, s := range recent {
+		sum += s.rank
+		if s.felt == "hard" {
+			hard++
+		}
+	}
+	level := int(math.Round(float64(sum) / float64(len(recent))))
+	if len(recent) >= 3 && hard == 0 && level < len(difficultyNames)-1 {
+		return level + 1, fmt.Sprintf("one step up from your recent %s solves", difficultyNames[level])
+	}
+	return level, fmt.Sprintf("matches the level of your recent solves (%s)", difficultyNames[level])
+}
+
+// rankNext scores every problem worth doing now and returns them best first.
+func rankNext(problems []Problem, plan *StudyPlan, cfg Config, now time.Time) []nextCandidate {
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+
+	planned := make(map[string]int)
+	if plan != nil && len(plan.Weeks) > 0 {
+		week := plan.Weeks[currentPlanWeek(plan, now)]
+		for _, item := range week.Items {
+			planned[item.ID] = week.Number
+		}
+	}
+
+	mastery := computeTagMastery(problems, cfg.Mastery, now)
+	scores := make(map[string]float64, len(mastery))
+	for _, m := range mastery {
+		scores[m.Tag] = m.Score
+	}
+	weak := weakTags(mastery, cfg.Mastery)
+	target, targetReason := targetDifficulty(problems)
+	focus, _ := focusTag(problems, cfg, now)
+
+	var candidates []nextCandidate
+	for _, p := range problems {
+		if p.Abandoned || p.Archived || isSnoozed(p, now) {
+			continue
+		}
+		c := nextCandidate{Problem: p}
+		solved := isSolved(p)
+
+		if due, ok := nextReview(p); ok && due.Before(tomorrow) {
+			overdue := int(startOfDay(now).Sub(startOfDay(due)).Hours() / 24)
+			c.Score += nextWeightReview + 0.1*float64(min(overdue, 10))
+			if overdue > 0 {
+				c.Reasons = append(c.Reasons, fmt.Sprintf("🔁 review overdue by %d day(s)", overdue))
+			} else {
+				c.Reasons = append(c.Reasons, "🔁 review due today")
+			}
+		} else if solved {
+			// Solved and not due: nothing to gain from it right now.
+			continue
+		}
+
+		if week, ok := planned[p.ID]; ok && !solved {
+			c.Score += nextWeightPlan
+			c.Reasons = append(c.Reasons, fmt.Sprintf("🗓️  scheduled for week %d of your %s plan", week, plan.Target))
+		}
+
+		weakest := ""
+		for _, tag := range p.Tags {
+			if weak[tag] && (weakest == "" || scores[tag] < scores[weakest]) {
+				weakest = tag
+			}
+		}
+		if weakest != "" {
+			c.Score += nextWeightWeakTag
+			c.Reasons = append(c.Reasons, fmt.Sprintf("🎯 practices your weak tag '%s' (mastery %.0f)", weakest, scores[weakest]))
+		}
+
+		if p.Priority > 0 {
+			c.Score += nextWeightPriority * float64(p.Priority) / float64(priorityLevels["high"])
+			c.Reasons = append(c.Reasons, fmt.Sprintf("🚨 %s priority", priorityName(p.Priority)))
+		}
+
+		if !solved {
+			c.Score += nextWeightUnsolved
+			if rank := labelRank(p.Difficulty); target >= 0 && rank == target {
+				c.Score += nextWeightDifficulty
+				c.Reasons = append(c.Reasons, fmt.Sprintf("📈 %s: %s", p.Difficulty, targetReason))
+			}
+			if focus != "" && hasTag(p, focus) {
+				c.Score += nextWeightFocus
+				c.Reasons = append(c.Reasons, fmt.Sprintf("🎯 this week's focus tag '%s'", focus))
+			}
+			if len(c.Reasons) == 0 {
+				c.Reasons = append(c.Reasons, "🆕 not solved yet")
+			}
+		}
+		candidates = append(candidates, c)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	return candidates
+}
+
+func nextCmd() *cobra.Command {
+	var alternatives int
+	cmd := &cobra.Command{
+		Use:   "next",
+		Short: "Recommend the best problem to do next, and why",
+		Long: "Weighs due reviews, your study plan, priorities, weak tags and difficulty progression to recommend a " +
+			"single problem, with the reasons it was chosen.",
+		Example: `  saitama next
+  saitama next --alternatives 3`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			plan, err := loadPlan()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (ignoring the study plan)"), err)
+			}
+
+			candidates := rankNext(problems, plan, cfg, time.Now())
+			shown := candidates[:min(len(candidates), max(0, alternatives)+1)]
+			if jsonOutput() {
+				printJSON(shown)
+				return
+			}
+			if len(candidates) == 0 {
+				color.Green(tr("🎉 Nothing to do: everything is solved and no review is due."))
+				color.Cyan(tr("💡 Add new problems with: saitama add"))
+				return
+			}
+
+			best := candidates[0]
+			p := best.Problem
+			fmt.Fprintln(stderr)
+			printResult(color.New(color.FgHiYellow), tr("👉 Next up: %s - %s"), p.ID, p.Name)
+			if p.Difficulty != "" {
+				printResult(color.New(color.FgWhite), tr("   💪 Difficulty: %s"), p.Difficulty)
+			}
+			if len(p.Tags) > 0 {
+				printResult(color.New(color.FgGreen), "   🏷️  %s", strings.Join(p.Tags, " • "))
+			}
+			if p.URL != "" {
+				printResult(color.New(color.FgBlue), "   🔗 %s", p.URL)
+			}
+			fmt.Fprintln(stdout)
+			printResult(color.New(color.FgHiCyan), tr("🤔 Why:"))
+			for _, reason := range best.Reasons {
+				printResult(color.New(color.FgWhite), "   %s", reason)
+			}
+
+			if rest := shown[1:]; len(rest) > 0 {
+				fmt.Fprintln(stdout)
+				printResult(color.New(color.FgHiBlack), tr("Also good:"))
+				for _, c := range rest {
+					printResult(color.New(color.FgHiBlack), "   %s - %s (%s)", c.Problem.ID, c.Problem.Name, c.Reasons[0])
+				}
+			}
+			fmt.Fprintln(stderr)
+			color.Cyan(tr("💡 Log it with: saitama solve %s"), p.ID)
+			printInactivityNudge(problems)
+		},
+	}
+	cmd.Flags().IntVarP(&alternatives, "alternatives", "n", 2, "number of runner-ups to show")
+	return cmd
+}
@@ -0,0 +1,87 @@
+// notes.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// NotesConfig holds the template of the retro notes written after a solve.
+type NotesConfig struct {
+	OnSolve  bool   `json:"on_solve"`           // offer the note after every interactive solve
+	Template string `json:"template,omitempty"` // Go text/template, see defaultNoteTemplate
+}
+
+// SolveNoteData is the data available to note templates.
+type SolveNoteData struct {
+	Problem
+	Date    time.Time
+	Minutes int
+}
+
+const defaultNoteTemplate = `{{.ID}} - {{.Name}}{{if .Difficulty}} ({{.Difficulty}}){{end}}{{if .Minutes}}, {{.Minutes}} min{{end}}
+
+Approach:
+
+Complexity:
+  Time:
+  Space:
+
+Pitfalls:
+`
+
+// renderNoteTemplate renders the configured note template (or the default one).
+func renderNoteTemplate(tmpl string, data SolveNoteData) (string, error) {
+	if tmpl == "" {
+		tmpl = defaultNoteTemplate
+	}
+	t, err := template.New("note").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid note template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render note: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// askSolveNote opens the rendered template in $EDITOR and returns the note, or "" when
+// it was left untouched.
+func askSolveNote(cfg NotesConfig, data SolveNoteData) (string, error) {
+	rendered, err := renderNoteTemplate(cfg.Template, data)
+	if err != nil {
+		return "", err
+	}
+	note := ""
+	prompt := &survey.Editor{
+		Message:       "📝 Retro note",
+		Default:       rendered,
+		AppendDefault: true,
+		HideDefault:   true,
+		FileName:      "saitama-note-*.md",
+	}
+	if err := survey.AskOne(prompt, &note); err != nil {
+		return "", err
+	}
+	note = strings.TrimSpace(note)
+	if note == strings.TrimSpace(rendered) {
+		return "", nil
+	}
+	return note, nil
+}
+
+// appendNote adds a timestamped entry to the problem's notes.
+func appendNote(p *Problem, note string, at time.Time) {
+	entry := fmt.Sprintf("[%s]\n%s", at.Format("2006-01-02 15:04"), note)
+	if p.Notes == "" {
+		p.Notes = entry
+		return
+	}
+	p.Notes = strings.TrimRight(p.Notes, "\n") + "\n\n" + entry
+}
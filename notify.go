@@ -0,0 +1,158 @@
+// notify.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// NotificationConfig controls the desktop notifications of timers and sessions.
+type NotificationConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// notifyTimeout bounds the notification helper, so a stuck one never holds up a timer.
+const notifyTimeout = 5 * time.Second
+
+// windowsToast shows a toast with the WinRT API, under PowerShell's app ID since
+// Windows only shows toasts of registered apps. The title and message are read from the
+// environment, never spliced into the script: PowerShell also ends strings on the
+// typographic quotes, so no escaping of the text is safe.
+const windowsToast = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$x = $t.GetElementsByTagName('text')
+$x.Item(0).AppendChild($t.CreateTextNode($env:SAITAMA_TOAST_TITLE)) > $null
+$x.Item(1).AppendChild($t.CreateTextNode($env:SAITAMA_TOAST_MESSAGE)) > $null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('{1AC14E77-02E7-4E5D-B744-2EB1AE5198B7}\WindowsPowerShell\v1.0\powershell.exe').Show([Windows.UI.Notifications.ToastNotification]::new($t))`
+
+// notificationCommand returns the command showing a desktop notification on this OS:
+// osascript on macOS, notify-send on Linux and the BSDs, a PowerShell toast on Windows.
+func notificationCommand(ctx context.Context, title, message string) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		script := fmt.Sprintf(`display notification "%s" with title "%s"`, quote.Replace(message), quote.Replace(title))
+		return exec.CommandContext(ctx, "osascript", "-e", script), nil
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToast)
+		cmd.Env = append(os.Environ(), "SAITAMA_TOAST_TITLE="+title, "SAITAMA_TOAST_MESSAGE="+message)
+		return cmd, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.CommandContext(ctx, "notify-send", "--app-name=saitama", title, message), nil
+	}
+	return nil, fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+}
+
+// showNotification shows a desktop notification, whatever the config says.
+func showNotification(title, message string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+	cmd, err := notificationCommand(ctx, ansiEscape.ReplaceAllString(title, ""), ansiEscape.ReplaceAllString(message, ""))
+	if err != nil {
+		return err
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		var missing *exec.Error
+		if errors.As(err, &missing) {
+			return fmt.Errorf("%s is not installed", cmd.Path)
+		}
+		return fmt.Errorf("%s failed: %v %s", cmd.Path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// notify shows a desktop notification unless they are turned off. Failures are only
+// logged: the terminal already shows the same message.
+func notify(title, message string) {
+	cfg, err := loadConfig()
+	if err != nil || !cfg.Notifications.Enabled {
+		return
+	}
+	if err := showNotification(title, message); err != nil {
+		slog.Debug("notification failed", "err", err)
+	}
+}
+
+func notifyCmd() *cobra.Command {
+	setNotifications := func(on bool) {
+		cfg, err := loadConfig()
+		if err != nil {
+			printError(tr("❌ Error loading config: %v"), err)
+			return
+		}
+		cfg.Notifications.Enabled = on
+		if err := saveConfig(cfg); err != nil {
+			printError(tr("❌ Error saving config: %v"), err)
+			return
+		}
+		if on {
+			color.Green(tr("🔔 Desktop notifications on."))
+		} else {
+			color.Green(tr("🔕 Desktop notifications off."))
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "notify",
+		Short: "Show whether timers and sessions send desktop notifications",
+		Long: "When a timer runs out or gets close to the end (boss fights, mock interviews, sessions), saitama also " +
+			"shows a desktop notification, so you notice it with the terminal in the background. It uses osascript on " +
+			"macOS, notify-send on Linux and a toast on Windows.",
+		Example: `  saitama notify
+  saitama notify test
+  saitama notify off`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			if !cfg.Notifications.Enabled {
+				color.Yellow(tr("🔕 Desktop notifications are off. Turn them on with: saitama notify on"))
+				return
+			}
+			color.Green(tr("🔔 Desktop notifications are on. Check they show up with: saitama notify test"))
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:   "test",
+		Short: "Send a test notification",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showNotification("saitama", tr("👊 Notifications work. Time to train!")); err != nil {
+				printError(tr("❌ Could not show a notification: %v"), err)
+				if runtime.GOOS == "linux" {
+					color.Cyan(tr("💡 Install notify-send, e.g. with: sudo apt install libnotify-bin"))
+				}
+				return
+			}
+			color.Green(tr("✅ Notification sent."))
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "off",
+		Annotations: mutates(),
+		Short:       "Stop the desktop notifications",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setNotifications(false) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "on",
+		Annotations: mutates(),
+		Short:       "Send desktop notifications again",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setNotifications(true) },
+	})
+	return cmd
+}
@@ -0,0 +1,621 @@
+// notion.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	notionAPI     = "https://api.notion.com/v1"
+	notionVersion = "2022-06-28"
+)
+
+// NotionConfig holds the Notion integration settings. Properties maps Problem fields
+// (id, name, tags, difficulty, platform, url, notes, solve_count, last_solved) to the
+// property names of the Notion database; unmapped fields use defaultNotionProperties.
+type NotionConfig struct {
+	Token      string            `json:"token,omitempty"` // or SAITAMA_NOTION_TOKEN
+	DatabaseID string            `json:"database_id,omitempty"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// notionField describes how a Problem field is stored in Notion.
+type notionField struct {
+	Field string // Problem field name, as used in the config mapping
+	Type  string // Notion property type
+}
+
+// notionFields lists the synced fields. "name" must be the database's title property.
+var notionFields = []notionField{
+	{"id", "rich_text"},
+	{"name", "title"},
+	{"tags", "multi_select"},
+	{"difficulty", "select"},
+	{"platform", "select"},
+	{"url", "url"},
+	{"notes", "rich_text"},
+	{"solve_count", "number"},
+	{"last_solved", "date"},
+}
+
+var defaultNotionProperties = map[string]string{
+	"id":          "ID",
+	"name":        "Name",
+	"tags":        "Tags",
+	"difficulty":  "Difficulty",
+	"platform":    "Platform",
+	"url":         "URL",
+	"notes":       "Notes",
+	"solve_count": "Solves",
+	"last_solved": "Last Solved",
+}
+
+// notionSyncState remembers, per problem ID, which page it is synced with and when.
+type notionSyncState map[string]notionSyncEntry
+
+type notionSyncEntry struct {
+	PageID   string    `json:"page_id"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// notionPage is a problem as read from Notion.
+type notionPage struct {
+	PageID     string
+	LastEdited time.Time
+	Problem    Problem
+}
+
+// notionClient talks to the Notion API.
+type notionClient struct {
+	token      string
+	databaseID string
+	properties map[string]string
+}
+
+func newNotionClient(cfg NotionConfig) (*notionClient, error) {
+	token := os.Getenv("SAITAMA_NOTION_TOKEN")
+	if token == "" {
+		token = cfg.Token
+	}
+	if token == "" || cfg.DatabaseID == "" {
+		return nil, fmt.Errorf("notion.token (or SAITAMA_NOTION_TOKEN) and notion.database_id must be set in the config file")
+	}
+	props := make(map[string]string)
+	for field, name := range defaultNotionProperties {
+		props[field] = name
+	}
+	for field, name := range cfg.Properties {
+		if _, ok := props[field]; !ok {
+			return nil, fmt.Errorf("unknown field '%s' in notion.properties", field)
+		}
+		props[field] = name
+	}
+	return &notionClient{token: token, databaseID: cfg.DatabaseID, properties: props}, nil
+}
+
+// do performs a Notion API request and decodes the JSON response into out.
+func (c *notionClient) do(method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, notionAPI+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Notion-Version", notionVersion)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("notion request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("notion responded with %s: %s", resp.Status, apiErr.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		slog.Debug("failed to decode notion response", "path", path, "err", err)
+		return err
+	}
+	return nil
+}
+
+// plainText concatenates the plain text of a Notion rich text array.
+func plainText(raw json.RawMessage) string {
+	var parts []struct {
+		PlainText string `json:"plain_text"`
+	}
+	_ = json.Unmarshal(raw, &parts)
+	var b strings.Builder
+	for _, p := range parts {
+		b.WriteString(p.PlainText)
+	}
+	return b.String()
+}
+
+// richText builds a Notion rich text array, split into the API's 2000 character chunks.
+func richText(s string) []map[string]interface{} {
+	chunks := []map[string]interface{}{}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		n := min(len(runes), 2000)
+		chunks = append(chunks, map[string]interface{}{"text": map[string]string{"content": string(runes[:n])}})
+		runes = runes[n:]
+	}
+	return chunks
+}
+
+// encodeProperties converts a problem into Notion page properties.
+func (c *notionClient) encodeProperties(p Problem) map[string]interface{} {
+	props := make(map[string]interface{})
+	for _, f := range notionFields {
+		name := c.properties[f.Field]
+		switch f.Field {
+		case "id":
+			props[name] = map[string]interface{}{"rich_text": richText(p.ID)}
+		case "name":
+			props[name] = map[string]interface{}{"title": richText(p.Name)}
+		case "tags":
+			options := []map[string]string{}
+			for _, tag := range p.Tags {
+				// Notion does not allow commas in select options.
+				options = append(options, map[string]string{"name": strings.ReplaceAll(tag, ",", " ")})
+			}
+			props[name] = map[string]interface{}{"multi_select": options}
+		case "difficulty", "platform":
+			value := p.Difficulty
+			if f.Field == "platform" {
+				value = p.Platform
+			}
+			if value == "" {
+				props[name] = map[string]interface{}{"select": nil}
+			} else {
+				props[name] = map[string]interface{}{"select": map[string]string{"name": value}}
+			}
+		case "url":
+			if p.URL == "" {
+				props[name] = map[string]interface{}{"url": nil}
+			} else {
+				props[name] = map[string]interface{}{"url": p.URL}
+			}
+		case "notes":
+			props[name] = map[string]interface{}{"rich_text": richText(p.Notes)}
+		case "solve_count":
+			props[name] = map[string]interface{}{"number": solveCount(p)}
+		case "last_solved":
+			if p.LastSolved.IsZero() {
+				props[name] = map[string]interface{}{"date": nil}
+			} else {
+				props[name] = map[string]interface{}{"date": map[string]string{"start": p.LastSolved.Format(time.RFC3339)}}
+			}
+		}
+	}
+	return props
+}
+
+// decodeProperties reads the mapped fields of a Notion page into a problem.
+func (c *notionClient) decodeProperties(raw map[string]json.RawMessage) Problem {
+	var p Problem
+	for _, f := range notionFields {
+		value, ok := raw[c.properties[f.Field]]
+		if !ok {
+			continue
+		}
+		var prop struct {
+			Title       json.RawMessage `json:"title"`
+			RichText    json.RawMessage `json:"rich_text"`
+			MultiSelect []struct {
+				Name string `json:"name"`
+			} `json:"multi_select"`
+			Select *struct {
+				Name string `json:"name"`
+			} `json:"select"`
+			URL    *string  `json:"url"`
+			Number *float64 `json:"number"`
+			Date   *struct {
+				Start string `json:"start"`
+			} `json:"date"`
+		}
+		if err := json.Unmarshal(value, &prop); err != nil {
+			continue
+		}
+		switch f.Field {
+		case "id":
+			p.ID = strings.ToUpper(strings.TrimSpace(plainText(prop.RichText)))
+		case "name":
+			p.Name = plainText(prop.Title)
+		case "tags":
+			for _, o := range prop.MultiSelect {
+				p.Tags = append(p.Tags, canonicalTag(o.Name))
+			}
+		case "difficulty":
+			if prop.Select != nil {
+				p.Difficulty = strings.ToLower(prop.Select.Name)
+			}
+		case "platform":
+			if prop.Select != nil {
+				p.Platform = strings.ToLower(prop.Select.Name)
+			}
+		case "url":
+			if prop.URL != nil {
+				p.URL = *prop.URL
+			}
+		case "notes":
+			p.Notes = plainText(prop.RichText)
+		case "solve_count":
+			if prop.Number != nil {
+				p.SolveCount = int(*prop.Number)
+			}
+		case "last_solved":
+			if prop.Date != nil {
+				if t, err := time.Parse(time.RFC3339, prop.Date.Start); err == nil {
+					p.LastSolved = t
+				} else if t, err := time.Parse("2006-01-02", prop.Date.Start); err == nil {
+					p.LastSolved = t
+				}
+			}
+		}
+	}
+	return p
+}
+
+// fetchPages returns every page of the database that has a problem ID.
+func (c *notionClient) fetchPages() ([]notionPage, error) {
+	var pages []notionPage
+	cursor := ""
+	for {
+		body := map[string]interface{}{"page_size": 100}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+		var result struct {
+			Results []struct {
+				ID             string                     `json:"id"`
+				LastEditedTime time.Time                  `json:"last_edited_time"`
+				Archived       bool                       `json:"archived"`
+				Properties     map[string]json.RawMessage `json:"properties"`
+			} `json:"results"`
+			HasMore    bool   `json:"has_more"`
+			NextCursor string `json:"next_cursor"`
+		}
+		if err := c.do(http.MethodPost, "/databases/"+c.databaseID+"/query", body, &result); err != nil {
+			return nil, err
+		}
+		for _, r := range result.Results {
+			p := c.decodeProperties(r.Properties)
+			if r.Archived || p.ID == "" {
+				continue
+			}
+			pages = append(pages, notionPage{PageID: r.ID, LastEdited: r.LastEditedTime, Problem: p})
+		}
+		if !result.HasMore {
+			return pages, nil
+		}
+		cursor = result.NextCursor
+	}
+}
+
+func (c *notionClient) createPage(p Problem) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	body := map[string]interface{}{
+		"parent":     map[string]string{"database_id": c.databaseID},
+		"properties": c.encodeProperties(p),
+	}
+	err := c.do(http.MethodPost, "/pages", body, &result)
+	return result.ID, err
+}
+
+func (c *notionClient) updatePage(pageID string, p Problem) error {
+	return c.do(http.MethodPatch, "/pages/"+pageID, map[string]interface{}{"properties": c.encodeProperties(p)}, nil)
+}
+
+// mergeNotionFields copies the synced fields of remote into local, keeping local-only
+// data such as attempts and relations.
+func mergeNotionFields(local *Problem, remote Problem) {
+	local.Name = remote.Name
+	local.Tags = remote.Tags
+	local.Difficulty = remote.Difficulty
+	local.Platform = remote.Platform
+	local.URL = remote.URL
+	local.Notes = remote.Notes
+	if remote.SolveCount > local.SolveCount {
+		local.SolveCount = remote.SolveCount
+	}
+	if remote.LastSolved.After(local.LastSolved) {
+		local.LastSolved = remote.LastSolved
+	}
+}
+
+func notionStatePath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "notion_sync.json"), nil
+}
+
+func loadNotionState() (notionSyncState, error) {
+	state := notionSyncState{}
+	path, err := notionStatePath()
+	if err != nil {
+		return state, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read notion sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse notion sync state: %w", err)
+	}
+	return state, nil
+}
+
+func saveNotionState(state notionSyncState) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, data, err := encodeNotionState(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// encodeNotionState returns the file of the sync state and its content, e.g. to stage it
+// on a transaction.
+func encodeNotionState(state notionSyncState) (string, []byte, error) {
+	path, err := notionStatePath()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return "", nil, err
+	}
+	return path, data, nil
+}
+
+// notionAction is what a sync does with one problem.
+type notionAction int
+
+const (
+	notionSkip notionAction = iota
+	notionPush
+	notionPull
+)
+
+func (a notionAction) String() string {
+	switch a {
+	case notionPush:
+		return "push"
+	case notionPull:
+		return "pull"
+	}
+	return "skip"
+}
+
+// decideNotionAction resolves which side wins for a problem present on both sides.
+// A side "changed" if it was edited after the last sync; when both changed (or the
+// problem was never synced), the most recent edit wins.
+func decideNotionAction(local Problem, remote notionPage, entry notionSyncEntry, synced bool) notionAction {
+	localChanged := !synced || local.UpdatedAt.After(entry.SyncedAt)
+	remoteChanged := !synced || remote.LastEdited.After(entry.SyncedAt)
+	switch {
+	case localChanged && remoteChanged:
+		if remote.LastEdited.After(local.UpdatedAt) {
+			return notionPull
+		}
+		return notionPush
+	case localChanged:
+		return notionPush
+	case remoteChanged:
+		return notionPull
+	}
+	return notionSkip
+}
+
+func syncNotionCmd() *cobra.Command {
+	var dryRun bool
+	var direction string
+	cmd := &cobra.Command{
+		Use:         "notion",
+		Annotations: mutates(),
+		Short:       "Two-way sync with a Notion database",
+		Long: "Push and pull problems to a Notion database. Configure \"notion\" in the config file with token, " +
+			"database_id and optionally a properties mapping (field -> Notion property name). " +
+			"When both sides changed since the last sync, the most recently edited side wins.",
+		Example: `  saitama sync notion --dry-run
+  saitama sync notion
+  saitama sync notion --direction pull`,
+		Run: func(cmd *cobra.Command, args []string) {
+			direction = strings.ToLower(direction)
+			if direction != "both" && direction != "push" && direction != "pull" {
+				printError(tr("❌ Unknown direction '%s' (use both, push or pull)"), direction)
+				return
+			}
+
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			client, err := newNotionClient(cfg.Notion)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			state, err := loadNotionState()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+
+			color.Cyan(tr("🔄 Fetching Notion database..."))
+			pages, err := client.fetchPages()
+			if err != nil {
+				printError(tr("❌ Error fetching Notion pages: %v"), err)
+				return
+			}
+			remote := make(map[string]notionPage)
+			for _, page := range pages {
+				remote[page.Problem.ID] = page
+			}
+
+			canPush := direction != "pull"
+			canPull := direction != "push"
+			pushed, pulled, created, failed := 0, 0, 0, 0
+			localChanged := false
+			touched := make(map[string]bool) // problems whose sync state is up to date after this run
+
+			for i := range problems {
+				p := &problems[i]
+				entry, synced := state[p.ID]
+				page, onRemote := remote[p.ID]
+
+				if !onRemote {
+					if synced || !canPush {
+						continue // deleted in Notion after a previous sync, or pull-only
+					}
+					color.Green(tr("   ➕ %s → Notion"), p.ID)
+					if !dryRun {
+						pageID, err := client.createPage(*p)
+						if err != nil {
+							printError("   ❌ %s: %v", p.ID, err)
+							failed++
+							continue
+						}
+						state[p.ID] = notionSyncEntry{PageID: pageID}
+						touched[p.ID] = true
+					}
+					created++
+					continue
+				}
+
+				action := decideNotionAction(*p, page, entry, synced)
+				slog.Debug("notion sync decision", "id", p.ID, "action", action, "local_updated", p.UpdatedAt,
+					"remote_edited", page.LastEdited, "synced_at", entry.SyncedAt)
+				switch action {
+				case notionPush:
+					if !canPush {
+						continue
+					}
+					color.Cyan(tr("   ⬆️  %s → Notion"), p.ID)
+					if !dryRun {
+						if err := client.updatePage(page.PageID, *p); err != nil {
+							printError("   ❌ %s: %v", p.ID, err)
+							failed++
+							continue
+						}
+					}
+					pushed++
+				case notionPull:
+					if !canPull {
+						continue
+					}
+					color.Magenta(tr("   ⬇️  %s ← Notion"), p.ID)
+					mergeNotionFields(p, page.Problem)
+					localChanged = true
+					pulled++
+				}
+				state[p.ID] = notionSyncEntry{PageID: page.PageID}
+				touched[p.ID] = true
+			}
+
+			if canPull {
+				for _, page := range pages {
+					if _, index := findProblemByID(problems, page.Problem.ID); index != -1 {
+						continue
+					}
+					if _, synced := state[page.Problem.ID]; synced {
+						continue // deleted locally after a previous sync
+					}
+					color.Magenta(tr("   ➕ %s ← Notion"), page.Problem.ID)
+					p := page.Problem
+					p.DateAdded = time.Now()
+					if p.Difficulty == "" {
+						p.Difficulty, _ = inferDifficulty(p)
+					}
+					problems = append(problems, p)
+					state[p.ID] = notionSyncEntry{PageID: page.PageID}
+					touched[p.ID] = true
+					localChanged = true
+					pulled++
+				}
+			}
+
+			if dryRun {
+				color.Yellow(tr("🧪 Dry run: %d to create, %d to push, %d to pull. Nothing was changed."), created, pushed, pulled)
+				return
+			}
+
+			if localChanged {
+				if err := saveProblems(problems); err != nil {
+					printError(tr("❌ Error saving problems: %v"), err)
+					return
+				}
+			}
+			now := time.Now()
+			for id := range touched {
+				entry := state[id]
+				entry.SyncedAt = now
+				state[id] = entry
+			}
+			if err := saveNotionState(state); err != nil {
+				printError(tr("❌ Error saving sync state: %v"), err)
+				return
+			}
+
+			slog.Info("notion sync finished", "created", created, "pushed", pushed, "pulled", pulled, "failed", failed)
+			color.Green(tr("✅ Notion sync done: %d created, %d pushed, %d pulled, %d failed"), created, pushed, pulled, failed)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would change without changing anything")
+	cmd.Flags().StringVar(&direction, "direction", "both", "sync direction: both, push or pull")
+	return cmd
+}
+
+func syncCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Synchronize your problems with external services",
+	}
+	cmd.AddCommand(syncNotionCmd())
+	cmd.AddCommand(syncLeetCodeCmd())
+	cmd.AddCommand(syncCodeforcesCmd())
+	return cmd
+}
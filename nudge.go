@@ -0,0 +1,117 @@
+// nudge.go
+package main
+
+import (
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// daysSinceLastSolve returns the number of calendar days since the last solve, or false
+// without any solve.
+func daysSinceLastSolve(problems []Problem, now time.Time) (int, bool) {
+	var last time.Time
+	for _, e := range activityLog(problems) {
+		if e.Solved && e.Date.After(last) {
+			last = e.Date
+		}
+	}
+	if last.IsZero() {
+		return 0, false
+	}
+	return int(startOfDay(now).Sub(startOfDay(last)).Hours() / 24), true
+}
+
+// inactivityNudge returns how many days passed since the last solve when it is at
+// least the configured inactive_days and nudges are on.
+func inactivityNudge(problems []Problem, cfg ReminderConfig, now time.Time) (int, bool) {
+	if !cfg.Nudges || cfg.InactiveDays <= 0 {
+		return 0, false
+	}
+	days, ok := daysSinceLastSolve(problems, now)
+	if !ok || days < cfg.InactiveDays {
+		return 0, false
+	}
+	return days, true
+}
+
+// printInactivityNudge reminds the user to train when they haven't solved anything for
+// a while. It stays quiet when the config can't be read.
+func printInactivityNudge(problems []Problem) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return
+	}
+	if days, ok := inactivityNudge(problems, cfg.Reminders, time.Now()); ok {
+		color.HiRed(tr("⏰ No solve in %d days. Even one problem today keeps you sharp: saitama next"), days)
+		color.HiBlack(tr("   (silence these with: saitama nag off)"))
+	}
+}
+
+func nagCmd() *cobra.Command {
+	setNudges := func(on bool) {
+		cfg, err := loadConfig()
+		if err != nil {
+			printError(tr("❌ Error loading config: %v"), err)
+			return
+		}
+		cfg.Reminders.Nudges = on
+		if err := saveConfig(cfg); err != nil {
+			printError(tr("❌ Error saving config: %v"), err)
+			return
+		}
+		if on {
+			color.Green(tr("✅ Inactivity nudges on: after %d day(s) without a solve."), cfg.Reminders.InactiveDays)
+		} else {
+			color.Green(tr("🔕 Inactivity nudges off."))
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "nag",
+		Short: "Show whether you get nudged after days without a solve",
+		Long: "After \"inactive_days\" days without a solve (3 by default, in the \"reminders\" section of the config), " +
+			"list and next remind you to train, and the daily reminder of the calendar feed ('saitama serve --feed') " +
+			"repeats more and more often.",
+		Example: `  saitama nag
+  saitama nag off
+  saitama nag on`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			if !cfg.Reminders.Nudges {
+				color.Yellow(tr("🔕 Inactivity nudges are off. Turn them on with: saitama nag on"))
+				return
+			}
+			color.Green(tr("🔔 Inactivity nudges are on: after %d day(s) without a solve."), cfg.Reminders.InactiveDays)
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			if days, ok := daysSinceLastSolve(problems, time.Now()); ok {
+				color.White(tr("   Last solve: %d day(s) ago"), days)
+			}
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:         "off",
+		Annotations: mutates(),
+		Short:       "Stop the inactivity nudges",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setNudges(false) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "on",
+		Annotations: mutates(),
+		Short:       "Nudge me again after days without a solve",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setNudges(true) },
+	})
+	return cmd
+}
@@ -0,0 +1,254 @@
+// onboarding.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var knownPlatforms = append(platformNames(builtinPlatforms), "other")
+
+const (
+	starterEmpty   = "Start with an empty collection"
+	starterImport  = "Import problems from a JSON backup file"
+	starterCurated = "Load the curated starter list (classic LeetCode problems per topic)"
+)
+
+// skipOnboarding lists commands that must never trigger the first-run wizard.
+var skipOnboarding = map[string]bool{
+	"saitama":    true,
+	"setup":      true,
+	"help":       true,
+	"wiki":       true,
+	"whereis":    true,
+	"config":     true,
+	"import":     true,
+	"completion": true,
+	"upgrade":    true,
+	"daemon":     true,
+	// Runs at every shell prompt, where a wizard has no business.
+	"prompt-status": true,
+}
+
+// isInteractive reports whether stdin and stdout are attached to a terminal, which the
+// prompts read from and draw on. /dev/null is a character device too, so the file mode
+// isn't enough.
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// maybeRunOnboarding launches the setup wizard the first time saitama is used,
+// i.e. when neither a problems file nor a config file exists yet. Output meant for
+// scripts (--quiet, --output json) never gets a wizard in the middle.
+func maybeRunOnboarding(cmd *cobra.Command) {
+	if skipOnboarding[cmd.Name()] || !isInteractive() || isReadOnly() || quietFlag || jsonOutput() {
+		return
+	}
+
+	dbPath, err := getDbPath()
+	if err != nil {
+		return
+	}
+	configPath, err := getConfigPath()
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(dbPath); !os.IsNotExist(err) {
+		return
+	}
+	// Upgrading from a version that kept the problems elsewhere: offer to bring them
+	// over instead of starting from scratch.
+	if len(legacyDataFiles(dbPath)) > 0 {
+		offerLegacyMigration(dbPath)
+		fmt.Fprintln(stderr)
+		return
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		return
+	}
+
+	runOnboarding()
+	fmt.Fprintln(stderr)
+}
+
+// curatedStarterProblems returns every curated taxonomy problem once.
+func curatedStarterProblems() []Problem {
+	var starter []Problem
+	seen := make(map[string]bool)
+	for _, t := range topicTaxonomy {
+		for _, p := range t.Curated {
+			if seen[p.ID] {
+				continue
+			}
+			seen[p.ID] = true
+			p.DateAdded = time.Now()
+			starter = append(starter, p)
+		}
+	}
+	return starter
+}
+
+// runOnboarding walks the user through the initial configuration.
+func runOnboarding() {
+	fmt.Fprintln(stderr)
+	color.HiMagenta("═══════════════════════════════════════")
+	color.HiMagenta(tr("      🥊 WELCOME TO SAITAMA, HERO! 🥊    "))
+	color.HiMagenta("═══════════════════════════════════════")
+	fmt.Fprintln(stderr)
+	color.Cyan(tr("Let's set up your training ground. This only takes a minute."))
+	color.HiBlack(tr("(Press Ctrl+C to skip, you can always run 'saitama setup' later.)"))
+	fmt.Fprintln(stderr)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		color.Yellow(tr("⚠️  %v (starting from defaults)"), err)
+	}
+	// A skip is remembered by writing the default config, so the wizard doesn't come
+	// back on the next command.
+	skipped := func() {
+		color.Yellow(tr("👋 Setup skipped."))
+		if path, err := getConfigPath(); err == nil {
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				if err := saveConfig(cfg); err != nil {
+					color.Yellow(tr("⚠️  Could not remember the skip: %v"), err)
+				}
+			}
+		}
+	}
+
+	var platforms []string
+	if err := survey.AskOne(&survey.MultiSelect{
+		Message: "🌐 Which platforms do you practice on?",
+		Options: knownPlatforms,
+		Default: cfg.Platforms,
+	}, &platforms); err != nil {
+		skipped()
+		return
+	}
+
+	var starter string
+	if err := survey.AskOne(&survey.Select{
+		Message: "📦 How do you want to start?",
+		Options: []string{starterEmpty, starterImport, starterCurated},
+	}, &starter); err != nil {
+		skipped()
+		return
+	}
+
+	var initial []Problem
+	switch starter {
+	case starterImport:
+		var path string
+		if err := survey.AskOne(&survey.Input{Message: "📂 Path to the JSON file:"}, &path, survey.WithValidator(survey.Required)); err != nil {
+			skipped()
+			return
+		}
+		imported, err := importProblems(path)
+		if err != nil {
+			printError(tr("❌ Error importing problems: %v"), err)
+			color.Cyan(tr("💡 You can retry later with: saitama import %s"), path)
+		} else {
+			initial = imported
+		}
+	case starterCurated:
+		initial = curatedStarterProblems()
+	}
+
+	countStr := strconv.Itoa(cfg.Pick.Count)
+	if err := survey.AskOne(&survey.Input{
+		Message: "🎯 How many problems should 'saitama pick' suggest per day?",
+		Default: countStr,
+	}, &countStr, survey.WithValidator(func(ans interface{}) error {
+		if c, err := strconv.Atoi(ans.(string)); err != nil || c <= 0 {
+			return fmt.Errorf("please enter a positive number")
+		}
+		return nil
+	})); err != nil {
+		skipped()
+		return
+	}
+	count, _ := strconv.Atoi(countStr)
+
+	reminders := cfg.Reminders
+	if err := survey.AskOne(&survey.Confirm{Message: "⏰ Enable daily training reminders?", Default: reminders.Enabled}, &reminders.Enabled); err != nil {
+		skipped()
+		return
+	}
+	if reminders.Enabled {
+		if reminders.Time == "" {
+			reminders.Time = "09:00"
+		}
+		if err := survey.AskOne(&survey.Input{Message: "🕘 Reminder time (HH:MM):", Default: reminders.Time}, &reminders.Time, survey.WithValidator(func(ans interface{}) error {
+			if _, err := time.Parse("15:04", ans.(string)); err != nil {
+				return fmt.Errorf("please use the HH:MM format")
+			}
+			return nil
+		})); err != nil {
+			skipped()
+			return
+		}
+	}
+
+	cfg.Platforms = platforms
+	cfg.Pick.Count = count
+	cfg.Reminders = reminders
+	if err := saveConfig(cfg); err != nil {
+		printError(tr("❌ Error saving config: %v"), err)
+		return
+	}
+
+	if len(initial) > 0 {
+		existing, err := loadProblems()
+		if err != nil {
+			printError(tr("❌ Error loading problems: %v"), err)
+			return
+		}
+		existingIDs := make(map[string]bool)
+		for _, p := range existing {
+			existingIDs[p.ID] = true
+		}
+		added := 0
+		for _, p := range initial {
+			if !existingIDs[p.ID] {
+				existingIDs[p.ID] = true
+				existing = append(existing, p)
+				added++
+			}
+		}
+		if added > 0 {
+			if err := saveProblems(existing); err != nil {
+				printError(tr("❌ Error saving problems: %v"), err)
+				return
+			}
+		}
+		color.Green(tr("✅ Added %d problems to your collection"), added)
+	}
+
+	fmt.Fprintln(stderr)
+	color.HiGreen(tr("🎉 Setup complete! Your training ground is ready."))
+	color.Cyan(tr("💡 Next steps:"))
+	color.Cyan(tr("   saitama add    # add your own problems"))
+	color.Cyan(tr("   saitama pick   # get today's %d problems"), count)
+	color.Cyan(tr("   saitama gaps   # see which topics need work"))
+}
+
+func setupCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "setup",
+		Annotations: mutates(),
+		Short:       "Run the interactive setup wizard",
+		Long:        "Choose your platforms, seed your collection and set your daily defaults. Runs automatically on first use.",
+		Example: `  saitama setup
+  saitama --data-dir ~/work-saitama setup   # A second, separate collection`,
+		Run: func(cmd *cobra.Command, args []string) {
+			runOnboarding()
+		},
+	}
+}
@@ -0,0 +1,180 @@
+// output.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+)
+
+var (
+	// plainFlag is set by the persistent --plain flag, plainConfig by the "plain" setting.
+	plainFlag   bool
+	plainConfig bool
+	// quietFlag and outputFlag are set by the persistent --quiet and --output flags.
+	quietFlag  bool
+	outputFlag string
+
+	// stdout carries the results of a command, what scripts consume. Everything else,
+	// banners, hints and progress, is printed with color.X to stderr, which --quiet
+	// silences. Errors are printed with printError and always shown.
+	stdout io.Writer = &renderWriter{out: os.Stdout}
+	stderr io.Writer = &renderWriter{out: os.Stderr, quiet: true}
+	errout io.Writer = &renderWriter{out: os.Stderr}
+)
+
+// outputFormats are the values of --output.
+var outputFormats = []string{"text", "json"}
+
+// isPlain reports whether output should be free of colors, emoji and box drawing, for
+// terminals or fonts that render them poorly and for log capture.
+func isPlain() bool {
+	if plainFlag || plainConfig {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("SAITAMA_PLAIN")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// jsonOutput reports whether results should be printed as JSON (--output json). Only
+// the commands that print results support it; the others print text as usual.
+func jsonOutput() bool {
+	return outputFlag == "json"
+}
+
+// setupOutput applies the output settings. It runs before every command; help output
+// is filtered according to the flags alone.
+func setupOutput(cfg Config) error {
+	plainConfig = cfg.Plain
+	hyperlinksConfig = cfg.Hyperlinks
+	pagerConfig = cfg.Pager
+	if isPlain() || jsonOutput() {
+		color.NoColor = true
+	}
+	if !slices.Contains(outputFormats, outputFlag) {
+		return fmt.Errorf("unknown output format '%s' (use %s)", outputFlag, strings.Join(outputFormats, " or "))
+	}
+	if err := validateHyperlinkMode(); err != nil {
+		return err
+	}
+	var err error
+	activeTheme, err = selectTheme(cfg)
+	return err
+}
+
+func init() {
+	color.Output = stderr
+}
+
+// errorPrinted records that the command reported an error, so that saitama exits with 1.
+var errorPrinted bool
+
+// printError prints an error in red. Unlike other messages, errors are shown in quiet
+// mode. The command then exits with 1 when it returns.
+func printError(format string, a ...any) {
+	errorPrinted = true
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	color.New(color.FgRed).Fprintf(errout, format, a...)
+}
+
+// printResult prints a line of a command's results to stdout, in the given color.
+func printResult(c *color.Color, format string, a ...any) {
+	if !strings.HasSuffix(format, "\n") {
+		format += "\n"
+	}
+	c.Fprintf(stdout, format, a...)
+}
+
+// printJSON prints a command's results as indented JSON to stdout. Nil slices and
+// maps are printed empty rather than as null.
+func printJSON(v any) {
+	switch rv := reflect.ValueOf(v); {
+	case rv.Kind() == reflect.Slice && rv.IsNil():
+		v = []any{}
+	case rv.Kind() == reflect.Map && rv.IsNil():
+		v = map[string]any{}
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		printError("❌ Error writing JSON: %v", err)
+	}
+}
+
+// renderWriter writes command output, stripping the decoration in plain mode. Every
+// print is a single write, so a print that was only decoration (a banner rule) is
+// dropped whole, newline included.
+type renderWriter struct {
+	out   io.Writer
+	quiet bool // silenced by --quiet
+}
+
+func (w *renderWriter) Write(p []byte) (int, error) {
+	if w.quiet && quietFlag {
+		return len(p), nil
+	}
+	if !isPlain() {
+		if _, err := io.WriteString(w.out, applyTheme(string(p), activeTheme)); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	text := string(p)
+	plain := stripDecoration(text)
+	if strings.TrimSpace(plain) == "" && strings.TrimSpace(text) != "" {
+		return len(p), nil
+	}
+	if _, err := io.WriteString(w.out, plain); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// isDecoration reports whether a rune is an emoji, a pictograph or a box-drawing
+// character. Block elements are kept, as charts like the heatmap are drawn with them.
+func isDecoration(r rune) bool {
+	switch {
+	case r >= 0x2580 && r <= 0x259F: // block elements
+		return false
+	case r == 0xFE0F || r == 0x200D: // emoji presentation selector, zero width joiner
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // skin tone modifiers
+		return true
+	}
+	return unicode.Is(unicode.So, r)
+}
+
+// stripDecoration removes color codes and decoration runes, along with the spaces that
+// separated them from the text and the spaces they leave at the end of lines.
+func stripDecoration(text string) string {
+	var b strings.Builder
+	skipSpaces := false
+	for _, r := range ansiEscape.ReplaceAllString(text, "") {
+		if isDecoration(r) {
+			skipSpaces = true
+			continue
+		}
+		if skipSpaces && r == ' ' {
+			continue
+		}
+		skipSpaces = false
+		b.WriteRune(r)
+	}
+	lines := strings.Split(b.String(), "\n")
+	for i := range lines[:len(lines)-1] {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,99 @@
+// overwrite.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+// preImportPrefix names the backups written before import --overwrite. They are not
+// pruned like the per-save backups, and the import is undone with 'backup restore'.
+const preImportPrefix = "pre-import_"
+
+// writePreImportBackup copies the database to a dedicated backup, with its checksum,
+// and returns its path.
+func writePreImportBackup() (string, error) {
+	dbPath, err := getDbPath()
+	if err != nil {
+		return "", err
+	}
+	backupDir, err := getBackupDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the database for the pre-import backup: %w", err)
+	}
+	path := filepath.Join(backupDir, preImportPrefix+time.Now().Format(snapshotTimeLayout)+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write the pre-import backup: %w", err)
+	}
+	if err := writeChecksum(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// printOverwriteSummary shows what an overwrite replaces: how many problems each field
+// changes on, then the first problems in detail.
+func printOverwriteSummary(changed []problemChange) {
+	counts := make(map[string]int)
+	for _, c := range changed {
+		for _, f := range c.Changes {
+			counts[f.Field]++
+		}
+	}
+	fields := make([]string, 0, len(counts))
+	for field := range counts {
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if counts[fields[i]] != counts[fields[j]] {
+			return counts[fields[i]] > counts[fields[j]]
+		}
+		return fields[i] < fields[j]
+	})
+
+	fmt.Fprintln(stderr)
+	color.HiYellow(tr("⚠️  %d existing problem(s) will be replaced by their imported version:"), len(changed))
+	for _, field := range fields {
+		name := field
+		if field == "other" {
+			name = tr("other fields")
+		}
+		printResult(color.New(color.FgWhite), tr("   %-18s changes on %d problem(s)"), name, counts[field])
+	}
+	fmt.Fprintln(stderr)
+	const shown = 10
+	printSnapshotDiff(snapshotDiff{Changed: changed[:min(len(changed), shown)]})
+	if len(changed) > shown {
+		color.HiBlack(tr("   ... and %d more"), len(changed)-shown)
+	}
+	fmt.Fprintln(stderr)
+}
+
+// confirmOverwrite asks twice before replacing problems: a yes/no question, then the
+// phrase "overwrite N problems" to type, so that a stray Enter can't confirm.
+func confirmOverwrite(n int) bool {
+	confirm := false
+	prompt := &survey.Confirm{Message: fmt.Sprintf("Replace %d existing problem(s) with the imported version?", n)}
+	if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+		return false
+	}
+	phrase := fmt.Sprintf("overwrite %d problems", n)
+	typed := ""
+	if err := survey.AskOne(&survey.Input{Message: fmt.Sprintf("Type '%s' to confirm:", phrase)}, &typed); err != nil {
+		return false
+	}
+	return typed == phrase
+}
@@ -0,0 +1,160 @@
+// pager.go
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"regexp"
+	"runtime"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+var (
+	// noPagerFlag is set by the persistent --no-pager flag.
+	noPagerFlag bool
+	// pagerConfig is the "pager" setting, applied by setupOutput.
+	pagerConfig string
+)
+
+// defaultPager is used when neither SAITAMA_PAGER, the setting nor $PAGER name one.
+// -R passes the colors through.
+const defaultPager = "less -R"
+
+// osc8Link matches the escape sequences around hyperlinks, which take no room on screen.
+var osc8Link = regexp.MustCompile(`\x1b]8;;[^\x1b]*\x1b\\`)
+
+// pagerCommand returns the pager that long output goes through: SAITAMA_PAGER, else the
+// setting, else $PAGER, else less -R. It is empty when paging is off, i.e. the command
+// is "never" or "cat".
+func pagerCommand() string {
+	command := defaultPager
+	for _, c := range []string{os.Getenv("SAITAMA_PAGER"), pagerConfig, os.Getenv("PAGER")} {
+		if strings.TrimSpace(c) != "" {
+			command = strings.TrimSpace(c)
+			break
+		}
+	}
+	switch command {
+	case "never", "cat":
+		return ""
+	}
+	return command
+}
+
+// pagerWriter holds back the output of a command until it no longer fits the terminal,
+// then starts the pager and streams everything to it. Output that fits is printed as is
+// when the command ends.
+type pagerWriter struct {
+	command       string
+	width, height int
+	rows          int
+	buf           bytes.Buffer
+	proc          *exec.Cmd
+	out           io.Writer // the pager, or stdout if it could not start; nil while holding back
+	pipe          io.WriteCloser
+	interrupts    chan os.Signal // caught while the pager runs
+}
+
+func (w *pagerWriter) Write(p []byte) (int, error) {
+	if w.out != nil {
+		// The pager may be gone if the user quit early; the rest is dropped.
+		_, _ = w.out.Write(p)
+		return len(p), nil
+	}
+	w.buf.Write(p)
+	for _, line := range strings.SplitAfter(string(p), "\n") {
+		if line == "" {
+			continue
+		}
+		visible := ansiEscape.ReplaceAllString(osc8Link.ReplaceAllString(strings.TrimSuffix(line, "\n"), ""), "")
+		if n := utf8.RuneCountInString(visible); n > w.width {
+			w.rows += (n + w.width - 1) / w.width
+		} else if strings.HasSuffix(line, "\n") {
+			w.rows++
+		}
+	}
+	if w.rows >= w.height-1 { // the last row is left for the prompt
+		w.start()
+	}
+	return len(p), nil
+}
+
+// start starts the pager and hands it what was held back. If it cannot start, the output
+// goes to stdout as if paging was off.
+func (w *pagerWriter) start() {
+	w.out = os.Stdout
+	// Through the shell, like git does, so the command may quote its arguments.
+	proc := exec.Command("sh", "-c", w.command)
+	if runtime.GOOS == "windows" {
+		proc = exec.Command("cmd", "/C", w.command)
+	}
+	proc.Stdout, proc.Stderr = os.Stdout, os.Stderr
+	proc.Env = os.Environ()
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		// Keep the colors, and the text on screen after quitting, as git does.
+		proc.Env = append(proc.Env, "LESS=FRX")
+	}
+	pipe, err := proc.StdinPipe()
+	if err == nil {
+		err = proc.Start()
+	}
+	if err != nil {
+		slog.Debug("could not start the pager, printing directly", "pager", w.command, "error", err)
+	} else {
+		w.proc, w.pipe, w.out = proc, pipe, pipe
+		// Ctrl-C is for the pager now; saitama only has to finish writing. The signals
+		// are caught on a channel nobody reads, which Stop releases without touching
+		// the handlers of the command.
+		w.interrupts = make(chan os.Signal, 1)
+		signal.Notify(w.interrupts, os.Interrupt)
+	}
+	_, _ = w.out.Write(w.buf.Bytes())
+	w.buf.Reset()
+}
+
+// startPager sends the results of a command, and its messages when they go to the same
+// terminal, through the pager once they would scroll off the screen. The returned
+// function ends the paging and is meant to be deferred. Paging is off with --no-pager,
+// for JSON output, when stdout is not a terminal and when the pager is "never".
+func startPager() func() {
+	command := pagerCommand()
+	if noPagerFlag || jsonOutput() || command == "" || os.Getenv("TERM") == "dumb" ||
+		!term.IsTerminal(int(os.Stdout.Fd())) {
+		return func() {}
+	}
+	width, height, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil || width < 20 || height < 5 {
+		return func() {}
+	}
+	w := &pagerWriter{command: command, width: width, height: height}
+
+	writers := []*renderWriter{stdout.(*renderWriter)}
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		writers = append(writers, stderr.(*renderWriter))
+	}
+	previous := make([]io.Writer, len(writers))
+	for i, rw := range writers {
+		previous[i], rw.out = rw.out, w
+	}
+	return func() {
+		for i, rw := range writers {
+			rw.out = previous[i]
+		}
+		if w.proc == nil {
+			_, _ = os.Stdout.Write(w.buf.Bytes())
+			return
+		}
+		w.pipe.Close()
+		if err := w.proc.Wait(); err != nil {
+			slog.Debug("pager exited", "pager", w.command, "error", err)
+		}
+		signal.Stop(w.interrupts)
+	}
+}
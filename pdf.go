@@ -0,0 +1,115 @@
+// pdf.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/skip2/go-qrcode"
+)
+
+// PDFOptions controls the layout of the printable practice sheet.
+type PDFOptions struct {
+	PerPage int  // problems per page
+	QR      bool // print a QR code linking to each problem's URL
+}
+
+// exportPDF writes a printable practice sheet with one block per problem and ruled
+// space for notes.
+func exportPDF(problems []Problem, filename string, opts PDFOptions) error {
+	if opts.PerPage <= 0 {
+		opts.PerPage = 3
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(false, 15)
+	pdf.SetTitle("Saitama practice sheet", true)
+	pdf.SetCreator("saitama", true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("") // core fonts only cover cp1252
+
+	pageWidth, pageHeight := pdf.GetPageSize()
+	left, top, right, bottom := pdf.GetMargins()
+	width := pageWidth - left - right
+	const headerHeight = 12.0
+	blockHeight := (pageHeight - top - bottom - headerHeight) / float64(opts.PerPage)
+
+	pages := (len(problems) + opts.PerPage - 1) / opts.PerPage
+	for i, p := range problems {
+		slot := i % opts.PerPage
+		if slot == 0 {
+			pdf.AddPage()
+			pdf.SetFont("Helvetica", "B", 14)
+			pdf.CellFormat(width/2, 8, "Saitama practice sheet", "", 0, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 9)
+			pdf.SetTextColor(120, 120, 120)
+			pdf.CellFormat(width/2, 8, fmt.Sprintf("%s  -  page %d/%d", time.Now().Format("2006-01-02"), i/opts.PerPage+1, pages), "", 0, "R", false, 0, "")
+			pdf.SetTextColor(0, 0, 0)
+		}
+
+		y := top + headerHeight + float64(slot)*blockHeight
+		qrSize := 0.0
+		if opts.QR && p.URL != "" {
+			qrSize = min(blockHeight-12, 32)
+			png, err := qrcode.Encode(p.URL, qrcode.Medium, 256)
+			if err != nil {
+				return fmt.Errorf("failed to encode QR code for %s: %w", p.ID, err)
+			}
+			name := "qr-" + p.ID
+			pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+			pdf.ImageOptions(name, left+width-qrSize, y+2, qrSize, qrSize, false, fpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+		}
+		textWidth := width - qrSize - 2
+
+		pdf.SetXY(left, y+2)
+		pdf.SetFont("Helvetica", "B", 12)
+		pdf.CellFormat(textWidth, 6, tr(fmt.Sprintf("%s - %s", p.ID, p.Name)), "", 1, "L", false, 0, "")
+
+		var details []string
+		if p.Difficulty != "" {
+			details = append(details, "Difficulty: "+p.Difficulty)
+		}
+		if p.Platform != "" {
+			details = append(details, "Platform: "+p.Platform)
+		}
+		if len(p.Tags) > 0 {
+			details = append(details, "Tags: "+strings.Join(p.Tags, ", "))
+		}
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetX(left)
+		pdf.CellFormat(textWidth, 5, tr(strings.Join(details, "   |   ")), "", 1, "L", false, 0, "")
+		if p.URL != "" {
+			pdf.SetX(left)
+			pdf.SetTextColor(40, 80, 200)
+			pdf.CellFormat(textWidth, 5, tr(p.URL), "", 1, "L", false, 0, p.URL)
+			pdf.SetTextColor(0, 0, 0)
+		}
+
+		// Ruled lines for notes below the header (and next to the QR code).
+		pdf.SetDrawColor(200, 200, 200)
+		for lineY := y + 22; lineY < y+blockHeight-3; lineY += 7 {
+			lineWidth := width
+			if lineY < y+2+qrSize {
+				lineWidth = textWidth
+			}
+			pdf.Line(left, lineY, left+lineWidth, lineY)
+		}
+		pdf.SetDrawColor(0, 0, 0)
+		if slot < opts.PerPage-1 && i < len(problems)-1 {
+			pdf.Line(left, y+blockHeight, left+width, y+blockHeight)
+		}
+	}
+
+	if len(problems) == 0 {
+		pdf.AddPage()
+		pdf.SetFont("Helvetica", "", 12)
+		pdf.CellFormat(width, 10, "No problems to practice yet.", "", 1, "L", false, 0, "")
+	}
+	if err := pdf.OutputFileAndClose(filename); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
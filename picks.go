@@ -0,0 +1,98 @@
+// picks.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// picksName is the file of the last time pick suggested each problem, by ID. It is kept
+// out of the database so that a pick neither takes the lock nor rotates the backups;
+// loading the problems lays it over their LastPicked, which the cooldown reads.
+const picksName = "picks.json"
+
+func picksPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, picksName), nil
+}
+
+// loadPicks returns the time of the last pick of each problem, empty when nothing was
+// picked yet.
+func loadPicks() (map[string]time.Time, error) {
+	picks := make(map[string]time.Time)
+	path, err := picksPath()
+	if err != nil {
+		return picks, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return picks, nil
+	}
+	if err != nil {
+		return picks, fmt.Errorf("failed to read picks: %w", err)
+	}
+	if err := json.Unmarshal(data, &picks); err != nil {
+		return picks, fmt.Errorf("failed to parse picks: %w", err)
+	}
+	return picks, nil
+}
+
+func savePicks(picks map[string]time.Time) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := picksPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(picks, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write picks: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// recordPicks stamps the problems suggested by a pick, for the cooldown.
+func recordPicks(picked []Problem, now time.Time) error {
+	picks, err := loadPicks()
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(picked))
+	for i, p := range picked {
+		ids[i] = strings.ToUpper(p.ID)
+		picks[ids[i]] = now
+	}
+	if err := savePicks(picks); err != nil {
+		return err
+	}
+	store.Picked(ids, now)
+	return nil
+}
+
+// applyPicks sets the LastPicked of the problems from the picks file, keeping the time
+// stored in the database when it is later (problems picked before the file existed).
+func applyPicks(problems []Problem) {
+	picks, err := loadPicks()
+	if err != nil {
+		slog.Warn("picks not loaded, the cooldown only sees older picks", "err", err)
+		return
+	}
+	for i := range problems {
+		if at, ok := picks[strings.ToUpper(problems[i].ID)]; ok && at.After(problems[i].LastPicked) {
+			problems[i].LastPicked = at
+		}
+	}
+}
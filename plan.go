@@ -0,0 +1,451 @@
+// plan.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// planTemplate is a built-in study goal: which taxonomy topics to cover and how many
+// problems to schedule per week.
+type planTemplate struct {
+	Name        string
+	Description string
+	Keywords    []string // words in --target that select this template
+	Topics      []string // taxonomy topic names, in study order
+	PerWeek     int
+}
+
+var planTemplates = []planTemplate{
+	{
+		Name:        "interview",
+		Description: "Broad coverage for coding interviews (FAANG-style onsites)",
+		Keywords:    []string{"faang", "onsite", "interview", "maang", "job", "offer"},
+		Topics: []string{"arrays", "hashing", "two pointers", "strings", "sliding window", "stack", "binary search",
+			"linked list", "trees", "heap", "intervals", "backtracking", "graphs", "greedy", "dynamic programming", "tries"},
+		PerWeek: 8,
+	},
+	{
+		Name:        "competitive",
+		Description: "Contest-oriented topics to climb a Codeforces/AtCoder rating",
+		Keywords:    []string{"contest", "codeforces", "atcoder", "rating", "icpc", "competitive", "cp"},
+		Topics: []string{"math", "greedy", "binary search", "two pointers", "bit manipulation", "graphs",
+			"dynamic programming", "trees"},
+		PerWeek: 10,
+	},
+	{
+		Name:        "fundamentals",
+		Description: "The core data structures, for beginners",
+		Keywords:    []string{"basics", "beginner", "fundamentals", "start", "learn"},
+		Topics:      []string{"arrays", "strings", "hashing", "two pointers", "stack", "linked list", "trees", "binary search"},
+		PerWeek:     6,
+	},
+}
+
+// templateForTarget returns the template named by name, or guessed from the target text.
+func templateForTarget(name, target string) (planTemplate, error) {
+	if name != "" {
+		for _, t := range planTemplates {
+			if strings.EqualFold(t.Name, name) {
+				return t, nil
+			}
+		}
+		var names []string
+		for _, t := range planTemplates {
+			names = append(names, t.Name)
+		}
+		return planTemplate{}, fmt.Errorf("unknown template '%s' (use %s)", name, strings.Join(names, ", "))
+	}
+	words := strings.FieldsFunc(strings.ToLower(target), func(r rune) bool {
+		return !('a' <= r && r <= 'z' || '0' <= r && r <= '9')
+	})
+	for _, t := range planTemplates {
+		for _, w := range words {
+			for _, k := range t.Keywords {
+				if w == k {
+					return t, nil
+				}
+			}
+		}
+	}
+	return planTemplates[0], nil
+}
+
+// PlanItem is one problem scheduled in a study plan.
+type PlanItem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Topic      string `json:"topic"`
+	Difficulty string `json:"difficulty,omitempty"`
+	URL        string `json:"url,omitempty"`
+	Curated    bool   `json:"curated,omitempty"` // from a curated list, not in the collection when planned
+}
+
+// PlanWeek is one week of a study plan.
+type PlanWeek struct {
+	Number int        `json:"number"`
+	Start  time.Time  `json:"start"`
+	Topics []string   `json:"topics"`
+	Items  []PlanItem `json:"items"`
+}
+
+// StudyPlan is the persisted week-by-week plan.
+type StudyPlan struct {
+	Target   string     `json:"target"`
+	Template string     `json:"template"`
+	Created  time.Time  `json:"created"`
+	Weeks    []PlanWeek `json:"weeks"`
+}
+
+func planPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "plan.json"), nil
+}
+
+// loadPlan returns the current plan, or nil if none was generated yet.
+func loadPlan() (*StudyPlan, error) {
+	path, err := planPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan: %w", err)
+	}
+	var plan StudyPlan
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan: %w", err)
+	}
+	return &plan, nil
+}
+
+func savePlan(plan *StudyPlan) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := planPath()
+	if err != nil {
+		return err
+	}
+	if plan == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove plan: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write plan: %w", err)
+	}
+	return nil
+}
+
+// topicByName looks up a taxonomy topic.
+func topicByName(name string) (Topic, bool) {
+	for _, t := range topicTaxonomy {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Topic{}, false
+}
+
+// generatePlan spreads the template's topics over the weeks (revisiting early topics
+// when there are more weeks than topics) and fills every week from the unsolved pool,
+// falling back to curated problems. Difficulty ramps from easy in the first third of the
+// plan to hard in the last third.
+func generatePlan(problems []Problem, tmpl planTemplate, target string, weeks, perWeek int, now time.Time) *StudyPlan {
+	if perWeek <= 0 {
+		perWeek = tmpl.PerWeek
+	}
+	plan := &StudyPlan{Target: target, Template: tmpl.Name, Created: now}
+
+	topicsPerWeek := max(1, (len(tmpl.Topics)+weeks-1)/weeks)
+	used := make(map[string]bool)
+	start := startOfDay(now)
+	for w := 0; w < weeks; w++ {
+		week := PlanWeek{Number: w + 1, Start: start.AddDate(0, 0, 7*w)}
+		for k := 0; k < topicsPerWeek; k++ {
+			week.Topics = append(week.Topics, tmpl.Topics[(w*topicsPerWeek+k)%len(tmpl.Topics)])
+		}
+		level := 3 * w / weeks // 0 easy, 1 medium, 2 hard
+
+		for n := 0; n < perWeek; n++ {
+			// Round-robin over the week's topics, trying the others if one runs dry.
+			for k := 0; k < len(week.Topics); k++ {
+				topicName := week.Topics[(n+k)%len(week.Topics)]
+				if item, ok := pickPlanItem(problems, topicName, level, used); ok {
+					week.Items = append(week.Items, item)
+					break
+				}
+			}
+		}
+		plan.Weeks = append(plan.Weeks, week)
+	}
+	return plan
+}
+
+// pickPlanItem chooses an unused problem of the topic whose difficulty is closest to the
+// wanted level, preferring the collection over curated problems.
+func pickPlanItem(problems []Problem, topicName string, level int, used map[string]bool) (PlanItem, bool) {
+	topic, ok := topicByName(topicName)
+	if !ok {
+		return PlanItem{}, false
+	}
+	distance := func(difficulty string) int {
+		rank := labelRank(difficulty)
+		if rank == -1 {
+			rank = 1
+		}
+		if rank > level {
+			return rank - level
+		}
+		return level - rank
+	}
+
+	var pool []Problem
+	for _, p := range problems {
+		if !isSolved(p) && !p.Archived && !used[p.ID] && topicMatches(topic, p) {
+			pool = append(pool, p)
+		}
+	}
+	curated := false
+	if len(pool) == 0 {
+		for _, p := range topic.Curated {
+			if _, index := findProblemByID(problems, p.ID); index == -1 && !used[p.ID] {
+				pool = append(pool, p)
+			}
+		}
+		curated = true
+	}
+	if len(pool) == 0 {
+		return PlanItem{}, false
+	}
+
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+	sort.SliceStable(pool, func(i, j int) bool { return distance(pool[i].Difficulty) < distance(pool[j].Difficulty) })
+	p := pool[0]
+	used[p.ID] = true
+	return PlanItem{ID: p.ID, Name: p.Name, Topic: topicName, Difficulty: p.Difficulty, URL: p.URL, Curated: curated}, true
+}
+
+// planItemDone reports whether a planned problem has been solved.
+func planItemDone(problems []Problem, item PlanItem) bool {
+	p, index := findProblemByID(problems, item.ID)
+	return index != -1 && isSolved(*p)
+}
+
+// currentPlanWeek returns the index of the week containing now (clamped to the plan).
+func currentPlanWeek(plan *StudyPlan, now time.Time) int {
+	current := 0
+	for i, w := range plan.Weeks {
+		if !now.Before(w.Start) {
+			current = i
+		}
+	}
+	return current
+}
+
+// printPlanWeek prints a week with a checkbox per item.
+func printPlanWeek(problems []Problem, week PlanWeek, current bool) {
+	done := 0
+	for _, item := range week.Items {
+		if planItemDone(problems, item) {
+			done++
+		}
+	}
+	header := fmt.Sprintf("📅 Week %d (%s) - %s  [%d/%d]", week.Number, week.Start.Format("Jan 02"), strings.Join(week.Topics, ", "), done, len(week.Items))
+	if current {
+		color.HiYellow("👉 %s", header)
+	} else {
+		color.HiCyan("   %s", header)
+	}
+	for _, item := range week.Items {
+		line := fmt.Sprintf("%s - %s (%s)", item.ID, item.Name, item.Topic)
+		if item.Difficulty != "" {
+			line = fmt.Sprintf("%s - %s (%s, %s)", item.ID, item.Name, item.Topic, item.Difficulty)
+		}
+		switch {
+		case planItemDone(problems, item):
+			color.Green("      ✅ %s", line)
+		case item.Curated:
+			if _, index := findProblemByID(problems, item.ID); index == -1 {
+				color.Cyan("      ➕ %s %s", line, item.URL)
+				continue
+			}
+			fallthrough
+		default:
+			color.White("      ⬜ %s", line)
+		}
+	}
+}
+
+func planCmd() *cobra.Command {
+	var weeks, perWeek int
+	var target, template string
+	var force bool
+	cmd := &cobra.Command{
+		Use:         "plan",
+		Annotations: mutates(),
+		Short:       "Generate a week-by-week study plan towards a goal",
+		Long: "Builds a plan from your unsolved problems (and curated lists when the pool runs dry), balancing " +
+			"topics and ramping difficulty from easy to hard. Track it with 'saitama plan status'.",
+		Example: `  saitama plan --weeks 8 --target "pass FAANG onsite"
+  saitama plan --weeks 6 --template competitive
+  saitama plan status`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if weeks <= 0 {
+				printError(tr("❌ --weeks must be positive"))
+				return
+			}
+			tmpl, err := templateForTarget(template, target)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			existing, err := loadPlan()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if existing != nil && !force {
+				replace := false
+				prompt := &survey.Confirm{Message: fmt.Sprintf("Replace your current plan (%q)?", existing.Target)}
+				if err := survey.AskOne(prompt, &replace); err != nil || !replace {
+					color.Yellow(tr("Plan unchanged."))
+					return
+				}
+			}
+
+			if target == "" {
+				target = tmpl.Description
+			}
+			plan := generatePlan(problems, tmpl, target, weeks, perWeek, time.Now())
+			if err := savePlan(plan); err != nil {
+				printError(tr("❌ Error saving plan: %v"), err)
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════════════════════")
+			color.HiMagenta(tr("              🗺️  YOUR TRAINING PLAN 🗺️                "))
+			color.HiMagenta("═══════════════════════════════════════════════════════")
+			color.HiYellow(tr("🎯 %s (%s template, %d weeks)"), plan.Target, plan.Template, weeks)
+			fmt.Fprintln(stderr)
+			curated := 0
+			for _, w := range plan.Weeks {
+				printPlanWeek(problems, w, false)
+				for _, item := range w.Items {
+					if item.Curated {
+						curated++
+					}
+				}
+			}
+			fmt.Fprintln(stderr)
+			if curated > 0 {
+				color.Cyan(tr("💡 %d problem(s) come from curated lists. Add them with: saitama add"), curated)
+			}
+			color.HiGreen(tr("💪 Plan saved! Check your progress with: saitama plan status"))
+		},
+	}
+	cmd.Flags().IntVarP(&weeks, "weeks", "w", 8, "length of the plan in weeks")
+	cmd.Flags().IntVar(&perWeek, "per-week", 0, "problems per week (default: from the template)")
+	cmd.Flags().StringVar(&target, "target", "", "your goal, e.g. \"pass FAANG onsite\" (also picks a template)")
+	cmd.Flags().StringVar(&template, "template", "", "plan template: interview, competitive or fundamentals")
+	cmd.Flags().BoolVar(&force, "force", false, "replace the current plan without asking")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the progress of the current plan",
+		Run: func(cmd *cobra.Command, args []string) {
+			plan, err := loadPlan()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if plan == nil {
+				color.Yellow(tr("🗺️  No plan yet! Create one with: saitama plan --weeks 8 --target \"...\""))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			now := time.Now()
+			current := currentPlanWeek(plan, now)
+			total, done, due := 0, 0, 0
+			for i, w := range plan.Weeks {
+				for _, item := range w.Items {
+					total++
+					if planItemDone(problems, item) {
+						done++
+					} else if i < current {
+						due++
+					}
+				}
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════════════════════")
+			color.HiMagenta(tr("              🗺️  PLAN STATUS 🗺️                       "))
+			color.HiMagenta("═══════════════════════════════════════════════════════")
+			color.HiYellow(tr("🎯 %s (started %s)"), plan.Target, plan.Created.Format("2006-01-02"))
+			fmt.Fprintln(stderr)
+			for i, w := range plan.Weeks {
+				printPlanWeek(problems, w, i == current)
+			}
+			fmt.Fprintln(stderr)
+
+			percent := 0.0
+			if total > 0 {
+				percent = 100 * float64(done) / float64(total)
+			}
+			color.HiYellow(tr("📈 Progress: %d/%d (%.0f%%) %s"), done, total, percent, strings.Repeat("█", int(percent/5)))
+			if due > 0 {
+				color.Red(tr("⏰ %d problem(s) from earlier weeks are still open. Catch up!"), due)
+			} else {
+				color.HiGreen(tr("💪 On schedule! ONE PUNCH!"))
+			}
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear",
+		Annotations: mutates(),
+		Short:       "Delete the current plan",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := savePlan(nil); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("✅ Plan deleted"))
+		},
+	})
+	return cmd
+}
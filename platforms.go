@@ -0,0 +1,292 @@
+// platforms.go
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// PlatformDef describes a judge: how its problem IDs look, which URL hosts belong to it
+// and how to build a problem URL from an ID. Users can add or override definitions
+// under "platform_defs" in the config.
+type PlatformDef struct {
+	Name        string   `json:"name"`
+	IDPattern   string   `json:"id_pattern,omitempty"`   // regexp matching the IDs of this platform
+	IDPrefixes  []string `json:"id_prefixes,omitempty"`  // IDs starting with one of these belong to the platform too
+	Hosts       []string `json:"hosts,omitempty"`        // URL hosts (including subdomains) of this platform
+	URLTemplate string   `json:"url_template,omitempty"` // {1}, {2}, ... are replaced by the ID pattern's groups, {1:lower} lowercased
+}
+
+// builtinPlatforms are the platforms known out of the box.
+var builtinPlatforms = []PlatformDef{
+	{Name: "leetcode", IDPattern: leetcodeID.String(), IDPrefixes: []string{"LC"}, Hosts: []string{"leetcode.com", "leetcode.cn"}},
+	{Name: "codeforces", IDPattern: codeforcesID.String(), IDPrefixes: []string{"CF"}, Hosts: []string{"codeforces.com"},
+		URLTemplate: "https://codeforces.com/problemset/problem/{1}/{2}"},
+	{Name: "hackerrank", IDPrefixes: []string{"HR"}, Hosts: []string{"hackerrank.com"}},
+	{Name: "atcoder", IDPattern: `^AT([A-Z]{3}\d+)_?([A-Z]\d?)$`, IDPrefixes: []string{"AT"}, Hosts: []string{"atcoder.jp"},
+		URLTemplate: "https://atcoder.jp/contests/{1:lower}/tasks/{1:lower}_{2:lower}"},
+	{Name: "codechef", IDPattern: `^CC([A-Z0-9]+)$`, IDPrefixes: []string{"CC"}, Hosts: []string{"codechef.com"},
+		URLTemplate: "https://www.codechef.com/problems/{1}"},
+}
+
+// platformNames returns the names of the platform definitions, in order.
+func platformNames(defs []PlatformDef) []string {
+	var names []string
+	for _, d := range defs {
+		names = append(names, d.Name)
+	}
+	return names
+}
+
+// platformRegistry returns the built-in platforms merged with the ones defined in the
+// config. A config definition with a built-in name replaces it.
+func platformRegistry(cfg Config) []PlatformDef {
+	defs := append([]PlatformDef(nil), builtinPlatforms...)
+	for _, custom := range cfg.PlatformDefs {
+		custom.Name = strings.ToLower(strings.TrimSpace(custom.Name))
+		if custom.Name == "" {
+			continue
+		}
+		replaced := false
+		for i := range defs {
+			if defs[i].Name == custom.Name {
+				defs[i], replaced = custom, true
+			}
+		}
+		if !replaced {
+			defs = append(defs, custom)
+		}
+	}
+
+	// "id_prefixes" of the config moves a prefix to its platform, e.g. {"AT": "atcoder"},
+	// without redefining the platform.
+	prefixes := make([]string, 0, len(cfg.IDPrefixes))
+	for prefix := range cfg.IDPrefixes {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		name := strings.ToLower(strings.TrimSpace(cfg.IDPrefixes[prefix]))
+		prefix = strings.ToUpper(strings.TrimSpace(prefix))
+		if name == "" || prefix == "" {
+			continue
+		}
+		found := false
+		for i := range defs {
+			defs[i].IDPrefixes = slices.DeleteFunc(slices.Clone(defs[i].IDPrefixes), func(p string) bool { return p == prefix })
+			if defs[i].Name == name {
+				defs[i].IDPrefixes, found = append(defs[i].IDPrefixes, prefix), true
+			}
+		}
+		if !found {
+			defs = append(defs, PlatformDef{Name: name, IDPrefixes: []string{prefix}})
+		}
+	}
+	return defs
+}
+
+// validatePlatformDefs checks the user-defined platforms of the config.
+func validatePlatformDefs(defs []PlatformDef) error {
+	for _, d := range defs {
+		if d.IDPattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(d.IDPattern); err != nil {
+			return fmt.Errorf("platform '%s' has an invalid id_pattern: %w", d.Name, err)
+		}
+	}
+	return nil
+}
+
+// validateIDPrefixes checks the "id_prefixes" of the config: letters only, each mapped
+// to a platform.
+func validateIDPrefixes(prefixes map[string]string) error {
+	for prefix, name := range prefixes {
+		if !idPrefixPattern.MatchString(strings.ToUpper(strings.TrimSpace(prefix))) {
+			return fmt.Errorf("invalid ID prefix '%s' in id_prefixes (use letters only, e.g. LC, CF, AT)", prefix)
+		}
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("ID prefix '%s' in id_prefixes has no platform", prefix)
+		}
+	}
+	return nil
+}
+
+func platformByName(defs []PlatformDef, name string) (PlatformDef, bool) {
+	for _, d := range defs {
+		if strings.EqualFold(d.Name, name) {
+			return d, true
+		}
+	}
+	return PlatformDef{}, false
+}
+
+// ownsHost reports whether a URL host belongs to the platform.
+func (d PlatformDef) ownsHost(host string) bool {
+	for _, h := range d.Hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchID returns the groups of the platform's ID pattern, or nil if the ID doesn't match.
+// Without a pattern, an ID starting with one of the platform's prefixes matches with the
+// rest of the ID as {1}.
+func (d PlatformDef) matchID(id string) []string {
+	id = strings.ToUpper(id)
+	if d.IDPattern == "" {
+		if prefix := d.idPrefix(id); prefix != "" {
+			return []string{id, id[len(prefix):]}
+		}
+		return nil
+	}
+	re, err := regexp.Compile(d.IDPattern)
+	if err != nil {
+		return nil
+	}
+	return re.FindStringSubmatch(id)
+}
+
+// idPrefix returns the longest of the platform's prefixes the ID starts with, followed
+// by at least one more character, or "".
+func (d PlatformDef) idPrefix(id string) string {
+	longest := ""
+	for _, prefix := range d.IDPrefixes {
+		if len(id) > len(prefix) && strings.HasPrefix(strings.ToUpper(id), strings.ToUpper(prefix)) && len(prefix) > len(longest) {
+			longest = prefix
+		}
+	}
+	return longest
+}
+
+// BuildURL builds the problem URL for an ID from the platform's template.
+func (d PlatformDef) BuildURL(id string) (string, bool) {
+	m := d.matchID(id)
+	if m == nil || d.URLTemplate == "" {
+		return "", false
+	}
+	url := d.URLTemplate
+	for i := 1; i < len(m); i++ {
+		url = strings.ReplaceAll(url, fmt.Sprintf("{%d}", i), m[i])
+		url = strings.ReplaceAll(url, fmt.Sprintf("{%d:lower}", i), strings.ToLower(m[i]))
+	}
+	return url, true
+}
+
+// platformForURL returns the platform owning the URL's host.
+func platformForURL(defs []PlatformDef, raw string) (PlatformDef, bool) {
+	host := urlHost(raw)
+	if host == "" {
+		return PlatformDef{}, false
+	}
+	for _, d := range defs {
+		if d.ownsHost(host) {
+			return d, true
+		}
+	}
+	return PlatformDef{}, false
+}
+
+// platformForID returns the platform whose ID pattern matches the ID, or else the one
+// with the longest prefix the ID starts with.
+func platformForID(defs []PlatformDef, id string) (PlatformDef, bool) {
+	for _, d := range defs {
+		if d.IDPattern != "" && d.matchID(id) != nil {
+			return d, true
+		}
+	}
+	best, longest := PlatformDef{}, ""
+	for _, d := range defs {
+		if prefix := d.idPrefix(id); len(prefix) > len(longest) {
+			best, longest = d, prefix
+		}
+	}
+	return best, longest != ""
+}
+
+// resolvePlatform fills in the platform (from the URL, then the ID) and the URL (from the
+// platform's template) when they are missing, and rejects contradictions such as a
+// leetcode problem with a codeforces URL. Unknown platform names are accepted as is.
+func resolvePlatform(defs []PlatformDef, p *Problem) error {
+	p.Platform = strings.ToLower(strings.TrimSpace(p.Platform))
+	byURL, hasURLPlatform := platformForURL(defs, p.URL)
+	byID, hasIDPlatform := platformForID(defs, p.ID)
+
+	if p.Platform == "" {
+		switch {
+		case hasURLPlatform:
+			p.Platform = byURL.Name
+		case hasIDPlatform:
+			p.Platform = byID.Name
+		}
+	}
+
+	def, known := platformByName(defs, p.Platform)
+	if !known {
+		return nil
+	}
+	if p.URL != "" && len(def.Hosts) > 0 && !def.ownsHost(urlHost(p.URL)) {
+		return fmt.Errorf("%s is not a %s URL (expected %s)", p.URL, def.Name, strings.Join(def.Hosts, " or "))
+	}
+	if hasIDPlatform && byID.Name != def.Name {
+		return fmt.Errorf("ID %s looks like a %s problem, not %s", p.ID, byID.Name, def.Name)
+	}
+	if p.URL == "" {
+		if url, ok := def.BuildURL(p.ID); ok {
+			p.URL = url
+		}
+	}
+	return nil
+}
+
+func platformsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "platforms",
+		Short: "List the known platforms, their ID formats and URL templates",
+		Long: "Platforms are used to fill in and check the platform and URL of new problems. Add your own " +
+			"under \"platform_defs\" in the config (name, id_pattern, id_prefixes, hosts, url_template), or map " +
+			"ID prefixes to platforms under \"id_prefixes\", e.g. {\"AT\": \"atcoder\"}.",
+		Example: `  saitama platforms`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (showing built-in platforms)"), err)
+			}
+			if err := validatePlatformDefs(cfg.PlatformDefs); err != nil {
+				color.Yellow("⚠️  %v", err)
+			}
+			if err := validateIDPrefixes(cfg.IDPrefixes); err != nil {
+				color.Yellow("⚠️  %v", err)
+			}
+			defs := platformRegistry(cfg)
+			sort.SliceStable(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("🌐 Platforms:"))
+			for _, d := range defs {
+				color.HiYellow("   %s", d.Name)
+				if d.IDPattern != "" {
+					color.White(tr("      🆔 IDs: %s"), d.IDPattern)
+				}
+				if len(d.IDPrefixes) > 0 {
+					color.White(tr("      🔤 ID prefixes: %s"), strings.Join(d.IDPrefixes, ", "))
+				}
+				if len(d.Hosts) > 0 {
+					color.White(tr("      🏠 Hosts: %s"), strings.Join(d.Hosts, ", "))
+				}
+				if d.URLTemplate != "" {
+					color.White(tr("      🔗 URL: %s"), d.URLTemplate)
+				}
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+}
@@ -0,0 +1,126 @@
+// priority.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// maxPriority bounds numeric priorities; the named levels are 1 to 3.
+const maxPriority = 5
+
+// priorityLevels maps the named priorities to their values. Higher is more urgent.
+var priorityLevels = map[string]int{"low": 1, "medium": 2, "high": 3}
+
+// parsePriority reads a priority given as a level name or a number from 1 to maxPriority.
+// "none" clears it and returns 0.
+func parsePriority(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "none" {
+		return 0, nil
+	}
+	if v, ok := priorityLevels[s]; ok {
+		return v, nil
+	}
+	if v, err := strconv.Atoi(s); err == nil && v >= 1 && v <= maxPriority {
+		return v, nil
+	}
+	return 0, fmt.Errorf("invalid priority '%s' (use high, medium, low, none or a number from 1 to %d)", s, maxPriority)
+}
+
+// priorityName returns the level name of a priority, its number above high, or "none".
+func priorityName(priority int) string {
+	for name, v := range priorityLevels {
+		if v == priority {
+			return name
+		}
+	}
+	if priority <= 0 {
+		return "none"
+	}
+	return strconv.Itoa(priority)
+}
+
+// priorityShuffle shuffles the problems with rng so that higher priorities tend to come
+// first: a problem of priority n is n+1 times as likely to be drawn as one without any.
+// Without any priority it is a plain shuffle, so seeds keep replaying the same selection.
+func priorityShuffle(problems []Problem, rng *rand.Rand) {
+	weighted := false
+	for _, p := range problems {
+		weighted = weighted || p.Priority > 0
+	}
+	if !weighted {
+		rng.Shuffle(len(problems), func(i, j int) { problems[i], problems[j] = problems[j], problems[i] })
+		return
+	}
+	// Weighted sampling without replacement: order by u^(1/w) for a uniform u.
+	keys := make(map[string]float64, len(problems))
+	for _, p := range problems {
+		keys[p.ID] = math.Pow(rng.Float64(), 1/float64(1+max(0, p.Priority)))
+	}
+	sort.SliceStable(problems, func(i, j int) bool { return keys[problems[i].ID] > keys[problems[j].ID] })
+}
+
+func prioritizeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         "prioritize <id>... <high|medium|low|none|1-5>",
+		Annotations: mutates(),
+		Short:       "Mark problems as more or less urgent",
+		Long: "Sets the priority of problems, e.g. the ones a coming interview is likely to ask. " +
+			"pick draws high-priority problems more often and next ranks them higher; " +
+			"sort the list by it with 'saitama list --sort -priority'. Numbers above 3 go beyond high.",
+		Example: `  saitama prioritize LC146 high
+  saitama prioritize LC200 LC207 medium
+  saitama prioritize CF1000A 5
+  saitama prioritize LC146 none`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			priority, err := parsePriority(args[len(args)-1])
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			var changed []string
+			for _, id := range args[:len(args)-1] {
+				targetID := strings.ToUpper(id)
+				p, index := findProblemByID(problems, targetID)
+				if index == -1 {
+					printError(tr("❌ Problem with ID '%s' not found"), targetID)
+					return
+				}
+				if p.Priority == priority {
+					continue
+				}
+				p.Priority = priority
+				changed = append(changed, p.ID)
+			}
+			if len(changed) == 0 {
+				color.Yellow(tr("Nothing to change."))
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			if priority == 0 {
+				color.Green(tr("✅ Priority cleared: %s"), strings.Join(changed, ", "))
+			} else {
+				color.Green(tr("🚨 Priority %s: %s"), priorityName(priority), strings.Join(changed, ", "))
+			}
+		},
+	}
+	return cmd
+}
@@ -4,8 +4,11 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -13,30 +16,72 @@ import (
 
 // Problem defines the structure for a coding problem
 type Problem struct {
-	ID         string    `json:"id"`
-	Name       string    `json:"name"`
-	Tags       []string  `json:"tags"`
-	DateAdded  time.Time `json:"date_added,omitempty"`
-	LastSolved time.Time `json:"last_solved,omitempty"`
-	SolveCount int       `json:"solve_count,omitempty"`
-	Difficulty string    `json:"difficulty,omitempty"` // easy, medium, hard
-	Platform   string    `json:"platform,omitempty"`   // leetcode, codeforces, etc.
-	URL        string    `json:"url,omitempty"`
-	Notes      string    `json:"notes,omitempty"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Tags       []string   `json:"tags"`
+	DateAdded  time.Time  `json:"date_added,omitempty"`
+	LastSolved time.Time  `json:"last_solved,omitempty"`
+	SolveCount int        `json:"solve_count,omitempty"`
+	Difficulty string     `json:"difficulty,omitempty"` // easy, medium, hard
+	Platform   string     `json:"platform,omitempty"`   // leetcode, codeforces, etc.
+	URL        string     `json:"url,omitempty"`
+	Notes      string     `json:"notes,omitempty"`
+	Relations  []Relation `json:"relations,omitempty"`
+	Rating     int        `json:"rating,omitempty"` // platform rating, e.g. Codeforces 1900
+	Attempts   []Attempt  `json:"attempts,omitempty"`
+	LastBoss   time.Time  `json:"last_boss,omitempty"`
+	LastPicked time.Time  `json:"last_picked,omitempty"` // last time pick suggested the problem, see picks.go
+	UpdatedAt  time.Time  `json:"updated_at,omitempty"`  // set by saveProblems when the problem changes
+	Hints      []string   `json:"hints,omitempty"`
+	HintsShown int        `json:"hints_shown,omitempty"` // hints revealed since the last logged attempt
+	Starred    bool       `json:"starred,omitempty"`
+	Abandoned  bool       `json:"abandoned,omitempty"` // given up on, off the stuck list
+	Archived   bool       `json:"archived,omitempty"`  // out of the active pool, see archive
+	Review     *Review    `json:"review,omitempty"`    // spaced-repetition state, see quiz
+
+	EditorialURL string      `json:"editorial_url,omitempty"` // official solution, see open --editorial
+	References   []Reference `json:"references,omitempty"`    // labeled links, e.g. a blog post
+
+	TimeComplexity  string `json:"time_complexity,omitempty"` // best solution so far, e.g. O(n log n)
+	SpaceComplexity string `json:"space_complexity,omitempty"`
+
+	PersonalDifficulty string `json:"personal_difficulty,omitempty"` // estimated from the attempts, see calibrate
+
+	SnoozedUntil time.Time `json:"snoozed_until,omitempty"` // kept out of pick, next and quiz until then, see snooze
+	Priority     int       `json:"priority,omitempty"`      // 1 low, 2 medium, 3 high and up to 5, see prioritize
+}
+
+// Attempt records a single try at solving a problem.
+type Attempt struct {
+	Date            time.Time `json:"date"`
+	Solved          bool      `json:"solved"`
+	DurationSeconds int       `json:"duration_seconds,omitempty"`
+	HintsUsed       int       `json:"hints_used,omitempty"`
+	Felt            string    `json:"felt,omitempty"` // perceived difficulty: easy, ok or hard
+	TimeComplexity  string    `json:"time_complexity,omitempty"`
+	SpaceComplexity string    `json:"space_complexity,omitempty"`
+	Verdict         string    `json:"verdict,omitempty"`    // judge verdict of a synced submission: AC, WA, TLE, ...
+	Submission      string    `json:"submission,omitempty"` // judge submission ID, e.g. cf:245987123
 }
 
 const maxBackups = 5
 
 // getDbPath finds the appropriate user config directory for data storage.
 // THIS IS THE CRITICAL FIX TO PREVENT DATA LOSS.
+// The directory can be moved, e.g. to a shared network location, with --data-dir.
 func getDbPath() (string, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", fmt.Errorf("could not get user config directory: %w", err)
+	appConfigDir := dataDir()
+	if appConfigDir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("could not get user config directory: %w", err)
+		}
+		appConfigDir = filepath.Join(configDir, "saitama")
 	}
-	appConfigDir := filepath.Join(configDir, "saitama")
-	if err := os.MkdirAll(appConfigDir, 0755); err != nil {
-		return "", fmt.Errorf("could not create app config directory: %w", err)
+	if !isReadOnly() {
+		if err := os.MkdirAll(appConfigDir, 0755); err != nil {
+			return "", fmt.Errorf("could not create app config directory: %w", err)
+		}
 	}
 	return filepath.Join(appConfigDir, "problems.json"), nil
 }
@@ -51,74 +96,62 @@ func getBackupDir() (string, error) {
 	return filepath.Join(filepath.Dir(dbPath), ".saitama_backups"), nil
 }
 
-// loadProblems reads the problems from the JSON file in the user's config directory.
+// loadProblems returns the problems stored in the user's config directory. The file is
+// only parsed once per invocation, see Store.
 func loadProblems() ([]Problem, error) {
-	dbPath, err := getDbPath()
-	if err != nil {
-		return nil, err
-	}
-
-	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
-		return []Problem{}, nil // File doesn't exist yet, return empty list.
-	}
-
-	data, err := os.ReadFile(dbPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read problems file: %w", err)
-	}
-
-	if len(data) == 0 {
-		return []Problem{}, nil // Handle empty file
-	}
-
-	var problems []Problem
-	if err := json.Unmarshal(data, &problems); err != nil {
-		return nil, fmt.Errorf("failed to parse problems file: %w", err)
-	}
-
-	// Data migration for older records without DateAdded
-	needsSave := false
-	for i := range problems {
-		if problems[i].DateAdded.IsZero() {
-			problems[i].DateAdded = time.Now() // Default to now
-			needsSave = true
-		}
-	}
-	if needsSave {
-		// Save migrated data silently
-		_ = saveProblems(problems)
-	}
-
-	return problems, nil
+	return store.Problems()
 }
 
 // saveProblems writes the current list of problems to the JSON file, creating a backup first.
+// It fails with errConflict, writing nothing, when another process saved the database
+// since the problems were loaded, instead of overwriting its changes. Compound operations
+// that stage other files along use a Tx.
 func saveProblems(problems []Problem) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
 	dbPath, err := getDbPath()
 	if err != nil {
 		return err
 	}
 
-	if err := createBackup(dbPath); err != nil {
-		// Don't fail the save operation if backup fails, just warn
-		color.Yellow("Warning: Failed to create backup: %v\n", err)
+	release, err := acquireLock(filepath.Dir(dbPath))
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	data, err := json.MarshalIndent(problems, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal problems: %w", err)
+	if store.ChangedOnDisk(dbPath) {
+		return errConflict
 	}
+	return writeProblems(dbPath, problems, nil)
+}
 
-	// Atomic write operation
-	tempFile := dbPath + ".tmp"
-	if err := os.WriteFile(tempFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temporary file: %w", err)
+// stampUpdates sets UpdatedAt on every problem that is new or differs from the version
+// currently stored on disk, so sync targets can tell which side changed last.
+func stampUpdates(dbPath string, problems []Problem, now time.Time) {
+	previous := make(map[string]string)
+	if data, err := os.ReadFile(dbPath); err == nil {
+		var stored []Problem
+		if json.Unmarshal(data, &stored) == nil {
+			for _, p := range stored {
+				previous[p.ID] = problemFingerprint(p)
+			}
+		}
 	}
-	if err := os.Rename(tempFile, dbPath); err != nil {
-		_ = os.Remove(tempFile) // Clean up temp file on failure
-		return fmt.Errorf("failed to replace problems file: %w", err)
+	for i := range problems {
+		if fp, ok := previous[problems[i].ID]; !ok || fp != problemFingerprint(problems[i]) {
+			problems[i].UpdatedAt = now
+		}
 	}
-	return nil
+}
+
+// problemFingerprint serializes a problem without its UpdatedAt timestamp. LastPicked is
+// left out too: being suggested by pick isn't an edit worth syncing.
+func problemFingerprint(p Problem) string {
+	p.UpdatedAt, p.LastPicked = time.Time{}, time.Time{}
+	data, _ := json.Marshal(p)
+	return string(data)
 }
 
 // createBackup creates a backup of the current problems file.
@@ -135,8 +168,8 @@ func createBackup(dbPath string) error {
 		return fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	timestamp := time.Now().Format("20060102_150405")
-	backupFile := filepath.Join(backupDir, fmt.Sprintf("problems_%s.json", timestamp))
+	now := time.Now()
+	backupFile := filepath.Join(backupDir, fmt.Sprintf("problems_%s.json", now.Format(snapshotTimeLayout)))
 
 	data, err := os.ReadFile(dbPath)
 	if err != nil {
@@ -145,38 +178,66 @@ func createBackup(dbPath string) error {
 	if err := os.WriteFile(backupFile, data, 0644); err != nil {
 		return fmt.Errorf("failed to write backup file: %w", err)
 	}
+	if err := writeChecksum(backupFile); err != nil {
+		return err
+	}
+	slog.Debug("created backup", "path", backupFile)
 
+	if err := createScheduledBackup(backupDir, now); err != nil {
+		color.Yellow(tr("Warning: Failed to create the daily backup: %v\n"), err)
+		slog.Warn("daily backup failed", "err", err)
+	}
 	return cleanupOldBackups(backupDir)
 }
 
-// cleanupOldBackups removes old backup files, keeping only the most recent ones.
+// cleanupOldBackups removes old per-save backups, keeping only the most recent ones.
 func cleanupOldBackups(backupDir string) error {
+	return pruneBackups(backupDir, "problems_", ".json", maxBackups)
+}
+
+// pruneBackups removes the oldest backups named prefix<timestamp>ext, and their checksums,
+// keeping the given number. They are ordered by the timestamp of their name, or by their
+// modification time when it can't be read.
+func pruneBackups(backupDir, prefix, ext string, keep int) error {
 	entries, err := os.ReadDir(backupDir)
 	if err != nil {
 		return err
 	}
 
-	var backups []os.DirEntry
+	type backup struct {
+		name string
+		at   time.Time
+	}
+	var backups []backup
 	for _, entry := range entries {
-		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
-			backups = append(backups, entry)
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		at, ok := backupTime(name, prefix, ext)
+		if !ok {
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			at = info.ModTime()
 		}
+		backups = append(backups, backup{name, at})
 	}
 
-	if len(backups) <= maxBackups {
+	if len(backups) <= keep {
 		return nil
 	}
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].at.Before(backups[j].at) })
 
-	// The user requested to remove explicit sorting.
-	// We now rely on the filesystem's default order, which is generally chronological
-	// for timestamped filenames but is not guaranteed across all systems.
-
-	// Remove the oldest backups (assuming first entries are the oldest)
-	for i := 0; i < len(backups)-maxBackups; i++ {
-		if err := os.Remove(filepath.Join(backupDir, backups[i].Name())); err != nil {
+	for _, b := range backups[:len(backups)-keep] {
+		slog.Debug("removing old backup", "file", b.name)
+		if err := os.Remove(filepath.Join(backupDir, b.name)); err != nil {
 			// Log error but continue trying to clean up others
-			fmt.Printf("Warning: could not remove old backup %s: %v\n", backups[i].Name(), err)
+			fmt.Fprintf(stderr, tr("Warning: could not remove old backup %s: %v\n"), b.name, err)
+			continue
 		}
+		_ = os.Remove(filepath.Join(backupDir, b.name+checksumExt))
 	}
 	return nil
 }
@@ -205,22 +266,91 @@ func exportProblems(problems []Problem, filename string) error {
 
 // importProblems imports problems from a specified file.
 func importProblems(filename string) ([]Problem, error) {
-	data, err := os.ReadFile(filename)
+	in, err := openImportFile(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read import file: %w", err)
+		return nil, err
 	}
+	defer in.close()
 
 	var importedProblems []Problem
-	if err := json.Unmarshal(data, &importedProblems); err != nil {
+	if err := json.NewDecoder(in).Decode(&importedProblems); err != nil {
 		return nil, fmt.Errorf("failed to parse import file: %w", err)
 	}
 
 	// Validate imported problems
 	for i, p := range importedProblems {
-		if p.ID == "" || p.Name == "" {
-			return nil, fmt.Errorf("invalid problem at index %d (ID or Name is empty)", i)
+		if err := validateImportedProblem(p); err != nil {
+			return nil, fmt.Errorf("invalid problem at index %d (%v)", i, err)
 		}
 	}
 	return importedProblems, nil
 }
 
+// isSolved reports whether a problem has been solved at least once.
+func isSolved(p Problem) bool {
+	return p.SolveCount > 0 || !p.LastSolved.IsZero()
+}
+
+// recordAttempt appends an attempt to the problem and updates its solve tracking.
+func recordAttempt(p *Problem, solved bool, duration time.Duration, at time.Time) {
+	p.Attempts = append(p.Attempts, Attempt{
+		Date:            at,
+		Solved:          solved,
+		DurationSeconds: int(duration.Seconds()),
+		HintsUsed:       p.HintsShown,
+	})
+	p.HintsShown = 0
+	p.Abandoned = false
+	if solved {
+		p.SolveCount++
+		p.LastSolved = at
+	}
+}
+
+// timedAttempt is the result of a problem tried against the clock, to be recorded.
+type timedAttempt struct {
+	ID     string
+	Solved bool
+	Spent  time.Duration
+	At     time.Time
+}
+
+// recordTimedAttempts records attempts on the current content of the database. Boss
+// fights, sessions and mock interviews record their results after a timer that can last
+// an hour: the problems they loaded before it are stale by then, and saving them back
+// would fail with errConflict when another command saved meanwhile. Problems deleted
+// in the meantime are skipped.
+func recordTimedAttempts(attempts []timedAttempt) error {
+	tx, err := store.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	err = tx.Update(func(problems []Problem) ([]Problem, error) {
+		for _, a := range attempts {
+			if _, index := findProblemByID(problems, a.ID); index >= 0 {
+				recordAttempt(&problems[index], a.Solved, a.Spent, a.At)
+			}
+		}
+		return problems, nil
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Apply()
+}
+
+// hasFailedAttempt reports whether the problem has at least one unsuccessful attempt.
+func hasFailedAttempt(p Problem) bool {
+	for _, a := range p.Attempts {
+		if !a.Solved {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTags splits a comma-separated tag string into canonical tags, see canonicalTag.
+func parseTags(input string) []string {
+	return canonicalTags(strings.Split(input, ","))
+}
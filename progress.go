@@ -0,0 +1,54 @@
+// progress.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const progressWidth = 30
+
+// progressBar draws a single-line progress bar on stderr. It stays silent when stderr
+// is not a terminal, so redirected output is not cluttered with redraws.
+type progressBar struct {
+	label   string
+	total   int64
+	enabled bool
+	drawn   time.Time
+}
+
+func newProgressBar(label string, total int64) *progressBar {
+	info, err := os.Stderr.Stat()
+	enabled := err == nil && info.Mode()&os.ModeCharDevice != 0
+	return &progressBar{label: label, total: total, enabled: enabled}
+}
+
+// Update redraws the bar at most ten times per second.
+func (b *progressBar) Update(done int64, detail string) {
+	if !b.enabled || time.Since(b.drawn) < 100*time.Millisecond {
+		return
+	}
+	b.drawn = time.Now()
+	b.draw(done, detail)
+}
+
+// Finish draws the final state and moves to the next line.
+func (b *progressBar) Finish(done int64, detail string) {
+	if !b.enabled {
+		return
+	}
+	b.draw(done, detail)
+	fmt.Fprintln(os.Stderr)
+}
+
+func (b *progressBar) draw(done int64, detail string) {
+	ratio := 0.0
+	if b.total > 0 {
+		ratio = min(float64(done)/float64(b.total), 1)
+	}
+	filled := int(ratio * progressWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressWidth-filled)
+	fmt.Fprintf(os.Stderr, "\r%s [%s] %3.0f%%  %s\033[K", b.label, bar, ratio*100, detail)
+}
@@ -0,0 +1,195 @@
+// prompt.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// promptStatusName is the file of the data directory caching what prompt-status shows,
+// so that shell prompts don't pay for loading the problems. Saving the problems
+// refreshes it; it is rebuilt when stale.
+const promptStatusName = "prompt-status.json"
+
+// promptStatus is what prompt-status shows, as of Day.
+type promptStatus struct {
+	Updated     time.Time `json:"updated"` // before the problems were read, see loadPromptStatus
+	Day         string    `json:"day"`
+	Streak      int       `json:"streak"`
+	ReviewsDue  int       `json:"reviews_due"` // due by the end of the day, as in today
+	SolvedToday int       `json:"solved_today"`
+	DailyGoal   int       `json:"daily_goal,omitempty"`
+	WeekDone    int       `json:"week_done,omitempty"`   // solves toward the weekly quotas
+	WeekTarget  int       `json:"week_target,omitempty"` // sum of the weekly quotas
+}
+
+// defaultPromptFormat omits the parts with nothing to tell. The daily goal is shown
+// when set, else the weekly quotas.
+const defaultPromptFormat = `{{if .Streak}}🔥{{.Streak}} {{end}}{{if .ReviewsDue}}🔁{{.ReviewsDue}} {{end}}` +
+	`{{if .DailyGoal}}🎯{{.SolvedToday}}/{{.DailyGoal}}{{else if .WeekTarget}}🎯{{.WeekDone}}/{{.WeekTarget}}w{{end}}`
+
+func promptStatusPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, promptStatusName), nil
+}
+
+// computePromptStatus works out the status from the problems and the goals.
+func computePromptStatus(problems []Problem, goals GoalsConfig, now time.Time) promptStatus {
+	days := solvesPerDay(activityLog(problems))
+	s := promptStatus{
+		Updated:     now,
+		Day:         dayKey(now),
+		Streak:      currentStreak(days, now),
+		SolvedToday: days[dayKey(now)],
+		DailyGoal:   goals.Daily,
+	}
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+	for _, p := range activeProblems(problems) {
+		if at, ok := nextReview(p); ok && at.Before(tomorrow) {
+			s.ReviewsDue++
+		}
+	}
+	for _, q := range weeklyQuotas(problems, goals.Quotas, now) {
+		s.WeekDone += min(q.Done, q.Target)
+		s.WeekTarget += q.Target
+	}
+	return s
+}
+
+// savePromptStatus writes the cached status. Like the usage log, it is a convenience:
+// callers only log failures.
+func savePromptStatus(s promptStatus) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := promptStatusPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write prompt status: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// refreshPromptStatus updates the cached status after the problems were saved.
+func refreshPromptStatus(problems []Problem) {
+	cfg, err := loadConfig()
+	if err != nil {
+		slog.Debug("prompt status not refreshed", "err", err)
+		return
+	}
+	if err := savePromptStatus(computePromptStatus(problems, cfg.Goals, time.Now())); err != nil {
+		slog.Debug("prompt status not refreshed", "err", err)
+	}
+}
+
+// modifiedAfter reports whether a file was changed after t; a missing file wasn't.
+func modifiedAfter(path string, t time.Time) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.ModTime().After(t)
+}
+
+// loadPromptStatus returns the cached status when it is still right: from today, and
+// newer than the problems and the config, which other tools or a sync may have changed.
+// Otherwise it is rebuilt from the problems, the slow path, and cached again.
+func loadPromptStatus(now time.Time) (promptStatus, error) {
+	var s promptStatus
+	path, err := promptStatusPath()
+	if err != nil {
+		return s, err
+	}
+	dbPath, err := getDbPath()
+	if err != nil {
+		return s, err
+	}
+	configPath, err := getConfigPath()
+	if err != nil {
+		return s, err
+	}
+	if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &s) == nil &&
+		s.Day == dayKey(now) && !modifiedAfter(dbPath, s.Updated) && !modifiedAfter(configPath, s.Updated) {
+		return s, nil
+	}
+
+	problems, err := loadProblems()
+	if err != nil {
+		return s, err
+	}
+	cfg, err := loadConfig()
+	if err != nil {
+		return s, err
+	}
+	s = computePromptStatus(problems, cfg.Goals, now)
+	if err := savePromptStatus(s); err != nil {
+		slog.Debug("prompt status not cached", "err", err)
+	}
+	return s, nil
+}
+
+func promptStatusCmd() *cobra.Command {
+	var format string
+	cmd := &cobra.Command{
+		Use:   "prompt-status",
+		Short: "Print a short status for your shell prompt: streak, due reviews and goal",
+		Long: "Prints a one-line status, e.g. \"🔥12 🔁3 🎯1/2\" for a 12 day streak, 3 reviews due today and 1 of the 2 " +
+			"solves of the daily goal ('saitama goal daily'), or of the weekly quotas without one. It reads a status " +
+			"cached in the data directory, kept up to date by the commands that save problems, so it is fast enough " +
+			"to run at every prompt. --format is a Go template over the fields of -o json, e.g. " +
+			"\"{{.Streak}}d {{.ReviewsDue}}r\". Nothing is printed when there is nothing to tell, and errors are silent.",
+		Example: `  saitama prompt-status
+  saitama prompt-status --format "{{.SolvedToday}}/{{.DailyGoal}}"
+
+  # bash, in ~/.bashrc
+  PS1='$(saitama prompt-status) '"$PS1"
+
+  # starship, in ~/.config/starship.toml
+  [custom.saitama]
+  command = "saitama prompt-status"
+  when = true`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			tmpl, err := template.New("prompt").Parse(format)
+			if err != nil {
+				printError(tr("❌ Invalid --format: %v"), err)
+				return
+			}
+			// A prompt must never show errors, it only goes without a status.
+			s, err := loadPromptStatus(time.Now())
+			if err != nil {
+				slog.Debug("no prompt status", "err", err)
+				return
+			}
+			if jsonOutput() {
+				printJSON(s)
+				return
+			}
+			var b strings.Builder
+			if err := tmpl.Execute(&b, s); err != nil {
+				slog.Debug("no prompt status", "err", err)
+				return
+			}
+			if status := strings.TrimSpace(b.String()); status != "" {
+				fmt.Fprintln(stdout, status)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&format, "format", defaultPromptFormat, "Go template of the status")
+	return cmd
+}
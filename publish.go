@@ -0,0 +1,328 @@
+// publish.go
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// The default templates of the public training log, printed by publish --print-template
+// as a starting point for the templates of the config.
+var (
+	//go:embed publish.html.tmpl
+	defaultPublishHTML string
+	//go:embed publish.md.tmpl
+	defaultPublishMarkdown string
+)
+
+// PublishConfig holds the settings of the public training log.
+type PublishConfig struct {
+	Title            string   `json:"title,omitempty"`             // defaults to "Training log"
+	Handle           string   `json:"handle,omitempty"`            // shown under the title, e.g. a GitHub username
+	Anonymize        string   `json:"anonymize,omitempty"`         // none, names or counts, see publishAnonymizeLevels
+	Exclude          []string `json:"exclude,omitempty"`           // problems with any of these tags are left out, e.g. "private"
+	HTMLTemplate     string   `json:"html_template,omitempty"`     // file of a Go html/template replacing the default one
+	MarkdownTemplate string   `json:"markdown_template,omitempty"` // file of a Go text/template replacing the default one
+}
+
+// publishAnonymizeLevels are the values of publish --anonymize, from the most to the
+// least revealing: everything, problem IDs without names or links, totals only.
+var publishAnonymizeLevels = []string{"none", "names", "counts"}
+
+// PublishProblem is a solved problem of the public log.
+type PublishProblem struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name,omitempty"`
+	URL        string    `json:"url,omitempty"`
+	Difficulty string    `json:"difficulty,omitempty"`
+	Platform   string    `json:"platform,omitempty"`
+	Tags       []string  `json:"tags,omitempty"`
+	LastSolved time.Time `json:"last_solved"`
+	Solves     int       `json:"solves"`
+}
+
+// PublishTag is the number of solved problems of a tag.
+type PublishTag struct {
+	Tag     string `json:"tag"`
+	Solved  int    `json:"solved"`
+	Percent int    `json:"percent"` // relative to the most solved tag, for bar charts
+}
+
+// PublishDifficulty is the number of solved problems of a difficulty.
+type PublishDifficulty struct {
+	Name   string `json:"name"`
+	Solved int    `json:"solved"`
+}
+
+// PublishPage is the data of the public training log, also available to its templates.
+type PublishPage struct {
+	Title         string              `json:"title"`
+	Handle        string              `json:"handle,omitempty"`
+	Generated     time.Time           `json:"generated"`
+	Anonymize     string              `json:"anonymize"`
+	Solved        int                 `json:"solved"`
+	Solves        int                 `json:"solves"`
+	Streak        int                 `json:"streak"`
+	LongestStreak int                 `json:"longest_streak"`
+	Difficulties  []PublishDifficulty `json:"difficulties,omitempty"`
+	Tags          []PublishTag        `json:"tags"`
+	Problems      []PublishProblem    `json:"problems,omitempty"` // most recently solved first; none when anonymized to counts
+}
+
+// buildPublishPage gathers the solved problems, streaks and tag breakdown of the log.
+// Notes, hints and attempt details are never published.
+func buildPublishPage(problems []Problem, cfg PublishConfig, now time.Time) PublishPage {
+	page := PublishPage{Title: cfg.Title, Handle: cfg.Handle, Generated: now, Anonymize: cfg.Anonymize}
+	if page.Title == "" {
+		page.Title = "Training log"
+	}
+	if page.Anonymize == "" {
+		page.Anonymize = "none"
+	}
+
+	var public []Problem
+	for _, p := range problems {
+		if len(cfg.Exclude) == 0 || !hasAnyTag(p, cfg.Exclude) {
+			public = append(public, p)
+		}
+	}
+	days := solvesPerDay(activityLog(public))
+	page.Streak = currentStreak(days, now)
+	first := ""
+	for d := range days {
+		if first == "" || d < first {
+			first = d
+		}
+	}
+	if first != "" {
+		start, _ := time.ParseInLocation("2006-01-02", first, time.Local)
+		page.LongestStreak = longestStreak(days, start, startOfDay(now).AddDate(0, 0, 1))
+	}
+
+	tags := make(map[string]int)
+	difficulties := make(map[string]int)
+	for _, p := range public {
+		if !isSolved(p) {
+			continue
+		}
+		page.Solved++
+		page.Solves += solveCount(p)
+		for _, tag := range p.Tags {
+			tags[strings.ToLower(tag)]++
+		}
+		if p.Difficulty != "" {
+			difficulties[strings.ToLower(p.Difficulty)]++
+		}
+		if page.Anonymize == "counts" {
+			continue
+		}
+		pp := PublishProblem{
+			ID:         p.ID,
+			Difficulty: p.Difficulty,
+			Platform:   p.Platform,
+			Tags:       p.Tags,
+			LastSolved: p.LastSolved,
+			Solves:     solveCount(p),
+		}
+		if page.Anonymize == "none" {
+			pp.Name, pp.URL = p.Name, p.URL
+		}
+		page.Problems = append(page.Problems, pp)
+	}
+	sort.SliceStable(page.Problems, func(i, j int) bool {
+		return page.Problems[i].LastSolved.After(page.Problems[j].LastSolved)
+	})
+
+	for name, n := range difficulties {
+		page.Difficulties = append(page.Difficulties, PublishDifficulty{Name: name, Solved: n})
+	}
+	sort.Slice(page.Difficulties, func(i, j int) bool {
+		return difficultyValue(page.Difficulties[i].Name) < difficultyValue(page.Difficulties[j].Name)
+	})
+
+	page.Tags = []PublishTag{}
+	most := 0
+	for tag, n := range tags {
+		page.Tags = append(page.Tags, PublishTag{Tag: tag, Solved: n})
+		most = max(most, n)
+	}
+	sort.Slice(page.Tags, func(i, j int) bool {
+		if page.Tags[i].Solved != page.Tags[j].Solved {
+			return page.Tags[i].Solved > page.Tags[j].Solved
+		}
+		return page.Tags[i].Tag < page.Tags[j].Tag
+	})
+	for i := range page.Tags {
+		page.Tags[i].Percent = page.Tags[i].Solved * 100 / most
+	}
+	return page
+}
+
+// publishFile returns the file a format is written to in the output folder.
+func publishFile(format string) string {
+	if format == "html" {
+		return "index.html"
+	}
+	return "index.md"
+}
+
+// publishTemplate returns the template of a format: the file set in the config, or the
+// embedded default.
+func publishTemplate(cfg PublishConfig, format string) (string, error) {
+	path, fallback := cfg.HTMLTemplate, defaultPublishHTML
+	if format == "markdown" {
+		path, fallback = cfg.MarkdownTemplate, defaultPublishMarkdown
+	}
+	if path == "" {
+		return fallback, nil
+	}
+	path, err := expandHome(path)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the %s template: %w", format, err)
+	}
+	return string(data), nil
+}
+
+// renderPublishPage renders the log as HTML or Markdown.
+func renderPublishPage(page PublishPage, tmpl, format string) (string, error) {
+	funcs := map[string]any{
+		"join": strings.Join,
+		// cell escapes the pipes of a Markdown table cell.
+		"cell": func(s string) string { return strings.ReplaceAll(s, "|", `\|`) },
+	}
+	var buf bytes.Buffer
+	switch format {
+	case "html":
+		t, err := htmltemplate.New("publish").Funcs(funcs).Parse(tmpl)
+		if err != nil {
+			return "", fmt.Errorf("invalid html template: %w", err)
+		}
+		if err := t.Execute(&buf, page); err != nil {
+			return "", fmt.Errorf("failed to render the page: %w", err)
+		}
+	default:
+		t, err := template.New("publish").Funcs(funcs).Parse(tmpl)
+		if err != nil {
+			return "", fmt.Errorf("invalid markdown template: %w", err)
+		}
+		if err := t.Execute(&buf, page); err != nil {
+			return "", fmt.Errorf("failed to render the page: %w", err)
+		}
+	}
+	return buf.String(), nil
+}
+
+func publishCmd() *cobra.Command {
+	var out, format, anonymize string
+	var printTemplate bool
+	cmd := &cobra.Command{
+		Use:   "publish",
+		Short: "Render a static page of your solved problems, e.g. for GitHub Pages",
+		Long: "Renders a public training log of your solved problems, streak and tag breakdown as index.html " +
+			"or index.md in the output folder, ready to be pushed to a GitHub Pages repository. Notes and hints " +
+			"are never published.\n\n" +
+			"Configure it in the \"publish\" section of the config: title, handle, anonymize (none, names to show " +
+			"only problem IDs, or counts to show only totals), exclude (tags of problems to leave out), and " +
+			"html_template / markdown_template to replace the default templates with your own. " +
+			"--print-template prints a default template to start from.",
+		Example: `  saitama publish --out ~/code/me.github.io
+  saitama publish --format markdown --anonymize names --out docs
+  saitama publish --out - > log.html
+  saitama publish --print-template --format markdown > ~/.config/saitama/publish.md.tmpl`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			format = strings.ToLower(format)
+			if format == "md" {
+				format = "markdown"
+			}
+			if format != "html" && format != "markdown" {
+				printError(tr("❌ Unknown format '%s' (use html or markdown)"), format)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if printTemplate {
+				if format == "html" {
+					fmt.Fprint(stdout, defaultPublishHTML)
+				} else {
+					fmt.Fprint(stdout, defaultPublishMarkdown)
+				}
+				return
+			}
+			if cmd.Flags().Changed("anonymize") {
+				cfg.Publish.Anonymize = anonymize
+			}
+			if a := cfg.Publish.Anonymize; a != "" && !slices.Contains(publishAnonymizeLevels, a) {
+				printError(tr("❌ Unknown anonymization '%s' (use %s)"), a, strings.Join(publishAnonymizeLevels, ", "))
+				return
+			}
+
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			page := buildPublishPage(problems, cfg.Publish, time.Now())
+			if jsonOutput() {
+				printJSON(page)
+				return
+			}
+			tmpl, err := publishTemplate(cfg.Publish, format)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			rendered, err := renderPublishPage(page, tmpl, format)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if out == "-" {
+				fmt.Fprint(stdout, rendered)
+				return
+			}
+
+			dir, err := expandHome(out)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				printError(tr("❌ Failed to create %s: %v"), dir, err)
+				return
+			}
+			path := filepath.Join(dir, publishFile(format))
+			if err := os.WriteFile(path, []byte(rendered), 0644); err != nil {
+				printError(tr("❌ Failed to write %s: %v"), path, err)
+				return
+			}
+			color.Green(tr("🌐 Published %d solved problem(s) to %s"), page.Solved, path)
+			if page.Anonymize != "none" {
+				color.HiBlack(tr("   anonymized: %s"), page.Anonymize)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&out, "out", "site", "output folder, or - to print the page")
+	cmd.Flags().StringVarP(&format, "format", "f", "html", "page format: html or markdown")
+	cmd.Flags().StringVar(&anonymize, "anonymize", "", "override the anonymization of the config: "+strings.Join(publishAnonymizeLevels, ", "))
+	cmd.Flags().BoolVar(&printTemplate, "print-template", false, "print the default template of the format and exit")
+	return cmd
+}
@@ -0,0 +1,70 @@
+// qr.go
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/skip2/go-qrcode"
+	"github.com/spf13/cobra"
+)
+
+// renderQR returns a terminal QR code for the text, using half blocks so that it stays
+// small. By default it is drawn for dark terminals; invert for light backgrounds.
+func renderQR(text string, invert bool) (string, error) {
+	code, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode QR code: %w", err)
+	}
+	return code.ToSmallString(invert), nil
+}
+
+// printQR prints a QR code indented by the given prefix.
+func printQR(text, indent string, invert bool) {
+	qr, err := renderQR(text, invert)
+	if err != nil {
+		printError("%s❌ %v", indent, err)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(qr, "\n"), "\n") {
+		fmt.Fprintln(stdout, indent+line)
+	}
+}
+
+func qrCmd() *cobra.Command {
+	var invert bool
+	cmd := &cobra.Command{
+		Use:   "qr <id>",
+		Short: "Show a problem's URL as a QR code to open it on your phone",
+		Example: `  saitama qr LC42
+  saitama pick 3 --qr`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+			if p.URL == "" {
+				color.Yellow(tr("🤷 '%s' has no URL."), p.ID)
+				color.Cyan(tr("💡 Try: saitama enrich"))
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan("📱 %s - %s", p.ID, p.Name)
+			printQR(p.URL, "  ", invert)
+			color.HiBlack("  %s", p.URL)
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().BoolVar(&invert, "invert", false, "invert colors for light terminal backgrounds")
+	return cmd
+}
@@ -0,0 +1,201 @@
+// query.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProblemQuery describes a filtered, sorted and paginated view of the collection.
+// It is shared by the CLI (list, search) and the REST server.
+type ProblemQuery struct {
+	Tags       []string // problems must carry at least one of these tags
+	NotTags    []string // problems must carry none of these tags
+	Difficulty string
+	Platform   string
+	ID         string // case-insensitive substring of the ID
+	Starred    bool   // only starred problems
+	Archived   string // archived problems are hidden, unless this is "include" or "only"
+	Complexity string // best time complexity, compared by growth: O(n*n) matches O(n^2)
+	Added      string // date expression on DateAdded, see parseDateExpr
+	Solved     string // date expression on LastSolved; "never" for unsolved problems
+	Sort       string // field name, prefixed with '-' for descending order
+	Offset     int
+	Limit      int // 0 means no limit
+}
+
+// sortKeys maps the sort field names accepted by queries to comparison functions.
+var sortKeys = map[string]func(a, b Problem) bool{
+	"id":         func(a, b Problem) bool { return a.ID < b.ID },
+	"name":       func(a, b Problem) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) },
+	"added":      func(a, b Problem) bool { return a.DateAdded.Before(b.DateAdded) },
+	"solved":     func(a, b Problem) bool { return a.LastSolved.Before(b.LastSolved) },
+	"solves":     func(a, b Problem) bool { return solveCount(a) < solveCount(b) },
+	"difficulty": func(a, b Problem) bool { return difficultyValue(a.Difficulty) < difficultyValue(b.Difficulty) },
+	"rating":     func(a, b Problem) bool { return a.Rating < b.Rating },
+	"priority":   func(a, b Problem) bool { return a.Priority < b.Priority },
+}
+
+// sortKeyNames returns the accepted sort fields, for error and help messages.
+func sortKeyNames() []string {
+	var names []string
+	for name := range sortKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate checks that the query only uses known sort fields, archive modes and a valid
+// complexity, and doesn't page from a negative offset.
+func (q ProblemQuery) Validate() error {
+	if q.Offset < 0 || q.Limit < 0 {
+		return fmt.Errorf("offset and limit must not be negative")
+	}
+	switch q.Archived {
+	case "", "include", "only":
+	default:
+		return fmt.Errorf("unknown archived mode '%s' (use include or only)", q.Archived)
+	}
+	if q.Complexity != "" {
+		if _, err := normalizeComplexity(q.Complexity); err != nil {
+			return err
+		}
+	}
+	for _, expr := range []string{q.Added, q.Solved} {
+		if expr == "" {
+			continue
+		}
+		if _, err := parseDateExpr(expr, time.Now()); err != nil {
+			return err
+		}
+	}
+	if q.Sort == "" {
+		return nil
+	}
+	if _, ok := sortKeys[strings.TrimPrefix(q.Sort, "-")]; !ok {
+		return fmt.Errorf("unknown sort field '%s' (use one of: %s)", q.Sort, strings.Join(sortKeyNames(), ", "))
+	}
+	return nil
+}
+
+// Matches reports whether a problem passes the query's filters.
+func (q ProblemQuery) Matches(p Problem) bool {
+	if !hasAnyTag(p, q.Tags) {
+		return false
+	}
+	if len(q.NotTags) > 0 && hasAnyTag(p, q.NotTags) {
+		return false
+	}
+	if q.Difficulty != "" && !strings.EqualFold(p.Difficulty, q.Difficulty) {
+		return false
+	}
+	if q.Platform != "" && !strings.EqualFold(p.Platform, q.Platform) {
+		return false
+	}
+	if q.ID != "" && !strings.Contains(strings.ToLower(p.ID), strings.ToLower(q.ID)) {
+		return false
+	}
+	if q.Starred && !p.Starred {
+		return false
+	}
+	if (q.Archived == "" && p.Archived) || (q.Archived == "only" && !p.Archived) {
+		return false
+	}
+	if q.Complexity != "" && !sameComplexity(p.TimeComplexity, q.Complexity) {
+		return false
+	}
+	if q.Added != "" && !matchesDateExpr(q.Added, p.DateAdded) {
+		return false
+	}
+	if q.Solved != "" && !matchesDateExpr(q.Solved, p.LastSolved) {
+		return false
+	}
+	return true
+}
+
+// Apply filters, sorts and paginates the problems. It returns the requested page and
+// the total number of matches before pagination. The input slice is not modified.
+func (q ProblemQuery) Apply(problems []Problem) ([]Problem, int) {
+	var matches []Problem
+	for _, p := range problems {
+		if q.Matches(p) {
+			matches = append(matches, p)
+		}
+	}
+
+	if less, ok := sortKeys[strings.TrimPrefix(q.Sort, "-")]; ok {
+		desc := strings.HasPrefix(q.Sort, "-")
+		sort.SliceStable(matches, func(i, j int) bool {
+			if desc {
+				return less(matches[j], matches[i])
+			}
+			return less(matches[i], matches[j])
+		})
+	}
+
+	total := len(matches)
+	if q.Offset >= total {
+		return nil, total
+	}
+	matches = matches[q.Offset:]
+	if q.Limit > 0 && q.Limit < len(matches) {
+		matches = matches[:q.Limit]
+	}
+	return matches, total
+}
+
+// problemFieldNames returns the JSON names of every Problem field.
+func problemFieldNames() []string {
+	var names []string
+	t := reflect.TypeOf(Problem{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// projectFields returns a sparse representation of the problems containing only the
+// requested JSON fields. An empty field list returns every field.
+func projectFields(problems []Problem, fields []string) ([]map[string]interface{}, error) {
+	known := make(map[string]bool)
+	for _, name := range problemFieldNames() {
+		known[name] = true
+	}
+	for _, f := range fields {
+		if !known[f] {
+			return nil, fmt.Errorf("unknown field '%s'", f)
+		}
+	}
+
+	result := make([]map[string]interface{}, 0, len(problems))
+	for _, p := range problems {
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, err
+		}
+		var full map[string]interface{}
+		if err := json.Unmarshal(data, &full); err != nil {
+			return nil, err
+		}
+		if len(fields) == 0 {
+			result = append(result, full)
+			continue
+		}
+		sparse := make(map[string]interface{}, len(fields))
+		for _, f := range fields {
+			if v, ok := full[f]; ok {
+				sparse[f] = v
+			}
+		}
+		result = append(result, sparse)
+	}
+	return result, nil
+}
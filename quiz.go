@@ -0,0 +1,148 @@
+// quiz.go
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// recallGrades are the self-grading options of a quiz card, best first.
+var recallGrades = []string{
+	"5 - Perfect, instant recall",
+	"4 - Correct after some thought",
+	"3 - Correct, but it was hard",
+	"2 - Wrong, but it felt familiar",
+	"1 - Wrong",
+	"0 - Complete blank",
+}
+
+// quizCandidates returns the indexes of solved problems to quiz on: due reviews first
+// (most overdue first), then random solved problems to fill up the count.
+func quizCandidates(problems []Problem, count int, now time.Time) []int {
+	today := startOfDay(now).AddDate(0, 0, 1)
+	var due, rest []int
+	for i, p := range problems {
+		if (!isSolved(p) && p.Review == nil) || isSnoozed(p, now) {
+			continue
+		}
+		if at, ok := nextReview(p); ok && at.Before(today) {
+			due = append(due, i)
+		} else {
+			rest = append(rest, i)
+		}
+	}
+	sort.SliceStable(due, func(a, b int) bool {
+		da, _ := nextReview(problems[due[a]])
+		db, _ := nextReview(problems[due[b]])
+		return da.Before(db)
+	})
+	rand.Shuffle(len(rest), func(a, b int) { rest[a], rest[b] = rest[b], rest[a] })
+
+	picked := append(due, rest...)
+	if len(picked) > count {
+		picked = picked[:count]
+	}
+	return picked
+}
+
+func quizCmd() *cobra.Command {
+	var count, minutes int
+	cmd := &cobra.Command{
+		Use:         "quiz",
+		Annotations: mutates(),
+		Short:       "Active-recall quiz on the techniques behind solved problems",
+		Long: "Shows a problem's name and tags, asks you to recall the core technique, then reveals your notes. " +
+			"Your self-graded answer schedules the next review (spaced repetition).",
+		Example: `  saitama quiz                # 10 cards, due reviews first
+  saitama quiz --count 5 --minutes 10`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			start := time.Now()
+			cards := quizCandidates(problems, count, start)
+			if len(cards) == 0 {
+				color.Yellow(tr("📝 Nothing to quiz on yet. Solve some problems first!"))
+				return
+			}
+			deadline := time.Time{}
+			if minutes > 0 {
+				deadline = start.Add(time.Duration(minutes) * time.Minute)
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("        🧠 RECALL QUIZ 🧠              "))
+			color.HiMagenta("═══════════════════════════════════════")
+
+			answered, totalQuality := 0, 0
+			for n, i := range cards {
+				if !deadline.IsZero() && time.Now().After(deadline) {
+					color.Yellow(tr("⏰ Time's up!"))
+					break
+				}
+				p := &problems[i]
+
+				fmt.Fprintln(stderr)
+				header := fmt.Sprintf("🃏 Card %d/%d", n+1, len(cards))
+				if !deadline.IsZero() {
+					header += fmt.Sprintf("  (⏳ %s left)", formatClock(time.Until(deadline)))
+				}
+				color.HiBlack(header)
+				color.HiCyan("🥊 %s - %s", p.ID, p.Name)
+				if len(p.Tags) > 0 {
+					color.Green("🏷️  %s", strings.Join(p.Tags, " • "))
+				}
+
+				answer := ""
+				if err := survey.AskOne(&survey.Input{Message: "💭 What's the core technique?"}, &answer); err != nil {
+					break
+				}
+
+				color.HiYellow(tr("📖 Your notes:"))
+				if strings.TrimSpace(p.Notes) == "" {
+					color.HiBlack(tr("   (no notes stored, add some with: saitama edit %s)"), p.ID)
+				} else {
+					for _, line := range strings.Split(strings.TrimSpace(p.Notes), "\n") {
+						fmt.Fprintf(stderr, "   %s\n", line)
+					}
+				}
+
+				grade := ""
+				if err := survey.AskOne(&survey.Select{Message: "How well did you recall it?", Options: recallGrades}, &grade); err != nil {
+					break
+				}
+				quality := int(grade[0] - '0')
+				applyRecall(p, quality, time.Now())
+				answered++
+				totalQuality += quality
+				color.Cyan(tr("📅 Next review in %d day(s)"), p.Review.Interval)
+			}
+
+			if answered == 0 {
+				color.Yellow(tr("Quiz cancelled."))
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			fmt.Fprintln(stderr)
+			color.HiGreen(tr("🎉 Quiz done: %d card(s), average recall %.1f/5 in %s"),
+				answered, float64(totalQuality)/float64(answered), formatClock(time.Since(start)))
+		},
+	}
+	cmd.Flags().IntVarP(&count, "count", "n", 10, "number of cards")
+	cmd.Flags().IntVarP(&minutes, "minutes", "m", 0, "time box for the whole quiz (0 for none)")
+	return cmd
+}
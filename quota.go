@@ -0,0 +1,280 @@
+// quota.go
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// GoalsConfig holds the weekly goals.
+type GoalsConfig struct {
+	Quotas map[string]int `json:"quotas,omitempty"` // solves per week by tag, e.g. {"graphs": 3, "dp": 2}
+	Daily  int            `json:"daily,omitempty"`  // solves per day, shown by today and prompt-status
+}
+
+// quotaLateWeekday is the day from which pick puts unmet quotas first: the week is half
+// gone by Thursday.
+const quotaLateWeekday = time.Thursday
+
+// quotaProgress is how far a tag quota is this week.
+type quotaProgress struct {
+	Tag    string `json:"tag"`
+	Target int    `json:"target"`
+	Done   int    `json:"done"`
+}
+
+// Left returns the solves still needed this week.
+func (q quotaProgress) Left() int {
+	return max(0, q.Target-q.Done)
+}
+
+// weekStart returns midnight of the Monday of the week containing t.
+func weekStart(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// lateInWeek reports whether the week is far enough along for unmet quotas to come first.
+func lateInWeek(now time.Time) bool {
+	wd := now.Local().Weekday()
+	return wd == time.Sunday || wd >= quotaLateWeekday
+}
+
+// weeklyQuotas returns the progress of every quota this week, sorted by tag. A solve
+// counts toward every quota its problem's tags match.
+func weeklyQuotas(problems []Problem, quotas map[string]int, now time.Time) []quotaProgress {
+	if len(quotas) == 0 {
+		return nil
+	}
+	start := weekStart(now)
+	events := activityLog(problems)
+	progress := make([]quotaProgress, 0, len(quotas))
+	for tag, target := range quotas {
+		q := quotaProgress{Tag: tag, Target: target}
+		for _, e := range events {
+			if e.Solved && !e.Date.Before(start) && slices.ContainsFunc(e.Tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				q.Done++
+			}
+		}
+		progress = append(progress, q)
+	}
+	sort.Slice(progress, func(i, j int) bool { return progress[i].Tag < progress[j].Tag })
+	return progress
+}
+
+// unmetQuotas returns the quotas not reached yet, the furthest behind first.
+func unmetQuotas(progress []quotaProgress) []quotaProgress {
+	var unmet []quotaProgress
+	for _, q := range progress {
+		if q.Left() > 0 {
+			unmet = append(unmet, q)
+		}
+	}
+	sort.SliceStable(unmet, func(i, j int) bool { return unmet[i].Left() > unmet[j].Left() })
+	return unmet
+}
+
+// biasTowardQuotas moves problems of the unmet quotas to the front of the candidates,
+// as many per quota as solves are left and at most n in all, keeping the order of the others.
+func biasTowardQuotas(candidates []Problem, unmet []quotaProgress, n int) []Problem {
+	taken := make(map[string]bool)
+	var front []Problem
+	for _, q := range unmet {
+		left := q.Left()
+		for _, p := range candidates {
+			if left == 0 || len(front) == n {
+				break
+			}
+			if !taken[p.ID] && hasTag(p, q.Tag) {
+				taken[p.ID] = true
+				front = append(front, p)
+				left--
+			}
+		}
+	}
+	for _, p := range candidates {
+		if !taken[p.ID] {
+			front = append(front, p)
+		}
+	}
+	return front
+}
+
+// formatQuotas prints the solves left of unmet quotas, e.g. "2 graphs, 1 dp".
+func formatQuotas(unmet []quotaProgress) string {
+	parts := make([]string, 0, len(unmet))
+	for _, q := range unmet {
+		parts = append(parts, fmt.Sprintf("%d %s", q.Left(), q.Tag))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// parseQuotas reads quotas written as "3 graphs + 2 dp", also accepting commas and
+// "graphs=3".
+func parseQuotas(expr string) (map[string]int, error) {
+	tokens := strings.Fields(strings.NewReplacer("+", " ", ",", " ").Replace(expr))
+	quotas := make(map[string]int)
+	for i := 0; i < len(tokens); i++ {
+		var tag, count string
+		if before, after, ok := strings.Cut(tokens[i], "="); ok {
+			tag, count = before, after
+		} else if i+1 < len(tokens) {
+			count, tag = tokens[i], tokens[i+1]
+			i++
+		}
+		n, err := strconv.Atoi(count)
+		tag = strings.ToLower(tag)
+		if err != nil || n <= 0 || tag == "" {
+			return nil, fmt.Errorf("invalid quotas '%s' (use e.g. \"3 graphs + 2 dp\")", expr)
+		}
+		quotas[tag] += n
+	}
+	if len(quotas) == 0 {
+		return nil, fmt.Errorf("no quota given (use e.g. \"3 graphs + 2 dp\")")
+	}
+	return quotas, nil
+}
+
+// printQuotas prints the progress of the weekly quotas, warning about the unmet ones
+// late in the week.
+func printQuotas(progress []quotaProgress, now time.Time) {
+	if len(progress) == 0 {
+		return
+	}
+	color.HiCyan(tr("🎯 Weekly quotas (week %s):"), weekKey(now))
+	for _, q := range progress {
+		line := fmt.Sprintf("%-20s %d/%d", q.Tag, q.Done, q.Target)
+		if q.Left() == 0 {
+			color.Green("   ✅ %s", line)
+		} else {
+			color.White("   ⬜ %s", line)
+		}
+	}
+	if unmet := unmetQuotas(progress); len(unmet) > 0 && lateInWeek(now) {
+		days := int(weekStart(now).AddDate(0, 0, 7).Sub(startOfDay(now)).Hours() / 24)
+		color.Yellow(tr("⏳ %d day(s) left this week and still to go: %s. pick puts them first."), days, formatQuotas(unmet))
+	}
+}
+
+func goalCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "goal",
+		Short: "Show the weekly per-tag quotas, the daily goal and your progress",
+		Long: "Quotas are solves per week by tag, e.g. 3 graphs and 2 dp problems. today shows how far you are, " +
+			"and from Thursday on pick puts problems of the unmet quotas first. Weeks start on Monday. " +
+			"The daily goal is a number of solves per day, shown by today and prompt-status.",
+		Example: `  saitama goal set "3 graphs + 2 dp"
+  saitama goal daily 2
+  saitama goal
+  saitama goal clear`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if len(cfg.Goals.Quotas) == 0 && cfg.Goals.Daily == 0 {
+				color.Yellow(tr("🎯 No weekly quotas yet. Set some with: saitama goal set \"3 graphs + 2 dp\""))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			now := time.Now()
+			progress := weeklyQuotas(problems, cfg.Goals.Quotas, now)
+			if jsonOutput() {
+				printJSON(progress)
+				return
+			}
+			if cfg.Goals.Daily > 0 {
+				done := solvesPerDay(activityLog(problems))[dayKey(now)]
+				color.HiCyan(tr("🎯 Daily goal: %d/%d solve(s) today"), done, cfg.Goals.Daily)
+			}
+			printQuotas(progress, now)
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "set <quotas>",
+		Annotations: mutates(),
+		Short:       "Set the weekly per-tag quotas, e.g. \"3 graphs + 2 dp\"",
+		Example:     `  saitama goal set "3 graphs + 2 dp"` + "\n" + `  saitama goal set graphs=3 dp=2`,
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			quotas, err := parseQuotas(strings.Join(args, " "))
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Goals.Quotas = quotas
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			color.Green(tr("🎯 Weekly quotas: %s"), formatQuotas(weeklyQuotas(nil, quotas, time.Now())))
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "daily <solves>",
+		Annotations: mutates(),
+		Short:       "Set the number of solves per day to aim for, 0 to remove it",
+		Example:     `  saitama goal daily 2`,
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			n, err := strconv.Atoi(args[0])
+			if err != nil || n < 0 {
+				printError(tr("❌ Invalid daily goal '%s' (use a number of solves, 0 to remove it)"), args[0])
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Goals.Daily = n
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			if n == 0 {
+				color.Green(tr("🧹 Daily goal removed."))
+				return
+			}
+			color.Green(tr("🎯 Daily goal: %d solve(s)"), n)
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear",
+		Annotations: mutates(),
+		Short:       "Remove the weekly quotas",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Goals.Quotas = nil
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			color.Green(tr("🧹 Weekly quotas removed."))
+		},
+	})
+	return cmd
+}
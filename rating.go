@@ -0,0 +1,172 @@
+// rating.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// The personal rating is an Elo estimate on the scale of the problem ratings
+// (Codeforces-style): every first try at a rated problem is a game against the problem.
+const (
+	ratingStart       = 1200.0 // assumed before the first rated attempt
+	ratingScale       = 400.0  // a rating gap of 400 gives 10:1 odds
+	ratingMaxK        = 160.0  // step of the first attempts, so that the estimate converges fast
+	ratingMinK        = 32.0   // step once the estimate has settled
+	ratingProvisional = 10     // rated attempts below which the estimate is provisional
+	ratingStretch     = 200    // pick --around-my-rating targets problems up to this much above the rating
+	ratingWeeks       = 12     // weeks in the trajectory of stats
+)
+
+// ratingPoint is the estimate right after a rated attempt.
+type ratingPoint struct {
+	Date          time.Time `json:"date"`
+	ProblemID     string    `json:"problem_id"`
+	ProblemRating int       `json:"problem_rating"`
+	Score         float64   `json:"score"` // 1 solved, 0.5 solved with hints, 0 failed
+	Rating        float64   `json:"rating"`
+}
+
+// expectedScore returns the chance to solve a problem of the rating, in the Elo model.
+func expectedScore(rating float64, problemRating int) float64 {
+	return 1 / (1 + math.Pow(10, (float64(problemRating)-rating)/ratingScale))
+}
+
+// ratingK returns the step of the n-th rated attempt (from 0): large at first, then
+// shrinking to ratingMinK.
+func ratingK(n int) float64 {
+	return max(ratingMinK, ratingMaxK/math.Sqrt(float64(n+1)))
+}
+
+// ratedGames returns the attempts that count toward the rating, oldest first: the
+// attempts at rated problems up to the first solve, as re-solves are reviews, not
+// new evidence. Problems solved without recorded attempts count as one solve.
+func ratedGames(problems []Problem) []ratingPoint {
+	var games []ratingPoint
+	for _, p := range problems {
+		if p.Rating <= 0 {
+			continue
+		}
+		if len(p.Attempts) == 0 {
+			if !p.LastSolved.IsZero() {
+				games = append(games, ratingPoint{Date: p.LastSolved, ProblemID: p.ID, ProblemRating: p.Rating, Score: 1})
+			}
+			continue
+		}
+		attempts := append([]Attempt{}, p.Attempts...)
+		sort.SliceStable(attempts, func(i, j int) bool { return attempts[i].Date.Before(attempts[j].Date) })
+		for _, a := range attempts {
+			score := 0.0
+			if a.Solved {
+				score = 1
+				if a.HintsUsed > 0 {
+					score = 0.5
+				}
+			}
+			games = append(games, ratingPoint{Date: a.Date, ProblemID: p.ID, ProblemRating: p.Rating, Score: score})
+			if a.Solved {
+				break
+			}
+		}
+	}
+	sort.SliceStable(games, func(i, j int) bool { return games[i].Date.Before(games[j].Date) })
+	return games
+}
+
+// estimateRating replays the rated attempts, archived ones included, and returns the
+// estimate after each of them.
+func estimateRating(problems []Problem) []ratingPoint {
+	games := ratedGames(withArchivedAttempts(problems))
+	rating := ratingStart
+	for i := range games {
+		g := &games[i]
+		rating += ratingK(i) * (g.Score - expectedScore(rating, g.ProblemRating))
+		g.Rating = rating
+	}
+	return games
+}
+
+// currentRating returns the latest estimate, and false without any rated attempt.
+func currentRating(history []ratingPoint) (float64, bool) {
+	if len(history) == 0 {
+		return 0, false
+	}
+	return history[len(history)-1].Rating, true
+}
+
+// ratingAt returns the estimate at the given time, or false before the first rated attempt.
+func ratingAt(history []ratingPoint, at time.Time) (float64, bool) {
+	i := sort.Search(len(history), func(i int) bool { return history[i].Date.After(at) })
+	if i == 0 {
+		return 0, false
+	}
+	return history[i-1].Rating, true
+}
+
+// ratingTrajectory returns the estimate at the end of each of the last weeks, oldest
+// first, with 0 for the weeks before the first rated attempt.
+func ratingTrajectory(history []ratingPoint, weeks int, now time.Time) []int {
+	start := weekStart(now).AddDate(0, 0, -7*(weeks-1))
+	points := make([]int, weeks)
+	for i := range points {
+		end := start.AddDate(0, 0, 7*(i+1))
+		if end.After(now) {
+			end = now
+		}
+		if r, ok := ratingAt(history, end); ok {
+			points[i] = int(math.Round(r))
+		}
+	}
+	return points
+}
+
+// ratingSparkline draws the trajectory relative to its lowest point, since sparkline
+// scales from zero and ratings differ by little compared to their size.
+func ratingSparkline(points []int) string {
+	low := 0
+	for _, r := range points {
+		if r > 0 && (low == 0 || r < low) {
+			low = r
+		}
+	}
+	shifted := make([]int, len(points))
+	for i, r := range points {
+		if r > 0 {
+			shifted[i] = r - low + 25 // the lowest point still shows as a low bar
+		}
+	}
+	return sparkline(shifted)
+}
+
+// ratingWindow returns the problem ratings targeted by pick --around-my-rating: from
+// the rating, rounded as shown in stats, to ratingStretch above it.
+func ratingWindow(rating float64) (int, int) {
+	low := int(math.Round(rating))
+	return low, low + ratingStretch
+}
+
+// printRating shows the estimate and its trajectory in stats.
+func printRating(history []ratingPoint, now time.Time) {
+	rating, ok := currentRating(history)
+	if !ok {
+		return
+	}
+	line := fmt.Sprintf(tr("📐 Estimated rating: %d"), int(math.Round(rating)))
+	if len(history) < ratingProvisional {
+		line += fmt.Sprintf(tr(" (provisional, %d rated attempt(s))"), len(history))
+	} else {
+		line += fmt.Sprintf(tr(" (%d rated attempts)"), len(history))
+	}
+	points := ratingTrajectory(history, ratingWeeks, now)
+	color.HiYellow("%s  %s", line, color.HiCyanString("%s", ratingSparkline(points)))
+	if points[0] > 0 {
+		delta := points[len(points)-1] - points[0]
+		color.HiBlack(tr("   %+d over the last %d weeks. Train just above it with: saitama pick --around-my-rating"), delta, ratingWeeks)
+	} else {
+		color.HiBlack(tr("   Train just above it with: saitama pick --around-my-rating"))
+	}
+}
@@ -0,0 +1,143 @@
+// rating_test.go
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpectedScore(t *testing.T) {
+	tests := []struct {
+		rating        float64
+		problemRating int
+		want          float64
+	}{
+		{1500, 1500, 0.5},
+		{1500, 1900, 1.0 / 11},
+		{1900, 1500, 10.0 / 11},
+		{1200, 2000, 1.0 / 101},
+	}
+	for _, tt := range tests {
+		if got := expectedScore(tt.rating, tt.problemRating); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("expectedScore(%v, %d) = %v, want %v", tt.rating, tt.problemRating, got, tt.want)
+		}
+	}
+}
+
+func TestRatingK(t *testing.T) {
+	tests := []struct {
+		n    int
+		want float64
+	}{
+		{0, ratingMaxK},
+		{3, ratingMaxK / 2},
+		{15, ratingMaxK / 4},
+		{24, ratingMinK},
+		{1000, ratingMinK},
+	}
+	for _, tt := range tests {
+		if got := ratingK(tt.n); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("ratingK(%d) = %v, want %v", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestRatedGames(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 12, 0, 0, 0, time.UTC) }
+	problems := []Problem{
+		{ID: "CF1A", Rating: 1400, Attempts: []Attempt{ // out of order, as after a merge
+			{Date: day(5), Solved: true},
+			{Date: day(2), Solved: false},
+			{Date: day(3), Solved: true, HintsUsed: 1},
+			{Date: day(4), Solved: false},
+		}},
+		{ID: "LC1", Attempts: []Attempt{{Date: day(1), Solved: true}}}, // no rating
+		{ID: "CF2B", Rating: 1600, LastSolved: day(1)},                 // solved before attempts were logged
+		{ID: "CF3C", Rating: 1800},                                     // never tried
+		{ID: "CF4D", Rating: 2000, Attempts: []Attempt{{Date: day(6), Solved: false}, {Date: day(7), Solved: false}}},
+	}
+	var got []string
+	for _, g := range ratedGames(problems) {
+		got = append(got, fmt.Sprintf("%s %d %v", g.ProblemID, g.Date.Day(), g.Score))
+	}
+	want := []string{"CF2B 1 1", "CF1A 2 0", "CF1A 3 0.5", "CF4D 6 0", "CF4D 7 0"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ratedGames = %q, want %q", got, want)
+	}
+}
+
+func TestEstimateRatingConverges(t *testing.T) {
+	useTempDataDir(t)
+	// Always solves problems rated 1500 and never those rated 1700: the estimate should
+	// settle halfway.
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var problems []Problem
+	for i := range 200 {
+		rating, solved := 1500, true
+		if i%2 == 1 {
+			rating, solved = 1700, false
+		}
+		at := start.Add(time.Duration(i) * time.Hour)
+		problems = append(problems, Problem{ID: fmt.Sprintf("CF%d", i), Rating: rating, Attempts: []Attempt{{Date: at, Solved: solved}}})
+	}
+	history := estimateRating(problems)
+	if len(history) != len(problems) {
+		t.Fatalf("got %d rated games, want %d", len(history), len(problems))
+	}
+	if history[0].Rating <= ratingStart {
+		t.Errorf("a first solve above the start rating should raise it, got %v", history[0].Rating)
+	}
+	rating, _ := currentRating(history)
+	if math.Abs(rating-1600) > 25 {
+		t.Errorf("estimate = %.1f, want about 1600", rating)
+	}
+}
+
+func TestRatingTrajectory(t *testing.T) {
+	// Wednesday 2026-03-18: the weeks start on Mondays 03-02, 03-09 and 03-16.
+	now := time.Date(2026, 3, 18, 15, 0, 0, 0, time.Local)
+	at := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 10, 0, 0, 0, time.Local) }
+	history := []ratingPoint{
+		{Date: at(3, 10), Rating: 1250.4},
+		{Date: at(3, 12), Rating: 1301.6},
+		{Date: at(3, 17), Rating: 1280},
+		{Date: at(3, 19), Rating: 1400}, // after now
+	}
+	tests := []struct {
+		name    string
+		history []ratingPoint
+		weeks   int
+		want    []int
+	}{
+		{"empty", nil, 3, []int{0, 0, 0}},
+		{"before the first attempt", history, 3, []int{0, 1302, 1280}},
+		{"one week", history, 1, []int{1280}},
+		{"older history", []ratingPoint{{Date: at(2, 1), Rating: 1199.5}}, 2, []int{1200, 1200}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ratingTrajectory(tt.history, tt.weeks, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ratingTrajectory = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRatingWindow(t *testing.T) {
+	tests := []struct {
+		rating    float64
+		low, high int
+	}{
+		{1549.6, 1550, 1750},
+		{1450.2, 1450, 1650},
+		{1200, 1200, 1400},
+	}
+	for _, tt := range tests {
+		if low, high := ratingWindow(tt.rating); low != tt.low || high != tt.high {
+			t.Errorf("ratingWindow(%v) = %d-%d, want %d-%d", tt.rating, low, high, tt.low, tt.high)
+		}
+	}
+}
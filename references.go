@@ -0,0 +1,178 @@
+// references.go
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Reference is a labeled link about a problem besides its page and editorial, e.g. a
+// blog post or a video explaining the solution.
+type Reference struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// validateLink checks that a link is an absolute http(s) URL.
+func validateLink(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid link '%s' (use a full http(s) URL)", raw)
+	}
+	return nil
+}
+
+// parseReference reads a reference given as label=url.
+func parseReference(s string) (Reference, error) {
+	label, link, ok := strings.Cut(s, "=")
+	label, link = strings.TrimSpace(label), strings.TrimSpace(link)
+	if !ok || label == "" {
+		return Reference{}, fmt.Errorf("invalid reference '%s' (use label=url, e.g. blog=https://...)", s)
+	}
+	if err := validateLink(link); err != nil {
+		return Reference{}, err
+	}
+	return Reference{Label: label, URL: link}, nil
+}
+
+// findReference returns the index of the reference with the label, case-insensitively,
+// or -1.
+func findReference(p Problem, label string) int {
+	for i, r := range p.References {
+		if strings.EqualFold(r.Label, label) {
+			return i
+		}
+	}
+	return -1
+}
+
+// setReference adds a reference, replacing the one with the same label.
+func setReference(p *Problem, ref Reference) {
+	if i := findReference(*p, ref.Label); i >= 0 {
+		p.References[i] = ref
+		return
+	}
+	p.References = append(p.References, ref)
+}
+
+// removeReference drops the reference with the label and reports whether there was one.
+func removeReference(p *Problem, label string) bool {
+	i := findReference(*p, label)
+	if i < 0 {
+		return false
+	}
+	p.References = append(p.References[:i], p.References[i+1:]...)
+	return true
+}
+
+// editLinks applies the link flags of edit to a problem.
+func editLinks(p *Problem, editorial string, refs, removed []string) error {
+	if editorial != "" && editorial != "none" {
+		if err := validateLink(editorial); err != nil {
+			return err
+		}
+		p.EditorialURL = editorial
+	} else if editorial == "none" {
+		p.EditorialURL = ""
+	}
+	for _, label := range removed {
+		if !removeReference(p, label) {
+			return fmt.Errorf("%s has no reference '%s'", p.ID, label)
+		}
+	}
+	for _, s := range refs {
+		ref, err := parseReference(s)
+		if err != nil {
+			return err
+		}
+		setReference(p, ref)
+	}
+	return nil
+}
+
+// printReferences shows the editorial and the references of a problem in show.
+func printReferences(p Problem) {
+	if p.EditorialURL != "" {
+		printResult(color.New(color.FgWhite), tr("📖 Editorial: %s"), p.EditorialURL)
+	}
+	for _, r := range p.References {
+		printResult(color.New(color.FgWhite), "📎 %s: %s", r.Label, r.URL)
+	}
+}
+
+func openCmd() *cobra.Command {
+	var editorial, printOnly bool
+	var ref string
+	cmd := &cobra.Command{
+		Use:   "open <id>",
+		Short: "Open a problem, its editorial or one of its references in the browser",
+		Long: "Opens the problem page, or with --editorial its official solution, e.g. after an attempt. " +
+			"Editorials are filled in by enrich, refresh and imports when the platform provides them (LeetCode); " +
+			"set them and add references such as blog posts with 'saitama edit <id> --editorial <url> --ref label=url'.",
+		Example: `  saitama open LC42
+  saitama open LC42 --editorial
+  saitama open CF1915F --ref blog
+  saitama open LC42 --editorial --print   # Only print the link`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if editorial && ref != "" {
+				printError(tr("❌ Use either --editorial or --ref"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			targetID := strings.ToUpper(args[0])
+			p, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+
+			link := p.URL
+			switch {
+			case editorial:
+				link = p.EditorialURL
+				if link == "" {
+					color.Yellow(tr("📖 %s has no editorial."), p.ID)
+					color.Cyan(tr("💡 Look it up with saitama enrich, or set it with: saitama edit %s --editorial <url>"), p.ID)
+					return
+				}
+			case ref != "":
+				i := findReference(*p, ref)
+				if i < 0 {
+					var labels []string
+					for _, r := range p.References {
+						labels = append(labels, r.Label)
+					}
+					printError(tr("❌ %s has no reference '%s' (references: %s)"), p.ID, ref, orNone(strings.Join(labels, ", ")))
+					return
+				}
+				link = p.References[i].URL
+			case link == "":
+				color.Yellow(tr("🔗 %s has no URL."), p.ID)
+				return
+			}
+
+			if printOnly {
+				fmt.Fprintln(stdout, link)
+				return
+			}
+			if err := openURL(link); err != nil {
+				printError(tr("❌ Could not open %s: %v"), link, err)
+				return
+			}
+			color.HiBlack(tr("🔗 Opened %s"), link)
+		},
+	}
+	cmd.Flags().BoolVar(&editorial, "editorial", false, "open the editorial (official solution) instead of the problem")
+	cmd.Flags().StringVar(&ref, "ref", "", "open the reference with this label instead of the problem")
+	cmd.Flags().BoolVar(&printOnly, "print", false, "print the link instead of opening it")
+	return cmd
+}
@@ -0,0 +1,251 @@
+// refresh.go
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// refreshResult is what the workers found out about one problem.
+type refreshResult struct {
+	Meta      problemMetadata
+	Known     bool  // the platform knows the problem
+	LookupErr error // the platform could not be queried
+	Status    int   // HTTP status of the problem URL, 0 if not checked
+	LinkErr   error // the URL could not be reached at all
+}
+
+// fieldChange is one field of a problem that differs from the platform's metadata, or
+// between two snapshots (see diff).
+type fieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+	apply func(p *Problem)
+}
+
+// refreshChanges compares a problem with fresh metadata from its platform.
+func refreshChanges(p Problem, meta problemMetadata) []fieldChange {
+	var changes []fieldChange
+	if meta.Title != "" && meta.Title != p.Name {
+		title := meta.Title
+		changes = append(changes, fieldChange{"name", p.Name, title, func(p *Problem) { p.Name = title }})
+	}
+	if meta.Difficulty != "" && !strings.EqualFold(meta.Difficulty, p.Difficulty) {
+		difficulty := meta.Difficulty
+		changes = append(changes, fieldChange{"difficulty", p.Difficulty, difficulty, func(p *Problem) { p.Difficulty = difficulty }})
+	}
+	if meta.Rating > 0 && meta.Rating != p.Rating {
+		rating := meta.Rating
+		changes = append(changes, fieldChange{"rating", strconv.Itoa(p.Rating), strconv.Itoa(rating), func(p *Problem) { p.Rating = rating }})
+	}
+	if meta.URL != "" && normalizeURL(meta.URL) != normalizeURL(p.URL) {
+		url := meta.URL
+		changes = append(changes, fieldChange{"url", p.URL, url, func(p *Problem) { p.URL = url }})
+	}
+	if meta.Editorial != "" && p.EditorialURL == "" {
+		editorial := meta.Editorial
+		changes = append(changes, fieldChange{"editorial_url", "", editorial, func(p *Problem) { p.EditorialURL = editorial }})
+	}
+	return changes
+}
+
+// checkLink requests a problem URL and returns its HTTP status. HEAD is tried first,
+// falling back to GET for sites that don't support it.
+func checkLink(raw string) (int, error) {
+	status := 0
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		req, err := http.NewRequest(method, raw, nil)
+		if err != nil {
+			return 0, err
+		}
+		req.Header.Set("User-Agent", "saitama-cli")
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		status = resp.StatusCode
+		if status != http.StatusMethodNotAllowed && status != http.StatusNotImplemented {
+			break
+		}
+	}
+	return status, nil
+}
+
+// isDeadLink reports whether a status means the problem page is gone.
+func isDeadLink(status int) bool {
+	return status == http.StatusNotFound || status == http.StatusGone
+}
+
+// refreshAll looks up every problem in a bounded pool of workers. progress is called
+// after each problem with the number of problems done.
+func refreshAll(problems []Problem, indexes []int, workers int, checkLinks bool, progress func(done int)) map[int]refreshResult {
+	jobs := make(chan int)
+	results := make(map[int]refreshResult, len(indexes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for w := 0; w < max(1, workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				p := problems[i]
+				var r refreshResult
+				if adapter := adapterFor(p); adapter != nil {
+					r.Meta, r.Known, r.LookupErr = adapter.Lookup(p)
+				}
+				if checkLinks && p.URL != "" {
+					r.Status, r.LinkErr = checkLink(p.URL)
+				}
+
+				mu.Lock()
+				results[i] = r
+				done := len(results)
+				mu.Unlock()
+				progress(done)
+			}
+		}()
+	}
+	for _, i := range indexes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func refreshCmd() *cobra.Command {
+	var workers int
+	var yes, dryRun, noLinks bool
+	cmd := &cobra.Command{
+		Use:         "refresh",
+		Annotations: mutates(),
+		Short:       "Re-fetch problem metadata from the platforms and find broken links",
+		Long: "Looks every problem up on its platform (LeetCode, Codeforces) to catch renamed titles, " +
+			"difficulty or rating changes and moved URLs, and checks every URL for 404s. Changes are " +
+			"shown for confirmation before they are applied; broken links are only reported.",
+		Example: `  saitama refresh
+  saitama refresh --workers 16 --yes
+  saitama refresh --no-links   # Skip the URL checks`,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			forceRefetch = true
+
+			var indexes []int
+			for i, p := range problems {
+				if adapterFor(p) != nil || (!noLinks && p.URL != "") {
+					indexes = append(indexes, i)
+				}
+			}
+			if len(indexes) == 0 {
+				color.Yellow(tr("🤷 No problems with a known platform or URL to refresh."))
+				return
+			}
+
+			color.Cyan(tr("🔄 Refreshing %d problem(s) with %d workers..."), len(indexes), workers)
+			bar := newProgressBar("🔄 Refreshing", int64(len(indexes)))
+			var barMu sync.Mutex
+			results := refreshAll(problems, indexes, workers, !noLinks, func(done int) {
+				barMu.Lock()
+				defer barMu.Unlock()
+				bar.Update(int64(done), fmt.Sprintf("%d/%d", done, len(indexes)))
+			})
+			bar.Finish(int64(len(indexes)), fmt.Sprintf("%d/%d", len(indexes), len(indexes)))
+
+			failed := make(map[string]error)
+			var dead, unreachable []int
+			changes := make(map[int][]fieldChange)
+			total := 0
+			for _, i := range indexes {
+				r := results[i]
+				if r.LookupErr != nil {
+					failed[adapterFor(problems[i]).Platform()] = r.LookupErr
+				} else if r.Known {
+					if c := refreshChanges(problems[i], r.Meta); len(c) > 0 {
+						changes[i] = c
+						total += len(c)
+					}
+				}
+				switch {
+				case r.LinkErr != nil:
+					unreachable = append(unreachable, i)
+				case isDeadLink(r.Status):
+					dead = append(dead, i)
+				}
+			}
+
+			fmt.Fprintln(stderr)
+			for platform, err := range failed {
+				color.Yellow(tr("⚠️  %s lookups skipped: %v"), platform, err)
+			}
+			if len(dead) > 0 {
+				color.Red(tr("💀 %d broken link(s):"), len(dead))
+				for _, i := range dead {
+					color.Red("   %s - %s: %s (%d)", problems[i].ID, problems[i].Name, problems[i].URL, results[i].Status)
+				}
+				color.Cyan(tr("💡 Fix them with: saitama edit <id>"))
+			}
+			if len(unreachable) > 0 {
+				color.Yellow(tr("🌐 %d link(s) could not be checked (network error)"), len(unreachable))
+			}
+
+			if len(changes) == 0 {
+				if len(failed) > 0 {
+					color.Yellow(tr("🤷 No changes among the problems that could be looked up."))
+				} else {
+					color.Green(tr("✅ All metadata is up to date."))
+				}
+				return
+			}
+			color.HiCyan(tr("📝 %d change(s) on %d problem(s):"), total, len(changes))
+			for _, i := range indexes {
+				if c, ok := changes[i]; ok {
+					color.HiYellow("   %s - %s", problems[i].ID, problems[i].Name)
+					for _, change := range c {
+						fmt.Fprintf(stderr, "      %s: %q → %q\n", change.Field, change.Old, change.New)
+					}
+				}
+			}
+
+			if dryRun {
+				color.Yellow(tr("🔍 Dry run: nothing was changed."))
+				return
+			}
+			if !yes {
+				apply := false
+				if err := survey.AskOne(&survey.Confirm{Message: "Apply these changes?"}, &apply); err != nil || !apply {
+					color.Yellow(tr("Refresh cancelled."))
+					return
+				}
+			}
+			for i, c := range changes {
+				for _, change := range c {
+					change.apply(&problems[i])
+				}
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Updated %d problem(s)."), len(changes))
+		},
+	}
+	cmd.Flags().IntVarP(&workers, "workers", "w", 8, "number of concurrent lookups")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply the changes without asking")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "only show what would change")
+	cmd.Flags().BoolVar(&noLinks, "no-links", false, "don't check URLs for broken links")
+	return cmd
+}
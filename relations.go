@@ -0,0 +1,245 @@
+// relations.go
+package main
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Relation types. A relation stored on problem A with target B reads as
+// "A <type> B", e.g. LC70 prerequisite LC746 means LC70 is a prerequisite of LC746.
+const (
+	RelationSimilar      = "similar"
+	RelationFollowUp     = "followup"
+	RelationPrerequisite = "prerequisite"
+)
+
+var relationTypes = []string{RelationSimilar, RelationFollowUp, RelationPrerequisite}
+
+// Relation links a problem to another problem in the collection.
+type Relation struct {
+	Type   string `json:"type"`
+	Target string `json:"target"`
+}
+
+// isValidRelationType reports whether t is one of the known relation types.
+func isValidRelationType(t string) bool {
+	for _, rt := range relationTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// describeRelation returns a human readable label for a relation as seen from
+// the problem that owns it (outgoing) or from its target (incoming).
+func describeRelation(relType string, incoming bool) string {
+	switch relType {
+	case RelationSimilar:
+		return "similar to"
+	case RelationFollowUp:
+		if incoming {
+			return "followed up by"
+		}
+		return "follow-up of"
+	case RelationPrerequisite:
+		if incoming {
+			return "requires"
+		}
+		return "prerequisite of"
+	}
+	return relType
+}
+
+// incomingRelations returns every relation in the collection that points at id,
+// keyed by the ID of the problem that owns it.
+func incomingRelations(problems []Problem, id string) map[string][]Relation {
+	incoming := make(map[string][]Relation)
+	for _, p := range problems {
+		for _, r := range p.Relations {
+			if r.Target == id {
+				incoming[p.ID] = append(incoming[p.ID], r)
+			}
+		}
+	}
+	return incoming
+}
+
+// removeRelationsTo strips every relation pointing at id and returns how many were removed.
+func removeRelationsTo(problems []Problem, id string) int {
+	removed := 0
+	for i := range problems {
+		var kept []Relation
+		for _, r := range problems[i].Relations {
+			if r.Target == id {
+				removed++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		problems[i].Relations = kept
+	}
+	return removed
+}
+
+// followUpsOf returns the problems that should be tackled after the problem with the given ID:
+// problems that are a follow-up of it, and problems it is a prerequisite of.
+func followUpsOf(problems []Problem, id string) []Problem {
+	var result []Problem
+	seen := make(map[string]bool)
+	add := func(target string) {
+		if seen[target] || target == id {
+			return
+		}
+		if p, index := findProblemByID(problems, target); index != -1 {
+			seen[target] = true
+			result = append(result, *p)
+		}
+	}
+
+	for _, p := range problems {
+		for _, r := range p.Relations {
+			if p.ID == id && r.Type == RelationPrerequisite {
+				add(r.Target)
+			}
+			if r.Target == id && r.Type == RelationFollowUp {
+				add(p.ID)
+			}
+		}
+	}
+	return result
+}
+
+func linkCmd() *cobra.Command {
+	var relType string
+	cmd := &cobra.Command{
+		Use:         "link <id> <target-id>",
+		Annotations: mutates(),
+		Short:       "Link two problems (similar, followup, prerequisite)",
+		Long: "Create a relation between two problems. The relation reads as '<id> <type> <target-id>', " +
+			"e.g. 'saitama link LC70 LC746 --type prerequisite' marks LC70 as a prerequisite of LC746.",
+		Example: `  saitama link LC3 LC159 --type similar
+  saitama link LC70 LC746 --type prerequisite
+  saitama link LC213 LC198 --type followup`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			relType = strings.ToLower(relType)
+			if !isValidRelationType(relType) {
+				printError(tr("❌ Unknown relation type '%s' (use one of: %s)"), relType, strings.Join(relationTypes, ", "))
+				return
+			}
+
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			sourceID := strings.ToUpper(args[0])
+			targetID := strings.ToUpper(args[1])
+			if sourceID == targetID {
+				printError(tr("❌ A problem cannot be linked to itself"))
+				return
+			}
+
+			source, index := findProblemByID(problems, sourceID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), sourceID)
+				return
+			}
+			if _, targetIndex := findProblemByID(problems, targetID); targetIndex == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+
+			for _, r := range source.Relations {
+				if r.Target == targetID && r.Type == relType {
+					color.Yellow(tr("⚠️  %s is already linked to %s as '%s'"), sourceID, targetID, relType)
+					return
+				}
+			}
+
+			problems[index].Relations = append(problems[index].Relations, Relation{Type: relType, Target: targetID})
+
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Linked: %s is %s %s"), sourceID, describeRelation(relType, false), targetID)
+		},
+	}
+	cmd.Flags().StringVarP(&relType, "type", "t", RelationSimilar, "relation type: similar, followup or prerequisite")
+	return cmd
+}
+
+func unlinkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "unlink <id> <target-id>",
+		Annotations: mutates(),
+		Short:       "Remove the relations between two problems",
+		Example:     `  saitama unlink LC1 LC15`,
+		Args:        cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			sourceID := strings.ToUpper(args[0])
+			targetID := strings.ToUpper(args[1])
+
+			removed := 0
+			for i := range problems {
+				if problems[i].ID != sourceID && problems[i].ID != targetID {
+					continue
+				}
+				var kept []Relation
+				for _, r := range problems[i].Relations {
+					if (problems[i].ID == sourceID && r.Target == targetID) || (problems[i].ID == targetID && r.Target == sourceID) {
+						removed++
+						continue
+					}
+					kept = append(kept, r)
+				}
+				problems[i].Relations = kept
+			}
+
+			if removed == 0 {
+				color.Yellow(tr("🔗 No relations found between %s and %s"), sourceID, targetID)
+				return
+			}
+
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Removed %d relation(s) between %s and %s"), removed, sourceID, targetID)
+		},
+	}
+}
+
+// printRelations prints the outgoing and incoming relations of a problem.
+func printRelations(problems []Problem, p *Problem) {
+	incoming := incomingRelations(problems, p.ID)
+	if len(p.Relations) == 0 && len(incoming) == 0 {
+		return
+	}
+
+	printResult(color.New(color.FgHiCyan), tr("🔗 Related problems:"))
+	for _, r := range p.Relations {
+		name := ""
+		if target, index := findProblemByID(problems, r.Target); index != -1 {
+			name = target.Name
+		}
+		printResult(color.New(color.FgCyan), "   • %s %s - %s", describeRelation(r.Type, false), r.Target, name)
+	}
+	for _, other := range problems {
+		for _, r := range incoming[other.ID] {
+			printResult(color.New(color.FgCyan), "   • %s %s - %s", describeRelation(r.Type, true), other.ID, other.Name)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// replace.go
+package main
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// replaceFields are the problem fields the replace command can edit.
+var replaceFields = []string{"name", "tags", "platform", "notes"}
+
+// replacement is a single pending change to one field of one problem.
+type replacement struct {
+	Index    int
+	Field    string
+	Old, New string
+}
+
+// fieldValue returns the current text of an editable field. Tags are joined by commas.
+func fieldValue(p Problem, field string) string {
+	switch field {
+	case "name":
+		return p.Name
+	case "tags":
+		return strings.Join(p.Tags, ", ")
+	case "platform":
+		return p.Platform
+	case "notes":
+		return p.Notes
+	}
+	return ""
+}
+
+// setFieldValue stores new text into an editable field, normalizing tags and platform.
+func setFieldValue(p *Problem, field, value string) {
+	switch field {
+	case "name":
+		p.Name = value
+	case "tags":
+		seen := make(map[string]bool)
+		p.Tags = nil
+		for _, tag := range parseTags(value) {
+			if !seen[tag] {
+				seen[tag] = true
+				p.Tags = append(p.Tags, tag)
+			}
+		}
+	case "platform":
+		p.Platform = strings.ToLower(strings.TrimSpace(value))
+	case "notes":
+		p.Notes = value
+	}
+}
+
+// findReplacements computes every change the pattern would make. Tags are matched one
+// by one so that anchors like ^ and $ apply to a single tag.
+func findReplacements(problems []Problem, fields []string, pattern *regexp.Regexp, repl string, literal bool) []replacement {
+	apply := func(s string) string {
+		if literal {
+			return pattern.ReplaceAllLiteralString(s, repl)
+		}
+		return pattern.ReplaceAllString(s, repl)
+	}
+
+	var changes []replacement
+	for i, p := range problems {
+		for _, field := range fields {
+			old := fieldValue(p, field)
+			var updated string
+			if field == "tags" {
+				tags := make([]string, len(p.Tags))
+				for j, tag := range p.Tags {
+					tags[j] = apply(tag)
+				}
+				updated = strings.Join(tags, ", ")
+			} else {
+				updated = apply(old)
+			}
+			if updated != old {
+				changes = append(changes, replacement{Index: i, Field: field, Old: old, New: updated})
+			}
+		}
+	}
+	return changes
+}
+
+func replaceCmd() *cobra.Command {
+	var fields []string
+	var find, repl string
+	var useRegex, ignoreCase, dryRun, yes bool
+	cmd := &cobra.Command{
+		Use:         "replace",
+		Annotations: mutates(),
+		Short:       "Find and replace text across problem fields",
+		Long:        "Bulk-edit names, tags, platforms or notes. Each change is previewed and confirmed one by one unless --yes is given.",
+		Example: `  saitama replace --field name --find "Leetcode" --replace "LeetCode"
+  saitama replace --field tags --find "^dynamic-programming$" --replace dp --regex
+  saitama replace --field name,notes --find "(\d+)sum" --replace '${1}Sum' --regex -i --dry-run`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if find == "" {
+				printError(tr("❌ --find is required"))
+				return
+			}
+			for i, f := range fields {
+				fields[i] = strings.ToLower(strings.TrimSpace(f))
+				valid := false
+				for _, known := range replaceFields {
+					valid = valid || fields[i] == known
+				}
+				if !valid {
+					printError(tr("❌ Unknown field '%s' (use %s)"), f, strings.Join(replaceFields, ", "))
+					return
+				}
+			}
+
+			expr := find
+			if !useRegex {
+				expr = regexp.QuoteMeta(find)
+			}
+			if ignoreCase {
+				expr = "(?i)" + expr
+			}
+			pattern, err := regexp.Compile(expr)
+			if err != nil {
+				printError(tr("❌ Invalid pattern: %v"), err)
+				return
+			}
+
+			// The accepted changes are applied in one transaction, so cancelling or a
+			// failed save never leaves half of them written.
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+			problems := tx.Problems()
+
+			changes := findReplacements(problems, fields, pattern, repl, !useRegex)
+			if len(changes) == 0 {
+				color.Yellow(tr("🤷 No matches found."))
+				return
+			}
+
+			var accepted []replacement
+			for _, c := range changes {
+				p := problems[c.Index]
+				color.Cyan("📝 %s - %s [%s]", p.ID, p.Name, c.Field)
+				color.Red("   - %s", c.Old)
+				color.Green("   + %s", c.New)
+				if dryRun {
+					continue
+				}
+				if !yes {
+					confirm := false
+					if err := survey.AskOne(&survey.Confirm{Message: "Apply this change?", Default: true}, &confirm); err != nil {
+						color.Yellow(tr("Replace cancelled, nothing was saved."))
+						return
+					}
+					if !confirm {
+						continue
+					}
+				}
+				accepted = append(accepted, c)
+			}
+
+			if dryRun {
+				color.Yellow(tr("🔍 Dry run: %d change(s) would be made."), len(changes))
+				return
+			}
+			if len(accepted) == 0 {
+				color.Yellow(tr("No changes applied."))
+				return
+			}
+			err = tx.Update(func(problems []Problem) ([]Problem, error) {
+				for _, c := range accepted {
+					setFieldValue(&problems[c.Index], c.Field, c.New)
+				}
+				return problems, nil
+			})
+			if err != nil {
+				printError(tr("❌ Error applying the changes: %v"), err)
+				return
+			}
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Applied %d change(s)."), len(accepted))
+		},
+	}
+	cmd.Flags().StringSliceVar(&fields, "field", []string{"name"}, "fields to edit: name, tags, platform, notes")
+	cmd.Flags().StringVar(&find, "find", "", "text (or regular expression with --regex) to look for")
+	cmd.Flags().StringVar(&repl, "replace", "", "replacement text; with --regex, $1 refers to capture groups")
+	cmd.Flags().BoolVar(&useRegex, "regex", false, "treat --find as a regular expression")
+	cmd.Flags().BoolVarP(&ignoreCase, "ignore-case", "i", false, "match case-insensitively")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "preview the changes without saving")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "apply every change without asking")
+	return cmd
+}
@@ -0,0 +1,378 @@
+// report.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// ReportConfig holds the delivery settings of the weekly report.
+type ReportConfig struct {
+	SMTP SMTPConfig `json:"smtp"`
+}
+
+// SMTPConfig is the mail server the weekly report is sent through.
+type SMTPConfig struct {
+	Host     string   `json:"host,omitempty"`
+	Port     int      `json:"port,omitempty"` // 587 by default; the server must offer STARTTLS to authenticate
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"` // or SAITAMA_SMTP_PASSWORD
+	From     string   `json:"from,omitempty"`     // defaults to username
+	To       []string `json:"to,omitempty"`
+}
+
+const (
+	reportWeakTags = 5
+	reportReviews  = 10
+)
+
+// ReportProblem is a problem solved in the period of a report.
+type ReportProblem struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Difficulty string `json:"difficulty,omitempty"`
+}
+
+// ReportReview is a problem due for review.
+type ReportReview struct {
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Due     time.Time `json:"due"`
+	Overdue bool      `json:"overdue,omitempty"`
+}
+
+// ReportGoal is the progress of the study plan (see 'saitama plan').
+type ReportGoal struct {
+	Target    string `json:"target"`
+	Week      int    `json:"week"`
+	Weeks     int    `json:"weeks"`
+	Done      int    `json:"done"`
+	Total     int    `json:"total"`
+	WeekDone  int    `json:"week_done"`
+	WeekTotal int    `json:"week_total"`
+}
+
+// WeeklyReport is the data of the weekly report, also available to its templates.
+type WeeklyReport struct {
+	Start          time.Time       `json:"start"`
+	End            time.Time       `json:"end"`
+	Solves         int             `json:"solves"`
+	PreviousSolves int             `json:"previous_solves"`
+	Attempts       int             `json:"attempts"`
+	NewProblems    int             `json:"new_problems"`
+	Streak         int             `json:"streak"`
+	Solved         []ReportProblem `json:"solved"`
+	WeakTags       []TagMastery    `json:"weak_tags"`
+	Goal           *ReportGoal     `json:"goal,omitempty"`
+	Reviews        []ReportReview  `json:"reviews"` // overdue or due within the coming week
+	MoreReviews    int             `json:"more_reviews,omitempty"`
+}
+
+// buildWeeklyReport summarizes the 7 days up to now. plan may be nil.
+func buildWeeklyReport(problems []Problem, cfg Config, plan *StudyPlan, now time.Time) WeeklyReport {
+	previous, current, _ := parseComparePeriods("last-week", now)
+	events := activityLog(problems)
+	summary := summarizePeriod(problems, events, current)
+	r := WeeklyReport{
+		Start:          current.Start,
+		End:            current.End.AddDate(0, 0, -1),
+		Solves:         summary.Solves,
+		PreviousSolves: summarizePeriod(problems, events, previous).Solves,
+		Attempts:       summary.Attempts,
+		NewProblems:    summary.NewProblems,
+		Streak:         currentStreak(solvesPerDay(events), now),
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range events {
+		if e.Solved && current.contains(e.Date) && !seen[e.ProblemID] {
+			seen[e.ProblemID] = true
+			if p, index := findProblemByID(problems, e.ProblemID); index != -1 {
+				r.Solved = append(r.Solved, ReportProblem{ID: p.ID, Name: p.Name, Difficulty: p.Difficulty})
+			}
+		}
+	}
+
+	for _, m := range computeTagMastery(problems, cfg.Mastery, now) {
+		if m.Score < cfg.Mastery.WeakThreshold && len(r.WeakTags) < reportWeakTags {
+			r.WeakTags = append(r.WeakTags, m)
+		}
+	}
+
+	if plan != nil && len(plan.Weeks) > 0 {
+		week := currentPlanWeek(plan, now)
+		goal := &ReportGoal{Target: plan.Target, Week: week + 1, Weeks: len(plan.Weeks)}
+		for i, w := range plan.Weeks {
+			for _, item := range w.Items {
+				done := planItemDone(problems, item)
+				goal.Total++
+				if done {
+					goal.Done++
+				}
+				if i == week {
+					goal.WeekTotal++
+					if done {
+						goal.WeekDone++
+					}
+				}
+			}
+		}
+		r.Goal = goal
+	}
+
+	today := startOfDay(now)
+	horizon := today.AddDate(0, 0, 8)
+	for _, p := range problems {
+		if due, ok := nextReview(p); ok && due.Before(horizon) {
+			r.Reviews = append(r.Reviews, ReportReview{ID: p.ID, Name: p.Name, Due: due, Overdue: due.Before(today)})
+		}
+	}
+	sort.SliceStable(r.Reviews, func(i, j int) bool { return r.Reviews[i].Due.Before(r.Reviews[j].Due) })
+	if len(r.Reviews) > reportReviews {
+		r.MoreReviews = len(r.Reviews) - reportReviews
+		r.Reviews = r.Reviews[:reportReviews]
+	}
+	return r
+}
+
+const markdownReportTemplate = `# 🥊 Saitama weekly report
+
+**{{.Start.Format "Jan 2"}} – {{.End.Format "Jan 2, 2006"}}**
+
+- ✅ **{{.Solves}}** solve(s) in {{.Attempts}} attempt(s){{if .PreviousSolves}} (previous week: {{.PreviousSolves}}){{end}}
+- 🔥 Current streak: **{{.Streak}}** day(s)
+- ➕ {{.NewProblems}} new problem(s)
+{{if .Solved}}
+## Solved this week
+{{range .Solved}}
+- {{.ID}} - {{.Name}}{{if .Difficulty}} ({{.Difficulty}}){{end}}{{end}}
+{{end}}{{with .Goal}}
+## Goal: {{.Target}}
+
+Week {{.Week}} of {{.Weeks}}: {{.WeekDone}}/{{.WeekTotal}} done this week, {{.Done}}/{{.Total}} overall.
+{{end}}{{if .WeakTags}}
+## Weak tags
+{{range .WeakTags}}
+- {{.Tag}}: {{printf "%.0f" .Score}}/100 ({{.Solves}} solve(s) over {{.Problems}} problem(s)){{end}}
+{{end}}{{if .Reviews}}
+## Upcoming reviews
+{{range .Reviews}}
+- {{.ID}} - {{.Name}}: {{if .Overdue}}**overdue** since {{end}}{{.Due.Format "Mon Jan 2"}}{{end}}{{if .MoreReviews}}
+- ... and {{.MoreReviews}} more{{end}}
+{{end}}`
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html>
+<body style="font-family: sans-serif; max-width: 600px; margin: auto; color: #222;">
+<h1>🥊 Saitama weekly report</h1>
+<p><strong>{{.Start.Format "Jan 2"}} – {{.End.Format "Jan 2, 2006"}}</strong></p>
+<ul>
+<li>✅ <strong>{{.Solves}}</strong> solve(s) in {{.Attempts}} attempt(s){{if .PreviousSolves}} (previous week: {{.PreviousSolves}}){{end}}</li>
+<li>🔥 Current streak: <strong>{{.Streak}}</strong> day(s)</li>
+<li>➕ {{.NewProblems}} new problem(s)</li>
+</ul>
+{{if .Solved}}<h2>Solved this week</h2>
+<ul>{{range .Solved}}
+<li>{{.ID}} - {{.Name}}{{if .Difficulty}} ({{.Difficulty}}){{end}}</li>{{end}}
+</ul>
+{{end}}{{with .Goal}}<h2>Goal: {{.Target}}</h2>
+<p>Week {{.Week}} of {{.Weeks}}: {{.WeekDone}}/{{.WeekTotal}} done this week, {{.Done}}/{{.Total}} overall.</p>
+{{end}}{{if .WeakTags}}<h2>Weak tags</h2>
+<ul>{{range .WeakTags}}
+<li>{{.Tag}}: {{printf "%.0f" .Score}}/100 ({{.Solves}} solve(s) over {{.Problems}} problem(s))</li>{{end}}
+</ul>
+{{end}}{{if .Reviews}}<h2>Upcoming reviews</h2>
+<ul>{{range .Reviews}}
+<li>{{.ID}} - {{.Name}}: {{if .Overdue}}<strong>overdue</strong> since {{end}}{{.Due.Format "Mon Jan 2"}}</li>{{end}}{{if .MoreReviews}}
+<li>... and {{.MoreReviews}} more</li>{{end}}
+</ul>
+{{end}}</body>
+</html>
+`
+
+// renderReport renders the report as Markdown or HTML.
+func renderReport(r WeeklyReport, format string) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case "markdown", "md":
+		err = template.Must(template.New("report").Parse(markdownReportTemplate)).Execute(&buf, r)
+	case "html":
+		err = htmltemplate.Must(htmltemplate.New("report").Parse(htmlReportTemplate)).Execute(&buf, r)
+	default:
+		return "", fmt.Errorf("unknown report format '%s' (use text, markdown or html)", format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to render report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// printWeeklyReport prints the report in the terminal.
+func printWeeklyReport(r WeeklyReport) {
+	fmt.Fprintln(stderr)
+	color.HiCyan(tr("🥊 Weekly report: %s – %s"), r.Start.Format("Jan 2"), r.End.Format("Jan 2, 2006"))
+	fmt.Fprintln(stderr)
+	printResult(color.New(color.FgGreen), tr("✅ Solves: %d in %d attempt(s) (previous week: %d)"), r.Solves, r.Attempts, r.PreviousSolves)
+	printResult(color.New(color.FgYellow), tr("🔥 Current streak: %d day(s)"), r.Streak)
+	printResult(color.New(color.FgWhite), tr("➕ New problems: %d"), r.NewProblems)
+	for _, p := range r.Solved {
+		printResult(color.New(color.FgWhite), "   %s - %s", p.ID, p.Name)
+	}
+
+	if g := r.Goal; g != nil {
+		fmt.Fprintln(stderr)
+		color.HiCyan(tr("🎯 Goal: %s"), g.Target)
+		printResult(color.New(color.FgWhite), tr("   Week %d of %d: %d/%d done this week, %d/%d overall"), g.Week, g.Weeks, g.WeekDone, g.WeekTotal, g.Done, g.Total)
+	}
+	if len(r.WeakTags) > 0 {
+		fmt.Fprintln(stderr)
+		color.HiCyan(tr("💪 Weak tags:"))
+		for _, m := range r.WeakTags {
+			printResult(color.New(color.FgRed), tr("   %s: %.0f/100 (%d solve(s) over %d problem(s))"), m.Tag, m.Score, m.Solves, m.Problems)
+		}
+	}
+	if len(r.Reviews) > 0 {
+		fmt.Fprintln(stderr)
+		color.HiCyan(tr("🔁 Upcoming reviews:"))
+		for _, p := range r.Reviews {
+			if p.Overdue {
+				printResult(color.New(color.FgRed), tr("   %s - %s: overdue since %s"), p.ID, p.Name, p.Due.Format("Mon Jan 2"))
+			} else {
+				printResult(color.New(color.FgWhite), "   %s - %s: %s", p.ID, p.Name, p.Due.Format("Mon Jan 2"))
+			}
+		}
+		if r.MoreReviews > 0 {
+			color.HiBlack(tr("   ... and %d more"), r.MoreReviews)
+		}
+	}
+	fmt.Fprintln(stderr)
+}
+
+// sendReportEmail mails an HTML report with the configured SMTP server.
+func sendReportEmail(cfg SMTPConfig, subject, body string) error {
+	password := os.Getenv("SAITAMA_SMTP_PASSWORD")
+	if password == "" {
+		password = cfg.Password
+	}
+	from := cfg.From
+	if from == "" {
+		from = cfg.Username
+	}
+	if cfg.Host == "" || from == "" || len(cfg.To) == 0 {
+		return fmt.Errorf("report.smtp.host, report.smtp.from (or username) and report.smtp.to must be set in the config file")
+	}
+	port := cfg.Port
+	if port == 0 {
+		port = 587
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, password, cfg.Host)
+	}
+	addr := net.JoinHostPort(cfg.Host, strconv.Itoa(port))
+	if err := smtp.SendMail(addr, auth, from, cfg.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send through %s: %w", addr, err)
+	}
+	return nil
+}
+
+func reportCmd() *cobra.Command {
+	var format string
+	var email bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize your training, in the terminal or by email",
+	}
+	weekly := &cobra.Command{
+		Use:   "weekly",
+		Short: "Summarize the last 7 days: solves, streak, weak tags, goal progress and upcoming reviews",
+		Long: "Prints the report in the terminal, or renders it as Markdown or HTML. With --email, the HTML report is " +
+			"sent through the mail server of the \"report.smtp\" section of the config file (host, port, username, " +
+			"password or SAITAMA_SMTP_PASSWORD, from, to). To get it without opening saitama, have the daemon send " +
+			"it (\"daemon\": {\"weekly_report\": \"sun 18:00\"} in the config, see 'saitama daemon'), or schedule " +
+			"it with cron.",
+		Example: `  saitama report weekly
+  saitama report weekly --format markdown > week.md
+  saitama report weekly --email
+  0 18 * * 0 saitama report weekly --email   # every Sunday at 6pm`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			plan, err := loadPlan()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (leaving out the goal progress)"), err)
+			}
+			report := buildWeeklyReport(problems, cfg, plan, time.Now())
+
+			if email {
+				body, err := renderReport(report, "html")
+				if err != nil {
+					printError(tr("❌ Error building report: %v"), err)
+					return
+				}
+				subject := fmt.Sprintf(tr("Saitama weekly report: %d solve(s), %d-day streak"), report.Solves, report.Streak)
+				if err := sendReportEmail(cfg.Report.SMTP, subject, body); err != nil {
+					printError(tr("❌ Error sending report: %v"), err)
+					return
+				}
+				color.Green(tr("📧 Weekly report sent to %s"), strings.Join(cfg.Report.SMTP.To, ", "))
+				return
+			}
+
+			if jsonOutput() {
+				printJSON(report)
+				return
+			}
+			switch format = strings.ToLower(format); format {
+			case "text":
+				printWeeklyReport(report)
+			default:
+				out, err := renderReport(report, format)
+				if err != nil {
+					printError("❌ %v", err)
+					return
+				}
+				fmt.Fprint(stdout, out)
+			}
+		},
+	}
+	weekly.Flags().StringVarP(&format, "format", "f", "text", "report format: text, markdown or html")
+	weekly.Flags().BoolVar(&email, "email", false, "send the HTML report by email instead of printing it")
+
+	cmd.AddCommand(weekly)
+	return cmd
+}
@@ -0,0 +1,349 @@
+// retro.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// retroWeek is the activity of a week, as walked through by retro.
+type retroWeek struct {
+	Start      time.Time       `json:"start"`
+	End        time.Time       `json:"end"`
+	Solved     []string        `json:"solved"`
+	Failed     []string        `json:"failed"`
+	Skipped    []string        `json:"skipped"` // picked during the week, not attempted since
+	ActiveDays int             `json:"active_days"`
+	TagSolves  map[string]int  `json:"tag_solves"`
+	TagFails   map[string]int  `json:"tag_fails"`
+	Quotas     []quotaProgress `json:"quotas,omitempty"`
+}
+
+// retroProposal is an adjustment of the goals or the focus suggested by a retro.
+type retroProposal struct {
+	Text  string
+	Apply func(cfg *Config)
+}
+
+// summarizeWeek collects the solves, failures and skipped picks of the week starting
+// at start. A problem is listed once per outcome, however many attempts it had.
+// The quotas are measured on the week too.
+func summarizeWeek(problems []Problem, quotas map[string]int, start time.Time) retroWeek {
+	end := start.AddDate(0, 0, 7)
+	w := retroWeek{Start: start, End: end, Solved: []string{}, Failed: []string{}, Skipped: []string{},
+		TagSolves: make(map[string]int), TagFails: make(map[string]int)}
+	inWeek := func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+
+	solved, failed, days := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	for _, e := range activityLog(problems) {
+		if !inWeek(e.Date) {
+			continue
+		}
+		days[dayKey(e.Date)] = true
+		counts, seen := w.TagFails, failed
+		if e.Solved {
+			counts, seen = w.TagSolves, solved
+		}
+		for _, tag := range e.Tags {
+			counts[strings.ToLower(tag)]++
+		}
+		if !seen[e.ProblemID] {
+			seen[e.ProblemID] = true
+			if e.Solved {
+				w.Solved = append(w.Solved, e.ProblemID)
+			} else {
+				w.Failed = append(w.Failed, e.ProblemID)
+			}
+		}
+	}
+	w.ActiveDays = len(days)
+
+	for _, p := range problems {
+		if !inWeek(p.LastPicked) {
+			continue
+		}
+		attempted := false
+		for _, a := range p.Attempts {
+			attempted = attempted || !a.Date.Before(p.LastPicked)
+		}
+		if !attempted && !p.LastSolved.After(p.LastPicked) {
+			w.Skipped = append(w.Skipped, p.ID)
+		}
+	}
+	for _, ids := range [][]string{w.Solved, w.Failed, w.Skipped} {
+		sort.Strings(ids)
+	}
+	// Like weeklyQuotas, but bounded by the end of the week.
+	for tag, target := range quotas {
+		w.Quotas = append(w.Quotas, quotaProgress{Tag: tag, Target: target, Done: w.TagSolves[strings.ToLower(tag)]})
+	}
+	sort.Slice(w.Quotas, func(i, j int) bool { return w.Quotas[i].Tag < w.Quotas[j].Tag })
+	return w
+}
+
+// retroProposals suggests adjustments from the week: quotas met with room to spare go
+// up, quotas far from met go down to something reachable, the tag that failed the most
+// becomes the focus and a focus that went unpracticed is dropped. Without quotas, the
+// weakest tag gets one.
+func retroProposals(w retroWeek, cfg Config, mastery []TagMastery) []retroProposal {
+	var proposals []retroProposal
+	for _, q := range w.Quotas {
+		tag := q.Tag
+		switch {
+		case q.Done >= q.Target+2:
+			target := q.Done
+			proposals = append(proposals, retroProposal{
+				Text:  fmt.Sprintf("Raise the %s quota from %d to %d a week (you did %d)", tag, q.Target, target, q.Done),
+				Apply: func(cfg *Config) { cfg.Goals.Quotas[tag] = target },
+			})
+		case q.Target > 1 && q.Done*2 < q.Target:
+			target := max(1, q.Done+1)
+			proposals = append(proposals, retroProposal{
+				Text:  fmt.Sprintf("Lower the %s quota from %d to %d a week (you did %d)", tag, q.Target, target, q.Done),
+				Apply: func(cfg *Config) { cfg.Goals.Quotas[tag] = target },
+			})
+		}
+	}
+
+	if len(cfg.Goals.Quotas) == 0 {
+		weak := weakTags(mastery, cfg.Mastery)
+		weakest, lowest := "", 0.0
+		for _, m := range mastery {
+			if weak[m.Tag] && (weakest == "" || m.Score < lowest) {
+				weakest, lowest = m.Tag, m.Score
+			}
+		}
+		if weakest != "" {
+			proposals = append(proposals, retroProposal{
+				Text: fmt.Sprintf("Set a weekly quota of 2 %s problems, your weakest tag", weakest),
+				Apply: func(cfg *Config) {
+					cfg.Goals.Quotas = map[string]int{weakest: 2}
+				},
+			})
+		}
+	}
+
+	hardest := ""
+	for tag, n := range w.TagFails {
+		if n >= 2 && (hardest == "" || n > w.TagFails[hardest] || (n == w.TagFails[hardest] && tag < hardest)) {
+			hardest = tag
+		}
+	}
+	switch {
+	case hardest != "" && hardest != cfg.Focus.Tag:
+		proposals = append(proposals, retroProposal{
+			Text:  fmt.Sprintf("Focus on %s next week (%d failed attempts)", hardest, w.TagFails[hardest]),
+			Apply: func(cfg *Config) { cfg.Focus.Tag = hardest },
+		})
+	case cfg.Focus.Tag != "" && w.TagSolves[cfg.Focus.Tag]+w.TagFails[cfg.Focus.Tag] == 0:
+		proposals = append(proposals, retroProposal{
+			Text:  fmt.Sprintf("Drop the focus on %s, untouched this week, and go back to the weekly rotation", cfg.Focus.Tag),
+			Apply: func(cfg *Config) { cfg.Focus.Tag = "" },
+		})
+	}
+	return proposals
+}
+
+// formatIDs lists up to limit IDs, then how many more there are.
+func formatIDs(ids []string, limit int) string {
+	if len(ids) <= limit {
+		return strings.Join(ids, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(ids[:limit], ", "), len(ids)-limit)
+}
+
+// topTags returns the tags with the most counts, most first.
+func topTags(counts map[string]int, n int) []string {
+	var tags []string
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags[:min(n, len(tags))]
+}
+
+// printRetroWeek shows the activity of the week.
+func printRetroWeek(w retroWeek) {
+	fmt.Fprintln(stderr)
+	color.HiCyan(tr("🪞 Week of %s to %s"), w.Start.Format("Mon Jan 2"), w.End.AddDate(0, 0, -1).Format("Mon Jan 2"))
+	fmt.Fprintln(stderr)
+	printResult(color.New(color.FgGreen), tr("   ✅ Solved:         %d  %s"), len(w.Solved), formatIDs(w.Solved, 6))
+	printResult(color.New(color.FgRed), tr("   ❌ Failed:         %d  %s"), len(w.Failed), formatIDs(w.Failed, 6))
+	printResult(color.New(color.FgYellow), tr("   ⏭️  Skipped picks:  %d  %s"), len(w.Skipped), formatIDs(w.Skipped, 6))
+	printResult(color.New(color.FgWhite), tr("   📅 Active days:    %d/7"), w.ActiveDays)
+	if tags := topTags(w.TagSolves, 3); len(tags) > 0 {
+		var parts []string
+		for _, tag := range tags {
+			parts = append(parts, fmt.Sprintf("%s %d", tag, w.TagSolves[tag]))
+		}
+		printResult(color.New(color.FgWhite), tr("   🏷️  Most solved:    %s"), strings.Join(parts, ", "))
+	}
+	if len(w.Quotas) > 0 {
+		var parts []string
+		for _, q := range w.Quotas {
+			mark := "✓"
+			if q.Left() > 0 {
+				mark = "✗"
+			}
+			parts = append(parts, fmt.Sprintf("%s %d/%d %s", q.Tag, q.Done, q.Target, mark))
+		}
+		printResult(color.New(color.FgWhite), tr("   🎯 Quotas:         %s"), strings.Join(parts, ", "))
+	}
+}
+
+// retroNote renders the retro as a section of the journal.
+func retroNote(w retroWeek, answers [][2]string, applied []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## Weekly retro, week of %s\n\n", dayKey(w.Start))
+	fmt.Fprintf(&b, "- Solved: %d %s\n", len(w.Solved), strings.Join(w.Solved, ", "))
+	fmt.Fprintf(&b, "- Failed: %d %s\n", len(w.Failed), strings.Join(w.Failed, ", "))
+	fmt.Fprintf(&b, "- Skipped picks: %d %s\n", len(w.Skipped), strings.Join(w.Skipped, ", "))
+	fmt.Fprintf(&b, "- Active days: %d/7\n", w.ActiveDays)
+	if len(w.Quotas) > 0 {
+		var parts []string
+		for _, q := range w.Quotas {
+			parts = append(parts, fmt.Sprintf("%s %d/%d", q.Tag, q.Done, q.Target))
+		}
+		fmt.Fprintf(&b, "- Quotas: %s\n", strings.Join(parts, ", "))
+	}
+	for _, a := range answers {
+		fmt.Fprintf(&b, "\n**%s** %s\n", a[0], a[1])
+	}
+	if len(applied) > 0 {
+		b.WriteString("\n**Adjustments:**\n\n")
+		for _, text := range applied {
+			fmt.Fprintf(&b, "- %s\n", text)
+		}
+	}
+	return b.String()
+}
+
+// retroQuestion is a guided reflection question, with the label of its answer in the note.
+type retroQuestion struct {
+	Prompt  string
+	Label   string
+	Skipped bool // only asked when picks were skipped
+}
+
+var retroQuestions = []retroQuestion{
+	{Prompt: "🌟 What went well this week?", Label: "Went well:"},
+	{Prompt: "🧱 What was hard, or got in the way?", Label: "Hard:"},
+	{Prompt: "⏭️  Why were picks skipped?", Label: "Skipped because:", Skipped: true},
+	{Prompt: "🔧 What will you do differently next week?", Label: "Next week:"},
+}
+
+func retroCmd() *cobra.Command {
+	var thisWeek, summary bool
+	cmd := &cobra.Command{
+		Use:         "retro",
+		Annotations: mutates(),
+		Short:       "Review last week: activity, reflection questions and goal adjustments",
+		Long: "Walks through last week's solves, failed attempts and skipped picks, asks a few reflection " +
+			"questions and stores the answers as a retro in today's journal (see 'saitama journal'). It then " +
+			"proposes adjustments to the weekly quotas and the focus tag, applied once you confirm them. " +
+			"Weeks start on Monday.",
+		Example: `  saitama retro
+  saitama retro --this-week     # On Sunday evening, before the week is over
+  saitama retro --summary       # Only the activity, no questions`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			now := time.Now()
+			start := weekStart(now)
+			if !thisWeek {
+				start = start.AddDate(0, 0, -7)
+			}
+			w := summarizeWeek(problems, cfg.Goals.Quotas, start)
+			if jsonOutput() {
+				printJSON(w)
+				return
+			}
+			printRetroWeek(w)
+			fmt.Fprintln(stderr)
+
+			if summary || !isInteractive() || isReadOnly() {
+				if !summary {
+					color.Cyan(tr("💡 Run saitama retro in a terminal to answer the questions and save the retro."))
+				}
+				return
+			}
+
+			var answers [][2]string
+			for _, q := range retroQuestions {
+				if q.Skipped && len(w.Skipped) == 0 {
+					continue
+				}
+				answer := ""
+				if err := survey.AskOne(&survey.Input{Message: q.Prompt}, &answer); err != nil {
+					color.Yellow(tr("Retro cancelled."))
+					return
+				}
+				if answer = strings.TrimSpace(answer); answer != "" {
+					answers = append(answers, [2]string{q.Label, answer})
+				}
+			}
+
+			var applied []string
+			proposals := retroProposals(w, cfg, computeTagMastery(problems, cfg.Mastery, now))
+			if len(proposals) > 0 {
+				fmt.Fprintln(stderr)
+				color.HiCyan(tr("🛠️  Suggested adjustments:"))
+			}
+			for _, p := range proposals {
+				apply := false
+				if err := survey.AskOne(&survey.Confirm{Message: p.Text + "?", Default: true}, &apply); err != nil {
+					color.Yellow(tr("Retro cancelled."))
+					return
+				}
+				if apply {
+					p.Apply(&cfg)
+					applied = append(applied, p.Text)
+				}
+			}
+			if len(applied) > 0 {
+				if err := saveConfig(cfg); err != nil {
+					printError(tr("❌ Error saving config: %v"), err)
+					return
+				}
+			}
+
+			dir, err := journalDir(cfg.Journal)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			path := journalFile(dir, now)
+			if err := appendJournal(path, retroNote(w, answers, applied), now); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			fmt.Fprintln(stderr)
+			if len(applied) > 0 {
+				color.Green(tr("🛠️  %d adjustment(s) applied, see saitama goal and saitama focus"), len(applied))
+			}
+			color.Green(tr("📓 Retro saved to %s"), path)
+		},
+	}
+	cmd.Flags().BoolVar(&thisWeek, "this-week", false, "review the current week so far instead of last week")
+	cmd.Flags().BoolVar(&summary, "summary", false, "only show the activity of the week, without questions")
+	return cmd
+}
@@ -0,0 +1,75 @@
+// review.go
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// Review is the spaced-repetition state of a problem, updated by quiz recalls.
+type Review struct {
+	Due      time.Time `json:"due"`
+	Interval int       `json:"interval_days"`
+	Ease     float64   `json:"ease"`
+	Recalls  []Recall  `json:"recalls,omitempty"`
+}
+
+// Recall is one self-graded quiz answer, from 0 (blank) to 5 (perfect).
+type Recall struct {
+	Date    time.Time `json:"date"`
+	Quality int       `json:"quality"`
+}
+
+const (
+	defaultEase = 2.5
+	minEase     = 1.3
+)
+
+// reviewIntervals is the spacing, in days, between a solve and the next review for
+// problems that were never quizzed. Each additional solve moves a problem one step
+// further along the ladder.
+var reviewIntervals = []int{1, 3, 7, 14, 30, 60, 120}
+
+// nextReview returns when a problem is next due for review: the quiz schedule if it
+// has one, otherwise a date derived from its solves.
+func nextReview(p Problem) (time.Time, bool) {
+	if p.Review != nil {
+		return p.Review.Due, true
+	}
+	if !isSolved(p) || p.LastSolved.IsZero() {
+		return time.Time{}, false
+	}
+	step := min(max(solveCount(p), 1)-1, len(reviewIntervals)-1)
+	return startOfDay(p.LastSolved).AddDate(0, 0, reviewIntervals[step]), true
+}
+
+// applyRecall records a recall and reschedules the problem using the SM-2 algorithm:
+// failed recalls (quality < 3) start over at one day, successful ones grow the interval
+// by the ease factor, which itself adapts to how hard the recall was.
+func applyRecall(p *Problem, quality int, now time.Time) {
+	quality = min(max(quality, 0), 5)
+	if p.Review == nil {
+		p.Review = &Review{Ease: defaultEase}
+	}
+	r := p.Review
+
+	streak := 0 // successful recalls in a row before this one
+	for i := len(r.Recalls) - 1; i >= 0 && r.Recalls[i].Quality >= 3; i-- {
+		streak++
+	}
+
+	switch {
+	case quality < 3:
+		r.Interval = 1
+	case streak == 0:
+		r.Interval = 1
+	case streak == 1:
+		r.Interval = 6
+	default:
+		r.Interval = int(math.Round(float64(max(r.Interval, 1)) * r.Ease))
+	}
+	miss := float64(5 - quality)
+	r.Ease = max(minEase, r.Ease+0.1-miss*(0.08+miss*0.02))
+	r.Due = startOfDay(now).AddDate(0, 0, r.Interval)
+	r.Recalls = append(r.Recalls, Recall{Date: now, Quality: quality})
+}
@@ -0,0 +1,400 @@
+// rpc.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// rpcProtocolVersion is the version of the 'saitama rpc' protocol. It is bumped on every
+// incompatible change of a method, its parameters or its result; adding methods or
+// optional parameters keeps it. Clients announce the version they speak in "initialize".
+const rpcProtocolVersion = 1
+
+// JSON-RPC 2.0 error codes, and the ones of the application in the server error range.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcInternalError  = -32603
+
+	rpcNotFound           = -32001 // no problem with the given ID
+	rpcConflict           = -32002 // the problem already exists
+	rpcReadOnly           = -32003 // read-only mode refused a change
+	rpcUnsupportedVersion = -32004 // the client speaks another protocol version
+)
+
+// rpcRequest is a JSON-RPC 2.0 request. Requests without an ID are notifications, which
+// get no response.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"` // null when the request couldn't be read
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+func newRPCError(code int, format string, a ...any) *rpcError {
+	return &rpcError{Code: code, Message: fmt.Sprintf(format, a...)}
+}
+
+// rpcSaveError maps a save error to an RPC error: read-only mode has its own code.
+func rpcSaveError(err error) *rpcError {
+	if errors.Is(err, errReadOnly) {
+		return newRPCError(rpcReadOnly, "%v", err)
+	}
+	return newRPCError(rpcInternalError, "failed to save problems: %v", err)
+}
+
+// rpcServer holds the state shared by the methods of 'saitama rpc'. Requests are handled
+// one at a time, in order.
+type rpcServer struct {
+	webhooks  []WebhookConfig
+	platforms []PlatformDef
+	pickCfg   PickConfig
+}
+
+// rpcMethods are the methods of the protocol, by name. They are registered in init, since
+// initialize lists them.
+var rpcMethods map[string]func(s *rpcServer, params json.RawMessage) (any, error)
+
+func init() {
+	rpcMethods = map[string]func(s *rpcServer, params json.RawMessage) (any, error){
+		"initialize": (*rpcServer).initialize,
+		"add":        (*rpcServer).add,
+		"search":     (*rpcServer).search,
+		"solve":      (*rpcServer).solve,
+		"pick":       (*rpcServer).pick,
+	}
+}
+
+// decodeParams decodes the parameters of a method. Unknown parameters are refused, so
+// that typos don't go unnoticed.
+func decodeParams(raw json.RawMessage, out any) error {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return newRPCError(rpcInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
+
+// initialize answers the handshake: {"protocol_version": 1} gets the server's version
+// and methods, or an error when the client speaks another version.
+func (s *rpcServer) initialize(raw json.RawMessage) (any, error) {
+	var params struct {
+		ProtocolVersion int `json:"protocol_version"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.ProtocolVersion != 0 && params.ProtocolVersion != rpcProtocolVersion {
+		return nil, newRPCError(rpcUnsupportedVersion, "unsupported protocol version %d (this saitama speaks %d)", params.ProtocolVersion, rpcProtocolVersion)
+	}
+	methods := make([]string, 0, len(rpcMethods))
+	for name := range rpcMethods {
+		methods = append(methods, name)
+	}
+	sort.Strings(methods)
+	return map[string]any{"protocol_version": rpcProtocolVersion, "methods": methods}, nil
+}
+
+// add creates a problem from {"id", "name", "tags", ...}, the fields of the export format.
+func (s *rpcServer) add(raw json.RawMessage) (any, error) {
+	var p Problem
+	if err := decodeParams(raw, &p); err != nil {
+		return nil, err
+	}
+	p.ID = strings.ToUpper(strings.TrimSpace(p.ID))
+	p.Name = strings.TrimSpace(p.Name)
+	if p.ID == "" || p.Name == "" {
+		return nil, newRPCError(rpcInvalidParams, "id and name are required")
+	}
+	p.Tags = parseTags(strings.Join(p.Tags, ","))
+	if err := resolvePlatform(s.platforms, &p); err != nil {
+		return nil, newRPCError(rpcInvalidParams, "%v", err)
+	}
+	if p.DateAdded.IsZero() {
+		p.DateAdded = time.Now()
+	}
+
+	problems, err := loadProblems()
+	if err != nil {
+		return nil, err
+	}
+	if _, index := findProblemByID(problems, p.ID); index != -1 {
+		return nil, newRPCError(rpcConflict, "problem '%s' already exists", p.ID)
+	}
+	if existing, index := findProblemByURL(problems, p.URL); index != -1 {
+		return nil, newRPCError(rpcConflict, "problem '%s' already has this URL", existing.ID)
+	}
+	if err := saveProblems(append(problems, p)); err != nil {
+		return nil, rpcSaveError(err)
+	}
+	dispatchWebhooks(s.webhooks, EventProblemCreated, p)
+	return p, nil
+}
+
+// search returns {"total", "problems"} for the filters of GET /problems.
+func (s *rpcServer) search(raw json.RawMessage) (any, error) {
+	var params struct {
+		Query      string   `json:"query"` // substring of the ID
+		Tags       []string `json:"tags"`
+		Difficulty string   `json:"difficulty"`
+		Platform   string   `json:"platform"`
+		Starred    bool     `json:"starred"`
+		Archived   string   `json:"archived"`
+		Complexity string   `json:"complexity"`
+		Added      string   `json:"added"`  // date expression, e.g. ">2024-01-01"
+		Solved     string   `json:"solved"` // date expression, e.g. "last-30d" or "never"
+		Sort       string   `json:"sort"`
+		Offset     int      `json:"offset"`
+		Limit      int      `json:"limit"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.Offset < 0 || params.Limit < 0 {
+		return nil, newRPCError(rpcInvalidParams, "offset and limit must not be negative")
+	}
+	q := ProblemQuery{
+		Tags:       params.Tags,
+		Difficulty: params.Difficulty,
+		Platform:   params.Platform,
+		ID:         params.Query,
+		Starred:    params.Starred,
+		Archived:   params.Archived,
+		Complexity: params.Complexity,
+		Added:      params.Added,
+		Solved:     params.Solved,
+		Sort:       params.Sort,
+		Offset:     params.Offset,
+		Limit:      params.Limit,
+	}
+	if err := q.Validate(); err != nil {
+		return nil, newRPCError(rpcInvalidParams, "%v", err)
+	}
+	results, total, err := store.Query(q)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []Problem{}
+	}
+	return map[string]any{"total": total, "problems": results}, nil
+}
+
+// solve logs an attempt from {"id", "solved" (true by default), "minutes", "felt"} and
+// returns the updated problem.
+func (s *rpcServer) solve(raw json.RawMessage) (any, error) {
+	var params struct {
+		ID      string `json:"id"`
+		Solved  *bool  `json:"solved"`
+		Minutes int    `json:"minutes"`
+		Felt    string `json:"felt"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.ID == "" {
+		return nil, newRPCError(rpcInvalidParams, "id is required")
+	}
+	if params.Minutes < 0 {
+		return nil, newRPCError(rpcInvalidParams, "minutes must not be negative")
+	}
+	felt, err := parseFelt(params.Felt)
+	if err != nil {
+		return nil, newRPCError(rpcInvalidParams, "%v", err)
+	}
+	solved := params.Solved == nil || *params.Solved
+
+	problems, err := loadProblems()
+	if err != nil {
+		return nil, err
+	}
+	_, index := findProblemByID(problems, strings.ToUpper(params.ID))
+	if index == -1 {
+		return nil, newRPCError(rpcNotFound, "problem '%s' not found", params.ID)
+	}
+	recordAttempt(&problems[index], solved, time.Duration(params.Minutes)*time.Minute, time.Now())
+	problems[index].Attempts[len(problems[index].Attempts)-1].Felt = felt
+	if err := saveProblems(problems); err != nil {
+		return nil, rpcSaveError(err)
+	}
+	if solved {
+		dispatchWebhooks(s.webhooks, EventProblemSolved, problems[index])
+	}
+	return problems[index], nil
+}
+
+// pick selects {"count"} random active problems like 'saitama pick', optionally
+// restricted to "tags" or "starred" ones, and returns {"seed", "problems"}.
+func (s *rpcServer) pick(raw json.RawMessage) (any, error) {
+	var params struct {
+		Count      int      `json:"count"`
+		Tags       []string `json:"tags"`
+		Starred    bool     `json:"starred"`
+		NoCooldown bool     `json:"no_cooldown"`
+		Seed       *int64   `json:"seed"`
+	}
+	if err := decodeParams(raw, &params); err != nil {
+		return nil, err
+	}
+	if params.Count < 0 {
+		return nil, newRPCError(rpcInvalidParams, "count must not be negative")
+	}
+	count := params.Count
+	if count == 0 {
+		count = max(s.pickCfg.Count, 1)
+	}
+
+	all, err := loadProblems()
+	if err != nil {
+		return nil, err
+	}
+	pool, _ := ProblemQuery{Tags: params.Tags, Starred: params.Starred}.Apply(activeProblems(all))
+	count = min(count, len(pool))
+
+	now := time.Now()
+	pickCfg := s.pickCfg
+	if params.NoCooldown {
+		pickCfg.CooldownDays, pickCfg.DifficultyCooldownDays = 0, nil
+	}
+	var seed int64
+	if params.Seed != nil {
+		seed = *params.Seed
+	}
+	rng, seed := selectionRand(seed, params.Seed != nil)
+	candidates, _ := pickCandidates(pool, pickCfg, now, rng)
+	picked := append([]Problem{}, candidates[:count]...)
+
+	if err := recordPicks(picked, now); err != nil && !errors.Is(err, errReadOnly) {
+		slog.Warn("could not record the picks for the cooldown", "err", err)
+	}
+	return map[string]any{"seed": seed, "problems": picked}, nil
+}
+
+// handle runs one request and returns its response, or nil for notifications.
+func (s *rpcServer) handle(line []byte) *rpcResponse {
+	return handleRPC(line, func(name string) (func(json.RawMessage) (any, error), bool) {
+		method, ok := rpcMethods[name]
+		if !ok {
+			return nil, false
+		}
+		return func(params json.RawMessage) (any, error) { return method(s, params) }, true
+	})
+}
+
+// handleRPC decodes a JSON-RPC request, runs the method found by lookup and returns the
+// response, or nil for notifications. It is shared by 'saitama rpc' and the daemon.
+func handleRPC(line []byte, lookup func(name string) (func(json.RawMessage) (any, error), bool)) *rpcResponse {
+	var req rpcRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		if bytes.HasPrefix(bytes.TrimSpace(line), []byte("[")) {
+			return &rpcResponse{JSONRPC: "2.0", Error: newRPCError(rpcInvalidRequest, "batch requests are not supported")}
+		}
+		return &rpcResponse{JSONRPC: "2.0", Error: newRPCError(rpcParseError, "parse error: %v", err)}
+	}
+	resp := &rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = newRPCError(rpcInvalidRequest, "invalid request: \"jsonrpc\" must be \"2.0\" and \"method\" is required")
+		return resp
+	}
+
+	method, ok := lookup(req.Method)
+	if !ok {
+		resp.Error = newRPCError(rpcMethodNotFound, "method '%s' not found", req.Method)
+	} else if result, err := method(req.Params); err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = newRPCError(rpcInternalError, "%v", err)
+		}
+		slog.Debug("rpc call failed", "method", req.Method, "code", rpcErr.Code, "err", rpcErr.Message)
+		resp.Error = rpcErr
+	} else {
+		resp.Result = result
+	}
+	if len(req.ID) == 0 {
+		return nil
+	}
+	return resp
+}
+
+// serve reads one request per line from in and writes one response per line to out,
+// until in is closed.
+func (s *rpcServer) serve(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	encoder := json.NewEncoder(out)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if resp := s.handle(line); resp != nil {
+			if err := encoder.Encode(resp); err != nil {
+				return fmt.Errorf("failed to write response: %w", err)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func rpcCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rpc",
+		Short: "Serve editor plugins over JSON-RPC on stdin and stdout",
+		Long: fmt.Sprintf("Runs a JSON-RPC 2.0 loop for editor plugins: one request per line on stdin, one response per "+
+			"line on stdout, until stdin is closed. Messages go to stderr. Protocol version %d.\n\n"+
+			"Methods:\n"+
+			"  initialize  {\"protocol_version\": %d} → {\"protocol_version\", \"methods\"}\n"+
+			"  add         the problem, as in 'saitama export' → the problem\n"+
+			"  search      {\"query\", \"tags\", \"difficulty\", \"platform\", \"starred\", \"archived\", \"complexity\", \"added\", \"solved\", \"sort\", \"offset\", \"limit\"} → {\"total\", \"problems\"}\n"+
+			"  solve       {\"id\", \"solved\", \"minutes\", \"felt\"} → the problem\n"+
+			"  pick        {\"count\", \"tags\", \"starred\", \"no_cooldown\", \"seed\"} → {\"seed\", \"problems\"}\n\n"+
+			"Errors use the JSON-RPC codes, and -32001 (not found), -32002 (already exists), -32003 (read-only) "+
+			"and -32004 (unsupported protocol version).", rpcProtocolVersion, rpcProtocolVersion),
+		Example: `  echo '{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {"protocol_version": 1}}' | saitama rpc
+  echo '{"jsonrpc": "2.0", "id": 2, "method": "search", "params": {"tags": ["dp"], "limit": 10}}' | saitama rpc`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			s := &rpcServer{webhooks: cfg.Webhooks, platforms: platformRegistry(cfg), pickCfg: cfg.Pick}
+			if err := s.serve(os.Stdin, os.Stdout); err != nil {
+				printError("❌ %v", err)
+			}
+		},
+	}
+}
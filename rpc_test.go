@@ -0,0 +1,192 @@
+// rpc_test.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// useTempDataDir points the invocation at an empty data directory for the test.
+func useTempDataDir(t testing.TB) string {
+	t.Helper()
+	dir := t.TempDir()
+	previousDir, previousStore := dataDirFlag, store
+	dataDirFlag, store = dir, &Store{}
+	t.Cleanup(func() { dataDirFlag, store = previousDir, previousStore })
+	return dir
+}
+
+func newTestRPCServer() *rpcServer {
+	cfg := defaultConfig()
+	return &rpcServer{platforms: platformRegistry(cfg), pickCfg: cfg.Pick}
+}
+
+// rpcCall sends a request to the server and decodes the response, as a client would.
+func rpcCall(t *testing.T, s *rpcServer, method string, params any) (json.RawMessage, *rpcError) {
+	t.Helper()
+	raw, err := json.Marshal(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": %q, "params": %s}`, method, raw)
+	resp := s.handle([]byte(line))
+	if resp == nil {
+		t.Fatalf("%s: no response", method)
+	}
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Result json.RawMessage `json:"result"`
+		Error  *rpcError       `json:"error"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return decoded.Result, decoded.Error
+}
+
+func TestHandleRPCErrors(t *testing.T) {
+	s := newTestRPCServer()
+	tests := []struct {
+		name string
+		line string
+		code int
+	}{
+		{"parse error", `{"jsonrpc": "2.0", "id": 1,`, rpcParseError},
+		{"batch", `[{"jsonrpc": "2.0", "id": 1, "method": "initialize"}]`, rpcInvalidRequest},
+		{"wrong version", `{"jsonrpc": "1.0", "id": 1, "method": "initialize"}`, rpcInvalidRequest},
+		{"no method", `{"jsonrpc": "2.0", "id": 1}`, rpcInvalidRequest},
+		{"unknown method", `{"jsonrpc": "2.0", "id": 1, "method": "delete"}`, rpcMethodNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := s.handle([]byte(tt.line))
+			if resp == nil || resp.Error == nil {
+				t.Fatalf("got %+v, want error %d", resp, tt.code)
+			}
+			if resp.Error.Code != tt.code {
+				t.Errorf("code = %d, want %d (%s)", resp.Error.Code, tt.code, resp.Error.Message)
+			}
+		})
+	}
+}
+
+func TestHandleRPCNotifications(t *testing.T) {
+	s := newTestRPCServer()
+	for _, line := range []string{
+		`{"jsonrpc": "2.0", "method": "initialize", "params": {"protocol_version": 1}}`,
+		`{"jsonrpc": "2.0", "method": "delete"}`,
+		`{"jsonrpc": "2.0", "method": "initialize", "params": {"protocol_version": 99}}`,
+	} {
+		if resp := s.handle([]byte(line)); resp != nil {
+			t.Errorf("%s: got response %+v, want none", line, resp)
+		}
+	}
+}
+
+func TestRPCInitialize(t *testing.T) {
+	s := newTestRPCServer()
+	result, rpcErr := rpcCall(t, s, "initialize", map[string]any{"protocol_version": rpcProtocolVersion})
+	if rpcErr != nil {
+		t.Fatalf("initialize: %v", rpcErr)
+	}
+	var hello struct {
+		ProtocolVersion int      `json:"protocol_version"`
+		Methods         []string `json:"methods"`
+	}
+	if err := json.Unmarshal(result, &hello); err != nil {
+		t.Fatal(err)
+	}
+	if hello.ProtocolVersion != rpcProtocolVersion || len(hello.Methods) != len(rpcMethods) {
+		t.Errorf("initialize = %+v", hello)
+	}
+
+	tests := []struct {
+		name   string
+		params any
+		code   int
+	}{
+		{"unknown param", map[string]any{"protocol_version": 1, "client": "vim"}, rpcInvalidParams},
+		{"version mismatch", map[string]any{"protocol_version": rpcProtocolVersion + 1}, rpcUnsupportedVersion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rpcErr := rpcCall(t, s, "initialize", tt.params)
+			if rpcErr == nil || rpcErr.Code != tt.code {
+				t.Errorf("got %v, want code %d", rpcErr, tt.code)
+			}
+		})
+	}
+}
+
+func TestRPCAddSolvePick(t *testing.T) {
+	useTempDataDir(t)
+	s := newTestRPCServer()
+
+	for _, p := range []map[string]any{
+		{"id": "lc1", "name": "Two Sum", "url": "https://leetcode.com/problems/two-sum/", "tags": []string{"arrays"}},
+		{"id": "LC70", "name": "Climbing Stairs", "url": "https://leetcode.com/problems/climbing-stairs/", "tags": []string{"dp"}},
+	} {
+		if _, rpcErr := rpcCall(t, s, "add", p); rpcErr != nil {
+			t.Fatalf("add %v: %v", p["id"], rpcErr)
+		}
+	}
+	if _, rpcErr := rpcCall(t, s, "add", map[string]any{"id": "LC1", "name": "Two Sum"}); rpcErr == nil || rpcErr.Code != rpcConflict {
+		t.Errorf("add of an existing ID: got %v, want code %d", rpcErr, rpcConflict)
+	}
+	if _, rpcErr := rpcCall(t, s, "add", map[string]any{"id": "LC2"}); rpcErr == nil || rpcErr.Code != rpcInvalidParams {
+		t.Errorf("add without a name: got %v, want code %d", rpcErr, rpcInvalidParams)
+	}
+
+	result, rpcErr := rpcCall(t, s, "solve", map[string]any{"id": "lc1", "minutes": 12, "felt": "easy"})
+	if rpcErr != nil {
+		t.Fatalf("solve: %v", rpcErr)
+	}
+	var solved Problem
+	if err := json.Unmarshal(result, &solved); err != nil {
+		t.Fatal(err)
+	}
+	if solved.ID != "LC1" || len(solved.Attempts) != 1 || !solved.Attempts[0].Solved || solved.LastSolved.IsZero() {
+		t.Errorf("solve = %+v", solved)
+	}
+	if _, rpcErr := rpcCall(t, s, "solve", map[string]any{"id": "LC999"}); rpcErr == nil || rpcErr.Code != rpcNotFound {
+		t.Errorf("solve of an unknown ID: got %v, want code %d", rpcErr, rpcNotFound)
+	}
+
+	problems, err := loadProblems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 2 {
+		t.Fatalf("saved %d problems, want 2", len(problems))
+	}
+
+	result, rpcErr = rpcCall(t, s, "pick", map[string]any{"count": 5, "no_cooldown": true, "seed": 42})
+	if rpcErr != nil {
+		t.Fatalf("pick: %v", rpcErr)
+	}
+	var picked struct {
+		Seed     int64     `json:"seed"`
+		Problems []Problem `json:"problems"`
+	}
+	if err := json.Unmarshal(result, &picked); err != nil {
+		t.Fatal(err)
+	}
+	if picked.Seed != 42 || len(picked.Problems) != 2 {
+		t.Errorf("pick = seed %d, %d problem(s), want seed 42 and 2 problems", picked.Seed, len(picked.Problems))
+	}
+
+	result, rpcErr = rpcCall(t, s, "pick", map[string]any{"tags": []string{"dynamic-programming"}, "no_cooldown": true})
+	if rpcErr != nil {
+		t.Fatalf("pick by tag: %v", rpcErr)
+	}
+	if err := json.Unmarshal(result, &picked); err != nil {
+		t.Fatal(err)
+	}
+	if len(picked.Problems) != 1 || picked.Problems[0].ID != "LC70" {
+		t.Errorf("pick by tag = %+v, want LC70", picked.Problems)
+	}
+}
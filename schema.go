@@ -0,0 +1,355 @@
+// schema.go
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaJSON is the JSON Schema of the export format, the contract for tools generating
+// import files. It must be kept in sync with Problem and the types it embeds.
+//
+//go:embed schema.json
+var schemaJSON string
+
+// jsonSchema is the subset of JSON Schema used by schema.json.
+type jsonSchema struct {
+	Ref                  string                 `json:"$ref"`
+	Defs                 map[string]*jsonSchema `json:"$defs"`
+	Type                 any                    `json:"type"` // a type name or a list of them
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []string               `json:"enum"`
+	MinLength            *int                   `json:"minLength"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Format               string                 `json:"format"`
+}
+
+// loadSchema parses the embedded schema.
+func loadSchema() (*jsonSchema, error) {
+	var s jsonSchema
+	if err := json.Unmarshal([]byte(schemaJSON), &s); err != nil {
+		return nil, fmt.Errorf("invalid embedded schema: %w", err)
+	}
+	return &s, nil
+}
+
+// jsonNode is a parsed JSON value that remembers where it was in the file, so that
+// violations can point at it.
+type jsonNode struct {
+	kind   string // object, array, string, number, boolean or null
+	offset int
+	scalar string // the string, number or boolean
+	keys   []string
+	fields map[string]*jsonNode
+	keyAt  map[string]int // offset of every key of an object
+	items  []*jsonNode
+}
+
+// schemaViolation is a place where an import file doesn't match the schema.
+type schemaViolation struct {
+	Line    int
+	Column  int
+	Path    string // e.g. [3].attempts[0].date, empty for the whole record
+	Message string
+}
+
+func (v schemaViolation) Error() string {
+	if v.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %s", v.Line, v.Column, v.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %s", v.Line, v.Column, v.Path, v.Message)
+}
+
+// positionParser builds a jsonNode tree out of the tokens of a decoder.
+type positionParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+// start returns the offset of the next token: the decoder's offset is the end of the
+// previous one, before any separator and whitespace.
+func (p *positionParser) start() int {
+	off := int(p.dec.InputOffset())
+	for off < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[off]) >= 0 {
+		off++
+	}
+	return off
+}
+
+func (p *positionParser) node() (*jsonNode, error) {
+	n := &jsonNode{offset: p.start()}
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			n.kind, n.fields, n.keyAt = "object", make(map[string]*jsonNode), make(map[string]int)
+			for p.dec.More() {
+				keyAt := p.start()
+				key, err := p.dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				child, err := p.node()
+				if err != nil {
+					return nil, err
+				}
+				k := key.(string)
+				n.keys = append(n.keys, k)
+				n.fields[k], n.keyAt[k] = child, keyAt
+			}
+		} else {
+			n.kind = "array"
+			for p.dec.More() {
+				child, err := p.node()
+				if err != nil {
+					return nil, err
+				}
+				n.items = append(n.items, child)
+			}
+		}
+		if _, err := p.dec.Token(); err != nil { // the closing delimiter
+			return nil, err
+		}
+	case string:
+		n.kind, n.scalar = "string", t
+	case json.Number:
+		n.kind, n.scalar = "number", t.String()
+	case bool:
+		n.kind, n.scalar = "boolean", strconv.FormatBool(t)
+	case nil:
+		n.kind = "null"
+	}
+	return n, nil
+}
+
+// lineColumn converts a byte offset to a 1-based line and column.
+func lineColumn(data []byte, offset int) (int, int) {
+	offset = min(offset, len(data))
+	before := data[:offset]
+	lineStart := strings.LastIndexByte(string(before), '\n') + 1
+	return strings.Count(string(before), "\n") + 1, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// parsePositioned parses a single JSON document, reporting syntax errors with their position.
+func parsePositioned(data []byte) (*jsonNode, *schemaViolation) {
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	p := &positionParser{data: data, dec: dec}
+	root, err := p.node()
+	if err == nil {
+		if _, extra := dec.Token(); extra != io.EOF {
+			line, col := lineColumn(data, p.start())
+			return nil, &schemaViolation{Line: line, Column: col, Message: "unexpected data after the end of the document"}
+		}
+		return root, nil
+	}
+	offset := int(dec.InputOffset())
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		offset = int(syntax.Offset)
+	} else if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		offset = len(data)
+		err = errors.New("unexpected end of the document")
+	}
+	line, col := lineColumn(data, offset)
+	return nil, &schemaViolation{Line: line, Column: col, Message: "invalid JSON: " + err.Error()}
+}
+
+// schemaValidator checks parsed documents against a schema.
+type schemaValidator struct {
+	root       *jsonSchema
+	data       []byte
+	violations []schemaViolation
+}
+
+func (v *schemaValidator) report(offset int, path, format string, a ...any) {
+	line, col := lineColumn(v.data, offset)
+	v.violations = append(v.violations, schemaViolation{Line: line, Column: col, Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+// schemaTypes returns the types allowed by a schema, none meaning any.
+func schemaTypes(s *jsonSchema) []string {
+	switch t := s.Type.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		types := make([]string, 0, len(t))
+		for _, name := range t {
+			types = append(types, fmt.Sprint(name))
+		}
+		return types
+	}
+	return nil
+}
+
+// matchesType reports whether a node is of a JSON Schema type. Integers are written
+// without a fraction or exponent, since they are decoded into Go ints.
+func matchesType(n *jsonNode, typ string) bool {
+	switch typ {
+	case "integer":
+		return n.kind == "number" && !strings.ContainsAny(n.scalar, ".eE")
+	default:
+		return n.kind == typ
+	}
+}
+
+func (v *schemaValidator) validate(s *jsonSchema, n *jsonNode, path string) {
+	if s.Ref != "" {
+		def, ok := v.root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+		if !ok {
+			v.report(n.offset, path, "unknown schema reference %s", s.Ref)
+			return
+		}
+		s = def
+	}
+
+	if types := schemaTypes(s); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return matchesType(n, t) }) {
+		v.report(n.offset, path, "expected %s, got %s", strings.Join(types, " or "), n.kind)
+		return
+	}
+
+	switch n.kind {
+	case "string":
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, n.scalar) {
+			v.report(n.offset, path, "%q is not one of %s", n.scalar, strings.Join(s.Enum, ", "))
+		}
+		if s.MinLength != nil && utf8.RuneCountInString(n.scalar) < *s.MinLength {
+			v.report(n.offset, path, "must not be empty")
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, n.scalar); err != nil {
+				v.report(n.offset, path, "%q is not an RFC 3339 date-time, e.g. 2025-01-31T18:30:00Z", n.scalar)
+			}
+		}
+	case "number":
+		value, err := strconv.ParseFloat(n.scalar, 64)
+		if err != nil || math.IsInf(value, 0) {
+			v.report(n.offset, path, "%s is out of range", n.scalar)
+			return
+		}
+		if s.Minimum != nil && value < *s.Minimum {
+			v.report(n.offset, path, "%s is below the minimum of %g", n.scalar, *s.Minimum)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			v.report(n.offset, path, "%s is above the maximum of %g", n.scalar, *s.Maximum)
+		}
+	case "object":
+		for _, name := range s.Required {
+			if _, ok := n.fields[name]; !ok {
+				v.report(n.offset, path, "missing required property %q", name)
+			}
+		}
+		for _, key := range n.keys {
+			child := joinSchemaPath(path, key)
+			if prop, ok := s.Properties[key]; ok {
+				v.validate(prop, n.fields[key], child)
+				continue
+			}
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				msg := fmt.Sprintf("unknown property %q", key)
+				for name := range s.Properties {
+					if strings.EqualFold(name, key) {
+						msg += fmt.Sprintf(" (did you mean %q?)", name)
+					}
+				}
+				v.report(n.keyAt[key], path, "%s", msg)
+			}
+		}
+	case "array":
+		if s.Items != nil {
+			for i, item := range n.items {
+				v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	}
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validateImportFile checks a JSON or NDJSON import file (optionally gzipped) against the
+// schema, each NDJSON line being one problem. It returns every violation found.
+func validateImportFile(filename string, ndjson bool) ([]schemaViolation, error) {
+	schema, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+	in, err := openImportFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	if !ndjson {
+		root, syntaxErr := parsePositioned(data)
+		if syntaxErr != nil {
+			return []schemaViolation{*syntaxErr}, nil
+		}
+		v := &schemaValidator{root: schema, data: data}
+		v.validate(schema, root, "")
+		return v.violations, nil
+	}
+
+	var violations []schemaViolation
+	for i, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		record := []byte(line)
+		found := len(violations)
+		if root, syntaxErr := parsePositioned(record); syntaxErr != nil {
+			violations = append(violations, *syntaxErr)
+		} else {
+			v := &schemaValidator{root: schema, data: record}
+			v.validate(schema.Items, root, "")
+			violations = append(violations, v.violations...)
+		}
+		// Positions are relative to the record, which is a single line of the file.
+		for j := found; j < len(violations); j++ {
+			violations[j].Line = i + 1
+		}
+	}
+	return violations, nil
+}
+
+func schemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the export and import format",
+		Long: "Prints the JSON Schema (draft 2020-12) of the files written by 'saitama export' and read by " +
+			"'saitama import': a list of problems. Every line of an NDJSON file is one problem, see $defs/problem. " +
+			"Tools generating import files can check them against it, or with 'saitama import --strict'.",
+		Example: `  saitama schema > saitama.schema.json
+  saitama import --strict generated.json`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprint(stdout, schemaJSON)
+		},
+	}
+}
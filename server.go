@@ -0,0 +1,327 @@
+// server.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// splitList splits repeated and comma-separated query parameters into one list.
+func splitList(values []string) []string {
+	var result []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// writeJSON writes a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": fmt.Sprintf(format, args...)})
+}
+
+// positiveParam parses an optional positive integer query parameter.
+func positiveParam(values url.Values, name string, def int) (int, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("'%s' must be a positive integer", name)
+	}
+	return n, nil
+}
+
+// saveErrorStatus maps a save error to a status code: read-only mode is a 403.
+func saveErrorStatus(err error) int {
+	if errors.Is(err, errReadOnly) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// apiServer holds the state shared by the HTTP handlers of server mode.
+type apiServer struct {
+	mu        sync.Mutex // serializes read-modify-write cycles on the problems file
+	webhooks  []WebhookConfig
+	platforms []PlatformDef
+	feed      bool // serve the review calendar at /calendar.ics
+}
+
+// handleListProblems serves GET /problems with filtering (tag, not_tag, difficulty, platform, q),
+// sorting (sort=-added), sparse fieldsets (fields=id,name) and pagination (page, per_page).
+func (s *apiServer) handleListProblems(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	page, err := positiveParam(params, "page", 1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	perPage, err := positiveParam(params, "per_page", defaultPageSize)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	perPage = min(perPage, maxPageSize)
+	if page > math.MaxInt/perPage {
+		writeError(w, http.StatusBadRequest, "page %d is out of range", page)
+		return
+	}
+
+	q := ProblemQuery{
+		Tags:       splitList(params["tag"]),
+		NotTags:    splitList(params["not_tag"]),
+		Difficulty: params.Get("difficulty"),
+		Platform:   params.Get("platform"),
+		ID:         params.Get("q"),
+		Sort:       params.Get("sort"),
+		Starred:    params.Get("starred") == "true",
+		Archived:   params.Get("archived"),
+		Complexity: params.Get("complexity"),
+		Added:      params.Get("added"),
+		Solved:     params.Get("solved"),
+		Offset:     (page - 1) * perPage,
+		Limit:      perPage,
+	}
+	if err := q.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	results, total, err := store.Query(q)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+
+	body, err := projectFields(results, splitList(params["fields"]))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	lastPage := max(1, (total+perPage-1)/perPage)
+	var links []string
+	pageLink := func(n int, rel string) string {
+		u := *r.URL
+		values := u.Query()
+		values.Set("page", strconv.Itoa(n))
+		values.Set("per_page", strconv.Itoa(perPage))
+		u.RawQuery = values.Encode()
+		return fmt.Sprintf("<%s>; rel=\"%s\"", u.RequestURI(), rel)
+	}
+	if page < lastPage {
+		links = append(links, pageLink(page+1, "next"), pageLink(lastPage, "last"))
+	}
+	if page > 1 {
+		links = append(links, pageLink(1, "first"), pageLink(min(page-1, lastPage), "prev"))
+	}
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	w.Header().Set("X-Page", strconv.Itoa(page))
+	w.Header().Set("X-Per-Page", strconv.Itoa(perPage))
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// handleGetProblem serves GET /problems/{id}, optionally with sparse fieldsets.
+func (s *apiServer) handleGetProblem(w http.ResponseWriter, r *http.Request) {
+	p, ok, err := store.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "problem '%s' not found", r.PathValue("id"))
+		return
+	}
+
+	body, err := projectFields([]Problem{p}, splitList(r.URL.Query()["fields"]))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, body[0])
+}
+
+// handleCreateProblem serves POST /problems and fires problem.created.
+func (s *apiServer) handleCreateProblem(w http.ResponseWriter, r *http.Request) {
+	var p Problem
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid problem JSON: %v", err)
+		return
+	}
+	p.ID = strings.ToUpper(strings.TrimSpace(p.ID))
+	p.Name = strings.TrimSpace(p.Name)
+	if p.ID == "" || p.Name == "" {
+		writeError(w, http.StatusBadRequest, "id and name are required")
+		return
+	}
+	p.Tags = parseTags(strings.Join(p.Tags, ","))
+	if err := resolvePlatform(s.platforms, &p); err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if p.DateAdded.IsZero() {
+		p.DateAdded = time.Now()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	problems, err := loadProblems()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+	if _, index := findProblemByID(problems, p.ID); index != -1 {
+		writeError(w, http.StatusConflict, "problem '%s' already exists", p.ID)
+		return
+	}
+	if existing, index := findProblemByURL(problems, p.URL); index != -1 {
+		writeError(w, http.StatusConflict, "problem '%s' already has this URL", existing.ID)
+		return
+	}
+	if err := saveProblems(append(problems, p)); err != nil {
+		writeError(w, saveErrorStatus(err), "failed to save problems: %v", err)
+		return
+	}
+
+	dispatchWebhooks(s.webhooks, EventProblemCreated, p)
+	writeJSON(w, http.StatusCreated, p)
+}
+
+// handleSolveProblem serves POST /problems/{id}/solve with an optional body of
+// {"solved": bool, "minutes": int} and fires problem.solved on success.
+func (s *apiServer) handleSolveProblem(w http.ResponseWriter, r *http.Request) {
+	body := struct {
+		Solved  *bool  `json:"solved"`
+		Minutes int    `json:"minutes"`
+		Felt    string `json:"felt"`
+	}{}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid JSON body: %v", err)
+			return
+		}
+	}
+	solved := body.Solved == nil || *body.Solved
+	felt, err := parseFelt(body.Felt)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	problems, err := loadProblems()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load problems: %v", err)
+		return
+	}
+	_, index := findProblemByID(problems, strings.ToUpper(r.PathValue("id")))
+	if index == -1 {
+		writeError(w, http.StatusNotFound, "problem '%s' not found", r.PathValue("id"))
+		return
+	}
+
+	recordAttempt(&problems[index], solved, time.Duration(body.Minutes)*time.Minute, time.Now())
+	problems[index].Attempts[len(problems[index].Attempts)-1].Felt = felt
+	if err := saveProblems(problems); err != nil {
+		writeError(w, saveErrorStatus(err), "failed to save problems: %v", err)
+		return
+	}
+
+	if solved {
+		dispatchWebhooks(s.webhooks, EventProblemSolved, problems[index])
+	}
+	writeJSON(w, http.StatusOK, problems[index])
+}
+
+// newServerMux builds the HTTP routes of server mode.
+func newServerMux(s *apiServer) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /problems", s.handleListProblems)
+	mux.HandleFunc("GET /problems/{id}", s.handleGetProblem)
+	mux.HandleFunc("POST /problems", s.handleCreateProblem)
+	mux.HandleFunc("POST /problems/{id}/solve", s.handleSolveProblem)
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	if s.feed {
+		mux.HandleFunc("GET /calendar.ics", s.handleCalendarFeed)
+	}
+	return mux
+}
+
+func serveCmd() *cobra.Command {
+	var addr string
+	var feed bool
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve your problems over a REST API",
+		Long:  "Start an HTTP server exposing your collection as JSON for dashboards, scripts, bots and other tools.",
+		Example: `  saitama serve --addr :8080
+  curl 'localhost:8080/problems?tag=dp&fields=id,name,difficulty&sort=-added&per_page=20'
+  curl 'localhost:8080/problems/LC1'
+  saitama serve --feed                # also serve the review calendar at /calendar.ics`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (webhooks disabled)"), err)
+			}
+
+			color.HiCyan(tr("🌐 Saitama server listening on %s"), addr)
+			if isReadOnly() {
+				color.Yellow(tr("🔒 Read-only mode: POST requests will be refused"))
+			}
+			color.HiBlack(tr("   GET  /problems              ?tag= &not_tag= &difficulty= &platform= &q= &starred= &archived= &added= &solved= &fields= &sort= &page= &per_page="))
+			color.HiBlack(tr("   GET  /problems/{id}         ?fields="))
+			color.HiBlack(tr("   POST /problems              {\"id\": ..., \"name\": ..., \"tags\": [...]}"))
+			color.HiBlack(tr("   POST /problems/{id}/solve   {\"solved\": true, \"minutes\": 30}"))
+			color.HiBlack(tr("   GET  /metrics               Prometheus metrics"))
+			if feed {
+				color.HiBlack(tr("   GET  /calendar.ics          subscribe to this URL in your calendar app"))
+			}
+			if len(cfg.Webhooks) > 0 {
+				color.HiBlack(tr("   🪝 %d webhook(s) configured"), len(cfg.Webhooks))
+			}
+			if err := http.ListenAndServe(addr, newServerMux(&apiServer{webhooks: cfg.Webhooks, platforms: platformRegistry(cfg), feed: feed})); err != nil {
+				printError(tr("❌ Server error: %v"), err)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", "localhost:8080", "address to listen on")
+	cmd.Flags().BoolVar(&feed, "feed", false, "serve the review schedule as a live iCalendar feed")
+	return cmd
+}
@@ -0,0 +1,44 @@
+// server_test.go
+package main
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestListProblemsPaging(t *testing.T) {
+	useTempDataDir(t)
+	if err := saveProblems([]Problem{{ID: "LC1", Name: "Two Sum"}, {ID: "LC2", Name: "Add Two Numbers"}}); err != nil {
+		t.Fatal(err)
+	}
+	s := &apiServer{}
+	tests := []struct {
+		query string
+		code  int
+	}{
+		{"page=1&per_page=1", http.StatusOK},
+		{"page=5", http.StatusOK},
+		{"page=0", http.StatusBadRequest},
+		{"page=" + strconv.Itoa(math.MaxInt/10+1) + "&per_page=10", http.StatusBadRequest},
+		{"page=" + strconv.Itoa(math.MaxInt), http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		s.handleListProblems(rec, httptest.NewRequest(http.MethodGet, "/problems?"+tt.query, nil))
+		if rec.Code != tt.code {
+			t.Errorf("GET /problems?%s = %d, want %d (%s)", tt.query, rec.Code, tt.code, rec.Body)
+		}
+	}
+}
+
+func TestProblemQueryValidateOffset(t *testing.T) {
+	if err := (ProblemQuery{Offset: -10, Limit: 10}).Validate(); err == nil {
+		t.Error("a negative offset should be rejected")
+	}
+	if err := (ProblemQuery{Offset: 10, Limit: 10}).Validate(); err != nil {
+		t.Errorf("Validate = %v", err)
+	}
+}
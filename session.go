@@ -0,0 +1,491 @@
+// session.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// sessionCheckpointInterval is how often a running session clock is written to disk.
+// At most this much time is lost when the terminal is closed mid-session.
+const sessionCheckpointInterval = 10 * time.Second
+
+// SessionProblem is the progress on one problem of a training session.
+type SessionProblem struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Done    bool   `json:"done"`
+	Solved  bool   `json:"solved"`
+	Seconds int    `json:"seconds"`
+}
+
+// Session is a timed training session: a set of problems sharing one time budget, like a
+// virtual contest. It is kept in session.json while in flight so that it survives a
+// closed terminal and can be resumed.
+type Session struct {
+	Started       time.Time        `json:"started"`
+	BudgetSeconds int              `json:"budget_seconds"`
+	UsedSeconds   int              `json:"used_seconds"`  // clock time of the finished runs
+	RunningSince  time.Time        `json:"running_since"` // zero while the clock is stopped
+	Checkpoint    time.Time        `json:"checkpoint"`    // last time the running clock was saved
+	Current       int              `json:"current"`
+	Seed          int64            `json:"seed"` // of the problem selection, see session start --seed
+	Problems      []SessionProblem `json:"problems"`
+}
+
+// running reports whether the clock was running when the session was last saved.
+func (s *Session) running() bool {
+	return !s.RunningSince.IsZero()
+}
+
+// interrupted reports whether the clock is marked as running but hasn't been
+// checkpointed lately, i.e. the process running it is gone.
+func (s *Session) interrupted(now time.Time) bool {
+	return s.running() && now.Sub(s.Checkpoint) > 2*sessionCheckpointInterval
+}
+
+// startClock starts the clock on the current problem.
+func (s *Session) startClock(now time.Time) {
+	s.RunningSince, s.Checkpoint = now, now
+}
+
+// stopClock stops the clock at the given time and charges the run to the current problem.
+func (s *Session) stopClock(now time.Time) {
+	if !s.running() {
+		return
+	}
+	spent := max(0, int(now.Sub(s.RunningSince).Seconds()))
+	s.UsedSeconds += spent
+	if s.Current < len(s.Problems) {
+		s.Problems[s.Current].Seconds += spent
+	}
+	s.RunningSince, s.Checkpoint = time.Time{}, time.Time{}
+}
+
+// recoverClock stops a clock left running by a closed terminal at its last checkpoint,
+// so the time after it isn't charged.
+func (s *Session) recoverClock() {
+	if s.running() {
+		s.stopClock(s.Checkpoint)
+	}
+}
+
+// remaining returns the time left in the session budget.
+func (s *Session) remaining(now time.Time) time.Duration {
+	used := time.Duration(s.UsedSeconds) * time.Second
+	if s.running() {
+		used += now.Sub(s.RunningSince)
+	}
+	return max(0, time.Duration(s.BudgetSeconds)*time.Second-used)
+}
+
+func sessionPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "session.json"), nil
+}
+
+// loadSession returns the session in flight, or nil if there is none.
+func loadSession() (*Session, error) {
+	path, err := sessionPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &s, nil
+}
+
+// saveSession writes the session atomically, or removes it when nil.
+func saveSession(s *Session) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := sessionPath()
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove session: %w", err)
+		}
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// pickSessionProblems picks up to n problems with one of the tags, unsolved ones first,
+// shuffled with rng.
+func pickSessionProblems(problems []Problem, n int, tags []string, rng *rand.Rand) []Problem {
+	var unsolved, solved []Problem
+	for _, p := range problems {
+		if !hasAnyTag(p, tags) {
+			continue
+		}
+		if isSolved(p) {
+			solved = append(solved, p)
+		} else {
+			unsolved = append(unsolved, p)
+		}
+	}
+	rng.Shuffle(len(unsolved), func(i, j int) { unsolved[i], unsolved[j] = unsolved[j], unsolved[i] })
+	rng.Shuffle(len(solved), func(i, j int) { solved[i], solved[j] = solved[j], solved[i] })
+	picked := append(unsolved, solved...)
+	return picked[:min(n, len(picked))]
+}
+
+// printSessionStatus shows the progress and the time left of a session.
+func printSessionStatus(s *Session, now time.Time) {
+	solved := 0
+	for _, sp := range s.Problems {
+		if sp.Solved {
+			solved++
+		}
+	}
+	color.White(tr("📅 Started %s"), s.Started.Format("2006-01-02 15:04"))
+	color.White(tr("⏳ %s left of %s"), formatClock(s.remaining(now)), formatClock(time.Duration(s.BudgetSeconds)*time.Second))
+	color.White(tr("✅ %d/%d solved"), solved, len(s.Problems))
+	if s.Seed != 0 {
+		color.HiBlack(tr("🎲 Seed %d (replay with: saitama session start --seed %d)"), s.Seed, s.Seed)
+	}
+	for i, sp := range s.Problems {
+		mark := "⬜"
+		switch {
+		case sp.Solved:
+			mark = "✅"
+		case sp.Done:
+			mark = "❌"
+		case i == s.Current:
+			mark = "👉"
+		}
+		spent := ""
+		if sp.Seconds > 0 {
+			spent = color.HiBlackString(" (%s)", formatClock(time.Duration(sp.Seconds)*time.Second))
+		}
+		fmt.Fprintf(stderr, "   %s %s - %s%s\n", mark, sp.ID, sp.Name, spent)
+	}
+}
+
+// runSession runs the session from its current problem until it is finished, the time
+// is up or the user pauses it. The state is saved after every step and checkpointed while
+// the clock runs.
+func runSession(s *Session) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+	save := func() {
+		if err := saveSession(s); err != nil {
+			color.Yellow(tr("⚠️  Could not save the session: %v"), err)
+		}
+	}
+
+	for s.Current < len(s.Problems) && s.remaining(time.Now()) > 0 {
+		sp := &s.Problems[s.Current]
+		fmt.Fprintln(stderr)
+		color.HiYellow(tr("🎯 Problem %d/%d: %s - %s"), s.Current+1, len(s.Problems), sp.ID, sp.Name)
+		if p, _ := findProblemByID(problems, sp.ID); p != nil {
+			if p.Difficulty != "" {
+				color.White(tr("   💪 Difficulty: %s"), p.Difficulty)
+			}
+			if p.URL != "" {
+				color.Blue("   🔗 %s", p.URL)
+			}
+		}
+
+		s.startClock(time.Now())
+		save()
+		// The daemon, if running, still announces the end of the time when this terminal is closed.
+		daemonRemind("session", tr("⏰ Session time is up!"), time.Now().Add(s.remaining(time.Now())))
+		_, timedOut := countdown{
+			total:    s.remaining(time.Now()),
+			label:    "⏳",
+			warnings: []time.Duration{10 * time.Minute, 5 * time.Minute},
+			onTick: func(time.Duration) {
+				if now := time.Now(); now.Sub(s.Checkpoint) >= sessionCheckpointInterval {
+					s.Checkpoint = now
+					save()
+				}
+			},
+		}.run()
+		s.stopClock(time.Now())
+		save()
+		daemonRemind("session", "", time.Time{})
+
+		const solved, pause = "✅ Solved", "⏸️  Pause the session"
+		options := []string{solved, "❌ Not solved"}
+		if !timedOut {
+			options = append(options, pause)
+		}
+		choice := ""
+		if err := survey.AskOne(&survey.Select{Message: "How did it go?", Options: options}, &choice); err != nil || choice == pause {
+			color.Yellow(tr("⏸️  Session paused with %s left."), formatClock(s.remaining(time.Now())))
+			color.Cyan(tr("💡 Continue with: saitama session resume"))
+			return
+		}
+		sp.Done, sp.Solved = true, choice == solved
+		s.Current++
+		save()
+	}
+
+	var attempts []timedAttempt
+	for _, sp := range s.Problems {
+		if sp.Done {
+			attempts = append(attempts, timedAttempt{ID: sp.ID, Solved: sp.Solved, Spent: time.Duration(sp.Seconds) * time.Second, At: time.Now()})
+		}
+	}
+	if err := recordTimedAttempts(attempts); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	if err := saveSession(nil); err != nil {
+		color.Yellow("⚠️  %v", err)
+	}
+
+	fmt.Fprintln(stderr)
+	color.HiMagenta("═══════════════════════════════════════")
+	color.HiMagenta(tr("       🏁 SESSION OVER 🏁               "))
+	color.HiMagenta("═══════════════════════════════════════")
+	printSessionStatus(s, time.Now())
+	solved := 0
+	for _, sp := range s.Problems {
+		if sp.Solved {
+			solved++
+		}
+	}
+	notify("saitama", fmt.Sprintf(tr("🏁 Session over: %d of %d problem(s) solved"), solved, len(s.Problems)))
+	fireSessionCompleted(SessionSummary{Kind: "session", Started: s.Started, Problems: s.Problems})
+}
+
+func sessionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Run timed training sessions that can be paused and resumed",
+		Long: "A session is a set of problems sharing one time budget, like a virtual contest. Its state " +
+			"(progress on every problem and the time left) is saved as you go, so a session paused from " +
+			"the prompt, or lost to a closed terminal, continues with 'saitama session resume'.",
+		Example: `  saitama session start --count 4 --minutes 120
+  saitama session start weekend-grind
+  saitama session status
+  saitama session resume`,
+	}
+
+	var count, minutes int
+	var seed int64
+	var tags []string
+	start := &cobra.Command{
+		Use:         "start [template]",
+		Annotations: mutates(),
+		Short:       "Start a new session, optionally from a template of the config",
+		Example: `  saitama session start
+  saitama session start --count 4 --minutes 120 --tag graphs
+  saitama session start --seed 4242   # Same problems as everyone using this seed
+  saitama session start warmup        # The "morning-warmup" template, see 'saitama session templates'`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var slots []sessionSlot
+			var template string
+			if len(args) > 0 {
+				if cmd.Flags().Changed("count") || cmd.Flags().Changed("tag") {
+					printError(tr("❌ --count and --tag can't be used with a template, which sets the problems"))
+					return
+				}
+				cfg, err := loadConfig()
+				if err != nil {
+					printError(tr("❌ Error loading config: %v"), err)
+					return
+				}
+				name, t, err := findSessionTemplate(cfg.SessionTemplates, args[0])
+				if err != nil {
+					printError("❌ %v", err)
+					return
+				}
+				var budget time.Duration
+				if slots, budget, err = t.parse(); err != nil {
+					printError(tr("❌ Session template '%s': %v"), name, err)
+					return
+				}
+				if !cmd.Flags().Changed("minutes") {
+					minutes = int(budget.Minutes())
+				}
+				template = fmt.Sprintf("%s (%s)", name, t.Problems)
+			}
+			if minutes <= 0 {
+				printError(tr("❌ --minutes must be positive"))
+				return
+			}
+
+			existing, err := loadSession()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if existing != nil {
+				color.Yellow(tr("⚠️  A session is already in progress."))
+				color.Cyan(tr("💡 Continue it with 'saitama session resume' or drop it with 'saitama session abandon'"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			rng, seed := selectionRand(seed, cmd.Flags().Changed("seed"))
+			var picked []Problem
+			if slots != nil {
+				picked = pickTemplateProblems(activeProblems(problems), slots, rng)
+				wanted := 0
+				for _, slot := range slots {
+					wanted += slot.Count
+				}
+				if len(picked) > 0 && len(picked) < wanted {
+					color.Yellow(tr("⚠️  Only %d of the %d problem(s) of the template could be found."), len(picked), wanted)
+				}
+			} else {
+				picked = pickSessionProblems(activeProblems(problems), count, tags, rng)
+			}
+			if len(picked) == 0 {
+				color.Yellow(tr("📝 No problems for a session! Add some first."))
+				return
+			}
+
+			s := &Session{Started: time.Now(), BudgetSeconds: minutes * 60, Seed: seed}
+			for _, p := range picked {
+				s.Problems = append(s.Problems, SessionProblem{ID: p.ID, Name: p.Name})
+			}
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("       🏋️ TRAINING SESSION 🏋️           "))
+			color.HiMagenta("═══════════════════════════════════════")
+			if template != "" {
+				color.Cyan(tr("📋 Template %s"), template)
+			}
+			color.HiBlack(tr("%d problem(s), %d minutes in total."), len(s.Problems), minutes)
+			runSession(s)
+		},
+	}
+	start.Flags().IntVarP(&count, "count", "n", 3, "number of problems")
+	start.Flags().IntVarP(&minutes, "minutes", "m", 90, "time budget of the whole session")
+	start.Flags().StringSliceVarP(&tags, "tag", "t", nil, "only pick problems with one of these tags")
+	start.Flags().Int64Var(&seed, "seed", 0, "seed of the random selection, to run the same session as someone else (random by default)")
+	cmd.AddCommand(start)
+	cmd.AddCommand(sessionTemplatesCmd())
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "resume",
+		Annotations: mutates(),
+		Short:       "Continue the session in progress where you left off",
+		Run: func(cmd *cobra.Command, args []string) {
+			s, err := loadSession()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if s == nil {
+				color.Yellow(tr("🏋️ No session in progress. Start one with: saitama session start"))
+				return
+			}
+			if s.running() && !s.interrupted(time.Now()) {
+				color.Yellow(tr("⚠️  The session looks like it's still running in another terminal."))
+				return
+			}
+			if s.running() {
+				s.recoverClock()
+				color.Yellow(tr("🩹 Recovered a session interrupted at %s."), s.Problems[min(s.Current, len(s.Problems)-1)].ID)
+			}
+			fmt.Fprintln(stderr)
+			color.HiMagenta("═══════════════════════════════════════")
+			color.HiMagenta(tr("       🏋️ SESSION RESUMED 🏋️            "))
+			color.HiMagenta("═══════════════════════════════════════")
+			printSessionStatus(s, time.Now())
+			runSession(s)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Show the session in progress",
+		Run: func(cmd *cobra.Command, args []string) {
+			s, err := loadSession()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if s == nil {
+				color.Yellow(tr("🏋️ No session in progress. Start one with: saitama session start"))
+				return
+			}
+			now := time.Now()
+			switch {
+			case s.interrupted(now):
+				// Show what resume will restore, not the wall-clock time since the crash.
+				s.recoverClock()
+				color.Yellow(tr("🩹 Interrupted (the terminal was closed), resume to continue."))
+			case s.running():
+				color.Green(tr("⏱️  Running in another terminal."))
+			default:
+				color.Yellow(tr("⏸️  Paused."))
+			}
+			printSessionStatus(s, now)
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "abandon",
+		Annotations: mutates(),
+		Short:       "Drop the session in progress without recording it",
+		Run: func(cmd *cobra.Command, args []string) {
+			s, err := loadSession()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if s == nil {
+				color.Yellow(tr("🏋️ No session in progress."))
+				return
+			}
+			confirm := false
+			if err := survey.AskOne(&survey.Confirm{Message: "Abandon the session? Its progress will be lost."}, &confirm); err != nil || !confirm {
+				color.Yellow(tr("Kept the session."))
+				return
+			}
+			if err := saveSession(nil); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🗑️  Session abandoned."))
+		},
+	})
+	return cmd
+}
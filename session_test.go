@@ -0,0 +1,109 @@
+// session_test.go
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSessionClock(t *testing.T) {
+	start := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	s := &Session{Started: start, BudgetSeconds: 3600, Problems: []SessionProblem{{ID: "LC1"}, {ID: "LC2"}}}
+
+	if got := s.remaining(start); got != time.Hour {
+		t.Errorf("remaining before the clock starts = %v, want 1h", got)
+	}
+	s.startClock(start)
+	if !s.running() {
+		t.Fatal("the clock should run after startClock")
+	}
+	if got := s.remaining(start.Add(10 * time.Minute)); got != 50*time.Minute {
+		t.Errorf("remaining while running = %v, want 50m", got)
+	}
+
+	s.stopClock(start.Add(20 * time.Minute))
+	if s.running() || s.UsedSeconds != 1200 || s.Problems[0].Seconds != 1200 {
+		t.Errorf("after stopClock: running %v, used %ds, charged %ds, want stopped with 1200s", s.running(), s.UsedSeconds, s.Problems[0].Seconds)
+	}
+	if got := s.remaining(start.Add(5 * time.Hour)); got != 40*time.Minute {
+		t.Errorf("remaining while stopped = %v, want 40m whatever the time", got)
+	}
+	s.stopClock(start.Add(30 * time.Minute)) // already stopped: nothing is charged
+	if s.UsedSeconds != 1200 {
+		t.Errorf("stopping a stopped clock charged time: used %ds", s.UsedSeconds)
+	}
+
+	s.Current = 1
+	s.startClock(start.Add(time.Hour))
+	if got := s.remaining(start.Add(3 * time.Hour)); got != 0 {
+		t.Errorf("remaining past the budget = %v, want 0", got)
+	}
+	s.stopClock(start.Add(time.Hour + 15*time.Minute))
+	if s.Problems[1].Seconds != 900 || s.UsedSeconds != 2100 {
+		t.Errorf("second run: charged %ds, used %ds, want 900s and 2100s", s.Problems[1].Seconds, s.UsedSeconds)
+	}
+}
+
+func TestSessionRecoverClock(t *testing.T) {
+	start := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	s := &Session{BudgetSeconds: 3600, Problems: []SessionProblem{{ID: "LC1"}}}
+	s.startClock(start)
+	s.Checkpoint = start.Add(5 * time.Minute) // the terminal was closed after this checkpoint
+
+	if s.interrupted(start.Add(5*time.Minute + sessionCheckpointInterval)) {
+		t.Error("a clock checkpointed on time should not count as interrupted")
+	}
+	if !s.interrupted(start.Add(2 * time.Hour)) {
+		t.Fatal("a clock not checkpointed for long should count as interrupted")
+	}
+	s.recoverClock()
+	if s.running() || s.UsedSeconds != 300 || s.Problems[0].Seconds != 300 {
+		t.Errorf("after recoverClock: running %v, used %ds, want stopped at the checkpoint with 300s", s.running(), s.UsedSeconds)
+	}
+}
+
+func TestRecordTimedAttemptsAfterAnotherSave(t *testing.T) {
+	useTempDataDir(t)
+	if err := saveProblems([]Problem{{ID: "LC1", Name: "Two Sum"}, {ID: "LC2", Name: "Add Two Numbers"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadProblems(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process adds a problem while the timer runs.
+	dbPath, err := getDbPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal([]Problem{{ID: "LC1", Name: "Two Sum"}, {ID: "LC2", Name: "Add Two Numbers"}, {ID: "LC3", Name: "Longest Substring"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dbPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(dbPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Now()
+	err = recordTimedAttempts([]timedAttempt{{ID: "LC1", Solved: true, Spent: 20 * time.Minute, At: at}, {ID: "LC9", Solved: true, At: at}})
+	if err != nil {
+		t.Fatalf("recordTimedAttempts: %v", err)
+	}
+	problems, err := loadProblems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 3 {
+		t.Fatalf("got %d problems, want the 3 saved by the other process", len(problems))
+	}
+	p, _ := findProblemByID(problems, "LC1")
+	if p == nil || len(p.Attempts) != 1 || !p.Attempts[0].Solved || p.Attempts[0].DurationSeconds != 1200 || p.SolveCount != 1 {
+		t.Errorf("LC1 = %+v, want one solved attempt of 20 minutes", p)
+	}
+}
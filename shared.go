@@ -0,0 +1,168 @@
+// shared.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	lockFileName = "saitama.lock"
+	lockTimeout  = 5 * time.Second
+	// Locks are only held while a file is being written, so an older lock was left
+	// behind by a crashed process and can be taken over.
+	staleLockAge = 30 * time.Second
+)
+
+var (
+	// dataDirFlag and readOnlyFlag are set by the persistent --data-dir and --read-only flags.
+	dataDirFlag  string
+	readOnlyFlag bool
+
+	errReadOnly = errors.New("the database is open in read-only mode")
+)
+
+// mutatesAnnotation marks the commands that change your data, see mutates. They are
+// refused up front in read-only mode; everything else may still run, and saving is
+// blocked in any case.
+const mutatesAnnotation = "saitama/mutates"
+
+// mutates returns the annotations of a command that changes the data: always, or when
+// given one of the flags, e.g. calibrate only stores its estimates with --apply.
+func mutates(flags ...string) map[string]string {
+	value := "always"
+	if len(flags) > 0 {
+		value = strings.Join(flags, ",")
+	}
+	return map[string]string{mutatesAnnotation: value}
+}
+
+// dataDir returns the directory override from --data-dir or SAITAMA_DATA_DIR, if any.
+func dataDir() string {
+	if dataDirFlag != "" {
+		return dataDirFlag
+	}
+	return os.Getenv("SAITAMA_DATA_DIR")
+}
+
+// isReadOnly reports whether writes are disabled by --read-only or SAITAMA_READ_ONLY.
+// --full-history implies it, see compact.
+func isReadOnly() bool {
+	if readOnlyFlag || fullHistoryFlag {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("SAITAMA_READ_ONLY")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// mutatesState reports whether a command, as invoked, changes the data.
+func mutatesState(cmd *cobra.Command) bool {
+	value, ok := cmd.Annotations[mutatesAnnotation]
+	if !ok || value == "always" {
+		return ok
+	}
+	for _, flag := range strings.Split(value, ",") {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+// lockInfo is stored in the lock file so other users can see who holds it.
+type lockInfo struct {
+	Host     string    `json:"host"`
+	PID      int       `json:"pid"`
+	Acquired time.Time `json:"acquired"`
+}
+
+// breakStaleLock moves a stale lock out of the way. Removing it directly would let two
+// processes that both saw it stale race: the second one would remove the lock the first
+// one just created. A rename only succeeds for one of them, and the lock it moved is
+// checked again, to be put back if it turns out to be a fresh one.
+func breakStaleLock(path string) bool {
+	moved := fmt.Sprintf("%s.stale.%d", path, os.Getpid())
+	if err := os.Rename(path, moved); err != nil {
+		return false // another process got to it first
+	}
+	defer os.Remove(moved)
+	if stat, err := os.Stat(moved); err == nil && time.Since(stat.ModTime()) <= staleLockAge {
+		// Taken over and recreated in the meantime: hand it back unless yet another
+		// process holds the lock by now.
+		_ = os.Link(moved, path)
+		return false
+	}
+	return true
+}
+
+// releaseLock removes the lock file if it is still the one taken. A writer slower than
+// staleLockAge may have lost it to another process, whose lock must stay.
+func releaseLock(path string, mine lockInfo) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		slog.Warn("lock vanished before release", "path", path, "err", err)
+		return
+	}
+	var held lockInfo
+	if err := json.Unmarshal(data, &held); err != nil || held.Host != mine.Host || held.PID != mine.PID || !held.Acquired.Equal(mine.Acquired) {
+		slog.Warn("lock was taken over by another process, leaving it", "path", path, "host", held.Host, "pid", held.PID)
+		return
+	}
+	_ = os.Remove(path)
+	slog.Debug("released lock", "path", path)
+}
+
+// acquireLock takes the data directory's lock file, waiting for other writers (possibly
+// on other machines sharing the directory) and recovering locks left by crashed ones.
+func acquireLock(dir string) (func(), error) {
+	path := filepath.Join(dir, lockFileName)
+	host, _ := os.Hostname()
+	mine := lockInfo{Host: host, PID: os.Getpid(), Acquired: time.Now()}
+	info, _ := json.Marshal(mine)
+
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, writeErr := f.Write(info)
+			closeErr := f.Close()
+			if writeErr != nil || closeErr != nil {
+				_ = os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file")
+			}
+			slog.Debug("acquired lock", "path", path)
+			return func() { releaseLock(path, mine) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		if stat, err := os.Stat(path); err == nil && time.Since(stat.ModTime()) > staleLockAge {
+			if breakStaleLock(path) {
+				slog.Warn("took over stale lock", "path", path, "age", time.Since(stat.ModTime()).Round(time.Second))
+			}
+			continue // try again right away
+		}
+		if time.Now().After(deadline) {
+			holder := lockInfo{}
+			if data, err := os.ReadFile(path); err == nil {
+				_ = json.Unmarshal(data, &holder)
+			}
+			slog.Warn("timed out waiting for lock", "path", path, "holder", holder.Host, "pid", holder.PID)
+			return nil, fmt.Errorf("the database is locked by %s (pid %d) since %s",
+				holder.Host, holder.PID, holder.Acquired.Format("15:04:05"))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
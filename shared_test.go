@@ -0,0 +1,39 @@
+// shared_test.go
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReleaseLock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, lockFileName)
+
+	release, err := acquireLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("the lock should be removed on release, got %v", err)
+	}
+
+	// A slow writer whose lock was taken over as stale must leave the new one.
+	release, err = acquireLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := json.Marshal(lockInfo{Host: "elsewhere", PID: 4242, Acquired: time.Now()})
+	if err := os.WriteFile(path, other, 0644); err != nil {
+		t.Fatal(err)
+	}
+	release()
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != string(other) {
+		t.Errorf("the lock of the other process was not kept: %q, %v", data, err)
+	}
+}
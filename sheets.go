@@ -0,0 +1,651 @@
+// sheets.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// SheetItem is one problem of a sheet, under the topic the sheet files it in.
+type SheetItem struct {
+	ID    string `json:"id"`
+	Topic string `json:"topic"`
+}
+
+// Sheet is a named topic checklist imported from a bootcamp sheet such as Striver's SDE
+// sheet or Love Babbar's 450, in the order of the sheet.
+type Sheet struct {
+	Name     string      `json:"name"`
+	Source   string      `json:"source"` // file it was imported from, for reference
+	Imported time.Time   `json:"imported"`
+	Items    []SheetItem `json:"items"`
+}
+
+// sheetRow is a problem as read from a sheet file, before it is matched to the collection.
+type sheetRow struct {
+	Topic string
+	Name  string
+	Links []string
+}
+
+var (
+	// sheetTopicNumbering matches the numbering in front of topics, e.g. "Step 3: " or "Day 12 - ".
+	sheetTopicNumbering = regexp.MustCompile(`(?i)^(step|day|lecture|lec|part)?\s*[\d.]+\s*[:.)–-]\s*`)
+	// sheetTopicHeaders name the topic column of CSV sheets, the most telling first: the
+	// day or step columns of day-by-day sheets only number the topics.
+	sheetTopicHeaders = []string{"topic", "category", "section", "step", "day"}
+	// sheetTopicSeparators split topics covering several tags, e.g. "Stacks & Queues".
+	sheetTopicSeparators = regexp.MustCompile(`(?i)\s*(&|,|/|\band\b)\s*`)
+)
+
+func sheetsPath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "sheets.json"), nil
+}
+
+// loadSheets returns the imported sheets by name, empty when there are none.
+func loadSheets() (map[string]*Sheet, error) {
+	sheets := make(map[string]*Sheet)
+	path, err := sheetsPath()
+	if err != nil {
+		return sheets, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return sheets, nil
+	}
+	if err != nil {
+		return sheets, fmt.Errorf("failed to read sheets: %w", err)
+	}
+	if err := json.Unmarshal(data, &sheets); err != nil {
+		return sheets, fmt.Errorf("failed to parse sheets: %w", err)
+	}
+	return sheets, nil
+}
+
+// saveSheets writes the sheets atomically.
+func saveSheets(sheets map[string]*Sheet) error {
+	if isReadOnly() {
+		return errReadOnly
+	}
+	path, err := sheetsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sheets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sheets: %w", err)
+	}
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write sheets: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		return fmt.Errorf("failed to write sheets: %w", err)
+	}
+	return nil
+}
+
+// readSheetFile reads the problems of a sheet file: the JSON of the 450 DSA tracker (topics
+// with their questions) or a flat JSON array, else CSV with topic, problem and link columns.
+func readSheetFile(filename string) ([]sheetRow, error) {
+	if isCSVFile(filename) {
+		rows, err := readCSVRows(filename)
+		if err != nil {
+			return nil, err
+		}
+		return parseSheetCSV(rows), nil
+	}
+	in, err := openImportFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer in.close()
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	return parseSheetJSON(data)
+}
+
+// parseSheetCSV finds the header row among the first rows, the one naming a problem
+// column, and reads the rows below it. Sheets often only fill in the topic on the first
+// row of each topic, so an empty topic is the one above.
+func parseSheetCSV(rows [][]string) []sheetRow {
+	for h := 0; h < min(len(rows), 5); h++ {
+		topicCol, topicRank, nameCol := -1, len(sheetTopicHeaders), -1
+		var linkCols []int
+		for i, header := range rows[h] {
+			header = strings.ToLower(strings.TrimSpace(header))
+			switch {
+			case strings.Contains(header, "link") || strings.Contains(header, "url"):
+				linkCols = append(linkCols, i)
+			case nameCol == -1 && (strings.Contains(header, "problem") || strings.Contains(header, "question") ||
+				strings.Contains(header, "title") || header == "name"):
+				nameCol = i
+			default:
+				for rank, word := range sheetTopicHeaders[:topicRank] {
+					if strings.Contains(header, word) {
+						topicCol, topicRank = i, rank
+						break
+					}
+				}
+			}
+		}
+		if nameCol == -1 {
+			continue
+		}
+
+		var out []sheetRow
+		topic := ""
+		for _, row := range rows[h+1:] {
+			cell := func(i int) string {
+				if i < 0 || i >= len(row) {
+					return ""
+				}
+				return strings.TrimSpace(row[i])
+			}
+			if t := cell(topicCol); t != "" {
+				topic = t
+			}
+			r := sheetRow{Topic: topic, Name: cell(nameCol)}
+			for _, i := range linkCols {
+				if link := cell(i); strings.HasPrefix(link, "http") {
+					r.Links = append(r.Links, link)
+				}
+			}
+			if r.Name != "" {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+	return nil
+}
+
+// sheetField returns the first of the keys the record has as a string, ignoring case.
+func sheetField(record map[string]any, keys ...string) string {
+	for _, key := range keys {
+		for k, v := range record {
+			if s, ok := v.(string); ok && strings.EqualFold(k, key) && strings.TrimSpace(s) != "" {
+				return strings.TrimSpace(s)
+			}
+		}
+	}
+	return ""
+}
+
+// parseSheetJSON reads the topics of the 450 DSA tracker, [{"topicName": ..., "questions":
+// [{"Problem": ..., "URL": ..., "URL2": ...}]}], or a flat array of such questions with
+// their "Topic", possibly under a "topics", "questions" or "data" key.
+func parseSheetJSON(data []byte) ([]sheetRow, error) {
+	var records []map[string]any
+	if err := json.Unmarshal(data, &records); err != nil {
+		var wrapper map[string]json.RawMessage
+		if json.Unmarshal(data, &wrapper) != nil {
+			return nil, fmt.Errorf("failed to parse the sheet: %w", err)
+		}
+		for _, key := range []string{"topics", "questions", "data"} {
+			if raw, ok := wrapper[key]; ok && json.Unmarshal(raw, &records) == nil {
+				break
+			}
+		}
+	}
+
+	var out []sheetRow
+	var add func(record map[string]any, topic string)
+	add = func(record map[string]any, topic string) {
+		if questions, ok := record["questions"].([]any); ok {
+			topic = sheetField(record, "topicName", "topic", "name", "title")
+			for _, q := range questions {
+				if question, ok := q.(map[string]any); ok {
+					add(question, topic)
+				}
+			}
+			return
+		}
+		r := sheetRow{
+			Topic: sheetField(record, "topic", "step", "category"),
+			Name:  sheetField(record, "problem", "question", "title", "name"),
+		}
+		if r.Topic == "" {
+			r.Topic = topic
+		}
+		for _, key := range []string{"url", "link", "url2", "link2", "url3"} {
+			if link := sheetField(record, key); strings.HasPrefix(link, "http") {
+				r.Links = append(r.Links, link)
+			}
+		}
+		if r.Name != "" {
+			out = append(out, r)
+		}
+	}
+	for _, record := range records {
+		add(record, "")
+	}
+	return out, nil
+}
+
+// sheetLink picks the link a problem is imported with: a problem page of a built-in
+// judge over an article about it, then any page of a known platform, then the first one.
+func sheetLink(defs []PlatformDef, links []string) string {
+	for _, link := range links {
+		def, ok := platformForURL(defs, link)
+		if prefix, builtin := problemPagePrefixes[def.Name]; ok && builtin && strings.HasPrefix(normalizeURL(link), prefix) {
+			return link
+		}
+	}
+	for _, link := range links {
+		if _, ok := platformForURL(defs, link); ok {
+			return link
+		}
+	}
+	if len(links) > 0 {
+		return links[0]
+	}
+	return ""
+}
+
+// sheetTopicTags turns the topic of a sheet into tags, e.g. "Step 9: Stacks & Queues"
+// into stack and queue.
+func sheetTopicTags(topic string) []string {
+	topic = sheetTopicNumbering.ReplaceAllString(strings.TrimSpace(topic), "")
+	return canonicalTags(sheetTopicSeparators.Split(topic, -1))
+}
+
+// curatedProblemByURL returns the curated problem of the taxonomy with the URL, whose
+// LeetCode number the sheets seldom give.
+func curatedProblemByURL(key string) (Problem, bool) {
+	for _, t := range topicTaxonomy {
+		for _, p := range t.Curated {
+			if normalizeURL(p.URL) == key {
+				return p, true
+			}
+		}
+	}
+	return Problem{}, false
+}
+
+// matchSheet files the rows of a sheet under the problems of the collection, found by
+// URL or else by name, and makes problems of the others. Those get the platform's ID when
+// the link or name carries it, else sequential IDs with the prefix. It returns the items
+// of the sheet, the new problems and how many rows were already in the collection.
+func matchSheet(rows []sheetRow, defs []PlatformDef, existing []Problem, prefix string, now time.Time) ([]SheetItem, []Problem, int) {
+	var items []SheetItem
+	var added []Problem
+	known := 0
+	nextID, _ := strconv.Atoi(strings.TrimPrefix(nextSequentialID(existing, prefix), strings.ToUpper(prefix)))
+	seen := make(map[string]bool)
+	for _, r := range rows {
+		link := sheetLink(defs, r.Links)
+		all := append(existing[:len(existing):len(existing)], added...)
+		p, index := findProblemByURL(all, link)
+		if index == -1 {
+			for i := range all {
+				if strings.EqualFold(all[i].Name, r.Name) {
+					p, index = &all[i], i
+					break
+				}
+			}
+		}
+		if index != -1 {
+			if !seen[p.ID] && index < len(existing) {
+				known++
+			}
+		} else {
+			def, _ := platformForURL(defs, link)
+			key := normalizeURL(link)
+			np := Problem{
+				ID:        bookmarkProblemID(def.Name, key, r.Name),
+				Name:      r.Name,
+				Tags:      sheetTopicTags(r.Topic),
+				Platform:  def.Name,
+				URL:       link,
+				DateAdded: now,
+			}
+			if key != "" {
+				np.Name = bookmarkProblemName(r.Name, key)
+			}
+			if c, ok := curatedProblemByURL(key); ok {
+				np.ID, np.Difficulty = c.ID, c.Difficulty
+				np.Tags = canonicalTags(append(np.Tags, c.Tags...))
+			}
+			if _, taken := findProblemByID(all, np.ID); np.ID == "" || taken != -1 {
+				np.ID = strings.ToUpper(prefix) + strconv.Itoa(nextID)
+				nextID++
+			}
+			added = append(added, np)
+			p = &added[len(added)-1]
+		}
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			items = append(items, SheetItem{ID: p.ID, Topic: strings.TrimSpace(r.Topic)})
+		}
+	}
+	return items, added, known
+}
+
+// sheetTopics returns the topics of a sheet in their order, with the items of each.
+func sheetTopics(s *Sheet) ([]string, map[string][]SheetItem) {
+	var topics []string
+	byTopic := make(map[string][]SheetItem)
+	for _, item := range s.Items {
+		if _, ok := byTopic[item.Topic]; !ok {
+			topics = append(topics, item.Topic)
+		}
+		byTopic[item.Topic] = append(byTopic[item.Topic], item)
+	}
+	return topics, byTopic
+}
+
+// sheetItemDone reports whether the problem of an item is solved.
+func sheetItemDone(problems []Problem, item SheetItem) bool {
+	p, index := findProblemByID(problems, item.ID)
+	return index != -1 && isSolved(*p)
+}
+
+// sheetProgress returns how many items of the sheet are done.
+func sheetProgress(problems []Problem, items []SheetItem) int {
+	done := 0
+	for _, item := range items {
+		if sheetItemDone(problems, item) {
+			done++
+		}
+	}
+	return done
+}
+
+// printSheet shows the problems of a sheet topic by topic, pointing at the first one to do.
+func printSheet(problems []Problem, s *Sheet, topic string) {
+	topics, byTopic := sheetTopics(s)
+	fmt.Fprintln(stderr)
+	color.HiCyan(tr("📋 %s  [%d/%d]"), s.Name, sheetProgress(problems, s.Items), len(s.Items))
+	next, shown := true, 0
+	for _, t := range topics {
+		if topic != "" && !strings.EqualFold(t, topic) && !slices.Contains(sheetTopicTags(t), canonicalTag(topic)) {
+			continue
+		}
+		shown++
+		items := byTopic[t]
+		fmt.Fprintln(stderr)
+		name := t
+		if name == "" {
+			name = tr("(no topic)")
+		}
+		color.HiWhite("📂 %s  [%d/%d]", name, sheetProgress(problems, items), len(items))
+		for _, item := range items {
+			line := item.ID
+			if p, index := findProblemByID(problems, item.ID); index != -1 {
+				line += " - " + p.Name
+				if p.Difficulty != "" {
+					line += " (" + p.Difficulty + ")"
+				}
+			}
+			switch {
+			case sheetItemDone(problems, item):
+				printResult(color.New(color.FgGreen), "   ✅ %s", line)
+			case next:
+				next = false
+				printResult(color.New(color.FgHiYellow), "👉 ⬜ %s", line)
+			default:
+				printResult(color.New(color.FgWhite), "   ⬜ %s", line)
+			}
+		}
+	}
+	fmt.Fprintln(stderr)
+	if shown == 0 {
+		color.Yellow(tr("📂 No topic '%s' in this sheet."), topic)
+	}
+}
+
+func sheetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sheet",
+		Short: "Import bootcamp sheets (Striver's SDE sheet, Love Babbar's 450, ...) and follow them topic by topic",
+		Example: `  saitama sheet import striver-sde.csv --name striver
+  saitama sheet import 450dsa.json --name babbar450 --prefix LB
+  saitama sheet show striver --topic arrays
+  saitama sheet list`,
+	}
+
+	var name, prefix string
+	var dryRun, force bool
+	importSheet := &cobra.Command{
+		Use:         "import <file>",
+		Annotations: mutates(),
+		Short:       "Import a sheet from its published CSV or JSON into a named list",
+		Long: "Reads a bootcamp sheet and adds its problems to your collection, tagged after the topics of the " +
+			"sheet (\"Stacks & Queues\" gives stack and queue), and saves the sheet as a named list to follow. " +
+			"CSV files need a problem column, and usually have a topic (or step, or day) column and link " +
+			"columns; JSON files are the topics with their questions of the 450 DSA tracker, or a flat array of " +
+			"questions with their topic, problem and URL. A problem page of LeetCode, Codeforces, ... is " +
+			"preferred over an article about the problem. Problems already in your collection, by URL or name, " +
+			"are kept as they are; new ones get their platform's ID when the link or name tells it (LC1, " +
+			"CF1000A), else sequential IDs with --prefix. The Done column of the sheets is not imported: log " +
+			"your solves with saitama solve.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			filePath := args[0]
+			if name == "" {
+				base := filepath.Base(strings.TrimSuffix(filePath, ".gz"))
+				name = strings.TrimSuffix(base, filepath.Ext(base))
+			}
+			name = normalizeTag(name)
+			prefix = strings.ToUpper(strings.TrimSpace(prefix))
+			if !idPrefixPattern.MatchString(prefix) {
+				printError(tr("❌ The prefix must be letters only, e.g. SH"))
+				return
+			}
+
+			rows, err := readSheetFile(filePath)
+			if err != nil {
+				printError(tr("❌ Error importing the sheet: %v"), err)
+				return
+			}
+			if len(rows) == 0 {
+				printError(tr("❌ No problems found in %s (expected a problem column, see saitama sheet import --help)"), filePath)
+				return
+			}
+			sheets, err := loadSheets()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if _, exists := sheets[name]; exists && !force && !dryRun {
+				printError(tr("❌ There is already a sheet named '%s' (replace it with --force, or pick another --name)"), name)
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using built-in platforms)"), err)
+			}
+			platforms := platformRegistry(cfg)
+
+			tx, err := store.Begin()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			defer tx.Rollback()
+			sheet := &Sheet{Name: name, Source: filepath.Base(filePath), Imported: time.Now()}
+			var added []Problem
+			var known int
+			err = tx.Update(func(problems []Problem) ([]Problem, error) {
+				sheet.Items, added, known = matchSheet(rows, platforms, problems, prefix, sheet.Imported)
+				for i := range added {
+					if err := resolvePlatform(platforms, &added[i]); err != nil {
+						return nil, fmt.Errorf("%s: %w", added[i].ID, err)
+					}
+				}
+				return append(problems, added...), nil
+			})
+			if err != nil {
+				printError(tr("❌ Error importing the sheet: %v"), err)
+				return
+			}
+
+			topics, _ := sheetTopics(sheet)
+			color.Cyan(tr("📋 %s: %d problem(s) in %d topic(s), %d new and %d already in your collection"),
+				filePath, len(sheet.Items), len(topics), len(added), known)
+			if dryRun {
+				for i, p := range added {
+					if i == 10 {
+						color.HiBlack(tr("   ... and %d more"), len(added)-i)
+						break
+					}
+					printResult(color.New(color.FgWhite), "   ➕ %s - %s [%s]", p.ID, p.Name, strings.Join(p.Tags, ", "))
+				}
+				color.Cyan(tr("🔍 Dry run: nothing was saved."))
+				return
+			}
+
+			sheets[name] = sheet
+			path, err := sheetsPath()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			data, err := json.MarshalIndent(sheets, "", "  ")
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			tx.WriteFile(path, data)
+			if err := tx.Apply(); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("📋 Sheet '%s' saved. Follow it with: saitama sheet show %s"), name, name)
+		},
+	}
+	importSheet.Flags().StringVar(&name, "name", "", "name to save the sheet under (the file name by default)")
+	importSheet.Flags().StringVar(&prefix, "prefix", "SH", "ID prefix of the problems without a platform ID")
+	importSheet.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be imported without saving")
+	importSheet.Flags().BoolVar(&force, "force", false, "replace the sheet of the same name")
+	cmd.AddCommand(importSheet)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Short:   "List the imported sheets and their progress",
+		Aliases: []string{"ls"},
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			sheets, err := loadSheets()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			var names []string
+			for name := range sheets {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			if jsonOutput() {
+				type sheetSummary struct {
+					Name   string `json:"name"`
+					Topics int    `json:"topics"`
+					Done   int    `json:"done"`
+					Items  int    `json:"items"`
+				}
+				summaries := []sheetSummary{}
+				for _, name := range names {
+					s := sheets[name]
+					topics, _ := sheetTopics(s)
+					summaries = append(summaries, sheetSummary{name, len(topics), sheetProgress(problems, s.Items), len(s.Items)})
+				}
+				printJSON(summaries)
+				return
+			}
+			if len(names) == 0 {
+				color.Yellow(tr("📋 No sheets yet. Import one with: saitama sheet import <file>"))
+				return
+			}
+			for _, name := range names {
+				s := sheets[name]
+				done, bar := sheetProgress(problems, s.Items), ""
+				if len(s.Items) > 0 {
+					bar = strings.Repeat("█", 20*done/len(s.Items))
+				}
+				topics, _ := sheetTopics(s)
+				printResult(color.New(color.FgWhite), "📋 %-20s %3d topic(s)  %-20s %d/%d", name, len(topics), bar, done, len(s.Items))
+			}
+		},
+	})
+
+	var topic string
+	show := &cobra.Command{
+		Use:   "show <name>",
+		Short: "Show the problems of a sheet by topic and the next one to do",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			sheets, err := loadSheets()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			s, ok := sheets[args[0]]
+			if !ok {
+				printError(tr("❌ No sheet named '%s' (see saitama sheet list)"), args[0])
+				return
+			}
+			if jsonOutput() {
+				printJSON(s)
+				return
+			}
+			defer startPager()()
+			printSheet(problems, s, topic)
+		},
+	}
+	show.Flags().StringVar(&topic, "topic", "", "only show a topic, by name or tag")
+	cmd.AddCommand(show)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "delete <name>",
+		Annotations: mutates(),
+		Short:       "Delete a sheet (the problems stay)",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			sheets, err := loadSheets()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if _, ok := sheets[args[0]]; !ok {
+				printError(tr("❌ No sheet named '%s' (see saitama sheet list)"), args[0])
+				return
+			}
+			delete(sheets, args[0])
+			if err := saveSheets(sheets); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			color.Green(tr("🗑️  Sheet '%s' deleted."), args[0])
+		},
+	})
+	return cmd
+}
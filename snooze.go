@@ -0,0 +1,154 @@
+// snooze.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// isSnoozed reports whether a problem is kept out of the suggestions at this time.
+func isSnoozed(p Problem, now time.Time) bool {
+	return p.SnoozedUntil.After(now)
+}
+
+// setSnoozed snoozes the problems with the given IDs until a time, or wakes them up
+// when until is zero.
+func setSnoozed(ids []string, until time.Time) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+
+	now := time.Now()
+	var changed []string
+	for _, id := range ids {
+		targetID := strings.ToUpper(id)
+		p, index := findProblemByID(problems, targetID)
+		if index == -1 {
+			printError(tr("❌ Problem with ID '%s' not found"), targetID)
+			return
+		}
+		if until.IsZero() && !isSnoozed(*p, now) {
+			continue
+		}
+		p.SnoozedUntil = until
+		changed = append(changed, p.ID)
+	}
+	if len(changed) == 0 {
+		color.Yellow(tr("Nothing to change."))
+		return
+	}
+	if err := saveProblems(problems); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	if until.IsZero() {
+		color.Green(tr("✅ Back in the suggestions: %s"), strings.Join(changed, ", "))
+	} else {
+		color.Cyan(tr("💤 Snoozed %s until %s"), strings.Join(changed, ", "), until.Format("Mon Jan 2, 2006"))
+	}
+}
+
+func snoozeCmd() *cobra.Command {
+	var span, until string
+	cmd := &cobra.Command{
+		Use:         "snooze <id>...",
+		Annotations: mutates(),
+		Short:       "Keep problems out of the suggestions for a while",
+		Long: "Snoozed problems stay in your collection and list, but pick, next, quiz, boss, mock, sessions and " +
+			"digests leave them out until the snooze expires, e.g. for a problem you aren't ready for yet. " +
+			"See them with 'saitama snoozed', wake them up early with 'saitama unsnooze'.",
+		Example: `  saitama snooze CF1000A --for 30d
+  saitama snooze LC4 LC10 --for 2w
+  saitama snooze LC42 --until 2024-09-01
+  saitama unsnooze LC42`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var wake time.Time
+			var err error
+			if until != "" {
+				if cmd.Flags().Changed("for") {
+					printError(tr("❌ Use either --for or --until"))
+					return
+				}
+				wake, err = time.ParseInLocation("2006-01-02", until, time.Local)
+				if err != nil {
+					printError(tr("❌ Invalid date '%s' (use YYYY-MM-DD)"), until)
+					return
+				}
+			} else if wake, err = shiftBySpan(startOfDay(time.Now()), span, 1); err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if !wake.After(time.Now()) {
+				printError(tr("❌ The snooze must end in the future"))
+				return
+			}
+			setSnoozed(args, wake)
+		},
+	}
+	cmd.Flags().StringVar(&span, "for", "30d", "how long to snooze, e.g. 30d, 2w or 3m")
+	cmd.Flags().StringVar(&until, "until", "", "snooze until this day (YYYY-MM-DD) instead")
+	return cmd
+}
+
+func unsnoozeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "unsnooze <id>...",
+		Annotations: mutates(),
+		Short:       "Put snoozed problems back in the suggestions",
+		Example:     `  saitama unsnooze CF1000A`,
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			setSnoozed(args, time.Time{})
+		},
+	}
+}
+
+func snoozedCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "snoozed",
+		Short: "List the snoozed problems, the first to wake up first",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			now := time.Now()
+			var snoozed []Problem
+			for _, p := range problems {
+				if isSnoozed(p, now) {
+					snoozed = append(snoozed, p)
+				}
+			}
+			sort.SliceStable(snoozed, func(i, j int) bool { return snoozed[i].SnoozedUntil.Before(snoozed[j].SnoozedUntil) })
+
+			if jsonOutput() {
+				if snoozed == nil {
+					snoozed = []Problem{}
+				}
+				printJSON(snoozed)
+				return
+			}
+			if len(snoozed) == 0 {
+				color.Yellow(tr("💤 No snoozed problems."))
+				return
+			}
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("💤 %d snoozed problem(s):"), len(snoozed))
+			for _, p := range snoozed {
+				days := int(startOfDay(p.SnoozedUntil).Sub(startOfDay(now)).Hours()/24 + 0.5)
+				printResult(color.New(color.FgWhite), tr("   %-12s %-40s until %s (%d day(s))"), p.ID, truncateText(p.Name, 40), p.SnoozedUntil.Format("Jan 2, 2006"), days)
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+}
@@ -0,0 +1,109 @@
+// solve.go
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+func solveCmd() *cobra.Command {
+	var failed, withNote, noNote bool
+	var minutes int
+	var felt, timeComplexity, spaceComplexity string
+	cmd := &cobra.Command{
+		Use:         "solve <id>",
+		Annotations: mutates(),
+		Short:       "Log an attempt at a problem (solved by default)",
+		Example: `  saitama solve LC1              # Mark LC1 as solved
+  saitama solve LC42 --minutes 45  # Solved in 45 minutes
+  saitama solve LC42 --felt hard   # Skip the "how did it feel" question
+  saitama solve LC42 --failed      # Log a failed attempt
+  saitama solve LC42 --time "O(n log n)" --space "O(n)"
+  saitama solve LC42 --note        # Write a retro note in $EDITOR`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+
+			targetID := strings.ToUpper(args[0])
+			_, index := findProblemByID(problems, targetID)
+			if index == -1 {
+				printError(tr("❌ Problem with ID '%s' not found"), targetID)
+				return
+			}
+
+			felt, err := parseFelt(felt)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			for _, c := range []*string{&timeComplexity, &spaceComplexity} {
+				if *c == "" {
+					continue
+				}
+				if *c, err = normalizeComplexity(*c); err != nil {
+					printError("❌ %v", err)
+					return
+				}
+			}
+			if !failed && felt == "" && isInteractive() {
+				// Cancelling the question still logs the solve, just without a rating.
+				felt, _ = askFelt()
+			}
+
+			now := time.Now()
+			cfg, err := loadConfig()
+			if err != nil {
+				color.Yellow(tr("⚠️  %v (using default settings)"), err)
+			}
+			if withNote || (cfg.Notes.OnSolve && !failed && !noNote && isInteractive()) {
+				note, err := askSolveNote(cfg.Notes, SolveNoteData{Problem: problems[index], Date: now, Minutes: minutes})
+				switch {
+				case err != nil:
+					color.Yellow(tr("⚠️  Note skipped: %v"), err)
+				case note != "":
+					appendNote(&problems[index], note, now)
+				}
+			}
+
+			recordAttempt(&problems[index], !failed, time.Duration(minutes)*time.Minute, now)
+			attempt := &problems[index].Attempts[len(problems[index].Attempts)-1]
+			attempt.Felt = felt
+			beaten := ""
+			if !failed {
+				beaten = recordComplexity(&problems[index], attempt, timeComplexity, spaceComplexity)
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+
+			p := problems[index]
+			if failed {
+				color.Yellow(tr("😤 Attempt on '%s' logged. Come back stronger!"), p.ID)
+				return
+			}
+			color.HiGreen(tr("🥊 ONE PUNCH! '%s - %s' solved (%d times total)"), p.ID, p.Name, p.SolveCount)
+			if streak := currentStreak(solvesPerDay(activityLog(problems)), time.Now()); streak > 1 {
+				color.HiYellow(tr("🔥 %d day streak!"), streak)
+			}
+			if beaten != "" {
+				color.HiMagenta(tr("⏱️  New best complexity: %s, down from %s"), p.TimeComplexity, beaten)
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&failed, "failed", false, "log an unsuccessful attempt")
+	cmd.Flags().IntVarP(&minutes, "minutes", "m", 0, "time spent on the attempt in minutes")
+	cmd.Flags().BoolVar(&withNote, "note", false, "write a retro note from the notes template in $EDITOR")
+	cmd.Flags().BoolVar(&noNote, "no-note", false, "don't offer the retro note, even with notes.on_solve set")
+	cmd.Flags().StringVar(&felt, "felt", "", "how hard the solve felt: easy, ok or hard (asked interactively otherwise)")
+	cmd.Flags().StringVar(&timeComplexity, "time", "", "time complexity of the solution, e.g. \"O(n log n)\"")
+	cmd.Flags().StringVar(&spaceComplexity, "space", "", "space complexity of the solution, e.g. \"O(1)\"")
+	return cmd
+}
@@ -0,0 +1,71 @@
+// star.go
+package main
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// setStarred stars or unstars the problems with the given IDs.
+func setStarred(ids []string, starred bool) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+
+	var changed []string
+	for _, id := range ids {
+		targetID := strings.ToUpper(id)
+		p, index := findProblemByID(problems, targetID)
+		if index == -1 {
+			printError(tr("❌ Problem with ID '%s' not found"), targetID)
+			return
+		}
+		if p.Starred != starred {
+			p.Starred = starred
+			changed = append(changed, p.ID)
+		}
+	}
+	if len(changed) == 0 {
+		color.Yellow(tr("Nothing to change."))
+		return
+	}
+	if err := saveProblems(problems); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	if starred {
+		color.HiYellow(tr("⭐ Starred %s"), strings.Join(changed, ", "))
+	} else {
+		color.Green(tr("✅ Unstarred %s"), strings.Join(changed, ", "))
+	}
+}
+
+func starCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "star <id>...",
+		Annotations: mutates(),
+		Short:       "Mark problems as favorites",
+		Example:     "  saitama star LC42 CF1000A\n  saitama list --starred",
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			setStarred(args, true)
+		},
+	}
+}
+
+func unstarCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:         "unstar <id>...",
+		Annotations: mutates(),
+		Short:       "Remove problems from your favorites",
+		Example:     `  saitama unstar LC1 CF1000A`,
+		Args:        cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			setStarred(args, false)
+		},
+	}
+}
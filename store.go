@@ -0,0 +1,233 @@
+// store.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store is the problem database shared by every command of an invocation. The file is
+// parsed lazily on first use and only read again when it changed on disk (e.g. written
+// by another process while serve or watch is running). Lookups by ID and tag are indexed.
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	modTime  time.Time
+	size     int64
+	loaded   bool
+	problems []Problem
+	byID     map[string]int
+	byTag    map[string][]int
+}
+
+// store is the database of the current invocation.
+var store = &Store{}
+
+// refresh (re)loads the file if it is not cached yet or changed since. The caller holds mu.
+func (s *Store) refresh() error {
+	dbPath, err := getDbPath()
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(dbPath)
+	if os.IsNotExist(err) {
+		s.remember(dbPath, time.Time{}, 0, []Problem{}) // File doesn't exist yet, start empty.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read problems file: %w", err)
+	}
+	if s.loaded && s.path == dbPath && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+
+	start := time.Now()
+	data, err := os.ReadFile(dbPath)
+	if err != nil {
+		return fmt.Errorf("failed to read problems file: %w", err)
+	}
+	var problems []Problem
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &problems); err != nil {
+			return fmt.Errorf("failed to parse problems file: %w", err)
+		}
+	}
+	if problems == nil {
+		problems = []Problem{} // Handle empty file
+	}
+
+	// Data migration for older records without DateAdded. It only happens in memory
+	// and is written with the next save, so read-only commands never write.
+	migrated := 0
+	for i := range problems {
+		if problems[i].DateAdded.IsZero() {
+			problems[i].DateAdded = start
+			migrated++
+		}
+	}
+	if migrated > 0 {
+		slog.Info("migrated problems without a date added", "count", migrated)
+	}
+	applyPicks(problems)
+	if fullHistoryFlag {
+		archive, err := loadAttemptArchive()
+		if err != nil {
+			return err
+		}
+		mergeArchivedAttempts(problems, archive)
+	}
+
+	s.remember(dbPath, info.ModTime(), info.Size(), problems)
+	slog.Debug("loaded problems", "path", dbPath, "count", len(problems), "bytes", len(data), "duration", time.Since(start))
+	return nil
+}
+
+// remember caches the problems as the current content of the file and rebuilds the
+// indexes. The caller holds mu.
+func (s *Store) remember(path string, modTime time.Time, size int64, problems []Problem) {
+	s.path, s.modTime, s.size, s.loaded = path, modTime, size, true
+	s.problems = problems
+	s.byID = make(map[string]int, len(problems))
+	s.byTag = make(map[string][]int)
+	for i, p := range problems {
+		if _, dup := s.byID[strings.ToUpper(p.ID)]; !dup {
+			s.byID[strings.ToUpper(p.ID)] = i // first match wins, like findProblemByID
+		}
+		for _, tag := range p.Tags {
+			s.byTag[tag] = append(s.byTag[tag], i)
+		}
+	}
+}
+
+// Problems returns a deep copy of every problem that the caller may modify and save:
+// changes that end up not saved never reach the cache.
+func (s *Store) Problems() ([]Problem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+	return cloneProblems(s.problems), nil
+}
+
+// Count returns the number of stored problems.
+func (s *Store) Count() (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return 0, err
+	}
+	return len(s.problems), nil
+}
+
+// Get looks a problem up by its ID (case-insensitive).
+func (s *Store) Get(id string) (Problem, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return Problem{}, false, err
+	}
+	i, ok := s.byID[strings.ToUpper(id)]
+	if !ok {
+		return Problem{}, false, nil
+	}
+	return cloneProblem(s.problems[i]), true, nil
+}
+
+// TagCounts returns the number of problems carrying each tag.
+func (s *Store) TagCounts() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(s.byTag))
+	for tag, indexes := range s.byTag {
+		counts[tag] = len(indexes)
+	}
+	return counts, nil
+}
+
+// Query runs a ProblemQuery, using the tag index to skip problems without any of the
+// requested tags.
+func (s *Store) Query(q ProblemQuery) ([]Problem, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return nil, 0, err
+	}
+	if len(q.Tags) == 0 {
+		matches, total := q.Apply(s.problems)
+		return cloneProblems(matches), total, nil
+	}
+
+	seen := make(map[int]bool)
+	var indexes []int
+	for tag, tagged := range s.byTag {
+		if !hasAnyTag(Problem{Tags: []string{tag}}, q.Tags) {
+			continue
+		}
+		for _, i := range tagged {
+			if !seen[i] {
+				seen[i] = true
+				indexes = append(indexes, i)
+			}
+		}
+	}
+	sort.Ints(indexes) // keep the file order, like a full scan
+	candidates := make([]Problem, len(indexes))
+	for n, i := range indexes {
+		candidates[n] = s.problems[i]
+	}
+	matches, total := q.Apply(candidates)
+	return cloneProblems(matches), total, nil
+}
+
+// ChangedOnDisk reports whether the database at path was written by another process
+// since it was last read or saved by this one, e.g. while a command waited on a prompt
+// or a timer. The caller holds the lock of the data directory.
+func (s *Store) ChangedOnDisk(path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.loaded || s.path != path {
+		return false
+	}
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return !s.modTime.IsZero()
+	}
+	return err != nil || !info.ModTime().Equal(s.modTime) || info.Size() != s.size
+}
+
+// Picked records in the cache that the problems with the given IDs were just suggested
+// by pick, see recordPicks.
+func (s *Store) Picked(ids []string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if i, ok := s.byID[strings.ToUpper(id)]; ok {
+			s.problems[i].LastPicked = at
+		}
+	}
+}
+
+// Saved records problems that were just written to path, so the next read is served
+// from memory. The store keeps its own copy: the caller may go on changing the problems
+// without the cache drifting from the file, e.g. when the next save fails.
+func (s *Store) Saved(path string, problems []Problem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	info, err := os.Stat(path)
+	if err != nil {
+		s.loaded = false
+		return
+	}
+	s.remember(path, info.ModTime(), info.Size(), cloneProblems(problems))
+}
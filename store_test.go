@@ -0,0 +1,92 @@
+// store_test.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestDatabase stores n generated problems in the data directory of the test.
+func writeTestDatabase(tb testing.TB, n int) {
+	tb.Helper()
+	dir := useTempDataDir(tb)
+	tags := []string{"arrays", "dynamic-programming", "graphs", "greedy", "math", "strings", "trees", "two-pointers"}
+	difficulties := []string{"easy", "medium", "hard"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	problems := make([]Problem, n)
+	for i := range problems {
+		added := start.Add(time.Duration(i) * time.Hour)
+		problems[i] = Problem{
+			ID:         fmt.Sprintf("CF%d", i),
+			Name:       fmt.Sprintf("Problem %d", i),
+			Tags:       []string{tags[i%len(tags)], tags[(i/len(tags))%len(tags)]},
+			DateAdded:  added,
+			Difficulty: difficulties[i%len(difficulties)],
+			Platform:   "codeforces",
+			Rating:     800 + i%20*100,
+			Attempts:   []Attempt{{Date: added.Add(time.Hour), Solved: i%3 != 0, DurationSeconds: 1200}},
+		}
+	}
+	data, err := json.Marshal(problems)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "problems.json"), data, 0644); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+func TestStoreProblemsIsolated(t *testing.T) {
+	writeTestDatabase(t, 3)
+	problems, err := store.Problems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	problems[1].Tags[0] = "changed"
+	problems[1].Attempts[0].Solved = false
+
+	again, err := store.Problems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again[1].Tags[0] != "dynamic-programming" || !again[1].Attempts[0].Solved {
+		t.Errorf("unsaved changes reached the cache: %v %+v", again[1].Tags, again[1].Attempts)
+	}
+	p, _, err := store.Get("CF1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Tags[0] = "changed"
+	if again, _ := store.Problems(); again[1].Tags[0] == "changed" {
+		t.Errorf("a change of a problem from Get reached the cache")
+	}
+}
+
+func BenchmarkLoad(b *testing.B) {
+	writeTestDatabase(b, 50000)
+	b.ResetTimer()
+	for range b.N {
+		store = &Store{}
+		if _, err := store.Count(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	writeTestDatabase(b, 50000)
+	if _, err := store.Count(); err != nil {
+		b.Fatal(err)
+	}
+	q := ProblemQuery{Tags: []string{"graphs"}, Difficulty: "hard", Sort: "added", Limit: 20}
+	b.ResetTimer()
+	for range b.N {
+		if _, _, err := store.Query(q); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
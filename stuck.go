@@ -0,0 +1,209 @@
+// stuck.go
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// lastAttempt returns the date of the most recent attempt, or the zero time.
+func lastAttempt(p Problem) time.Time {
+	var last time.Time
+	for _, a := range p.Attempts {
+		if a.Date.After(last) {
+			last = a.Date
+		}
+	}
+	return last
+}
+
+// stuckProblems returns the problems attempted but never solved, most recently
+// attempted first. Abandoned problems are listed only when abandoned is true, alone.
+func stuckProblems(problems []Problem, abandoned bool) []Problem {
+	var stuck []Problem
+	for _, p := range problems {
+		if len(p.Attempts) > 0 && !isSolved(p) && p.Abandoned == abandoned {
+			stuck = append(stuck, p)
+		}
+	}
+	sort.SliceStable(stuck, func(i, j int) bool { return lastAttempt(stuck[i]).After(lastAttempt(stuck[j])) })
+	return stuck
+}
+
+// openURL opens a link in the default browser.
+func openURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// setAbandoned marks problems as abandoned, or back on the stuck list.
+func setAbandoned(ids []string, abandoned bool) {
+	problems, err := loadProblems()
+	if err != nil {
+		printError(tr("❌ Error loading problems: %v"), err)
+		return
+	}
+	var changed []string
+	for _, id := range ids {
+		targetID := strings.ToUpper(id)
+		p, index := findProblemByID(problems, targetID)
+		if index == -1 {
+			printError(tr("❌ Problem with ID '%s' not found"), targetID)
+			return
+		}
+		if p.Abandoned != abandoned {
+			p.Abandoned = abandoned
+			changed = append(changed, p.ID)
+		}
+	}
+	if len(changed) == 0 {
+		color.Yellow(tr("Nothing to change."))
+		return
+	}
+	if err := saveProblems(problems); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	if abandoned {
+		color.Green(tr("🏳️  Abandoned %s"), strings.Join(changed, ", "))
+	} else {
+		color.Green(tr("😤 %s back on the revenge list"), strings.Join(changed, ", "))
+	}
+}
+
+// stuckAction offers the quick actions on one of the stuck problems.
+func stuckAction(stuck []Problem) {
+	options := make([]string, len(stuck))
+	for i, p := range stuck {
+		options[i] = p.ID + " - " + p.Name
+	}
+	choice := 0
+	if err := survey.AskOne(&survey.Select{Message: "Which problem?", Options: options}, &choice); err != nil {
+		return
+	}
+	p := stuck[choice]
+
+	const (
+		open    = "🔗 Open the URL"
+		hints   = "💡 Reveal hints"
+		abandon = "🏳️  Mark abandoned"
+	)
+	var actions []string
+	if p.URL != "" {
+		actions = append(actions, open)
+	}
+	if len(p.Hints) > 0 {
+		actions = append(actions, hints)
+	}
+	actions = append(actions, abandon)
+	action := ""
+	if err := survey.AskOne(&survey.Select{Message: p.ID + ":", Options: actions}, &action); err != nil {
+		return
+	}
+	switch action {
+	case open:
+		if err := openURL(p.URL); err != nil {
+			printError(tr("❌ Could not open %s: %v"), p.URL, err)
+		}
+	case hints:
+		revealHints(p.ID)
+	case abandon:
+		setAbandoned([]string{p.ID}, true)
+	}
+}
+
+func stuckCmd() *cobra.Command {
+	var abandoned, interactive bool
+	cmd := &cobra.Command{
+		Use:   "stuck",
+		Short: "List the problems you attempted but haven't solved yet",
+		Long: "Your revenge list: problems with attempts but no solve, most recently attempted first. " +
+			"With --interactive, pick one to open its URL, reveal its hints or mark it abandoned.",
+		Example: `  saitama stuck
+  saitama stuck -i
+  saitama stuck abandon CF1000A
+  saitama stuck --abandoned`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			stuck := stuckProblems(problems, abandoned)
+			if jsonOutput() {
+				printJSON(stuck)
+				return
+			}
+			if len(stuck) == 0 {
+				if abandoned {
+					color.Yellow(tr("🏳️  No abandoned problems."))
+				} else {
+					color.Green(tr("🎉 Nothing on your revenge list: every attempted problem is solved."))
+				}
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			if abandoned {
+				color.HiBlack(tr("🏳️  Abandoned problems:"))
+			} else {
+				color.HiRed(tr("😤 REVENGE LIST"))
+			}
+			fmt.Fprintln(stderr)
+			now := time.Now()
+			for _, p := range stuck {
+				days := int(startOfDay(now).Sub(startOfDay(lastAttempt(p))).Hours() / 24)
+				printResult(color.New(color.FgHiYellow), "🥊 %s - %s", p.ID, p.Name)
+				printResult(color.New(color.FgWhite), tr("   ❌ %d attempt(s), last %d day(s) ago"), len(p.Attempts), days)
+				if len(p.Hints) > 0 {
+					printResult(color.New(color.FgWhite), tr("   💡 %d hint(s), %d revealed"), len(p.Hints), p.HintsShown)
+				}
+				if p.URL != "" {
+					printResult(color.New(color.FgBlue), "   🔗 %s", p.URL)
+				}
+			}
+			fmt.Fprintln(stderr)
+
+			if interactive {
+				stuckAction(stuck)
+			} else if !abandoned {
+				color.Cyan(tr("💡 Open, get hints or give up with: saitama stuck -i"))
+			}
+		},
+	}
+	cmd.Flags().BoolVar(&abandoned, "abandoned", false, "list the abandoned problems instead")
+	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "pick a problem to open, get hints for or abandon")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "abandon <id>...",
+		Annotations: mutates(),
+		Short:       "Take problems off the revenge list",
+		Args:        cobra.MinimumNArgs(1),
+		Run:         func(cmd *cobra.Command, args []string) { setAbandoned(args, true) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "revive <id>...",
+		Annotations: mutates(),
+		Short:       "Put abandoned problems back on the revenge list",
+		Args:        cobra.MinimumNArgs(1),
+		Run:         func(cmd *cobra.Command, args []string) { setAbandoned(args, false) },
+	})
+	return cmd
+}
@@ -0,0 +1,232 @@
+// submissions.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+)
+
+// archivedSubmission is a submission of a judge's export, with the problem it was made on
+// as it would be added to the collection.
+type archivedSubmission struct {
+	judgeSubmission
+	Problem Problem
+}
+
+// atcoderSubmission is a submission of the AtCoder Problems export
+// (kenkoooo.com/atcoder/atcoder-api/v3/user/submissions).
+type atcoderSubmission struct {
+	ID        int64  `json:"id"`
+	Epoch     int64  `json:"epoch_second"`
+	ProblemID string `json:"problem_id"` // e.g. abc123_a
+	ContestID string `json:"contest_id"`
+	Result    string `json:"result"` // AC, WA, TLE, ... or WJ while judging
+}
+
+// newProblem returns the problem of a submission, as added to the collection. The export
+// has no problem names, 'saitama enrich' can fetch them.
+func (s atcoderSubmission) newProblem(now time.Time) Problem {
+	return Problem{
+		ID:        "AT" + strings.ToUpper(s.ProblemID),
+		Name:      s.ProblemID,
+		Platform:  "atcoder",
+		URL:       fmt.Sprintf("https://atcoder.jp/contests/%s/tasks/%s", s.ContestID, s.ProblemID),
+		DateAdded: now,
+	}
+}
+
+// readSubmissionArchive reads a judge's submission export and returns the name of the
+// judge with its judged submissions. It reads the Codeforces user.status response (or its
+// "result" array) and AtCoder Problems' submission list, optionally gzipped.
+func readSubmissionArchive(filename string, now time.Time) (string, []archivedSubmission, error) {
+	in, err := openImportFile(filename)
+	if err != nil {
+		return "", nil, err
+	}
+	defer in.close()
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+
+	var wrapped struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err == nil && wrapped.Result != nil {
+		data = wrapped.Result
+	}
+	var records []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return "", nil, fmt.Errorf("%s isn't a list of submissions", filename)
+	}
+	if len(records) == 0 {
+		return "", nil, fmt.Errorf("%s has no submissions", filename)
+	}
+
+	var subs []archivedSubmission
+	switch {
+	case records[0]["creationTimeSeconds"] != nil:
+		var cf []codeforcesSubmission
+		if err := json.Unmarshal(data, &cf); err != nil {
+			return "", nil, fmt.Errorf("failed to parse the Codeforces submissions: %w", err)
+		}
+		for _, s := range cf {
+			if s.Problem.ContestID == 0 || s.Verdict == "" || unjudgedVerdicts[normalizeVerdict(s.Verdict)] {
+				continue // problems outside contests can't be linked to
+			}
+			subs = append(subs, archivedSubmission{judgeSubmission: s.judged(), Problem: s.newProblem(now)})
+		}
+		return "codeforces", subs, nil
+	case records[0]["epoch_second"] != nil:
+		var at []atcoderSubmission
+		if err := json.Unmarshal(data, &at); err != nil {
+			return "", nil, fmt.Errorf("failed to parse the AtCoder submissions: %w", err)
+		}
+		for _, s := range at {
+			if s.ProblemID == "" || s.ContestID == "" || unjudgedVerdicts[normalizeVerdict(s.Result)] {
+				continue
+			}
+			subs = append(subs, archivedSubmission{
+				judgeSubmission: judgeSubmission{
+					ID:      "atcoder:" + strconv.FormatInt(s.ID, 10),
+					Date:    time.Unix(s.Epoch, 0),
+					Verdict: normalizeVerdict(s.Result),
+				},
+				Problem: s.newProblem(now),
+			})
+		}
+		return "atcoder", subs, nil
+	}
+	return "", nil, fmt.Errorf("%s isn't a known submission export (use the Codeforces user.status or AtCoder Problems submissions JSON)", filename)
+}
+
+// submissionImport is the outcome of recording the submissions of an export.
+type submissionImport struct {
+	Problems  int      // problems the submissions were made on
+	Created   []string // problems added to the collection
+	Updated   []string // problems with newly recorded submissions
+	Untracked []string // problems not in the collection, left out
+	Recorded  int
+	Verdicts  map[string]int // of the recorded submissions
+}
+
+// applySubmissions records the submissions as attempts on their problems, matched by URL
+// and then by ID. Problems not in the collection are added when create is set, and left
+// out otherwise. Submissions recorded before are skipped, so an export can be imported
+// again as it grows, and after compact.
+func applySubmissions(problems []Problem, archive attemptArchive, subs []archivedSubmission, create bool) ([]Problem, submissionImport) {
+	var order []string
+	byProblem := make(map[string][]archivedSubmission)
+	for _, s := range subs {
+		if _, ok := byProblem[s.Problem.ID]; !ok {
+			order = append(order, s.Problem.ID)
+		}
+		byProblem[s.Problem.ID] = append(byProblem[s.Problem.ID], s)
+	}
+
+	result := submissionImport{Problems: len(order), Verdicts: make(map[string]int)}
+	for _, id := range order {
+		group := byProblem[id]
+		_, index := findProblemByURL(problems, group[0].Problem.URL)
+		if index < 0 {
+			_, index = findProblemByID(problems, id)
+		}
+		if index < 0 {
+			if !create {
+				result.Untracked = append(result.Untracked, id)
+				continue
+			}
+			problems = append(problems, group[0].Problem)
+			index = len(problems) - 1
+			result.Created = append(result.Created, id)
+		}
+
+		judged := make([]judgeSubmission, len(group))
+		for i, s := range group {
+			judged[i] = s.judgeSubmission
+		}
+		added := recordSubmissions(&problems[index], archive.Attempts[problems[index].ID], judged)
+		if len(added) == 0 {
+			continue
+		}
+		result.Recorded += len(added)
+		result.Updated = append(result.Updated, problems[index].ID)
+		for _, s := range added {
+			result.Verdicts[s.Verdict]++
+		}
+	}
+	return problems, result
+}
+
+// importSubmissions records the submissions of a judge's export as attempt history, after
+// showing what would change and asking for confirmation.
+func importSubmissions(filename string, create bool) {
+	tx, err := store.Begin()
+	if err != nil {
+		printError(tr("❌ Error loading current problems: %v"), err)
+		return
+	}
+	defer tx.Rollback()
+	judge, subs, err := readSubmissionArchive(filename, time.Now())
+	if err != nil {
+		printError(tr("❌ Error importing submissions: %v"), err)
+		return
+	}
+	archive, err := loadAttemptArchive()
+	if err != nil {
+		printError("❌ %v", err)
+		return
+	}
+	var result submissionImport
+	err = tx.Update(func(problems []Problem) ([]Problem, error) {
+		problems, result = applySubmissions(problems, archive, subs, create)
+		return problems, nil
+	})
+	if err != nil {
+		printError(tr("❌ Error importing submissions: %v"), err)
+		return
+	}
+
+	fmt.Fprintln(stderr)
+	color.HiCyan(tr("📥 %s: %d judged submission(s) on %d problem(s)"), judge, len(subs), result.Problems)
+	if create {
+		color.White(tr("   ➕ %d new problem(s)"), len(result.Created))
+	}
+	color.White(tr("   📥 %d new submission(s) on %d problem(s)"), result.Recorded, len(result.Updated))
+	if len(result.Verdicts) > 0 {
+		color.HiBlack("      %s", formatVerdictCounts(result.Verdicts))
+	}
+	if len(result.Untracked) > 0 {
+		color.Yellow(tr("   ⚠️  %d problem(s) aren't in your collection, add them with --create-missing:"), len(result.Untracked))
+		for i, id := range result.Untracked {
+			if i == 10 {
+				color.HiBlack(tr("   ... and %d more"), len(result.Untracked)-i)
+				break
+			}
+			color.HiBlack("      %s", id)
+		}
+	}
+	if result.Recorded+len(result.Created) == 0 {
+		color.Green(tr("✅ Already up to date."))
+		return
+	}
+
+	confirm := false
+	prompt := &survey.Confirm{Message: "Record these submissions in your attempt history?"}
+	if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+		color.Yellow(tr("Import cancelled."))
+		return
+	}
+	if err := tx.Apply(); err != nil {
+		printError(tr("❌ Error saving: %v"), err)
+		return
+	}
+	color.Green(tr("✅ Recorded %d submission(s) from %s."), result.Recorded, filename)
+}
@@ -0,0 +1,261 @@
+// tags.go
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// builtinTagAliases maps common spellings of a tag to its canonical one, after
+// normalizeTag. The canonical tags follow the LeetCode slugs, which Codeforces mostly
+// agrees with once spaces become hyphens.
+var builtinTagAliases = map[string]string{
+	"dp":                  "dynamic-programming",
+	"bs":                  "binary-search",
+	"binarysearch":        "binary-search",
+	"twopointers":         "two-pointers",
+	"two-pointer":         "two-pointers",
+	"slidingwindow":       "sliding-window",
+	"hashmap":             "hash-table",
+	"hash-map":            "hash-table",
+	"hashtable":           "hash-table",
+	"linkedlist":          "linked-list",
+	"arrays":              "array",
+	"strings":             "string",
+	"trees":               "tree",
+	"graphs":              "graph",
+	"pq":                  "priority-queue",
+	"priorityqueue":       "priority-queue",
+	"dsu":                 "union-find",
+	"disjoint-set":        "union-find",
+	"disjoint-set-union":  "union-find",
+	"toposort":            "topological-sort",
+	"topo-sort":           "topological-sort",
+	"bit-manip":           "bit-manipulation",
+	"bits":                "bit-manipulation",
+	"segtree":             "segment-tree",
+	"seg-tree":            "segment-tree",
+	"bitmasks":            "bitmask",
+	"dfs-and-similar":     "dfs",
+	"shortest-paths":      "shortest-path",
+	"dynamicprogramming":  "dynamic-programming",
+	"greedy-algorithms":   "greedy",
+	"monotonicstack":      "monotonic-stack",
+	"binary-search-trees": "binary-search-tree",
+	"bst":                 "binary-search-tree",
+	"binary-trees":        "binary-tree",
+	"linked-lists":        "linked-list",
+	"stacks":              "stack",
+	"queues":              "queue",
+	"heaps":               "heap",
+	"tries":               "trie",
+}
+
+// tagAliases are the built-in aliases plus the "tag_aliases" of the config, set up
+// before every command.
+var tagAliases = builtinTagAliases
+
+// setupTagAliases adds the aliases of the config to the built-in ones.
+func setupTagAliases(cfg Config) {
+	if len(cfg.TagAliases) == 0 {
+		return
+	}
+	tagAliases = make(map[string]string, len(builtinTagAliases)+len(cfg.TagAliases))
+	for k, v := range builtinTagAliases {
+		tagAliases[k] = v
+	}
+	for k, v := range cfg.TagAliases {
+		tagAliases[normalizeTag(k)] = normalizeTag(v)
+	}
+}
+
+// normalizeTag lowercases a tag and spells it with single hyphens between words, e.g.
+// "Dynamic Programming" and "dynamic_programming" become "dynamic-programming".
+func normalizeTag(tag string) string {
+	words := strings.FieldsFunc(strings.ToLower(tag), func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '_' || r == '-'
+	})
+	return strings.Join(words, "-")
+}
+
+// canonicalTag returns the canonical spelling of a tag: normalized, then resolved
+// through the aliases.
+func canonicalTag(tag string) string {
+	t := normalizeTag(tag)
+	if alias, ok := tagAliases[t]; ok {
+		return alias
+	}
+	return t
+}
+
+// canonicalTags canonicalizes tags, dropping empty ones and duplicates.
+func canonicalTags(tags []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		t := canonicalTag(tag)
+		if t != "" && !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// knownTags returns the tags in use, the most used first.
+func knownTags(problems []Problem) []string {
+	counts := make(map[string]int)
+	for _, p := range problems {
+		for _, tag := range p.Tags {
+			counts[tag]++
+		}
+	}
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	return tags
+}
+
+// tagQuestions asks for the tags of a problem in add and edit: the tags already in use
+// are picked from a list ("tags"), with the current ones selected, and new ones are
+// typed ("new_tags"), with Tab completing the last one.
+func tagQuestions(problems []Problem, current []string) []*survey.Question {
+	known := knownTags(problems)
+	typed := &survey.Input{
+		Message: "🏷️  Tags (comma-separated):",
+		Help:    "e.g., array,hashmap,easy. Spellings are unified, so DP and \"dynamic programming\" both become dynamic-programming",
+		Suggest: func(toComplete string) []string {
+			head, last := "", toComplete
+			if i := strings.LastIndex(toComplete, ","); i >= 0 {
+				head, last = toComplete[:i+1]+" ", toComplete[i+1:]
+			}
+			last = normalizeTag(last)
+			var suggestions []string
+			for _, tag := range known {
+				if strings.HasPrefix(tag, last) {
+					suggestions = append(suggestions, head+tag)
+				}
+			}
+			return suggestions
+		},
+	}
+	if len(known) == 0 {
+		return []*survey.Question{{Name: "new_tags", Prompt: typed}}
+	}
+	typed.Message = "🏷️  New tags (comma-separated, optional):"
+	return []*survey.Question{
+		{
+			Name: "tags",
+			Prompt: &survey.MultiSelect{
+				Message:  "🏷️  Tags:",
+				Options:  known,
+				Default:  current,
+				PageSize: 12,
+				Help:     "Space selects, typing filters, Enter confirms. Tags not listed come next",
+			},
+		},
+		{Name: "new_tags", Prompt: typed},
+	}
+}
+
+// answeredTags merges the answers to tagQuestions into canonical tags, telling which
+// typed tags were respelled.
+func answeredTags(selected []string, typed string) []string {
+	for _, raw := range strings.Split(typed, ",") {
+		raw = strings.TrimSpace(raw)
+		if t := canonicalTag(raw); raw != "" && t != raw {
+			color.HiBlack(tr("   🏷️  %s → %s"), raw, t)
+		}
+	}
+	return canonicalTags(append(append([]string{}, selected...), strings.Split(typed, ",")...))
+}
+
+// tagRewrite is one respelling made by 'saitama tags canonicalize'.
+type tagRewrite struct {
+	From, To string
+	Problems int
+}
+
+// canonicalizeProblemTags canonicalizes the tags of every problem and returns the
+// respellings, the most frequent first, and the number of problems changed.
+func canonicalizeProblemTags(problems []Problem) ([]tagRewrite, int) {
+	rewrites := make(map[[2]string]int)
+	changed := 0
+	for i := range problems {
+		p := &problems[i]
+		tags := canonicalTags(p.Tags)
+		if strings.Join(tags, ",") == strings.Join(p.Tags, ",") {
+			continue
+		}
+		for _, tag := range p.Tags {
+			if t := canonicalTag(tag); t != tag {
+				rewrites[[2]string{tag, t}]++
+			}
+		}
+		p.Tags = tags
+		changed++
+	}
+	var out []tagRewrite
+	for k, n := range rewrites {
+		out = append(out, tagRewrite{From: k[0], To: k[1], Problems: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Problems != out[j].Problems {
+			return out[i].Problems > out[j].Problems
+		}
+		return out[i].From < out[j].From
+	})
+	return out, changed
+}
+
+func tagsCanonicalizeCmd() *cobra.Command {
+	var dryRun bool
+	cmd := &cobra.Command{
+		Use:         "canonicalize",
+		Annotations: mutates(),
+		Short:       "Unify the spellings of your tags, e.g. DP and \"dynamic programming\"",
+		Long: "Rewrites every tag to its canonical spelling: lowercase, words joined by hyphens, and common aliases " +
+			"resolved (dp → dynamic-programming, hashmap → hash-table, ...). New tags get the same treatment when " +
+			"they are added. Add your own aliases to \"tag_aliases\" in the config, e.g. {\"sssp\": \"shortest-path\"}.",
+		Example: `  saitama tags canonicalize --dry-run
+  saitama tags canonicalize`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			rewrites, changed := canonicalizeProblemTags(problems)
+			if changed == 0 {
+				color.Green(tr("✅ All tags are already canonical."))
+				return
+			}
+			for _, r := range rewrites {
+				printResult(color.New(color.FgWhite), tr("🏷️  %-24s → %-24s (%d problem(s))"), r.From, r.To, r.Problems)
+			}
+			if dryRun {
+				color.Cyan(tr("🔍 Dry run: %d problem(s) would change."), changed)
+				return
+			}
+			if err := saveProblems(problems); err != nil {
+				printError(tr("❌ Error saving: %v"), err)
+				return
+			}
+			color.Green(tr("✅ Tags of %d problem(s) canonicalized."), changed)
+		},
+	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the respellings without changing anything")
+	return cmd
+}
@@ -0,0 +1,123 @@
+// taxonomy.go
+package main
+
+import "strings"
+
+// Topic is an entry of the built-in canonical topic taxonomy.
+type Topic struct {
+	Name    string
+	Aliases []string // tags that count towards this topic
+	Curated []Problem
+}
+
+// curated builds a curated LeetCode problem for the taxonomy.
+func curated(number, name, difficulty, slug string, tags ...string) Problem {
+	return Problem{
+		ID:         "LC" + number,
+		Name:       name,
+		Tags:       tags,
+		Difficulty: difficulty,
+		Platform:   "leetcode",
+		URL:        "https://leetcode.com/problems/" + slug + "/",
+	}
+}
+
+// topicTaxonomy lists the topics every well-rounded training plan should cover,
+// roughly in the order they are usually learned.
+var topicTaxonomy = []Topic{
+	{Name: "arrays", Aliases: []string{"array", "arrays"}, Curated: []Problem{
+		curated("1", "Two Sum", "easy", "two-sum", "array", "hashmap"),
+		curated("238", "Product of Array Except Self", "medium", "product-of-array-except-self", "array", "prefix-sum"),
+		curated("41", "First Missing Positive", "hard", "first-missing-positive", "array"),
+	}},
+	{Name: "strings", Aliases: []string{"string", "strings"}, Curated: []Problem{
+		curated("242", "Valid Anagram", "easy", "valid-anagram", "string", "hashmap"),
+		curated("5", "Longest Palindromic Substring", "medium", "longest-palindromic-substring", "string", "dp"),
+	}},
+	{Name: "hashing", Aliases: []string{"hashmap", "hashtable", "hash-table", "hashing", "hashset"}, Curated: []Problem{
+		curated("49", "Group Anagrams", "medium", "group-anagrams", "hashmap", "string"),
+		curated("128", "Longest Consecutive Sequence", "medium", "longest-consecutive-sequence", "hashmap", "array"),
+	}},
+	{Name: "two pointers", Aliases: []string{"twopointers", "two-pointers", "two pointers"}, Curated: []Problem{
+		curated("125", "Valid Palindrome", "easy", "valid-palindrome", "two-pointers", "string"),
+		curated("15", "3Sum", "medium", "3sum", "two-pointers", "array"),
+		curated("42", "Trapping Rain Water", "hard", "trapping-rain-water", "two-pointers", "array"),
+	}},
+	{Name: "sliding window", Aliases: []string{"sliding-window", "slidingwindow", "sliding window"}, Curated: []Problem{
+		curated("3", "Longest Substring Without Repeating Characters", "medium", "longest-substring-without-repeating-characters", "sliding-window", "string"),
+		curated("76", "Minimum Window Substring", "hard", "minimum-window-substring", "sliding-window", "string"),
+	}},
+	{Name: "stack", Aliases: []string{"stack", "monotonic-stack"}, Curated: []Problem{
+		curated("20", "Valid Parentheses", "easy", "valid-parentheses", "stack", "string"),
+		curated("739", "Daily Temperatures", "medium", "daily-temperatures", "stack", "monotonic-stack"),
+		curated("84", "Largest Rectangle in Histogram", "hard", "largest-rectangle-in-histogram", "stack", "monotonic-stack"),
+	}},
+	{Name: "binary search", Aliases: []string{"binary-search", "binarysearch", "binary search", "bs"}, Curated: []Problem{
+		curated("704", "Binary Search", "easy", "binary-search", "binary-search", "array"),
+		curated("33", "Search in Rotated Sorted Array", "medium", "search-in-rotated-sorted-array", "binary-search", "array"),
+		curated("4", "Median of Two Sorted Arrays", "hard", "median-of-two-sorted-arrays", "binary-search", "array"),
+	}},
+	{Name: "linked list", Aliases: []string{"linkedlist", "linked-list", "linked list", "list"}, Curated: []Problem{
+		curated("206", "Reverse Linked List", "easy", "reverse-linked-list", "linked-list"),
+		curated("141", "Linked List Cycle", "easy", "linked-list-cycle", "linked-list", "two-pointers"),
+		curated("23", "Merge k Sorted Lists", "hard", "merge-k-sorted-lists", "linked-list", "heap"),
+	}},
+	{Name: "trees", Aliases: []string{"tree", "trees", "binary-tree", "bst", "binary-search-tree"}, Curated: []Problem{
+		curated("104", "Maximum Depth of Binary Tree", "easy", "maximum-depth-of-binary-tree", "tree", "dfs"),
+		curated("98", "Validate Binary Search Tree", "medium", "validate-binary-search-tree", "tree", "bst"),
+		curated("124", "Binary Tree Maximum Path Sum", "hard", "binary-tree-maximum-path-sum", "tree", "dfs"),
+	}},
+	{Name: "heap", Aliases: []string{"heap", "priority-queue", "priorityqueue"}, Curated: []Problem{
+		curated("703", "Kth Largest Element in a Stream", "easy", "kth-largest-element-in-a-stream", "heap"),
+		curated("347", "Top K Frequent Elements", "medium", "top-k-frequent-elements", "heap", "hashmap"),
+		curated("295", "Find Median from Data Stream", "hard", "find-median-from-data-stream", "heap"),
+	}},
+	{Name: "backtracking", Aliases: []string{"backtracking", "recursion"}, Curated: []Problem{
+		curated("78", "Subsets", "medium", "subsets", "backtracking"),
+		curated("46", "Permutations", "medium", "permutations", "backtracking"),
+		curated("51", "N-Queens", "hard", "n-queens", "backtracking"),
+	}},
+	{Name: "graphs", Aliases: []string{"graph", "graphs", "bfs", "dfs", "union-find", "topological-sort"}, Curated: []Problem{
+		curated("200", "Number of Islands", "medium", "number-of-islands", "graph", "dfs"),
+		curated("207", "Course Schedule", "medium", "course-schedule", "graph", "topological-sort"),
+		curated("127", "Word Ladder", "hard", "word-ladder", "graph", "bfs"),
+	}},
+	{Name: "dynamic programming", Aliases: []string{"dp", "dynamic-programming", "dynamic programming"}, Curated: []Problem{
+		curated("70", "Climbing Stairs", "easy", "climbing-stairs", "dp"),
+		curated("322", "Coin Change", "medium", "coin-change", "dp"),
+		curated("72", "Edit Distance", "hard", "edit-distance", "dp", "string"),
+	}},
+	{Name: "greedy", Aliases: []string{"greedy"}, Curated: []Problem{
+		curated("55", "Jump Game", "medium", "jump-game", "greedy", "array"),
+		curated("135", "Candy", "hard", "candy", "greedy", "array"),
+	}},
+	{Name: "intervals", Aliases: []string{"interval", "intervals", "sweep-line"}, Curated: []Problem{
+		curated("56", "Merge Intervals", "medium", "merge-intervals", "intervals", "sorting"),
+		curated("435", "Non-overlapping Intervals", "medium", "non-overlapping-intervals", "intervals", "greedy"),
+	}},
+	{Name: "bit manipulation", Aliases: []string{"bit", "bits", "bitmask", "bit-manipulation"}, Curated: []Problem{
+		curated("136", "Single Number", "easy", "single-number", "bit-manipulation"),
+		curated("338", "Counting Bits", "easy", "counting-bits", "bit-manipulation", "dp"),
+	}},
+	{Name: "math", Aliases: []string{"math", "number-theory", "geometry", "combinatorics"}, Curated: []Problem{
+		curated("50", "Pow(x, n)", "medium", "powx-n", "math", "recursion"),
+		curated("48", "Rotate Image", "medium", "rotate-image", "math", "array"),
+	}},
+	{Name: "tries", Aliases: []string{"trie", "tries", "prefix-tree"}, Curated: []Problem{
+		curated("208", "Implement Trie (Prefix Tree)", "medium", "implement-trie-prefix-tree", "trie"),
+		curated("212", "Word Search II", "hard", "word-search-ii", "trie", "backtracking"),
+	}},
+}
+
+// topicMatches reports whether a problem is tagged with any alias of the topic.
+func topicMatches(t Topic, p Problem) bool {
+	for _, tag := range p.Tags {
+		tag = strings.ToLower(tag)
+		for _, alias := range t.Aliases {
+			if tag == alias {
+				return true
+			}
+		}
+	}
+	return false
+}
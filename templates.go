@@ -0,0 +1,176 @@
+// templates.go
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// SessionTemplate is a named session recipe of the config, started with
+// 'saitama session start <name>'.
+type SessionTemplate struct {
+	Problems string `json:"problems"` // e.g. "2 easy array" or "1 hard + 2 medium"
+	Duration string `json:"duration"` // time budget, e.g. "20m" or "3h"
+}
+
+// sessionSlot is one part of a template: how many problems of which difficulty and tags.
+type sessionSlot struct {
+	Count      int      `json:"count"`
+	Difficulty string   `json:"difficulty,omitempty"`
+	Tags       []string `json:"tags,omitempty"` // any of them
+}
+
+// sessionDifficulties are the difficulties a template slot can ask for.
+var sessionDifficulties = []string{"easy", "medium", "hard"}
+
+// parseSessionSlots reads the problems of a template: parts separated by "+", each a count
+// followed by an optional difficulty and tags, e.g. "1 hard + 2 medium graphs".
+func parseSessionSlots(spec string) ([]sessionSlot, error) {
+	var slots []sessionSlot
+	for _, part := range strings.Split(spec, "+") {
+		words := strings.Fields(strings.ToLower(part))
+		if len(words) == 0 {
+			return nil, fmt.Errorf("empty part in '%s'", spec)
+		}
+		n, err := strconv.Atoi(words[0])
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("'%s' doesn't start with a number of problems", strings.TrimSpace(part))
+		}
+		slot := sessionSlot{Count: n}
+		for _, w := range words[1:] {
+			switch {
+			case slices.Contains(sessionDifficulties, w) && slot.Difficulty == "":
+				slot.Difficulty = w
+			case slices.Contains(sessionDifficulties, w):
+				return nil, fmt.Errorf("'%s' has two difficulties", strings.TrimSpace(part))
+			default:
+				slot.Tags = append(slot.Tags, w)
+			}
+		}
+		slots = append(slots, slot)
+	}
+	return slots, nil
+}
+
+// parse validates the template and returns its slots and time budget.
+func (t SessionTemplate) parse() ([]sessionSlot, time.Duration, error) {
+	slots, err := parseSessionSlots(t.Problems)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid problems: %w", err)
+	}
+	budget, err := time.ParseDuration(t.Duration)
+	if err != nil || budget < time.Minute {
+		return nil, 0, fmt.Errorf("invalid duration '%s' (use e.g. 20m or 3h)", t.Duration)
+	}
+	return slots, budget, nil
+}
+
+// findSessionTemplate returns the template with this name, or else the only one whose name
+// contains it, so "warmup" starts "morning-warmup".
+func findSessionTemplate(templates map[string]SessionTemplate, name string) (string, SessionTemplate, error) {
+	if t, ok := templates[name]; ok {
+		return name, t, nil
+	}
+	var matches []string
+	for n := range templates {
+		if strings.Contains(strings.ToLower(n), strings.ToLower(name)) {
+			matches = append(matches, n)
+		}
+	}
+	sort.Strings(matches)
+	switch len(matches) {
+	case 0:
+		return "", SessionTemplate{}, fmt.Errorf("no session template '%s' (see 'saitama session templates')", name)
+	case 1:
+		return matches[0], templates[matches[0]], nil
+	}
+	return "", SessionTemplate{}, fmt.Errorf("'%s' matches several session templates: %s", name, strings.Join(matches, ", "))
+}
+
+// pickTemplateProblems picks the problems of every slot in turn, never the same one twice.
+// It returns fewer problems than asked when the collection runs short.
+func pickTemplateProblems(problems []Problem, slots []sessionSlot, rng *rand.Rand) []Problem {
+	taken := make(map[string]bool)
+	var picked []Problem
+	for _, slot := range slots {
+		var pool []Problem
+		for _, p := range problems {
+			if !taken[p.ID] && (slot.Difficulty == "" || strings.EqualFold(p.Difficulty, slot.Difficulty)) {
+				pool = append(pool, p)
+			}
+		}
+		for _, p := range pickSessionProblems(pool, slot.Count, slot.Tags, rng) {
+			taken[p.ID] = true
+			picked = append(picked, p)
+		}
+	}
+	return picked
+}
+
+func sessionTemplatesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "templates",
+		Short: "List the session templates of the config",
+		Long: "Session templates are defined in the \"session_templates\" section of the config, by name, e.g.\n\n" +
+			`  "session_templates": {` + "\n" +
+			`    "morning-warmup": {"problems": "2 easy array", "duration": "20m"},` + "\n" +
+			`    "weekend-grind": {"problems": "1 hard + 2 medium", "duration": "3h"}` + "\n" +
+			"  }\n\n" +
+			"Problems are parts separated by \"+\", each a count followed by an optional difficulty and tags.",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			names := make([]string, 0, len(cfg.SessionTemplates))
+			for name := range cfg.SessionTemplates {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			if jsonOutput() {
+				type templateInfo struct {
+					Name    string        `json:"name"`
+					Slots   []sessionSlot `json:"slots,omitempty"`
+					Minutes int           `json:"minutes,omitempty"`
+					Error   string        `json:"error,omitempty"`
+				}
+				infos := make([]templateInfo, 0, len(names))
+				for _, name := range names {
+					info := templateInfo{Name: name}
+					slots, budget, err := cfg.SessionTemplates[name].parse()
+					if err != nil {
+						info.Error = err.Error()
+					}
+					info.Slots, info.Minutes = slots, int(budget.Minutes())
+					infos = append(infos, info)
+				}
+				printJSON(infos)
+				return
+			}
+			if len(names) == 0 {
+				color.Yellow(tr("🏋️ No session templates yet."))
+				color.Cyan(tr("💡 Define some in the \"session_templates\" section of the config, see 'saitama session templates --help'"))
+				return
+			}
+			for _, name := range names {
+				t := cfg.SessionTemplates[name]
+				if _, _, err := t.parse(); err != nil {
+					printResult(color.New(color.FgRed), "❌ %-20s %v", name, err)
+					continue
+				}
+				printResult(color.New(color.FgWhite), "🏋️ %-20s %s, %s", name, t.Problems, t.Duration)
+			}
+		},
+	}
+}
@@ -0,0 +1,274 @@
+// theme.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// rgb is a 24-bit color.
+type rgb struct{ R, G, B uint8 }
+
+// theme recolors the output. Commands keep printing with the 16 basic colors of
+// color.X; the renderWriter swaps every basic color code for the theme's color, so
+// banners, tables, badges and charts all follow the theme.
+type theme struct {
+	Name        string
+	Description string
+	// colors maps the basic colors (0 black to 7 white, +8 for the Hi variants) to the
+	// theme's colors. A nil map keeps the terminal's own palette.
+	colors map[int]rgb
+	// mono drops every color, keeping the Hi variants in bold so that emphasis survives.
+	mono bool
+}
+
+const (
+	ansiBlack = iota
+	ansiRed
+	ansiGreen
+	ansiYellow
+	ansiBlue
+	ansiMagenta
+	ansiCyan
+	ansiWhite
+	ansiHi = 8 // offset of the Hi variants
+)
+
+// themes are the built-in themes, selected with --theme or "theme" in the config.
+var themes = []theme{
+	{Name: "default", Description: "the terminal's own colors"},
+	{
+		// Okabe-Ito palette: good and bad are blue and vermillion rather than green and red.
+		Name:        "colorblind",
+		Description: "safe for red-green color blindness (Okabe-Ito palette)",
+		colors: map[int]rgb{
+			ansiRed: {213, 94, 0}, ansiGreen: {0, 114, 178}, ansiYellow: {230, 159, 0}, ansiBlue: {86, 180, 233},
+			ansiMagenta: {204, 121, 167}, ansiCyan: {0, 158, 115}, ansiWhite: {220, 220, 220}, ansiBlack: {90, 90, 90},
+			ansiHi + ansiRed: {240, 120, 30}, ansiHi + ansiGreen: {60, 150, 220}, ansiHi + ansiYellow: {240, 228, 66}, ansiHi + ansiBlue: {140, 205, 245},
+			ansiHi + ansiMagenta: {225, 150, 195}, ansiHi + ansiCyan: {40, 190, 150}, ansiHi + ansiWhite: {255, 255, 255}, ansiHi + ansiBlack: {130, 130, 130},
+		},
+	},
+	{
+		Name:        "solarized",
+		Description: "Ethan Schoonover's Solarized accents",
+		colors: map[int]rgb{
+			ansiRed: {220, 50, 47}, ansiGreen: {133, 153, 0}, ansiYellow: {181, 137, 0}, ansiBlue: {38, 139, 210},
+			ansiMagenta: {211, 54, 130}, ansiCyan: {42, 161, 152}, ansiWhite: {238, 232, 213}, ansiBlack: {7, 54, 66},
+			ansiHi + ansiRed: {203, 75, 22}, ansiHi + ansiGreen: {133, 153, 0}, ansiHi + ansiYellow: {181, 137, 0}, ansiHi + ansiBlue: {38, 139, 210},
+			ansiHi + ansiMagenta: {108, 113, 196}, ansiHi + ansiCyan: {42, 161, 152}, ansiHi + ansiWhite: {253, 246, 227}, ansiHi + ansiBlack: {88, 110, 117},
+		},
+	},
+	{Name: "monochrome", Description: "no colors, bold for emphasis", mono: true},
+}
+
+var (
+	// themeFlag is set by the persistent --theme flag, activeTheme by setupOutput.
+	themeFlag   string
+	activeTheme theme
+
+	sgrSequence = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+)
+
+// themeNames returns the names of the built-in themes.
+func themeNames() []string {
+	var names []string
+	for _, t := range themes {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// themeByName finds a built-in theme.
+func themeByName(name string) (theme, bool) {
+	for _, t := range themes {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return theme{}, false
+}
+
+// selectTheme returns the theme chosen with --theme, $SAITAMA_THEME or the config, in
+// that order.
+func selectTheme(cfg Config) (theme, error) {
+	name := themeFlag
+	if name == "" {
+		name = os.Getenv("SAITAMA_THEME")
+	}
+	if name == "" {
+		name = cfg.Theme
+	}
+	if name == "" {
+		name = "default"
+	}
+	t, ok := themeByName(name)
+	if !ok {
+		return themes[0], fmt.Errorf("unknown theme '%s' (use %s)", name, strings.Join(themeNames(), ", "))
+	}
+	return t, nil
+}
+
+// trueColor reports whether the terminal understands 24-bit colors. Others get the
+// closest of the 256 colors.
+func trueColor() bool {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return true
+	}
+	return false
+}
+
+// sgrColor returns the SGR parameters selecting a color, as foreground or background.
+func sgrColor(c rgb, background bool) string {
+	kind := "38"
+	if background {
+		kind = "48"
+	}
+	if trueColor() {
+		return fmt.Sprintf("%s;2;%d;%d;%d", kind, c.R, c.G, c.B)
+	}
+	return fmt.Sprintf("%s;5;%d", kind, ansi256(c))
+}
+
+// ansi256 returns the closest color of the 6x6x6 cube of the 256-color palette, or of
+// its grayscale ramp for grays.
+func ansi256(c rgb) int {
+	if c.R == c.G && c.G == c.B {
+		switch {
+		case c.R < 8:
+			return 16
+		case c.R > 248:
+			return 231
+		}
+		return 232 + (int(c.R)-8)*24/241
+	}
+	level := func(v uint8) int {
+		if v < 48 {
+			return 0
+		}
+		return min(5, (int(v)-35)/40)
+	}
+	return 16 + 36*level(c.R) + 6*level(c.G) + level(c.B)
+}
+
+// applyTheme rewrites the color codes of the text for the theme.
+func applyTheme(text string, t theme) string {
+	if t.colors == nil && !t.mono {
+		return text
+	}
+	return sgrSequence.ReplaceAllStringFunc(text, func(seq string) string {
+		params := strings.Split(sgrSequence.FindStringSubmatch(seq)[1], ";")
+		var out []string
+		for i := 0; i < len(params); i++ {
+			code, err := strconv.Atoi(params[i])
+			if err != nil {
+				out = append(out, params[i])
+				continue
+			}
+			var basic int
+			var background bool
+			switch {
+			case code == 38 || code == 48:
+				// Already an extended color: 38;5;n or 38;2;r;g;b, kept as is.
+				n := 2
+				if i+1 < len(params) && params[i+1] == "2" {
+					n = 4
+				}
+				end := min(len(params), i+n+1)
+				if !t.mono {
+					out = append(out, params[i:end]...)
+				}
+				i = end - 1
+				continue
+			case code >= 30 && code <= 37:
+				basic = code - 30
+			case code >= 90 && code <= 97:
+				basic = code - 90 + ansiHi
+			case code >= 40 && code <= 47:
+				basic, background = code-40, true
+			case code >= 100 && code <= 107:
+				basic, background = code-100+ansiHi, true
+			default:
+				out = append(out, params[i])
+				continue
+			}
+			if t.mono {
+				if basic >= ansiHi && !background {
+					out = append(out, "1")
+				}
+				continue
+			}
+			if c, ok := t.colors[basic]; ok {
+				out = append(out, sgrColor(c, background))
+			} else {
+				out = append(out, params[i])
+			}
+		}
+		if len(out) == 0 {
+			return ""
+		}
+		return "\x1b[" + strings.Join(out, ";") + "m"
+	})
+}
+
+func themeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "theme",
+		Short: "Preview the color themes",
+		Long: "Themes recolor everything saitama prints. Choose one with --theme, $SAITAMA_THEME or " +
+			"'saitama theme set'. Terminals announcing 24-bit color (COLORTERM=truecolor) get the exact colors, " +
+			"others the closest of 256.",
+		Example: `  saitama theme
+  saitama theme set colorblind
+  saitama list --theme solarized`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Each sample is themed here, the renderWriter leaves them alone meanwhile.
+			current := activeTheme
+			activeTheme = themes[0]
+			defer func() { activeTheme = current }()
+			for _, t := range themes {
+				marker := "  "
+				if t.Name == current.Name {
+					marker = "▶ "
+				}
+				sample := fmt.Sprintf("%s %s %s %s %s %s",
+					color.GreenString("easy"), color.YellowString("medium"), color.RedString("hard"),
+					color.CyanString("▒▓█"), color.HiMagentaString("═══"), color.HiBlackString("hint"))
+				fmt.Fprintf(stdout, "%s%-11s %s  %s\n", marker, t.Name, applyTheme(sample, t), t.Description)
+			}
+		},
+	}
+	cmd.AddCommand(&cobra.Command{
+		Use:         "set <name>",
+		Annotations: mutates(),
+		Short:       "Use a theme from now on",
+		Args:        cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			t, ok := themeByName(args[0])
+			if !ok {
+				printError(tr("❌ Unknown theme '%s' (use %s)"), args[0], strings.Join(themeNames(), ", "))
+				return
+			}
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			cfg.Theme = t.Name
+			if err := saveConfig(cfg); err != nil {
+				printError(tr("❌ Error saving config: %v"), err)
+				return
+			}
+			activeTheme = t
+			color.Green(tr("🎨 Theme set to %s."), t.Name)
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,94 @@
+// timer.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// formatClock formats a duration as MM:SS (or H:MM:SS for long durations).
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := int(d.Hours())
+	m := int(d.Minutes()) % 60
+	s := int(d.Seconds()) % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// countdown is a live timer on a single terminal line. onTick, when set, is called
+// every second with the elapsed time, e.g. to checkpoint the state of a session.
+type countdown struct {
+	total    time.Duration
+	label    string
+	warnings []time.Duration
+	onTick   func(elapsed time.Duration)
+}
+
+// runCountdown shows a live countdown on a single terminal line until the time is up
+// or the user presses Ctrl+C. It returns the elapsed time and whether the timer ran out.
+// A warning is printed (with a terminal bell) when the remaining time drops below each
+// of the given thresholds, largest first.
+func runCountdown(total time.Duration, label string, warnings ...time.Duration) (time.Duration, bool) {
+	return countdown{total: total, label: label, warnings: warnings}.run()
+}
+
+// title names the timer in its notifications, e.g. "Boss fight" for "⏳ Boss fight:".
+func (c countdown) title() string {
+	if title := strings.TrimSpace(strings.TrimSuffix(stripDecoration(c.label), ":")); title != "" {
+		return title
+	}
+	return "saitama"
+}
+
+func (c countdown) run() (time.Duration, bool) {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	start := time.Now()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	warnings := c.warnings
+	for len(warnings) > 0 && warnings[0] >= c.total {
+		warnings = warnings[1:]
+	}
+
+	color.HiBlack(tr("(Press Ctrl+C to stop the timer early)"))
+	for {
+		elapsed := time.Since(start)
+		remaining := c.total - elapsed
+		if remaining <= 0 {
+			fmt.Fprintf(stderr, "\r%s %s\n", c.label, color.HiRedString("00:00"))
+			color.HiRed(tr("⏰ Time's up!"))
+			notify(c.title(), tr("⏰ Time's up!"))
+			return c.total, true
+		}
+		for len(warnings) > 0 && remaining <= warnings[0] {
+			fmt.Fprint(stderr, "\a\n")
+			color.HiRed(tr("⚠️  %s left!"), formatClock(warnings[0]))
+			go notify(c.title(), fmt.Sprintf(tr("⚠️  %s left!"), formatClock(warnings[0])))
+			warnings = warnings[1:]
+		}
+		fmt.Fprintf(stderr, "\r%s %s ", c.label, color.HiYellowString(formatClock(remaining)))
+
+		select {
+		case <-ticker.C:
+			if c.onTick != nil {
+				c.onTick(time.Since(start))
+			}
+		case <-interrupt:
+			fmt.Fprintln(stderr)
+			color.Yellow(tr("⏹️  Timer stopped after %s"), formatClock(elapsed))
+			return elapsed, false
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// trend.go
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// tagTrend is the number of solves of a tag in each month of a window, oldest first.
+type tagTrend struct {
+	Tag    string `json:"tag"`
+	Counts []int  `json:"counts"`
+	Total  int    `json:"total"`
+}
+
+// computeTagTrends buckets the solves of the last months (the current one included) by
+// tag and month. Tags are sorted by solves in the window, then by name; a solve counts
+// for every tag of its problem.
+func computeTagTrends(events []activityEvent, months int, now time.Time) ([]string, []tagTrend) {
+	now = now.Local()
+	first := time.Date(now.Year(), now.Month()-time.Month(months-1), 1, 0, 0, 0, 0, time.Local)
+	keys := make([]string, months)
+	index := make(map[string]int, months)
+	for i := range keys {
+		keys[i] = first.AddDate(0, i, 0).Format("2006-01")
+		index[keys[i]] = i
+	}
+
+	byTag := make(map[string]*tagTrend)
+	for _, e := range events {
+		i, ok := index[e.Date.Local().Format("2006-01")]
+		if !e.Solved || !ok {
+			continue
+		}
+		for _, tag := range e.Tags {
+			tag = strings.ToLower(tag)
+			t := byTag[tag]
+			if t == nil {
+				t = &tagTrend{Tag: tag, Counts: make([]int, months)}
+				byTag[tag] = t
+			}
+			t.Counts[i]++
+			t.Total++
+		}
+	}
+
+	trends := make([]tagTrend, 0, len(byTag))
+	for _, t := range byTag {
+		trends = append(trends, *t)
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].Total != trends[j].Total {
+			return trends[i].Total > trends[j].Total
+		}
+		return trends[i].Tag < trends[j].Tag
+	})
+	return keys, trends
+}
+
+// trendCell renders a month of a tag trend with its change from the month before.
+func trendCell(count, previous int, first bool) string {
+	switch {
+	case first || count == previous:
+		return fmt.Sprintf("%d", count)
+	case count > previous:
+		return fmt.Sprintf("%d (+%d)", count, count-previous)
+	}
+	return fmt.Sprintf("%d (-%d)", count, previous-count)
+}
+
+// printTagTrends prints a table of solves per tag and month, with the change from one
+// month to the next and a sparkline of the window.
+func printTagTrends(months []string, trends []tagTrend) {
+	fmt.Fprintf(stderr, "%-20s", "")
+	for _, m := range months {
+		t, _ := time.Parse("2006-01", m)
+		fmt.Fprintf(stderr, " %-8s", t.Format("Jan 06"))
+	}
+	fmt.Fprintln(stderr)
+	for _, t := range trends {
+		var row strings.Builder
+		fmt.Fprintf(&row, "%-20s", t.Tag)
+		for i, n := range t.Counts {
+			cell := fmt.Sprintf(" %-8s", trendCell(n, t.Counts[max(0, i-1)], i == 0))
+			switch {
+			case i > 0 && n > t.Counts[i-1]:
+				cell = color.GreenString("%s", cell)
+			case i > 0 && n < t.Counts[i-1]:
+				cell = color.RedString("%s", cell)
+			}
+			row.WriteString(cell)
+		}
+		printResult(color.New(color.FgWhite), "%s %s", row.String(), color.HiCyanString("%s", sparkline(t.Counts)))
+	}
+}
+
+func statsTagsCmd() *cobra.Command {
+	var trend bool
+	var months, limit int
+	var tags []string
+	cmd := &cobra.Command{
+		Use:   "tags",
+		Short: "Show the solves of every tag, month over month with --trend",
+		Long: "Counts the solves of every tag over the last months. With --trend, shows them month by month " +
+			"with the change from the month before, e.g. to check that resolving to practice graphs paid off. " +
+			"The current month is counted so far.",
+		Example: `  saitama stats tags
+  saitama stats tags --trend
+  saitama stats tags --trend --months 12 --tag graphs,dp`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if months < 2 {
+				printError(tr("❌ --months must be at least 2"))
+				return
+			}
+			problems, err := loadProblems()
+			if err != nil {
+				printError(tr("❌ Error loading problems: %v"), err)
+				return
+			}
+			keys, trends := computeTagTrends(activityLog(problems), months, time.Now())
+			if len(tags) > 0 {
+				filtered := trends[:0]
+				for _, t := range trends {
+					if hasAnyTag(Problem{Tags: []string{t.Tag}}, tags) {
+						filtered = append(filtered, t)
+					}
+				}
+				trends = filtered
+			}
+			more := 0
+			if limit > 0 && len(trends) > limit {
+				trends, more = trends[:limit], len(trends)-limit
+			}
+
+			if jsonOutput() {
+				printJSON(struct {
+					Months []string   `json:"months"`
+					Tags   []tagTrend `json:"tags"`
+				}{keys, trends})
+				return
+			}
+			if len(trends) == 0 {
+				color.Yellow(tr("📝 No solves in the last %d months."), months)
+				return
+			}
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("🏷️  Solves per tag, last %d months:"), months)
+			if trend {
+				printTagTrends(keys, trends)
+			} else {
+				for _, t := range trends {
+					printResult(color.New(color.FgWhite), "   %-20s %4d  %s", t.Tag, t.Total, color.GreenString("%s", strings.Repeat("█", min(t.Total, 40))))
+				}
+			}
+			if more > 0 {
+				color.HiBlack(tr("   ... and %d more"), more)
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().BoolVar(&trend, "trend", false, "show the solves month by month, with the change from the month before")
+	cmd.Flags().IntVar(&months, "months", 6, "number of months, the current one included")
+	cmd.Flags().IntVar(&limit, "limit", 15, "maximum number of tags, the most solved first (0 for all)")
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "only show these tags")
+	return cmd
+}
@@ -0,0 +1,212 @@
+// tx.go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+var (
+	// errConflict is returned by Tx.Apply when another command wrote the database after
+	// the transaction began.
+	errConflict = errors.New("the database was changed by another command in the meantime; nothing was saved, run the command again")
+	// errTxDone is returned when a transaction is used after Apply or Rollback.
+	errTxDone = errors.New("the transaction was already applied or rolled back")
+)
+
+// stagedFile is a file to replace, written to a temporary file first.
+type stagedFile struct {
+	Path string
+	Data []byte
+}
+
+// Tx is a transaction on the problem database. A compound operation (an import merging
+// duplicates, a bulk edit, a rename that also updates the sync state, ...) runs its steps
+// on a private copy of the problems, then Apply writes the result together with the other
+// files staged on the transaction: either every file is replaced or none is. A failed
+// step, Rollback or an error while writing leaves everything on disk as it was.
+type Tx struct {
+	store    *Store
+	path     string
+	modTime  time.Time // of the database when the transaction began, to detect conflicts
+	size     int64
+	problems []Problem
+	files    []stagedFile
+	done     bool
+}
+
+// Begin starts a transaction on the current content of the database. Callers should
+// defer Rollback, which does nothing once the transaction is applied.
+func (s *Store) Begin() (*Tx, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+	return &Tx{store: s, path: s.path, modTime: s.modTime, size: s.size, problems: cloneProblems(s.problems)}, nil
+}
+
+// cloneProblems returns a deep copy of the problems, so a step can modify their tags,
+// attempts and relations without touching the originals.
+func cloneProblems(problems []Problem) []Problem {
+	if problems == nil {
+		return nil
+	}
+	clone := make([]Problem, len(problems))
+	for i, p := range problems {
+		clone[i] = cloneProblem(p)
+	}
+	return clone
+}
+
+// cloneProblem returns a copy of a problem that shares none of its slices or its review.
+func cloneProblem(p Problem) Problem {
+	p.Tags = slices.Clone(p.Tags)
+	p.Relations = slices.Clone(p.Relations)
+	p.Attempts = slices.Clone(p.Attempts)
+	p.Hints = slices.Clone(p.Hints)
+	p.References = slices.Clone(p.References)
+	if p.Review != nil {
+		review := *p.Review
+		review.Recalls = slices.Clone(review.Recalls)
+		p.Review = &review
+	}
+	return p
+}
+
+// Problems returns a copy of the problems as changed by the steps so far.
+func (tx *Tx) Problems() []Problem {
+	return cloneProblems(tx.problems)
+}
+
+// Update runs a step of the transaction on a copy of its problems. When the step fails,
+// the transaction keeps the problems it had before the step and the error is returned;
+// the caller usually rolls back then.
+func (tx *Tx) Update(step func(problems []Problem) ([]Problem, error)) error {
+	if tx.done {
+		return errTxDone
+	}
+	problems, err := step(cloneProblems(tx.problems))
+	if err != nil {
+		return err
+	}
+	tx.problems = problems
+	return nil
+}
+
+// WriteFile stages another file of the data directory, e.g. a sync state, to be replaced
+// together with the database on Apply.
+func (tx *Tx) WriteFile(path string, data []byte) {
+	for i := range tx.files {
+		if tx.files[i].Path == path {
+			tx.files[i].Data = data
+			return
+		}
+	}
+	tx.files = append(tx.files, stagedFile{Path: path, Data: data})
+}
+
+// Apply writes the problems and the staged files, after a backup of the database like
+// saveProblems. It fails with errConflict, writing nothing, when the database changed on
+// disk since Begin.
+func (tx *Tx) Apply() error {
+	if tx.done {
+		return errTxDone
+	}
+	tx.done = true
+	if isReadOnly() {
+		return errReadOnly
+	}
+
+	release, err := acquireLock(filepath.Dir(tx.path))
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	info, err := os.Stat(tx.path)
+	switch {
+	case os.IsNotExist(err):
+		if !tx.modTime.IsZero() {
+			return errConflict
+		}
+	case err != nil:
+		return fmt.Errorf("failed to read problems file: %w", err)
+	case !info.ModTime().Equal(tx.modTime) || info.Size() != tx.size:
+		return errConflict
+	}
+	return writeProblems(tx.path, tx.problems, tx.files)
+}
+
+// Rollback discards the transaction, leaving the files on disk untouched.
+func (tx *Tx) Rollback() {
+	tx.done = true
+	tx.problems, tx.files = nil, nil
+}
+
+// writeProblems replaces the database and the other files. Every file is written to a
+// temporary file first and only renamed over the original once all of them were
+// written; the database is renamed last, and when a rename fails the files already
+// replaced are restored. The caller holds the lock of the data directory.
+func writeProblems(dbPath string, problems []Problem, files []stagedFile) error {
+	stampUpdates(dbPath, problems, time.Now())
+
+	if err := createBackup(dbPath); err != nil {
+		// Don't fail the save operation if backup fails, just warn
+		color.Yellow(tr("Warning: Failed to create backup: %v\n"), err)
+		slog.Warn("backup failed", "err", err)
+	}
+
+	data, err := json.MarshalIndent(problems, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal problems: %w", err)
+	}
+	staged := append(append([]stagedFile(nil), files...), stagedFile{Path: dbPath, Data: data})
+
+	for i, f := range staged {
+		if err := os.WriteFile(f.Path+".tmp", f.Data, 0644); err != nil {
+			removeTempFiles(staged[:i+1])
+			return fmt.Errorf("failed to write temporary file: %w", err)
+		}
+	}
+
+	// Keep the files about to be replaced, to put them back if a later rename fails.
+	previous := make([][]byte, len(files))
+	for i, f := range files {
+		if old, err := os.ReadFile(f.Path); err == nil {
+			previous[i] = old
+		}
+	}
+	for i, f := range staged {
+		if err := os.Rename(f.Path+".tmp", f.Path); err != nil {
+			removeTempFiles(staged[i:])
+			for j := range staged[:i] {
+				if previous[j] == nil {
+					_ = os.Remove(staged[j].Path)
+				} else {
+					_ = os.WriteFile(staged[j].Path, previous[j], 0644)
+				}
+			}
+			return fmt.Errorf("failed to replace %s: %w", filepath.Base(f.Path), err)
+		}
+	}
+	store.Saved(dbPath, problems)
+	refreshPromptStatus(problems)
+	slog.Info("saved problems", "path", dbPath, "count", len(problems), "bytes", len(data), "files", len(files))
+	return nil
+}
+
+// removeTempFiles cleans up the temporary files of staged files.
+func removeTempFiles(staged []stagedFile) {
+	for _, f := range staged {
+		_ = os.Remove(f.Path + ".tmp")
+	}
+}
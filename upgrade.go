@@ -0,0 +1,428 @@
+// upgrade.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// version is the version of this build, set when releasing with
+	// -ldflags "-X main.version=v1.4.0". Builds from source are "dev".
+	version = "dev"
+	// releasePublicKey is the base64 ed25519 key the checksums of the releases are signed
+	// with, set when releasing with -ldflags "-X main.releasePublicKey=...". Upgrade
+	// refuses releases without a valid signature, and builds without a key (from source)
+	// only upgrade with --allow-unsigned.
+	releasePublicKey = ""
+)
+
+const (
+	checksumsAsset = "checksums.txt"
+	signatureAsset = "checksums.txt.sig"
+)
+
+// latestReleaseURL is the GitHub API endpoint of the latest release.
+var latestReleaseURL = "https://api.github.com/repos/Thedrogon/saitama-cli/releases/latest"
+
+// downloadClient is httpClient with room for downloading a release binary.
+var downloadClient = &http.Client{Timeout: 5 * time.Minute, Transport: loggingTransport{next: http.DefaultTransport}}
+
+// githubRelease is a release as returned by the GitHub API.
+type githubRelease struct {
+	TagName string `json:"tag_name"`
+	HTMLURL string `json:"html_url"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+		Size int64  `json:"size"`
+	} `json:"assets"`
+}
+
+// assetURL returns the download URL of an asset of the release, or "".
+func (r githubRelease) assetURL(name string) string {
+	for _, a := range r.Assets {
+		if a.Name == name {
+			return a.URL
+		}
+	}
+	return ""
+}
+
+// releaseAssetName is the name of the binary of a release for a platform, e.g.
+// saitama_linux_amd64 or saitama_windows_arm64.exe.
+func releaseAssetName(goos, goarch string) string {
+	name := fmt.Sprintf("saitama_%s_%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// parseVersion splits a version such as v1.4.0 or 1.5.0-rc1 into its numbers and
+// pre-release suffix.
+func parseVersion(v string) ([3]int, string, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, pre, _ := strings.Cut(v, "-")
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, "", false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, "", false
+		}
+		parts[i] = n
+	}
+	return parts, pre, true
+}
+
+// newerVersion reports whether version a is newer than b. A pre-release is older than
+// the release of the same numbers.
+func newerVersion(a, b string) bool {
+	va, preA, okA := parseVersion(a)
+	vb, preB, okB := parseVersion(b)
+	if !okA || !okB {
+		return false
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			return va[i] > vb[i]
+		}
+	}
+	if (preA == "") != (preB == "") {
+		return preA == ""
+	}
+	return comparePrerelease(preA, preB) > 0
+}
+
+// comparePrerelease orders pre-release suffixes such as rc9, rc10 or beta.2, returning
+// -1, 0 or 1. The dot separated identifiers are compared in turn, and the runs of digits
+// within them by their value, so that rc10 comes after rc9.
+func comparePrerelease(a, b string) int {
+	idsA, idsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(idsA) && i < len(idsB); i++ {
+		if c := compareIdentifier(idsA[i], idsB[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(idsA), len(idsB))
+}
+
+func compareIdentifier(a, b string) int {
+	for a != "" && b != "" {
+		runA, runB := leadingRun(a), leadingRun(b)
+		a, b = a[len(runA):], b[len(runB):]
+		numA, errA := strconv.Atoi(runA)
+		numB, errB := strconv.Atoi(runB)
+		var c int
+		switch {
+		case errA == nil && errB == nil:
+			c = cmp.Compare(numA, numB)
+		case errA == nil:
+			c = -1 // numbers sort before letters, as in semver
+		case errB == nil:
+			c = 1
+		default:
+			c = strings.Compare(runA, runB)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// leadingRun returns the leading run of digits, or of other characters, of s.
+func leadingRun(s string) string {
+	digit := s[0] >= '0' && s[0] <= '9'
+	for i := 1; i < len(s); i++ {
+		if (s[i] >= '0' && s[i] <= '9') != digit {
+			return s[:i]
+		}
+	}
+	return s
+}
+
+// fetchLatestRelease asks GitHub for the latest release. GITHUB_TOKEN is used when set,
+// to avoid the rate limit of anonymous requests.
+func fetchLatestRelease() (githubRelease, error) {
+	var release githubRelease
+	req, err := http.NewRequest(http.MethodGet, latestReleaseURL, nil)
+	if err != nil {
+		return release, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return release, fmt.Errorf("failed to check for updates: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return release, errors.New("no release has been published yet")
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return release, fmt.Errorf("GitHub responded with %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return release, fmt.Errorf("failed to parse the release: %w", err)
+	}
+	return release, nil
+}
+
+// download returns the content of a URL.
+func download(url string) ([]byte, error) {
+	resp, err := downloadClient.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// expectedChecksum finds the SHA-256 of a file in a sha256sum style checksums file.
+func expectedChecksum(checksums []byte, name string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(checksums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), true
+		}
+	}
+	return "", false
+}
+
+// verifySignature checks the ed25519 signature of the checksums file, given raw or
+// base64, against the public key of the build.
+func verifySignature(checksums, signature []byte, publicKey string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("the release public key of this build is invalid")
+	}
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+		if err != nil {
+			return errors.New("the signature of the checksums is malformed")
+		}
+		signature = decoded
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), checksums, signature) {
+		return errors.New("the signature of the checksums doesn't match: the release may have been tampered with")
+	}
+	return nil
+}
+
+// downloadRelease downloads the binary of the release for this platform and checks it
+// against the checksums of the release, and their signature when the build has a
+// release public key. Without one, the checksums only catch a corrupted download.
+func downloadRelease(release githubRelease) ([]byte, error) {
+	name := releaseAssetName(runtime.GOOS, runtime.GOARCH)
+	binaryURL := release.assetURL(name)
+	if binaryURL == "" {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s (%s)", release.TagName, runtime.GOOS, runtime.GOARCH, name)
+	}
+	checksumsURL := release.assetURL(checksumsAsset)
+	if checksumsURL == "" {
+		return nil, fmt.Errorf("release %s has no %s to verify the download with", release.TagName, checksumsAsset)
+	}
+	checksums, err := download(checksumsURL)
+	if err != nil {
+		return nil, err
+	}
+	if releasePublicKey != "" {
+		signatureURL := release.assetURL(signatureAsset)
+		if signatureURL == "" {
+			return nil, fmt.Errorf("release %s isn't signed (no %s)", release.TagName, signatureAsset)
+		}
+		signature, err := download(signatureURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(checksums, signature, releasePublicKey); err != nil {
+			return nil, err
+		}
+	}
+	want, ok := expectedChecksum(checksums, name)
+	if !ok {
+		return nil, fmt.Errorf("%s has no checksum for %s", checksumsAsset, name)
+	}
+
+	binary, err := download(binaryURL)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(binary)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: expected %s, got %s", name, want, got)
+	}
+	return binary, nil
+}
+
+// replaceExecutable puts the new binary in place of the one at path. The new binary is
+// written next to it and renamed over it, so the swap is atomic. Windows doesn't allow
+// replacing a running executable, so it is moved aside first and removed by the next
+// upgrade.
+func replaceExecutable(path string, binary []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tempFile := path + ".new"
+	if err := os.WriteFile(tempFile, binary, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(path, old); err != nil {
+			_ = os.Remove(tempFile)
+			return fmt.Errorf("failed to move the current binary aside: %w", err)
+		}
+		if err := os.Rename(tempFile, path); err != nil {
+			_ = os.Rename(old, path)
+			_ = os.Remove(tempFile)
+			return fmt.Errorf("failed to replace the binary: %w", err)
+		}
+		return nil
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		_ = os.Remove(tempFile)
+		return fmt.Errorf("failed to replace the binary: %w", err)
+	}
+	return nil
+}
+
+func upgradeCmd() *cobra.Command {
+	var check, force, yes, allowUnsigned bool
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Update saitama to the latest release",
+		Long: "Checks the latest GitHub release and, when it is newer, downloads the binary for this platform, " +
+			"verifies it against the SHA-256 checksums of the release and their signature, and replaces the running " +
+			"binary in place. Builds from source can't check the signature and need --allow-unsigned. Installed " +
+			"with a package manager or go install? Update it that way instead. --check only reports whether an " +
+			"update is available.",
+		Example: `  saitama upgrade --check
+  saitama upgrade
+  saitama upgrade --yes   # e.g. from a script
+  sudo saitama upgrade    # when installed in a system folder`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			release, err := fetchLatestRelease()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			available := newerVersion(release.TagName, version)
+
+			if check {
+				if jsonOutput() {
+					printJSON(struct {
+						Current   string `json:"current"`
+						Latest    string `json:"latest"`
+						Available bool   `json:"update_available"`
+						URL       string `json:"url,omitempty"`
+					}{version, release.TagName, available, release.HTMLURL})
+					return
+				}
+				switch {
+				case available:
+					printResult(color.New(color.FgHiGreen), tr("⬆️  saitama %s is available (you have %s)"), release.TagName, version)
+					color.Cyan(tr("💡 Update with: saitama upgrade"))
+				case version == "dev":
+					printResult(color.New(color.FgYellow), tr("🛠️  This is a development build; the latest release is %s"), release.TagName)
+				default:
+					printResult(color.New(color.FgGreen), tr("✅ saitama %s is the latest version"), version)
+				}
+				return
+			}
+
+			if !available && !force {
+				if version == "dev" {
+					color.Yellow(tr("🛠️  This is a development build; use --force to replace it with release %s"), release.TagName)
+				} else {
+					color.Green(tr("✅ saitama %s is the latest version"), version)
+				}
+				return
+			}
+
+			if releasePublicKey == "" {
+				if !allowUnsigned {
+					printError(tr("❌ This build has no release public key, so the signature of %s can't be checked"), release.TagName)
+					color.Cyan(tr("💡 Reinstall from a release, or upgrade anyway with --allow-unsigned"))
+					return
+				}
+				color.Yellow(tr("⚠️  Not checking the signature of %s: the download is only compared with the checksums published next to it"), release.TagName)
+			}
+
+			exe, err := os.Executable()
+			if err == nil {
+				exe, err = filepath.EvalSymlinks(exe)
+			}
+			if err != nil {
+				printError(tr("❌ Could not find the running binary: %v"), err)
+				return
+			}
+			if runtime.GOOS == "windows" {
+				_ = os.Remove(exe + ".old") // left by the previous upgrade
+			}
+			if !yes {
+				confirm := false
+				prompt := &survey.Confirm{Message: fmt.Sprintf("Replace %s (%s) with %s?", exe, version, release.TagName), Default: true}
+				if err := survey.AskOne(prompt, &confirm); err != nil || !confirm {
+					color.Yellow(tr("Upgrade cancelled."))
+					return
+				}
+			}
+
+			color.Cyan(tr("⬇️  Downloading %s..."), release.TagName)
+			binary, err := downloadRelease(release)
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if err := replaceExecutable(exe, binary); err != nil {
+				printError("❌ %v", err)
+				if errors.Is(err, os.ErrPermission) {
+					color.Cyan(tr("💡 %s isn't writable: run the upgrade with sudo, or update saitama the way you installed it"), filepath.Dir(exe))
+				}
+				return
+			}
+			color.Green(tr("✅ Upgraded saitama from %s to %s"), version, release.TagName)
+		},
+	}
+	cmd.Flags().BoolVar(&check, "check", false, "only report whether a newer release is available")
+	cmd.Flags().BoolVar(&force, "force", false, "install the latest release even if it isn't newer, e.g. over a development build")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "replace the binary without asking for confirmation")
+	cmd.Flags().BoolVar(&allowUnsigned, "allow-unsigned", false, "upgrade a build without a release public key, which can't verify the signature")
+	return cmd
+}
@@ -0,0 +1,29 @@
+// upgrade_test.go
+package main
+
+import "testing"
+
+func TestNewerVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"v1.4.0", "v1.3.9", true},
+		{"v1.10.0", "v1.9.0", true},
+		{"1.4", "v1.4.0", false},
+		{"v1.4.0", "v1.4.0-rc1", true},
+		{"v1.4.0-rc1", "v1.4.0", false},
+		{"v1.4.0-rc10", "v1.4.0-rc9", true},
+		{"v1.4.0-rc9", "v1.4.0-rc10", false},
+		{"v1.4.0-rc1", "v1.4.0-beta2", true},
+		{"v1.4.0-beta.10", "v1.4.0-beta.9", true},
+		{"v1.4.0-beta.2", "v1.4.0-beta", true},
+		{"v1.4.0", "dev", false},
+		{"dev", "v1.4.0", false},
+	}
+	for _, tt := range tests {
+		if got := newerVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("newerVersion(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// urls.go
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// urlImport is a line of a URL list being turned into a problem.
+type urlImport struct {
+	Line    int
+	Problem Problem
+	Err     error
+}
+
+// readURLList reads a plain text list of problem URLs, one per line. Blank lines and
+// lines starting with # are ignored.
+func readURLList(filename string) ([]urlImport, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	defer f.Close()
+
+	var lines []urlImport
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		link := strings.TrimSpace(scanner.Text())
+		if link == "" || strings.HasPrefix(link, "#") {
+			continue
+		}
+		lines = append(lines, urlImport{Line: n, Problem: Problem{URL: link}})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read URL list: %w", err)
+	}
+	return lines, nil
+}
+
+// importURLs turns a list of problem URLs into problems: the platform is detected from
+// the URL and the name, difficulty, rating and tags come from the platform's adapter,
+// looked up by a bounded pool of workers. URLs already in existing (or listed twice) are
+// left out and counted. Lines that can't be imported are returned with their error.
+func importURLs(filename string, defs []PlatformDef, existing []Problem, workers int) ([]Problem, []urlImport, int, error) {
+	lines, err := readURLList(filename)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var pending, failed []urlImport
+	seen := make(map[string]bool)
+	known := 0
+	for _, l := range lines {
+		u, err := url.Parse(l.Problem.URL)
+		if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+			l.Err = fmt.Errorf("not a URL")
+			failed = append(failed, l)
+			continue
+		}
+		key := normalizeURL(l.Problem.URL)
+		if _, index := findProblemByURL(existing, l.Problem.URL); index != -1 || seen[key] {
+			known++
+			continue
+		}
+		seen[key] = true
+		def, ok := platformForURL(defs, l.Problem.URL)
+		if !ok {
+			l.Err = fmt.Errorf("unknown platform %s (add it under \"platform_defs\" in the config)", urlHost(l.Problem.URL))
+			failed = append(failed, l)
+			continue
+		}
+		l.Problem.Platform = def.Name
+		pending = append(pending, l)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+	bar := newProgressBar("🔗 Fetching", int64(len(pending)))
+	for w := 0; w < max(1, workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pending[i].Problem, pending[i].Err = problemFromURL(pending[i].Problem)
+				mu.Lock()
+				done++
+				bar.Update(int64(done), fmt.Sprintf("%d/%d", done, len(pending)))
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	bar.Finish(int64(len(pending)), fmt.Sprintf("%d/%d", len(pending), len(pending)))
+
+	var problems []Problem
+	nextURL, _ := strconv.Atoi(strings.TrimPrefix(nextSequentialID(existing, "URL"), "URL"))
+	for _, l := range pending {
+		if l.Err != nil {
+			failed = append(failed, l)
+			continue
+		}
+		if l.Problem.ID == "" {
+			l.Problem.ID = "URL" + strconv.Itoa(nextURL)
+			nextURL++
+		}
+		problems = append(problems, l.Problem)
+	}
+	return problems, failed, known, nil
+}
+
+// problemFromURL fills in a problem from its URL and platform. Platforms without an
+// adapter get their name and ID from the URL alone.
+func problemFromURL(p Problem) (Problem, error) {
+	key := normalizeURL(p.URL)
+	p.Tags = []string{}
+	p.DateAdded = time.Now()
+	p.ID = bookmarkProblemID(p.Platform, key, "")
+	p.Name = bookmarkProblemName("", key)
+
+	adapter := adapterFor(p)
+	if adapter == nil {
+		return p, nil
+	}
+	meta, ok, err := adapter.Lookup(p)
+	if err != nil {
+		return p, err
+	}
+	if !ok {
+		return p, fmt.Errorf("not found on %s", adapter.Platform())
+	}
+	if meta.ID != "" {
+		p.ID = meta.ID
+	}
+	p.Name = meta.Title
+	p.Difficulty = meta.Difficulty
+	p.Rating = meta.Rating
+	p.EditorialURL = meta.Editorial
+	for _, tag := range meta.Tags {
+		p.Tags = append(p.Tags, canonicalTag(tag))
+	}
+	return p, nil
+}
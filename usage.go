@@ -0,0 +1,364 @@
+// usage.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// UsageConfig holds the settings of the local usage log.
+type UsageConfig struct {
+	Enabled bool `json:"enabled"` // count the commands you run, see 'saitama usage'
+}
+
+// usageRetentionDays is how long the daily counts are kept; the totals are kept forever.
+const usageRetentionDays = 365
+
+// usageLog counts the commands run on this machine. It is only ever read by 'saitama
+// usage' and never leaves the data directory.
+type usageLog struct {
+	Since    time.Time                 `json:"since"`
+	Commands map[string]*usageCount    `json:"commands"`
+	Days     map[string]map[string]int `json:"days"`  // YYYY-MM-DD -> command -> runs
+	Hours    [24]int                   `json:"hours"` // runs by hour of the day
+}
+
+// usageCount is the number of runs of a command.
+type usageCount struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+func usagePath() (string, error) {
+	appDir, err := getAppDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(appDir, "usage.json"), nil
+}
+
+// loadUsage returns the usage log, empty when nothing was recorded yet.
+func loadUsage() (usageLog, error) {
+	log := usageLog{Commands: map[string]*usageCount{}, Days: map[string]map[string]int{}}
+	path, err := usagePath()
+	if err != nil {
+		return log, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return log, fmt.Errorf("failed to read usage: %w", err)
+	}
+	if err := json.Unmarshal(data, &log); err != nil {
+		return log, fmt.Errorf("failed to parse usage: %w", err)
+	}
+	if log.Commands == nil {
+		log.Commands = map[string]*usageCount{}
+	}
+	if log.Days == nil {
+		log.Days = map[string]map[string]int{}
+	}
+	return log, nil
+}
+
+// saveUsage writes the usage log atomically.
+func saveUsage(log usageLog) error {
+	path, err := usagePath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage: %w", err)
+	}
+	tempFile := path + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write usage: %w", err)
+	}
+	if err := os.Rename(tempFile, path); err != nil {
+		return fmt.Errorf("failed to write usage: %w", err)
+	}
+	return nil
+}
+
+// usageCommand returns the name a command is counted under, e.g. "stats tags", or ""
+// for the commands that aren't counted: the root, help, shell completion, and
+// prompt-status, which the shell prompt runs rather than you.
+func usageCommand(cmd *cobra.Command) string {
+	if cmd.Hidden || !cmd.HasParent() || strings.HasPrefix(cmd.Name(), "__") {
+		return ""
+	}
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	if path == "help" || path == "prompt-status" || strings.HasPrefix(path, "completion") {
+		return ""
+	}
+	return path
+}
+
+// recordUsage counts a run of the command, unless usage tracking is off or the data
+// directory is read-only. Failures are only logged: counting must never get in the way.
+func recordUsage(cfg Config, cmd *cobra.Command, now time.Time) {
+	name := usageCommand(cmd)
+	if name == "" || !cfg.Usage.Enabled || isReadOnly() {
+		return
+	}
+	log, err := loadUsage()
+	if err != nil {
+		slog.Debug("usage not recorded", "err", err)
+		return
+	}
+	if log.Since.IsZero() {
+		log.Since = now
+	}
+	count := log.Commands[name]
+	if count == nil {
+		count = &usageCount{}
+		log.Commands[name] = count
+	}
+	count.Count++
+	count.Last = now
+
+	day := dayKey(now)
+	if log.Days[day] == nil {
+		log.Days[day] = map[string]int{}
+	}
+	log.Days[day][name]++
+	log.Hours[now.Hour()]++
+	oldest := dayKey(now.AddDate(0, 0, -usageRetentionDays))
+	for d := range log.Days {
+		if d < oldest {
+			delete(log.Days, d)
+		}
+	}
+	if err := saveUsage(log); err != nil {
+		slog.Debug("usage not recorded", "err", err)
+	}
+}
+
+// usageSummary is the usage of the last days, as shown by 'saitama usage'.
+type usageSummary struct {
+	Since      time.Time      `json:"since"`
+	Days       int            `json:"days"`
+	ActiveDays int            `json:"active_days"`
+	Runs       int            `json:"runs"`
+	Commands   map[string]int `json:"commands"` // runs in the last days
+	Totals     map[string]int `json:"totals"`   // runs ever
+	PeakHour   int            `json:"peak_hour"`
+	Insights   []string       `json:"insights"`
+}
+
+// runs adds up the runs of the commands in the summary's window.
+func (s usageSummary) runs(commands ...string) int {
+	n := 0
+	for _, c := range commands {
+		n += s.Commands[c]
+	}
+	return n
+}
+
+// summarizeUsage sums the runs of the last days and derives habits from them.
+func summarizeUsage(log usageLog, days int, now time.Time) usageSummary {
+	s := usageSummary{Since: log.Since, Days: days, Commands: map[string]int{}, Totals: map[string]int{}, Insights: []string{}}
+	for name, c := range log.Commands {
+		s.Totals[name] = c.Count
+	}
+	oldest := dayKey(now.AddDate(0, 0, 1-days))
+	for day, commands := range log.Days {
+		if day < oldest {
+			continue
+		}
+		s.ActiveDays++
+		for name, n := range commands {
+			s.Commands[name] += n
+			s.Runs += n
+		}
+	}
+	for h, n := range log.Hours {
+		if n > log.Hours[s.PeakHour] {
+			s.PeakHour = h
+		}
+	}
+	s.Insights = usageInsights(s, now)
+	return s
+}
+
+// usageInsights turns the counts into observations about your routine.
+func usageInsights(s usageSummary, now time.Time) []string {
+	var insights []string
+	if s.Runs == 0 {
+		return insights
+	}
+	suggested := s.runs("pick", "next", "boss", "session start")
+	solves := s.runs("solve", "session resume")
+	switch {
+	case suggested >= 5 && solves*3 <= suggested:
+		insights = append(insights, fmt.Sprintf(tr("You asked for problems %d times but logged %d solve(s): log them with 'saitama solve' so your stats and reviews keep up."), suggested, solves))
+	case solves >= 5 && suggested == 0:
+		insights = append(insights, tr("You log solves without 'pick' or 'next': let them choose sometimes, they balance tags and reviews."))
+	}
+	if adds := s.runs("add", "import"); adds >= 5 && adds > 2*solves {
+		insights = append(insights, fmt.Sprintf(tr("You added problems %d times and solved %d time(s): the backlog grows faster than you work through it."), adds, solves))
+	}
+	if looks := s.runs("stats", "stats tags", "heatmap", "today", "report weekly"); looks >= 5 && looks > 2*solves {
+		insights = append(insights, fmt.Sprintf(tr("You checked your stats %d times for %d solve(s): fewer looks, more punches."), looks, solves))
+	}
+	if s.Totals["quiz"] == 0 && solves >= 10 {
+		insights = append(insights, tr("You never ran 'saitama quiz': it brings back what you solved before you forget it."))
+	}
+	// Only the days since counting started tell anything about regularity.
+	counted := min(s.Days, int(startOfDay(now).Sub(startOfDay(s.Since)).Hours()/24+0.5)+1)
+	if counted >= 14 && s.ActiveDays*4 < counted {
+		insights = append(insights, fmt.Sprintf(tr("You used saitama on %d of the last %d days: a short daily session beats a weekly marathon."), s.ActiveDays, counted))
+	}
+	return insights
+}
+
+func usageCmd() *cobra.Command {
+	var days, limit int
+	setTracking := func(on bool) {
+		cfg, err := loadConfig()
+		if err != nil {
+			printError(tr("❌ Error loading config: %v"), err)
+			return
+		}
+		cfg.Usage.Enabled = on
+		if err := saveConfig(cfg); err != nil {
+			printError(tr("❌ Error saving config: %v"), err)
+			return
+		}
+		if on {
+			color.Green(tr("📈 Counting the commands you run again."))
+		} else {
+			color.Green(tr("📉 No longer counting the commands you run. Delete the counts with: saitama usage clear"))
+		}
+	}
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show which commands you run and what it says about your habits",
+		Long: "saitama counts the commands you run in usage.json in the data directory: only the command names, " +
+			"per day and hour, never their arguments. The counts never leave your machine; nothing is sent " +
+			"anywhere. This command shows them, with observations about your routine, e.g. picking a lot but " +
+			"rarely logging solves. Turn counting off with 'saitama usage off'.",
+		Example: `  saitama usage
+  saitama usage --days 90
+  saitama usage -o json
+  saitama usage off`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			if days < 1 || days > usageRetentionDays {
+				printError(tr("❌ --days must be between 1 and %d"), usageRetentionDays)
+				return
+			}
+			log, err := loadUsage()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			summary := summarizeUsage(log, days, time.Now())
+			if jsonOutput() {
+				printJSON(summary)
+				return
+			}
+			if cfg, err := loadConfig(); err == nil && !cfg.Usage.Enabled {
+				color.Yellow(tr("📉 Counting is off. Turn it on with: saitama usage on"))
+			}
+			if len(log.Commands) == 0 {
+				color.Yellow(tr("📈 Nothing counted yet: come back after a few days of training."))
+				return
+			}
+
+			type row struct {
+				Name        string
+				Runs, Total int
+			}
+			var rows []row
+			for name, total := range summary.Totals {
+				rows = append(rows, row{name, summary.Commands[name], total})
+			}
+			sort.Slice(rows, func(i, j int) bool {
+				if rows[i].Runs != rows[j].Runs {
+					return rows[i].Runs > rows[j].Runs
+				}
+				if rows[i].Total != rows[j].Total {
+					return rows[i].Total > rows[j].Total
+				}
+				return rows[i].Name < rows[j].Name
+			})
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("📈 Your usage over the last %d days: %d run(s) on %d day(s)"), days, summary.Runs, summary.ActiveDays)
+			color.HiBlack(tr("   counted since %s, only on this machine"), summary.Since.Format("Jan 2, 2006"))
+			fmt.Fprintln(stderr)
+			color.HiBlack("   %-22s %8s %8s", "COMMAND", fmt.Sprintf("LAST %dD", days), "TOTAL")
+			for i, r := range rows {
+				if limit > 0 && i == limit {
+					color.HiBlack(tr("   ... and %d more"), len(rows)-i)
+					break
+				}
+				last := fmt.Sprint(r.Runs)
+				if r.Runs == 0 {
+					last = "-"
+				}
+				printResult(color.New(color.FgWhite), "   %-22s %8s %8d", r.Name, last, r.Total)
+			}
+			fmt.Fprintln(stderr)
+			color.Cyan(tr("🕒 You use saitama most around %02d:00"), summary.PeakHour)
+			if len(summary.Insights) > 0 {
+				fmt.Fprintln(stderr)
+				color.HiYellow(tr("💡 Habits:"))
+				for _, insight := range summary.Insights {
+					printResult(color.New(color.FgYellow), "   • %s", insight)
+				}
+			}
+			fmt.Fprintln(stderr)
+		},
+	}
+	cmd.Flags().IntVar(&days, "days", 30, "number of days to look back")
+	cmd.Flags().IntVar(&limit, "limit", 15, "maximum number of commands to show (0 for all)")
+
+	cmd.AddCommand(&cobra.Command{
+		Use:         "clear",
+		Annotations: mutates(),
+		Short:       "Delete the usage counts",
+		Args:        cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			path, err := usagePath()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				printError(tr("❌ Could not delete %s: %v"), path, err)
+				return
+			}
+			color.Green(tr("🗑️  Usage counts deleted."))
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "off",
+		Annotations: mutates(),
+		Short:       "Stop counting the commands you run",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setTracking(false) },
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:         "on",
+		Annotations: mutates(),
+		Short:       "Count the commands you run again",
+		Args:        cobra.NoArgs,
+		Run:         func(cmd *cobra.Command, args []string) { setTracking(true) },
+	})
+	return cmd
+}
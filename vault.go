@@ -0,0 +1,233 @@
+// vault.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Markers delimiting the part of a vault note that saitama owns. Everything outside
+// them (and any frontmatter key saitama does not manage) is left untouched on re-export.
+const (
+	vaultBeginMarker = "<!-- saitama:begin -->"
+	vaultEndMarker   = "<!-- saitama:end -->"
+	vaultIndexFile   = "index.md"
+)
+
+var (
+	unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+	frontmatterKey  = regexp.MustCompile(`^([A-Za-z0-9_-]+):`)
+)
+
+// vaultFileName returns the note file name of a problem.
+func vaultFileName(id string) string {
+	return unsafeFileChars.ReplaceAllString(id, "_") + ".md"
+}
+
+// yamlString quotes a value for YAML frontmatter.
+func yamlString(s string) string {
+	return strconv.Quote(s)
+}
+
+// vaultFrontmatter returns the managed frontmatter keys of a problem, in order.
+func vaultFrontmatter(p Problem) [][2]string {
+	tags := make([]string, len(p.Tags))
+	for i, tag := range p.Tags {
+		tags[i] = yamlString(tag)
+	}
+	fields := [][2]string{
+		{"id", yamlString(p.ID)},
+		{"name", yamlString(p.Name)},
+		{"tags", "[" + strings.Join(tags, ", ") + "]"},
+		{"difficulty", yamlString(p.Difficulty)},
+		{"platform", yamlString(p.Platform)},
+		{"url", yamlString(p.URL)},
+		{"date_added", p.DateAdded.Format("2006-01-02")},
+	}
+	if !p.LastSolved.IsZero() {
+		fields = append(fields, [2]string{"last_solved", p.LastSolved.Format("2006-01-02")})
+	} else {
+		fields = append(fields, [2]string{"last_solved", "null"})
+	}
+	fields = append(fields, [2]string{"solve_count", strconv.Itoa(solveCount(p))})
+	// Always written, so that unstarring a problem overwrites the starred: true of its note.
+	fields = append(fields, [2]string{"starred", strconv.FormatBool(p.Starred)})
+	return fields
+}
+
+// vaultBody returns the managed body section of a problem note.
+func vaultBody(p Problem) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s - %s\n\n", p.ID, p.Name)
+	if p.URL != "" {
+		fmt.Fprintf(&b, "🔗 [Open problem](%s)\n\n", p.URL)
+	}
+	if len(p.Relations) > 0 {
+		b.WriteString("## Related\n\n")
+		for _, r := range p.Relations {
+			fmt.Fprintf(&b, "- %s [[%s]]\n", describeRelation(r.Type, false), strings.TrimSuffix(vaultFileName(r.Target), ".md"))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("## Notes\n\n")
+	if p.Notes != "" {
+		b.WriteString(strings.TrimSpace(p.Notes) + "\n")
+	} else {
+		b.WriteString("_No notes yet._\n")
+	}
+	return b.String()
+}
+
+// splitFrontmatter separates the YAML frontmatter lines from the rest of a note.
+func splitFrontmatter(content string) (frontmatter []string, rest string) {
+	if !strings.HasPrefix(content, "---\n") {
+		return nil, content
+	}
+	end := strings.Index(content[4:], "\n---\n")
+	if end == -1 {
+		return nil, content
+	}
+	block := content[4 : 4+end]
+	if block != "" {
+		frontmatter = strings.Split(block, "\n")
+	}
+	return frontmatter, content[4+end+5:]
+}
+
+// mergeFrontmatter writes the managed keys and keeps every unmanaged key (including
+// its indented continuation lines) from the existing frontmatter.
+func mergeFrontmatter(existing []string, managed [][2]string) []string {
+	isManaged := make(map[string]bool)
+	var lines []string
+	for _, kv := range managed {
+		isManaged[kv[0]] = true
+		lines = append(lines, kv[0]+": "+kv[1])
+	}
+
+	keep := false
+	for _, line := range existing {
+		if m := frontmatterKey.FindStringSubmatch(line); m != nil {
+			keep = !isManaged[m[1]]
+		}
+		if keep {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// replaceManagedSection swaps the content between the markers, or prepends a managed
+// section when the note has none yet.
+func replaceManagedSection(rest, section string) string {
+	managed := vaultBeginMarker + "\n" + section + vaultEndMarker
+	begin := strings.Index(rest, vaultBeginMarker)
+	end := strings.Index(rest, vaultEndMarker)
+	if begin == -1 || end < begin {
+		return managed + "\n" + rest
+	}
+	return rest[:begin] + managed + rest[end+len(vaultEndMarker):]
+}
+
+// renderVaultNote renders (or re-renders) a note while preserving user edits.
+func renderVaultNote(existing string, frontmatter [][2]string, section string) string {
+	oldFrontmatter, rest := splitFrontmatter(existing)
+	lines := mergeFrontmatter(oldFrontmatter, frontmatter)
+	if existing == "" {
+		rest = "\n"
+	}
+	return "---\n" + strings.Join(lines, "\n") + "\n---\n" + replaceManagedSection(rest, section)
+}
+
+// vaultIndex returns the managed section of the index note.
+func vaultIndex(problems []Problem) string {
+	byTag := make(map[string][]Problem)
+	for _, p := range problems {
+		if len(p.Tags) == 0 {
+			byTag["untagged"] = append(byTag["untagged"], p)
+		}
+		for _, tag := range p.Tags {
+			byTag[tag] = append(byTag[tag], p)
+		}
+	}
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 🥊 Saitama problems\n\n%d problems.\n\n", len(problems))
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "## %s\n\n", tag)
+		for _, p := range byTag[tag] {
+			check := " "
+			if isSolved(p) {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "- [%s] [[%s|%s - %s]]\n", check, strings.TrimSuffix(vaultFileName(p.ID), ".md"), p.ID, p.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// vaultExportResult counts what an export changed.
+type vaultExportResult struct {
+	Created, Updated, Unchanged int
+}
+
+// writeVaultFile writes content to path if it differs from what is there.
+func writeVaultFile(path, content string, result *vaultExportResult) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err == nil && bytes.Equal(existing, []byte(content)) {
+		result.Unchanged++
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if os.IsNotExist(err) || existing == nil {
+		result.Created++
+	} else {
+		result.Updated++
+	}
+	return nil
+}
+
+// exportVault writes one Markdown note per problem plus an index into dir.
+func exportVault(problems []Problem, dir string) (vaultExportResult, error) {
+	var result vaultExportResult
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return result, fmt.Errorf("failed to create vault directory: %w", err)
+	}
+
+	for _, p := range problems {
+		path := filepath.Join(dir, vaultFileName(p.ID))
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return result, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		content := renderVaultNote(string(existing), vaultFrontmatter(p), vaultBody(p))
+		if err := writeVaultFile(path, content, &result); err != nil {
+			return result, err
+		}
+	}
+
+	indexPath := filepath.Join(dir, vaultIndexFile)
+	existing, err := os.ReadFile(indexPath)
+	if err != nil && !os.IsNotExist(err) {
+		return result, fmt.Errorf("failed to read %s: %w", indexPath, err)
+	}
+	indexFrontmatter := [][2]string{{"title", yamlString("Saitama problems")}}
+	content := renderVaultNote(string(existing), indexFrontmatter, vaultIndex(problems))
+	return result, writeVaultFile(indexPath, content, &result)
+}
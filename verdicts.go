@@ -0,0 +1,237 @@
+// verdicts.go
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// judgeVerdicts maps the verdicts of the judges, uppercased with underscores for spaces,
+// to the short codes stored in attempts: Codeforces says WRONG_ANSWER, LeetCode "Wrong Answer".
+var judgeVerdicts = map[string]string{
+	"OK":                      "AC",
+	"ACCEPTED":                "AC",
+	"WRONG_ANSWER":            "WA",
+	"TIME_LIMIT_EXCEEDED":     "TLE",
+	"MEMORY_LIMIT_EXCEEDED":   "MLE",
+	"OUTPUT_LIMIT_EXCEEDED":   "OLE",
+	"IDLENESS_LIMIT_EXCEEDED": "ILE",
+	"RUNTIME_ERROR":           "RE",
+	"COMPILATION_ERROR":       "CE",
+	"COMPILE_ERROR":           "CE",
+	"PRESENTATION_ERROR":      "PE",
+	"CHALLENGED":              "HACKED", // accepted, then hacked during the contest
+}
+
+// unjudgedVerdicts are submissions without a final verdict, which aren't recorded. WJ is
+// AtCoder's "waiting for judge".
+var unjudgedVerdicts = map[string]bool{"TESTING": true, "PENDING": true, "JUDGING": true, "SUBMITTED": true, "SKIPPED": true, "WJ": true}
+
+// normalizeVerdict returns the short code of a judge verdict, e.g. AC or TLE. Unknown
+// verdicts are kept, uppercased.
+func normalizeVerdict(raw string) string {
+	key := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(raw), " ", "_"))
+	if code, ok := judgeVerdicts[key]; ok {
+		return code
+	}
+	return key
+}
+
+// formatVerdictCounts lists the number of submissions of every verdict, e.g. "AC 12, WA 3".
+func formatVerdictCounts(verdicts map[string]int) string {
+	codes := make([]string, 0, len(verdicts))
+	for code := range verdicts {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for i, code := range codes {
+		codes[i] = fmt.Sprintf("%s %d", code, verdicts[code])
+	}
+	return strings.Join(codes, ", ")
+}
+
+// judgeSubmission is a submission fetched from a judge.
+type judgeSubmission struct {
+	ID      string // unique across platforms, e.g. "cf:245987123"
+	Date    time.Time
+	Verdict string // normalized
+}
+
+// recordSubmissions logs the submissions that aren't recorded yet as attempts, and returns
+// them. Those among the archived attempts of the problem were recorded before compact
+// moved them out. A submission made the day of an attempt logged by hand with the same
+// outcome completes that attempt rather than counting twice.
+func recordSubmissions(p *Problem, archived []Attempt, subs []judgeSubmission) []judgeSubmission {
+	seen := make(map[string]bool)
+	for _, a := range append(slices.Clone(archived), p.Attempts...) {
+		if a.Submission != "" {
+			seen[a.Submission] = true
+		}
+	}
+	sort.SliceStable(subs, func(i, j int) bool { return subs[i].Date.Before(subs[j].Date) })
+
+	var recorded []judgeSubmission
+	for _, s := range subs {
+		if seen[s.ID] || unjudgedVerdicts[s.Verdict] {
+			continue
+		}
+		seen[s.ID] = true
+		recorded = append(recorded, s)
+		solved := s.Verdict == "AC"
+		if i := manualAttemptOn(p, s.Date, solved); i >= 0 {
+			p.Attempts[i].Verdict, p.Attempts[i].Submission = s.Verdict, s.ID
+			continue
+		}
+		p.Attempts = append(p.Attempts, Attempt{Date: s.Date, Solved: solved, Verdict: s.Verdict, Submission: s.ID})
+		if solved {
+			p.SolveCount++
+			if s.Date.After(p.LastSolved) {
+				p.LastSolved = s.Date
+			}
+		}
+	}
+	sort.SliceStable(p.Attempts, func(i, j int) bool { return p.Attempts[i].Date.Before(p.Attempts[j].Date) })
+	return recorded
+}
+
+// manualAttemptOn returns the index of an attempt logged by hand on the day of at with
+// the given outcome, or -1.
+func manualAttemptOn(p *Problem, at time.Time, solved bool) int {
+	day := at.Local().Format("2006-01-02")
+	for i, a := range p.Attempts {
+		if a.Submission == "" && a.Solved == solved && a.Date.Local().Format("2006-01-02") == day {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstACRate is the share of problems accepted on their first judged submission.
+type firstACRate struct {
+	Key      string  `json:"key"` // a month (2006-01) or a tag
+	Problems int     `json:"problems"`
+	FirstAC  int     `json:"first_ac"`
+	Rate     float64 `json:"rate"` // percent
+}
+
+func (r *firstACRate) add(firstAC bool) {
+	r.Problems++
+	if firstAC {
+		r.FirstAC++
+	}
+	r.Rate = 100 * float64(r.FirstAC) / float64(r.Problems)
+}
+
+// verdictStats summarizes the judged submissions.
+type verdictStats struct {
+	Submissions int            `json:"submissions"`
+	Verdicts    map[string]int `json:"verdicts"`
+	Overall     firstACRate    `json:"overall"`
+	Months      []firstACRate  `json:"months"` // oldest first
+	Tags        []firstACRate  `json:"tags"`   // lowest rate first
+}
+
+// minVerdictTagProblems is how many judged problems a tag needs for its rate to be shown.
+const minVerdictTagProblems = 3
+
+// computeVerdictStats counts the verdicts of the synced submissions, and how often a
+// problem was accepted on the first one, per month of that first submission and per tag.
+func computeVerdictStats(problems []Problem) verdictStats {
+	stats := verdictStats{Verdicts: make(map[string]int)}
+	months := make(map[string]*firstACRate)
+	tags := make(map[string]*firstACRate)
+	for _, p := range problems {
+		var first *Attempt
+		for i, a := range p.Attempts {
+			if a.Verdict == "" {
+				continue
+			}
+			stats.Submissions++
+			stats.Verdicts[a.Verdict]++
+			if first == nil || a.Date.Before(first.Date) {
+				first = &p.Attempts[i]
+			}
+		}
+		if first == nil {
+			continue
+		}
+		firstAC := first.Verdict == "AC"
+		stats.Overall.add(firstAC)
+		month := first.Date.Local().Format("2006-01")
+		if months[month] == nil {
+			months[month] = &firstACRate{Key: month}
+		}
+		months[month].add(firstAC)
+		for _, tag := range p.Tags {
+			if tags[tag] == nil {
+				tags[tag] = &firstACRate{Key: tag}
+			}
+			tags[tag].add(firstAC)
+		}
+	}
+
+	for _, r := range months {
+		stats.Months = append(stats.Months, *r)
+	}
+	sort.Slice(stats.Months, func(i, j int) bool { return stats.Months[i].Key < stats.Months[j].Key })
+	for _, r := range tags {
+		if r.Problems >= minVerdictTagProblems {
+			stats.Tags = append(stats.Tags, *r)
+		}
+	}
+	sort.Slice(stats.Tags, func(i, j int) bool {
+		if stats.Tags[i].Rate != stats.Tags[j].Rate {
+			return stats.Tags[i].Rate < stats.Tags[j].Rate
+		}
+		return stats.Tags[i].Key < stats.Tags[j].Key
+	})
+	return stats
+}
+
+// printVerdictStats prints the first-attempt acceptance section of 'saitama stats'.
+func printVerdictStats(stats verdictStats) {
+	if stats.Submissions == 0 {
+		return
+	}
+	codes := make([]string, 0, len(stats.Verdicts))
+	for code := range stats.Verdicts {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if stats.Verdicts[codes[i]] != stats.Verdicts[codes[j]] {
+			return stats.Verdicts[codes[i]] > stats.Verdicts[codes[j]]
+		}
+		return codes[i] < codes[j]
+	})
+	counts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		counts = append(counts, fmt.Sprintf("%s %d", code, stats.Verdicts[code]))
+	}
+
+	bar := func(r firstACRate) string {
+		return fmt.Sprintf("%5.1f%%  %-20s (%d of %d)", r.Rate, strings.Repeat("█", int(r.Rate/5)), r.FirstAC, r.Problems)
+	}
+	color.HiCyan(tr("🎯 First-attempt AC: %.1f%% of %d judged problem(s)"), stats.Overall.Rate, stats.Overall.Problems)
+	color.White(tr("   %d submission(s): %s"), stats.Submissions, strings.Join(counts, ", "))
+	months := stats.Months
+	if len(months) > 6 {
+		months = months[len(months)-6:]
+	}
+	for _, m := range months {
+		color.White("   %-20s %s", m.Key, bar(m))
+	}
+	for _, t := range stats.Tags {
+		line := fmt.Sprintf("%-17s %s", t.Key, bar(t))
+		if t.Rate < stats.Overall.Rate {
+			color.Red("   🏷️  %s", line)
+		} else {
+			color.Green("   🏷️  %s", line)
+		}
+	}
+	fmt.Fprintln(stderr)
+}
@@ -0,0 +1,101 @@
+// watch.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+const watchDebounce = 250 * time.Millisecond
+
+func watchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "watch [list|stats|tags]",
+		Short: "Re-render a view whenever the problems file changes",
+		Long:  "Keep a live view of your collection that refreshes when another process (sync, web UI, another terminal) modifies it.",
+		Example: `  saitama watch          # Live list
+  saitama watch stats    # Live statistics`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: []string{"list", "stats", "tags"},
+		Run: func(cmd *cobra.Command, args []string) {
+			view := "list"
+			if len(args) > 0 {
+				view = args[0]
+			}
+			viewCmd, _, err := cmd.Root().Find([]string{view})
+			if err != nil || viewCmd == cmd.Root() || (view != "list" && view != "stats" && view != "tags") {
+				printError(tr("❌ Unknown view '%s' (use list, stats or tags)"), view)
+				return
+			}
+
+			dbPath, err := getDbPath()
+			if err != nil {
+				printError(tr("❌ Error locating problems file: %v"), err)
+				return
+			}
+
+			watcher, err := fsnotify.NewWatcher()
+			if err != nil {
+				printError(tr("❌ Error starting file watcher: %v"), err)
+				return
+			}
+			defer watcher.Close()
+
+			// Watch the directory rather than the file: saves replace the file through an
+			// atomic rename, which would silently detach a watch on the file itself.
+			if err := watcher.Add(filepath.Dir(dbPath)); err != nil {
+				printError(tr("❌ Error watching %s: %v"), filepath.Dir(dbPath), err)
+				return
+			}
+
+			// The view is redrawn in place: a pager would take the screen over and
+			// block the next refresh.
+			noPagerFlag = true
+			render := func() {
+				fmt.Fprint(stderr, "\033[H\033[2J")
+				viewCmd.Run(viewCmd, nil)
+				color.HiBlack(tr("👀 Watching %s (updated %s, Ctrl+C to quit)"), dbPath, time.Now().Format("15:04:05"))
+			}
+			render()
+
+			interrupt := make(chan os.Signal, 1)
+			signal.Notify(interrupt, os.Interrupt)
+			defer signal.Stop(interrupt)
+
+			debounce := time.NewTimer(watchDebounce)
+			debounce.Stop()
+			for {
+				select {
+				case event, ok := <-watcher.Events:
+					if !ok {
+						return
+					}
+					if filepath.Base(event.Name) != filepath.Base(dbPath) {
+						continue
+					}
+					if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) != 0 {
+						debounce.Reset(watchDebounce)
+					}
+				case err, ok := <-watcher.Errors:
+					if !ok {
+						return
+					}
+					color.Yellow(tr("⚠️  Watcher error: %v"), err)
+				case <-debounce.C:
+					render()
+				case <-interrupt:
+					fmt.Fprintln(stderr)
+					color.Cyan(tr("👋 Stopped watching."))
+					return
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,202 @@
+// webhook.go
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// Webhook event names.
+const (
+	EventProblemCreated   = "problem.created"
+	EventProblemSolved    = "problem.solved"
+	EventSessionCompleted = "session.completed"
+	EventWebhookTest      = "webhook.test"
+)
+
+const webhookMaxAttempts = 3
+
+// WebhookConfig is a webhook endpoint configured in the config file.
+type WebhookConfig struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"` // used to sign payloads with HMAC-SHA256
+	Events []string `json:"events,omitempty"` // empty means every event
+}
+
+// WebhookEvent is the JSON payload posted to webhook endpoints.
+type WebhookEvent struct {
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// httpClient is shared by every outgoing HTTP request (webhooks, chat, GitHub).
+// Requests are logged through loggingTransport.
+var httpClient = &http.Client{Timeout: 10 * time.Second, Transport: loggingTransport{next: http.DefaultTransport}}
+
+// wants reports whether the webhook subscribes to the given event.
+func (w WebhookConfig) wants(event string) bool {
+	if len(w.Events) == 0 || event == EventWebhookTest {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// signPayload returns the hex encoded HMAC-SHA256 of the payload.
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deliverWebhook posts an event to one endpoint, retrying with exponential backoff on
+// network errors and 5xx responses.
+func deliverWebhook(hook WebhookConfig, event WebhookEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	backoff := time.Second
+	var lastErr error
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		req, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(payload))
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", "saitama-webhook")
+		req.Header.Set("X-Saitama-Event", event.Event)
+		if hook.Secret != "" {
+			req.Header.Set("X-Saitama-Signature", "sha256="+signPayload(hook.Secret, payload))
+		}
+
+		resp, err := httpClient.Do(req)
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < 300 {
+				return nil
+			}
+			lastErr = fmt.Errorf("endpoint responded with %s", resp.Status)
+			if resp.StatusCode < 500 {
+				return lastErr // client errors won't fix themselves
+			}
+		} else {
+			lastErr = err
+		}
+
+		if attempt < webhookMaxAttempts {
+			slog.Debug("retrying webhook", "host", urlHost(hook.URL), "event", event.Event, "attempt", attempt, "backoff", backoff, "err", lastErr)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", webhookMaxAttempts, lastErr)
+}
+
+// dispatchWebhooks delivers an event to every subscribed webhook in the background. The
+// returned group is done once every delivery is over, for commands about to exit.
+func dispatchWebhooks(hooks []WebhookConfig, name string, data interface{}) *sync.WaitGroup {
+	event := WebhookEvent{Event: name, Timestamp: time.Now(), Data: data}
+	var wg sync.WaitGroup
+	for _, hook := range hooks {
+		if !hook.wants(name) {
+			continue
+		}
+		wg.Add(1)
+		go func(hook WebhookConfig) {
+			defer wg.Done()
+			if err := deliverWebhook(hook, event); err != nil {
+				slog.Warn("webhook delivery failed", "host", urlHost(hook.URL), "event", name, "err", err)
+				color.Yellow(tr("⚠️  Webhook %s failed for %s: %v"), hook.URL, name, err)
+			}
+		}(hook)
+	}
+	return &wg
+}
+
+// SessionSummary is the payload of session.completed, sent at the end of a training
+// session, a mock interview or a boss fight.
+type SessionSummary struct {
+	Kind     string           `json:"kind"` // session, mock or boss
+	Started  time.Time        `json:"started"`
+	Seconds  int              `json:"seconds"` // time spent on the problems
+	Solved   int              `json:"solved"`
+	Problems []SessionProblem `json:"problems"`
+}
+
+// fireSessionCompleted sends session.completed to the configured webhooks and waits for
+// the deliveries, since the command ends right after.
+func fireSessionCompleted(summary SessionSummary) {
+	cfg, err := loadConfig()
+	if err != nil || len(cfg.Webhooks) == 0 {
+		return
+	}
+	for _, p := range summary.Problems {
+		summary.Seconds += p.Seconds
+		if p.Solved {
+			summary.Solved++
+		}
+	}
+	dispatchWebhooks(cfg.Webhooks, EventSessionCompleted, summary).Wait()
+}
+
+func webhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage webhooks fired as problems change",
+		Long: "Webhooks are configured in the config file (see 'saitama config') under \"webhooks\", e.g.\n" +
+			`  "webhooks": [{"url": "https://example.com/hook", "secret": "s3cret", "events": ["problem.solved"]}]` + "\n\n" +
+			"Events: problem.created and problem.solved (server mode and 'saitama rpc'), and session.completed " +
+			"at the end of a session, a mock interview or a boss fight.",
+		Example: `  saitama webhook test
+  saitama serve            # Fires the configured webhooks as problems change`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "test",
+		Short: "Send a test event to every configured webhook",
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := loadConfig()
+			if err != nil {
+				printError(tr("❌ Error loading config: %v"), err)
+				return
+			}
+			if len(cfg.Webhooks) == 0 {
+				color.Yellow(tr("🪝 No webhooks configured."))
+				color.Cyan(tr("💡 Add them to the \"webhooks\" section of your config file (saitama config)"))
+				return
+			}
+
+			event := WebhookEvent{
+				Event:     EventWebhookTest,
+				Timestamp: time.Now(),
+				Data:      map[string]string{"message": "ONE PUNCH! Your webhook works 🥊"},
+			}
+			for _, hook := range cfg.Webhooks {
+				if err := deliverWebhook(hook, event); err != nil {
+					printError("❌ %s: %v", hook.URL, err)
+					continue
+				}
+				color.Green("✅ %s", hook.URL)
+			}
+		},
+	})
+	return cmd
+}
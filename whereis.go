@@ -0,0 +1,179 @@
+// whereis.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// dataDirSource describes where the data directory in use comes from.
+func dataDirSource() string {
+	switch {
+	case dataDirFlag != "":
+		return "--data-dir flag"
+	case os.Getenv("SAITAMA_DATA_DIR") != "":
+		return "SAITAMA_DATA_DIR"
+	default:
+		return "default (user config directory)"
+	}
+}
+
+// legacyDataFiles returns problem files left in other locations than the one in use:
+// a problems.json in the working directory, where early versions kept it, and the
+// default location when the data directory is overridden.
+func legacyDataFiles(dbPath string) []string {
+	var candidates []string
+	if cwd, err := os.Getwd(); err == nil {
+		candidates = append(candidates, filepath.Join(cwd, "problems.json"))
+	}
+	if dataDir() != "" {
+		if configDir, err := os.UserConfigDir(); err == nil {
+			candidates = append(candidates, filepath.Join(configDir, "saitama", "problems.json"))
+		}
+	}
+
+	active, _ := os.Stat(dbPath)
+	var found []string
+	for _, path := range candidates {
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || info.Size() == 0 {
+			continue
+		}
+		if active != nil && os.SameFile(info, active) {
+			continue
+		}
+		found = append(found, path)
+	}
+	return found
+}
+
+// migrateLegacyFile merges the problems of a legacy file into the database, keeping the
+// existing problem when both have the same ID, and renames the legacy file so it isn't
+// offered again. It returns the number of problems added.
+func migrateLegacyFile(path string) (int, error) {
+	legacy, err := importProblems(path)
+	if err != nil {
+		return 0, err
+	}
+	problems, err := loadProblems()
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for _, p := range legacy {
+		if existing, _ := findProblemByID(problems, p.ID); existing != nil {
+			continue
+		}
+		problems = append(problems, p)
+		added++
+	}
+	if err := saveProblems(problems); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(path, path+".migrated"); err != nil {
+		return added, fmt.Errorf("problems migrated, but failed to rename %s: %w", path, err)
+	}
+	return added, nil
+}
+
+// offerLegacyMigration tells the user about legacy problem files and, when possible,
+// asks whether to merge each of them into the database in use.
+func offerLegacyMigration(dbPath string) {
+	legacy := legacyDataFiles(dbPath)
+	if len(legacy) == 0 {
+		return
+	}
+	for _, path := range legacy {
+		color.Yellow(tr("⚠️  Found another problems file at %s"), path)
+		if !isInteractive() || isReadOnly() || quietFlag || jsonOutput() {
+			continue
+		}
+		migrate := false
+		message := fmt.Sprintf("Merge it into %s? (it will be renamed to problems.json.migrated)", dbPath)
+		if err := survey.AskOne(&survey.Confirm{Message: message}, &migrate); err != nil || !migrate {
+			continue
+		}
+		added, err := migrateLegacyFile(path)
+		if err != nil {
+			printError(tr("❌ Migration failed: %v"), err)
+			continue
+		}
+		color.Green(tr("✅ Migrated %d problem(s) from %s"), added, path)
+	}
+	if !isInteractive() || isReadOnly() {
+		color.Cyan(tr("💡 Run 'saitama whereis' in a terminal to merge it into your data."))
+	}
+}
+
+// printPath prints a labeled path with a note about whether it exists.
+func printPath(label, path string) {
+	note := " " + color.HiBlackString("(not created yet)")
+	if info, err := os.Stat(path); err == nil {
+		if info.IsDir() {
+			note = ""
+		} else {
+			note = " " + color.HiBlackString("(%d bytes)", info.Size())
+		}
+	}
+	fmt.Fprintf(stdout, "%-14s %s%s\n", label, path, note)
+}
+
+func whereisCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "whereis",
+		Short: "Show where your data, config and backups are stored",
+		Long: "Prints the files saitama uses and where the data directory setting comes from, and " +
+			"looks for problems files left in other locations by older versions, offering to merge them.",
+		Example: `  saitama whereis
+  SAITAMA_DATA_DIR=/mnt/shared/saitama saitama whereis`,
+		Run: func(cmd *cobra.Command, args []string) {
+			dbPath, err := getDbPath()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			configPath, err := getConfigPath()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			backupDir, err := getBackupDir()
+			if err != nil {
+				printError("❌ %v", err)
+				return
+			}
+			appDir := filepath.Dir(dbPath)
+
+			fmt.Fprintln(stderr)
+			color.HiCyan(tr("📂 Data directory: %s"), appDir)
+			color.HiBlack(tr("   from %s"), dataDirSource())
+			printPath("Problems:", dbPath)
+			printPath("Config:", configPath)
+			printPath("Backups:", backupDir)
+			printPath("Logs:", filepath.Join(appDir, logDirName))
+			printPath("Cache:", filepath.Join(appDir, cacheDirName))
+			if isReadOnly() {
+				color.Yellow(tr("🔒 Read-only mode"))
+			}
+
+			if data, err := os.ReadFile(filepath.Join(appDir, lockFileName)); err == nil {
+				holder := lockInfo{}
+				_ = json.Unmarshal(data, &holder)
+				color.Yellow(tr("🔐 Locked by %s (pid %d) since %s"), holder.Host, holder.PID, holder.Acquired.Format("15:04:05"))
+			}
+
+			fmt.Fprintln(stderr)
+			if len(legacyDataFiles(dbPath)) == 0 {
+				color.Green(tr("✅ No problems files in other locations."))
+				return
+			}
+			offerLegacyMigration(dbPath)
+		},
+	}
+}